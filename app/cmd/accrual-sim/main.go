@@ -0,0 +1,85 @@
+// Command accrual-sim serves a scriptable accrual system simulator for local and e2e runs.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"gophermart/internal/accrualsim"
+)
+
+func main() {
+	addr := flag.String("a", "127.0.0.1:8081", "address to listen on")
+	script := flag.String("script", "", "path to JSON script (orders, default, rate_limit, latency, error_rate)")
+	defaultAccrual := flag.String("default-accrual", "", "answer unknown numbers PROCESSING, then PROCESSED with this accrual (empty — 204)")
+	latency := flag.Duration("latency", 0, "delay every response")
+	errorRate := flag.Float64("error-rate", 0, "share of requests answered with 500, from 0 to 1")
+	rateLimit := flag.Int("rate-limit", 0, "requests per rate window before 429 (0 — unlimited)")
+	rateWindow := flag.Duration("rate-window", time.Minute, "rate limit window")
+	retryAfter := flag.Duration("retry-after", 0, "Retry-After reported on 429 (0 — rest of the window)")
+	flag.Parse()
+
+	cfg, err := loadScript(*script)
+	if err != nil {
+		log.Fatalf("accrual-sim: %v", err)
+	}
+
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "default-accrual":
+			var accrual accrualsim.Amount
+			if err := accrual.UnmarshalJSON([]byte(*defaultAccrual)); err != nil {
+				log.Fatalf("accrual-sim: -default-accrual: %v", err)
+			}
+			cfg.Default = []accrualsim.Step{{Status: "PROCESSING"}, {Status: "PROCESSED", Accrual: &accrual}}
+		case "latency":
+			cfg.Latency = accrualsim.Duration(*latency)
+		case "error-rate":
+			cfg.ErrorRate = *errorRate
+		case "rate-limit", "rate-window", "retry-after":
+			cfg.RateLimit.Requests = *rateLimit
+			cfg.RateLimit.Window = accrualsim.Duration(*rateWindow)
+			cfg.RateLimit.RetryAfter = accrualsim.Duration(*retryAfter)
+		}
+	})
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           accrualsim.New(cfg),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("accrual-sim: listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("accrual-sim: %v", err)
+	}
+}
+
+func loadScript(path string) (accrualsim.Config, error) {
+	if path == "" {
+		return accrualsim.Config{}, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return accrualsim.Config{}, err
+	}
+	defer f.Close()
+	return accrualsim.LoadConfig(f)
+}
@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	balanceservice "gophermart/internal/gophermart/modules/balance/domain/service"
 	balanceworker "gophermart/internal/gophermart/modules/balance/presentation/worker"
 	identityauth "gophermart/internal/gophermart/modules/identity/adapters/auth"
+	identityrepomemory "gophermart/internal/gophermart/modules/identity/adapters/repository/memory"
 	identityrepopostgres "gophermart/internal/gophermart/modules/identity/adapters/repository/postgres"
 	identityport "gophermart/internal/gophermart/modules/identity/application/port"
 	identityworker "gophermart/internal/gophermart/modules/identity/presentation/worker"
@@ -31,10 +33,18 @@ type App struct {
 }
 
 type repositories struct {
-	userRepo       identityport.UserRepository
-	orderRepo      ordersport.OrderRepository
-	balanceRepo    balanceport.BalanceAccountRepository
-	withdrawalRepo balanceport.WithdrawalRepository
+	userRepo        identityport.UserRepository
+	sessionRepo     identityport.SessionRepository
+	loginFailures   identityport.LoginFailureRepository
+	orderRepo       ordersport.OrderRepository
+	campaignRepo    ordersport.CampaignRepository
+	balanceRepo     balanceport.BalanceAccountRepository
+	withdrawalRepo  balanceport.WithdrawalRepository
+	creditRepo      balanceport.AccrualCreditRepository
+	ledgerRepo      balanceport.LedgerRepository
+	idempotencyRepo balanceport.IdempotencyKeyRepository
+	lotRepo         balanceport.AccrualLotRepository
+	holdRepo        balanceport.HoldRepository
 }
 
 type backgroundWorker interface {
@@ -42,23 +52,72 @@ type backgroundWorker interface {
 }
 
 // NewApp wires dependencies and returns the application (composition root).
-func NewApp(cfg config.Config, log port.Logger, transactor *postgres.Transactor) *App {
-	hasher := identityauth.NewBCryptHasher(cfg.Auth.BCryptCost)
-	tokens := identityauth.NewJWTProvider(cfg.Auth.JWTSecret, cfg.Auth.JWTTTL)
+func NewApp(cfg config.Config, log port.Logger, transactor *postgres.Transactor) (*App, error) {
+	ucFactory, err := newUseCaseFactory(cfg, log, transactor)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := NewRouter(ucFactory, cfg.Accrual.WebhookSecret, cfg.Admin.Token, cfg.Server.TrustedProxies, log)
+	if err != nil {
+		return nil, err
+	}
+	srv := newServer(cfg.Server.Address, router)
+	workers := newBackgroundWorkers(
+		ucFactory,
+		log,
+		cfg.Accrual.PollInterval,
+		cfg.Points.ExpiryInterval,
+		cfg.Holds.ExpiryInterval,
+		cfg.Reconcile.Interval,
+		cfg.Idempotency.PurgeInterval,
+		cfg.LoginThrottle.PurgeInterval,
+		cfg.Auth.SessionPurgeInterval,
+	)
+
+	return &App{Server: srv, workers: workers}, nil
+}
+
+// newUseCaseFactory builds repositories and adapters from config and wires all module use cases.
+// It fails if the password hasher settings are invalid or the JWT key files cannot be loaded.
+func newUseCaseFactory(cfg config.Config, log port.Logger, transactor *postgres.Transactor) (UseCaseFactory, error) {
+	hasher, err := identityauth.NewPasswordHasher(
+		cfg.Auth.PasswordHasher, cfg.Auth.BCryptCost, cfg.Auth.Argon2, cfg.Auth.Argon2Concurrency,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("init password hasher: %w", err)
+	}
+	jwtKeys, err := identityauth.LoadKeySet(cfg.Auth.JWTSecret, cfg.Auth.JWTKeys, cfg.Auth.JWTSigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("load JWT keys: %w", err)
+	}
+	tokens := identityauth.NewJWTProvider(jwtKeys, cfg.Auth.JWTTTL)
+	refreshTokens := identityauth.NewOpaqueTokenGenerator()
 	luhnValidator := ordersvalidation.NewLuhnValidator()
 
 	accrualClient := ordersaccrual.NewClientFromConfig(cfg.Accrual.Client)
-	repos := newRepositories(transactor)
+	repos := newRepositories(transactor, cfg.LoginThrottle.Store)
 
 	balanceSvc := balanceservice.BalanceService{}
 	clk := adapterclock.Real{}
 
-	ucFactory := NewUseCaseFactory(
+	return NewUseCaseFactory(
 		WithUserRepo(repos.userRepo),
+		WithSessionRepo(repos.sessionRepo),
+		WithLoginFailureRepo(repos.loginFailures),
+		WithLoginThrottle(cfg.LoginThrottle.Login, cfg.LoginThrottle.IP),
 		WithOrderRepo(repos.orderRepo),
+		WithCampaignRepo(repos.campaignRepo),
 		WithBalanceRepo(repos.balanceRepo),
 		WithWithdrawalRepo(repos.withdrawalRepo),
+		WithAccrualCreditRepo(repos.creditRepo),
+		WithLedgerRepo(repos.ledgerRepo),
+		WithIdempotencyKeyRepo(repos.idempotencyRepo),
+		WithAccrualLotRepo(repos.lotRepo),
+		WithHoldRepo(repos.holdRepo),
 		WithHasher(hasher),
+		WithTokens(tokens, refreshTokens),
+		WithSessionTTL(cfg.Auth.RefreshTTL, cfg.Auth.SessionMaxLifetime),
 		WithTransactor(transactor),
 		WithValidator(luhnValidator),
 		WithAccrualClient(accrualClient),
@@ -67,22 +126,38 @@ func NewApp(cfg config.Config, log port.Logger, transactor *postgres.Transactor)
 		WithLogger(log),
 		WithBatchSize(cfg.Accrual.BatchSize),
 		WithMaxWorkers(cfg.Accrual.MaxWorkers),
+		WithPollPolicy(cfg.Accrual.PollPolicy),
+		WithPollLease(cfg.Accrual.InstanceID, cfg.Accrual.LeaseTTL),
+		WithPointsExpiry(cfg.Points.ExpiryPolicy, cfg.Points.ExpiryBatchSize),
+		WithHolds(cfg.Holds.TTL, cfg.Holds.ExpiryBatchSize),
+		WithReconcile(cfg.Reconcile.BatchSize, cfg.Reconcile.Fix),
+		WithIdempotencyKeyTTL(cfg.Idempotency.KeyTTL),
+		WithWithdrawalLimits(cfg.WithdrawalLimits),
 		WithOptimisticRetries(cfg.OptimisticRetries),
-	)
-
-	router := NewRouter(ucFactory, tokens, log)
-	srv := newServer(cfg.Server.Address, router)
-	workers := newBackgroundWorkers(ucFactory, log, cfg.Accrual.PollInterval)
-
-	return &App{Server: srv, workers: workers}
+	), nil
 }
 
-func newRepositories(transactor *postgres.Transactor) repositories {
+// newRepositories builds PostgreSQL repositories; failed logins are kept in memory
+// if loginThrottleStore is config.LoginThrottleStoreMemory.
+func newRepositories(transactor *postgres.Transactor, loginThrottleStore string) repositories {
+	var loginFailures identityport.LoginFailureRepository = identityrepopostgres.NewLoginFailureRepository(transactor)
+	if loginThrottleStore == config.LoginThrottleStoreMemory {
+		loginFailures = identityrepomemory.NewLoginFailureRepository()
+	}
+
 	return repositories{
-		userRepo:       identityrepopostgres.NewUserRepository(transactor),
-		orderRepo:      ordersrepopostgres.NewOrderRepository(transactor),
-		balanceRepo:    balancerepopostgres.NewBalanceAccountRepository(transactor),
-		withdrawalRepo: balancerepopostgres.NewWithdrawalRepository(transactor),
+		userRepo:        identityrepopostgres.NewUserRepository(transactor),
+		sessionRepo:     identityrepopostgres.NewSessionRepository(transactor),
+		loginFailures:   loginFailures,
+		orderRepo:       ordersrepopostgres.NewOrderRepository(transactor),
+		campaignRepo:    ordersrepopostgres.NewCampaignRepository(transactor),
+		balanceRepo:     balancerepopostgres.NewBalanceAccountRepository(transactor),
+		withdrawalRepo:  balancerepopostgres.NewWithdrawalRepository(transactor),
+		creditRepo:      balancerepopostgres.NewAccrualCreditRepository(transactor),
+		ledgerRepo:      balancerepopostgres.NewLedgerRepository(transactor),
+		idempotencyRepo: balancerepopostgres.NewIdempotencyKeyRepository(transactor),
+		lotRepo:         balancerepopostgres.NewAccrualLotRepository(transactor),
+		holdRepo:        balancerepopostgres.NewHoldRepository(transactor),
 	}
 }
 
@@ -97,14 +172,32 @@ func newBackgroundWorkers(
 	ucFactory UseCaseFactory,
 	log port.Logger,
 	pollInterval time.Duration,
+	expiryInterval time.Duration,
+	holdExpiryInterval time.Duration,
+	reconcileInterval time.Duration,
+	idempotencyPurgeInterval time.Duration,
+	loginFailuresPurgeInterval time.Duration,
+	sessionPurgeInterval time.Duration,
 ) []backgroundWorker {
-	identityWorkers := identityworker.BuildWorkers(identityworker.RegistryParams{})
+	identityWorkers := identityworker.BuildWorkers(identityworker.RegistryParams{
+		UseCases:                   ucFactory,
+		Log:                        log,
+		LoginFailuresPurgeInterval: loginFailuresPurgeInterval,
+		SessionPurgeInterval:       sessionPurgeInterval,
+	})
 	ordersWorkers := ordersworker.BuildWorkers(ordersworker.RegistryParams{
 		UseCases:     ucFactory,
 		Log:          log,
 		PollInterval: pollInterval,
 	})
-	balanceWorkers := balanceworker.BuildWorkers(balanceworker.RegistryParams{})
+	balanceWorkers := balanceworker.BuildWorkers(balanceworker.RegistryParams{
+		UseCases:                 ucFactory,
+		Log:                      log,
+		ExpiryInterval:           expiryInterval,
+		HoldExpiryInterval:       holdExpiryInterval,
+		ReconcileInterval:        reconcileInterval,
+		IdempotencyPurgeInterval: idempotencyPurgeInterval,
+	})
 
 	workers := make(
 		[]backgroundWorker,
@@ -24,18 +24,62 @@ func Run() error {
 
 	log.Debug("starting server",
 		"address", cfg.Server.Address,
+		"trusted_proxies", cfg.Server.TrustedProxies,
 		"accrual_address", cfg.Accrual.Client.Address,
 		"database_configured", cfg.DB.Pool.URI != "",
 		"db_max_conns", cfg.DB.Pool.MaxConns,
 		"db_min_conns", cfg.DB.Pool.MinConns,
 		"db_retry_max_retries", cfg.DB.Retry.MaxRetries,
 		"jwt_ttl", cfg.Auth.JWTTTL,
+		"refresh_ttl", cfg.Auth.RefreshTTL,
+		"session_max_lifetime", cfg.Auth.SessionMaxLifetime,
+		"session_purge_interval", cfg.Auth.SessionPurgeInterval,
 		"jwt_secret_configured", cfg.Auth.JWTSecret != "",
+		"jwt_keys", len(cfg.Auth.JWTKeys),
+		"jwt_signing_key", cfg.Auth.JWTSigningKey,
 		"log_level", cfg.Logger.Level,
+		"password_hasher", cfg.Auth.PasswordHasher,
 		"bcrypt_cost", cfg.Auth.BCryptCost,
+		"argon2_concurrency", cfg.Auth.Argon2Concurrency,
+		"login_throttle_store", cfg.LoginThrottle.Store,
+		"login_throttle_free_attempts", cfg.LoginThrottle.Login.FreeAttempts,
+		"login_lockout_threshold", cfg.LoginThrottle.Login.LockoutThreshold,
+		"login_lockout_duration", cfg.LoginThrottle.Login.LockoutDuration,
+		"login_ip_free_attempts", cfg.LoginThrottle.IP.FreeAttempts,
+		"login_ip_lockout_threshold", cfg.LoginThrottle.IP.LockoutThreshold,
+		"login_throttle_purge_interval", cfg.LoginThrottle.PurgeInterval,
 		"accrual_poll_interval", cfg.Accrual.PollInterval,
 		"accrual_batch_size", cfg.Accrual.BatchSize,
 		"accrual_max_workers", cfg.Accrual.MaxWorkers,
+		"accrual_rate_limit", cfg.Accrual.Client.RateLimit,
+		"accrual_rate_burst", cfg.Accrual.Client.RateBurst,
+		"accrual_breaker_threshold", cfg.Accrual.Client.BreakerThreshold,
+		"accrual_breaker_timeout", cfg.Accrual.Client.BreakerTimeout,
+		"accrual_poll_max_attempts", cfg.Accrual.PollPolicy.MaxAttempts,
+		"accrual_poll_ttl", cfg.Accrual.PollPolicy.TTL,
+		"accrual_poll_backoff_base", cfg.Accrual.PollPolicy.BaseDelay,
+		"accrual_poll_backoff_max", cfg.Accrual.PollPolicy.MaxDelay,
+		"accrual_instance_id", cfg.Accrual.InstanceID,
+		"accrual_lease_ttl", cfg.Accrual.LeaseTTL,
+		"accrual_webhook_enabled", cfg.Accrual.WebhookSecret != "",
+		"admin_api_enabled", cfg.Admin.Token != "",
+		"points_ttl", cfg.Points.ExpiryPolicy.TTL,
+		"points_expiring_soon", cfg.Points.ExpiryPolicy.SoonWindow,
+		"points_expiry_interval", cfg.Points.ExpiryInterval,
+		"points_expiry_batch_size", cfg.Points.ExpiryBatchSize,
+		"hold_ttl", cfg.Holds.TTL,
+		"hold_expiry_interval", cfg.Holds.ExpiryInterval,
+		"hold_expiry_batch_size", cfg.Holds.ExpiryBatchSize,
+		"idempotency_key_ttl", cfg.Idempotency.KeyTTL,
+		"idempotency_purge_interval", cfg.Idempotency.PurgeInterval,
+		"reconcile_interval", cfg.Reconcile.Interval,
+		"reconcile_batch_size", cfg.Reconcile.BatchSize,
+		"reconcile_fix", cfg.Reconcile.Fix,
+		"withdrawal_min_sum", cfg.WithdrawalLimits.MinSum,
+		"withdrawal_max_sum", cfg.WithdrawalLimits.MaxSum,
+		"withdrawal_daily_cap", cfg.WithdrawalLimits.DailyCap,
+		"withdrawal_monthly_cap", cfg.WithdrawalLimits.MonthlyCap,
+		"withdrawal_cooldown", cfg.WithdrawalLimits.Cooldown,
 		"optimistic_retries", cfg.OptimisticRetries,
 	)
 
@@ -53,7 +97,10 @@ func Run() error {
 		postgres.WithExponentialBackoff(cfg.DB.Retry.BaseDelay, cfg.DB.Retry.MaxDelay),
 	)
 
-	app := NewApp(cfg, log, transactor)
+	app, err := NewApp(cfg, log, transactor)
+	if err != nil {
+		return fmt.Errorf("init app: %w", err)
+	}
 
 	// Start module background workers
 	workerCtx, workerCancel := context.WithCancel(ctx)
@@ -1,7 +1,10 @@
 package bootstrap
 
 import (
+	"time"
+
 	"gophermart/internal/gophermart/application/port"
+	balanceintermodule "gophermart/internal/gophermart/modules/balance/adapters/intermodule"
 	balanceapi "gophermart/internal/gophermart/modules/balance/application/api"
 	balancedto "gophermart/internal/gophermart/modules/balance/application/dto"
 	balancefactory "gophermart/internal/gophermart/modules/balance/application/factory"
@@ -10,12 +13,15 @@ import (
 	balancevo "gophermart/internal/gophermart/modules/balance/domain/vo"
 	balancepresentationfactory "gophermart/internal/gophermart/modules/balance/presentation/factory"
 	identityintermodule "gophermart/internal/gophermart/modules/identity/adapters/intermodule"
+	identitynotify "gophermart/internal/gophermart/modules/identity/adapters/notify"
+	identityapi "gophermart/internal/gophermart/modules/identity/application/api"
 	identitydto "gophermart/internal/gophermart/modules/identity/application/dto"
 	identityfactory "gophermart/internal/gophermart/modules/identity/application/factory"
 	identityport "gophermart/internal/gophermart/modules/identity/application/port"
 	identityvo "gophermart/internal/gophermart/modules/identity/domain/vo"
 	identitypresentationfactory "gophermart/internal/gophermart/modules/identity/presentation/factory"
 	ordersintermodule "gophermart/internal/gophermart/modules/orders/adapters/intermodule"
+	ordersapi "gophermart/internal/gophermart/modules/orders/application/api"
 	ordersdto "gophermart/internal/gophermart/modules/orders/application/dto"
 	ordersfactory "gophermart/internal/gophermart/modules/orders/application/factory"
 	ordersport "gophermart/internal/gophermart/modules/orders/application/port"
@@ -35,21 +41,63 @@ type UseCaseFactory interface {
 type useCaseFactory struct {
 	register        port.UseCase[identitydto.RegisterInput, identityvo.UserID]
 	login           port.UseCase[identitydto.LoginInput, identityvo.UserID]
+	changePassword  port.UseCase[identitydto.ChangePasswordInput, struct{}]
+	createSession   port.UseCase[identityvo.UserID, identitydto.SessionTokens]
+	refreshSession  port.UseCase[string, identitydto.SessionTokens]
+	revokeSession   port.UseCase[identityvo.SessionID, struct{}]
+	authenticate    port.UseCase[string, identitydto.Principal]
+	listPublicKeys  port.UseCase[struct{}, []identitydto.PublicKey]
+	purgeFailures   port.BackgroundRunner
+	purgeSessions   port.BackgroundRunner
 	uploadOrder     port.UseCase[ordersdto.UploadOrderInput, struct{}]
-	listOrders      port.UseCase[ordersvo.UserID, []ordersdto.OrderOutput]
+	listOrders      port.UseCase[ordersdto.ListOrdersInput, ordersdto.OrderPageOutput]
+	getOrder        port.UseCase[ordersdto.GetOrderInput, ordersdto.OrderDetailsOutput]
+	ingestAccrual   port.UseCase[ordersdto.AccrualPushInput, struct{}]
+	createCampaign  port.UseCase[ordersdto.CampaignInput, ordersdto.CampaignOutput]
+	updateCampaign  port.UseCase[ordersdto.UpdateCampaignInput, ordersdto.CampaignOutput]
+	deleteCampaign  port.UseCase[int64, struct{}]
+	getCampaign     port.UseCase[int64, ordersdto.CampaignOutput]
+	listCampaigns   port.UseCase[struct{}, []ordersdto.CampaignOutput]
 	getBalance      port.UseCase[balancevo.UserID, balancedto.BalanceOutput]
 	withdraw        port.UseCase[balancedto.WithdrawInput, struct{}]
-	listWithdrawals port.UseCase[balancevo.UserID, []balancedto.WithdrawalOutput]
+	listWithdrawals port.UseCase[balancedto.ListWithdrawalsInput, balancedto.WithdrawalPageOutput]
+	refund          port.UseCase[balancedto.RefundInput, balancedto.WithdrawalOutput]
+	listTx          port.UseCase[balancevo.UserID, []balancedto.TransactionOutput]
+	statement       port.UseCase[balancedto.StatementInput, balancedto.StatementOutput]
+	transfer        port.UseCase[balancedto.TransferInput, struct{}]
+	authorizeHold   port.UseCase[balancedto.AuthorizeHoldInput, balancedto.HoldOutput]
+	captureHold     port.UseCase[balancedto.HoldActionInput, balancedto.HoldOutput]
+	voidHold        port.UseCase[balancedto.HoldActionInput, balancedto.HoldOutput]
+	listHolds       port.UseCase[balancevo.UserID, []balancedto.HoldOutput]
 	processAccrual  port.BackgroundRunner
+	expirePoints    port.BackgroundRunner
+	expireHolds     port.BackgroundRunner
+	reconcile       port.BackgroundRunner
+	purgeIdemKeys   port.BackgroundRunner
 }
 
 // factoryParams holds all dependencies needed to build the use case factory.
 type factoryParams struct {
 	userRepo          identityport.UserRepository
+	sessionRepo       identityport.SessionRepository
+	loginFailureRepo  identityport.LoginFailureRepository
 	orderRepo         ordersport.OrderRepository
+	campaignRepo      ordersport.CampaignRepository
 	balanceRepo       balanceport.BalanceAccountRepository
 	withdrawalRepo    balanceport.WithdrawalRepository
+	accrualCreditRepo balanceport.AccrualCreditRepository
+	ledgerRepo        balanceport.LedgerRepository
+	idempotencyRepo   balanceport.IdempotencyKeyRepository
+	lotRepo           balanceport.AccrualLotRepository
+	holdRepo          balanceport.HoldRepository
 	hasher            port.PasswordHasher
+	tokens            identityport.TokenProvider
+	refreshTokens     identityport.RefreshTokenGenerator
+	sessionTTL        time.Duration
+	sessionMaxLife    time.Duration
+	loginPolicy       identityvo.LoginThrottlePolicy
+	ipPolicy          identityvo.LoginThrottlePolicy
+	lockoutNotifier   identityport.LockoutNotifier
 	transactor        port.Transactor
 	validator         ordersvo.OrderNumberValidator
 	accrualClient     ordersport.AccrualClient
@@ -59,24 +107,62 @@ type factoryParams struct {
 	batchSize         int
 	maxWorkers        int
 	optimisticRetries int
+	pollPolicy        ordersvo.PollPolicy
+	leaseOwner        string
+	leaseTTL          time.Duration
+	expiryPolicy      balancevo.ExpiryPolicy
+	expiryBatchSize   int
+	holdTTL           time.Duration
+	holdBatchSize     int
+	reconcileBatch    int
+	reconcileFix      bool
+	idempotencyTTL    time.Duration
+	withdrawalLimits  balancevo.WithdrawalLimits
 }
 
 func (p factoryParams) validate() {
 	if p.userRepo == nil {
 		panic("NewUseCaseFactory: WithUserRepo is required")
 	}
+	if p.sessionRepo == nil {
+		panic("NewUseCaseFactory: WithSessionRepo is required")
+	}
+	if p.loginFailureRepo == nil {
+		panic("NewUseCaseFactory: WithLoginFailureRepo is required")
+	}
 	if p.orderRepo == nil {
 		panic("NewUseCaseFactory: WithOrderRepo is required")
 	}
+	if p.campaignRepo == nil {
+		panic("NewUseCaseFactory: WithCampaignRepo is required")
+	}
 	if p.balanceRepo == nil {
 		panic("NewUseCaseFactory: WithBalanceRepo is required")
 	}
 	if p.withdrawalRepo == nil {
 		panic("NewUseCaseFactory: WithWithdrawalRepo is required")
 	}
+	if p.accrualCreditRepo == nil {
+		panic("NewUseCaseFactory: WithAccrualCreditRepo is required")
+	}
+	if p.ledgerRepo == nil {
+		panic("NewUseCaseFactory: WithLedgerRepo is required")
+	}
+	if p.idempotencyRepo == nil {
+		panic("NewUseCaseFactory: WithIdempotencyKeyRepo is required")
+	}
+	if p.lotRepo == nil {
+		panic("NewUseCaseFactory: WithAccrualLotRepo is required")
+	}
+	if p.holdRepo == nil {
+		panic("NewUseCaseFactory: WithHoldRepo is required")
+	}
 	if p.hasher == nil {
 		panic("NewUseCaseFactory: WithHasher is required")
 	}
+	if p.tokens == nil || p.refreshTokens == nil {
+		panic("NewUseCaseFactory: WithTokens is required")
+	}
 	if p.transactor == nil {
 		panic("NewUseCaseFactory: WithTransactor is required")
 	}
@@ -95,10 +181,22 @@ func WithUserRepo(r identityport.UserRepository) option.Option[factoryParams] {
 	return func(p *factoryParams) { p.userRepo = r }
 }
 
+func WithSessionRepo(r identityport.SessionRepository) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.sessionRepo = r }
+}
+
+func WithLoginFailureRepo(r identityport.LoginFailureRepository) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.loginFailureRepo = r }
+}
+
 func WithOrderRepo(r ordersport.OrderRepository) option.Option[factoryParams] {
 	return func(p *factoryParams) { p.orderRepo = r }
 }
 
+func WithCampaignRepo(r ordersport.CampaignRepository) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.campaignRepo = r }
+}
+
 func WithBalanceRepo(r balanceport.BalanceAccountRepository) option.Option[factoryParams] {
 	return func(p *factoryParams) { p.balanceRepo = r }
 }
@@ -107,10 +205,55 @@ func WithWithdrawalRepo(r balanceport.WithdrawalRepository) option.Option[factor
 	return func(p *factoryParams) { p.withdrawalRepo = r }
 }
 
+func WithAccrualCreditRepo(r balanceport.AccrualCreditRepository) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.accrualCreditRepo = r }
+}
+
+func WithLedgerRepo(r balanceport.LedgerRepository) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.ledgerRepo = r }
+}
+
+func WithIdempotencyKeyRepo(r balanceport.IdempotencyKeyRepository) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.idempotencyRepo = r }
+}
+
+func WithAccrualLotRepo(r balanceport.AccrualLotRepository) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.lotRepo = r }
+}
+
+func WithHoldRepo(r balanceport.HoldRepository) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.holdRepo = r }
+}
+
 func WithHasher(h port.PasswordHasher) option.Option[factoryParams] {
 	return func(p *factoryParams) { p.hasher = h }
 }
 
+func WithTokens(tokens identityport.TokenProvider, refreshTokens identityport.RefreshTokenGenerator) option.Option[factoryParams] {
+	return func(p *factoryParams) {
+		p.tokens = tokens
+		p.refreshTokens = refreshTokens
+	}
+}
+
+func WithSessionTTL(ttl, maxLifetime time.Duration) option.Option[factoryParams] {
+	return func(p *factoryParams) {
+		p.sessionTTL = ttl
+		p.sessionMaxLife = maxLifetime
+	}
+}
+
+func WithLoginThrottle(loginPolicy, ipPolicy identityvo.LoginThrottlePolicy) option.Option[factoryParams] {
+	return func(p *factoryParams) {
+		p.loginPolicy = loginPolicy
+		p.ipPolicy = ipPolicy
+	}
+}
+
+func WithLockoutNotifier(n identityport.LockoutNotifier) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.lockoutNotifier = n }
+}
+
 func WithTransactor(t port.Transactor) option.Option[factoryParams] {
 	return func(p *factoryParams) { p.transactor = t }
 }
@@ -147,29 +290,110 @@ func WithOptimisticRetries(n int) option.Option[factoryParams] {
 	return func(p *factoryParams) { p.optimisticRetries = n }
 }
 
+func WithPollPolicy(policy ordersvo.PollPolicy) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.pollPolicy = policy }
+}
+
+func WithPollLease(owner string, ttl time.Duration) option.Option[factoryParams] {
+	return func(p *factoryParams) {
+		p.leaseOwner = owner
+		p.leaseTTL = ttl
+	}
+}
+
+func WithPointsExpiry(policy balancevo.ExpiryPolicy, batchSize int) option.Option[factoryParams] {
+	return func(p *factoryParams) {
+		p.expiryPolicy = policy
+		p.expiryBatchSize = batchSize
+	}
+}
+
+func WithHolds(ttl time.Duration, batchSize int) option.Option[factoryParams] {
+	return func(p *factoryParams) {
+		p.holdTTL = ttl
+		p.holdBatchSize = batchSize
+	}
+}
+
+func WithReconcile(batchSize int, fix bool) option.Option[factoryParams] {
+	return func(p *factoryParams) {
+		p.reconcileBatch = batchSize
+		p.reconcileFix = fix
+	}
+}
+
+func WithIdempotencyKeyTTL(ttl time.Duration) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.idempotencyTTL = ttl }
+}
+
+func WithWithdrawalLimits(limits balancevo.WithdrawalLimits) option.Option[factoryParams] {
+	return func(p *factoryParams) { p.withdrawalLimits = limits }
+}
+
 // NewUseCaseFactory builds the use case factory using functional options.
 func NewUseCaseFactory(opts ...option.Option[factoryParams]) UseCaseFactory {
 	p := factoryParams{
 		batchSize:         50,
 		maxWorkers:        5,
 		optimisticRetries: 3,
+		leaseOwner:        "gophermart",
+		leaseTTL:          time.Minute,
+		expiryBatchSize:   100,
+		holdTTL:           15 * time.Minute,
+		holdBatchSize:     100,
+		reconcileBatch:    100,
+		idempotencyTTL:    24 * time.Hour,
+		sessionTTL:        30 * 24 * time.Hour,
+		sessionMaxLife:    90 * 24 * time.Hour,
 	}
 	option.Apply(&p, opts...)
 	p.validate()
+	if p.lockoutNotifier == nil {
+		p.lockoutNotifier = identitynotify.NewLogLockoutNotifier(p.log)
+	}
 
-	balanceUC := buildBalanceUseCases(p)
+	userDirectory := identityfactory.NewUserDirectory(p.userRepo)
+	accrualHistory := ordersfactory.NewAccrualHistory(p.orderRepo)
+	balanceUC := buildBalanceUseCases(p, userDirectory, accrualHistory)
 	identityUC := buildIdentityUseCases(p, balanceUC.OpenAccount)
 	ordersUC := buildOrdersUseCases(p, balanceUC.ApplyAccrual)
 
 	return &useCaseFactory{
 		register:        identityUC.Register,
 		login:           identityUC.Login,
+		changePassword:  identityUC.ChangePassword,
+		createSession:   identityUC.CreateSession,
+		refreshSession:  identityUC.RefreshSession,
+		revokeSession:   identityUC.RevokeSession,
+		authenticate:    identityUC.Authenticate,
+		listPublicKeys:  identityUC.ListPublicKeys,
+		purgeFailures:   identityUC.PurgeLoginFailures,
+		purgeSessions:   identityUC.PurgeSessions,
 		uploadOrder:     ordersUC.UploadOrder,
 		listOrders:      ordersUC.ListOrders,
+		getOrder:        ordersUC.GetOrder,
+		ingestAccrual:   ordersUC.IngestAccrual,
+		createCampaign:  ordersUC.CreateCampaign,
+		updateCampaign:  ordersUC.UpdateCampaign,
+		deleteCampaign:  ordersUC.DeleteCampaign,
+		getCampaign:     ordersUC.GetCampaign,
+		listCampaigns:   ordersUC.ListCampaigns,
 		getBalance:      balanceUC.GetBalance,
 		withdraw:        balanceUC.Withdraw,
 		listWithdrawals: balanceUC.ListWithdrawals,
+		refund:          balanceUC.RefundWithdrawal,
+		listTx:          balanceUC.ListTransactions,
+		statement:       balanceUC.GetStatement,
+		transfer:        balanceUC.Transfer,
+		authorizeHold:   balanceUC.AuthorizeHold,
+		captureHold:     balanceUC.CaptureHold,
+		voidHold:        balanceUC.VoidHold,
+		listHolds:       balanceUC.ListHolds,
 		processAccrual:  ordersUC.ProcessAccrual,
+		expirePoints:    balanceUC.ExpirePoints,
+		expireHolds:     balanceUC.ExpireHolds,
+		reconcile:       balanceUC.ReconcileBalances,
+		purgeIdemKeys:   balanceUC.PurgeIdempotencyKeys,
 	}
 }
 
@@ -181,14 +405,74 @@ func (f *useCaseFactory) LoginUseCase() port.UseCase[identitydto.LoginInput, ide
 	return f.login
 }
 
+func (f *useCaseFactory) ChangePasswordUseCase() port.UseCase[identitydto.ChangePasswordInput, struct{}] {
+	return f.changePassword
+}
+
+func (f *useCaseFactory) CreateSessionUseCase() port.UseCase[identityvo.UserID, identitydto.SessionTokens] {
+	return f.createSession
+}
+
+func (f *useCaseFactory) RefreshSessionUseCase() port.UseCase[string, identitydto.SessionTokens] {
+	return f.refreshSession
+}
+
+func (f *useCaseFactory) RevokeSessionUseCase() port.UseCase[identityvo.SessionID, struct{}] {
+	return f.revokeSession
+}
+
+func (f *useCaseFactory) AuthenticateUseCase() port.UseCase[string, identitydto.Principal] {
+	return f.authenticate
+}
+
+func (f *useCaseFactory) ListPublicKeysUseCase() port.UseCase[struct{}, []identitydto.PublicKey] {
+	return f.listPublicKeys
+}
+
+func (f *useCaseFactory) PurgeLoginFailuresUseCase() port.BackgroundRunner {
+	return f.purgeFailures
+}
+
+func (f *useCaseFactory) PurgeSessionsUseCase() port.BackgroundRunner {
+	return f.purgeSessions
+}
+
 func (f *useCaseFactory) UploadOrderUseCase() port.UseCase[ordersdto.UploadOrderInput, struct{}] {
 	return f.uploadOrder
 }
 
-func (f *useCaseFactory) ListOrdersUseCase() port.UseCase[ordersvo.UserID, []ordersdto.OrderOutput] {
+func (f *useCaseFactory) ListOrdersUseCase() port.UseCase[ordersdto.ListOrdersInput, ordersdto.OrderPageOutput] {
 	return f.listOrders
 }
 
+func (f *useCaseFactory) GetOrderUseCase() port.UseCase[ordersdto.GetOrderInput, ordersdto.OrderDetailsOutput] {
+	return f.getOrder
+}
+
+func (f *useCaseFactory) IngestAccrualUseCase() port.UseCase[ordersdto.AccrualPushInput, struct{}] {
+	return f.ingestAccrual
+}
+
+func (f *useCaseFactory) CreateCampaignUseCase() port.UseCase[ordersdto.CampaignInput, ordersdto.CampaignOutput] {
+	return f.createCampaign
+}
+
+func (f *useCaseFactory) UpdateCampaignUseCase() port.UseCase[ordersdto.UpdateCampaignInput, ordersdto.CampaignOutput] {
+	return f.updateCampaign
+}
+
+func (f *useCaseFactory) DeleteCampaignUseCase() port.UseCase[int64, struct{}] {
+	return f.deleteCampaign
+}
+
+func (f *useCaseFactory) GetCampaignUseCase() port.UseCase[int64, ordersdto.CampaignOutput] {
+	return f.getCampaign
+}
+
+func (f *useCaseFactory) ListCampaignsUseCase() port.UseCase[struct{}, []ordersdto.CampaignOutput] {
+	return f.listCampaigns
+}
+
 func (f *useCaseFactory) GetBalanceUseCase() port.UseCase[balancevo.UserID, balancedto.BalanceOutput] {
 	return f.getBalance
 }
@@ -197,37 +481,118 @@ func (f *useCaseFactory) WithdrawUseCase() port.UseCase[balancedto.WithdrawInput
 	return f.withdraw
 }
 
-func (f *useCaseFactory) ListWithdrawalsUseCase() port.UseCase[balancevo.UserID, []balancedto.WithdrawalOutput] {
+func (f *useCaseFactory) ListWithdrawalsUseCase() port.UseCase[balancedto.ListWithdrawalsInput, balancedto.WithdrawalPageOutput] {
 	return f.listWithdrawals
 }
 
+func (f *useCaseFactory) RefundWithdrawalUseCase() port.UseCase[balancedto.RefundInput, balancedto.WithdrawalOutput] {
+	return f.refund
+}
+
+func (f *useCaseFactory) ListTransactionsUseCase() port.UseCase[balancevo.UserID, []balancedto.TransactionOutput] {
+	return f.listTx
+}
+
+func (f *useCaseFactory) GetStatementUseCase() port.UseCase[balancedto.StatementInput, balancedto.StatementOutput] {
+	return f.statement
+}
+
+func (f *useCaseFactory) TransferUseCase() port.UseCase[balancedto.TransferInput, struct{}] {
+	return f.transfer
+}
+
+func (f *useCaseFactory) AuthorizeHoldUseCase() port.UseCase[balancedto.AuthorizeHoldInput, balancedto.HoldOutput] {
+	return f.authorizeHold
+}
+
+func (f *useCaseFactory) CaptureHoldUseCase() port.UseCase[balancedto.HoldActionInput, balancedto.HoldOutput] {
+	return f.captureHold
+}
+
+func (f *useCaseFactory) VoidHoldUseCase() port.UseCase[balancedto.HoldActionInput, balancedto.HoldOutput] {
+	return f.voidHold
+}
+
+func (f *useCaseFactory) ListHoldsUseCase() port.UseCase[balancevo.UserID, []balancedto.HoldOutput] {
+	return f.listHolds
+}
+
 func (f *useCaseFactory) ProcessAccrualUseCase() port.BackgroundRunner {
 	return f.processAccrual
 }
 
-func (p factoryParams) balanceParams() balancefactory.Params {
+func (f *useCaseFactory) ExpirePointsUseCase() port.BackgroundRunner {
+	return f.expirePoints
+}
+
+func (f *useCaseFactory) ExpireHoldsUseCase() port.BackgroundRunner {
+	return f.expireHolds
+}
+
+func (f *useCaseFactory) ReconcileBalancesUseCase() port.BackgroundRunner {
+	return f.reconcile
+}
+
+func (f *useCaseFactory) PurgeIdempotencyKeysUseCase() port.BackgroundRunner {
+	return f.purgeIdemKeys
+}
+
+func (p factoryParams) balanceParams(
+	userDirectoryAPI identityapi.UserDirectoryAPI,
+	accrualHistoryAPI ordersapi.AccrualHistoryAPI,
+) balancefactory.Params {
 	return balancefactory.Params{
-		BalanceRepo:       p.balanceRepo,
-		WithdrawalRepo:    p.withdrawalRepo,
-		Transactor:        p.transactor,
-		Validator:         p.validator,
-		Clock:             p.clock,
-		BalanceSvc:        p.balanceSvc,
-		OptimisticRetries: p.optimisticRetries,
+		BalanceRepo:        p.balanceRepo,
+		WithdrawalRepo:     p.withdrawalRepo,
+		AccrualCreditRepo:  p.accrualCreditRepo,
+		LedgerRepo:         p.ledgerRepo,
+		IdempotencyRepo:    p.idempotencyRepo,
+		LotRepo:            p.lotRepo,
+		HoldRepo:           p.holdRepo,
+		IdentityGateway:    balanceintermodule.NewIdentityGatewayAdapter(userDirectoryAPI),
+		OrdersGateway:      balanceintermodule.NewOrdersGatewayAdapter(accrualHistoryAPI),
+		Transactor:         p.transactor,
+		Validator:          p.validator,
+		Clock:              p.clock,
+		BalanceSvc:         p.balanceSvc,
+		Log:                p.log,
+		ExpiryPolicy:       p.expiryPolicy,
+		WithdrawalPolicy:   balanceservice.WithdrawalPolicy{Limits: p.withdrawalLimits},
+		ExpiryBatchSize:    p.expiryBatchSize,
+		HoldTTL:            p.holdTTL,
+		HoldBatchSize:      p.holdBatchSize,
+		ReconcileBatchSize: p.reconcileBatch,
+		ReconcileFix:       p.reconcileFix,
+		IdempotencyKeyTTL:  p.idempotencyTTL,
+		OptimisticRetries:  p.optimisticRetries,
 	}
 }
 
-func buildBalanceUseCases(p factoryParams) balancefactory.UseCases {
-	return balancefactory.NewUseCases(p.balanceParams())
+func buildBalanceUseCases(
+	p factoryParams,
+	userDirectoryAPI identityapi.UserDirectoryAPI,
+	accrualHistoryAPI ordersapi.AccrualHistoryAPI,
+) balancefactory.UseCases {
+	return balancefactory.NewUseCases(p.balanceParams(userDirectoryAPI, accrualHistoryAPI))
 }
 
 func (p factoryParams) identityParams(balanceAccountAPI balanceapi.AccountAPI) identityfactory.Params {
 	return identityfactory.Params{
-		UserRepo:       p.userRepo,
-		BalanceGateway: identityintermodule.NewBalanceGatewayAdapter(balanceAccountAPI),
-		Transactor:     p.transactor,
-		Hasher:         p.hasher,
-		Clock:          p.clock,
+		UserRepo:           p.userRepo,
+		SessionRepo:        p.sessionRepo,
+		BalanceGateway:     identityintermodule.NewBalanceGatewayAdapter(balanceAccountAPI),
+		Transactor:         p.transactor,
+		Hasher:             p.hasher,
+		Tokens:             p.tokens,
+		RefreshTokens:      p.refreshTokens,
+		LoginFailures:      p.loginFailureRepo,
+		Notifier:           p.lockoutNotifier,
+		Clock:              p.clock,
+		Log:                p.log,
+		SessionTTL:         p.sessionTTL,
+		SessionMaxLifetime: p.sessionMaxLife,
+		LoginPolicy:        p.loginPolicy,
+		IPPolicy:           p.ipPolicy,
 	}
 }
 
@@ -238,6 +603,7 @@ func buildIdentityUseCases(p factoryParams, balanceAccountAPI balanceapi.Account
 func (p factoryParams) ordersParams(balanceAccrualAPI balanceapi.AccrualAPI) ordersfactory.Params {
 	return ordersfactory.Params{
 		OrderRepo:         p.orderRepo,
+		CampaignRepo:      p.campaignRepo,
 		BalanceGateway:    ordersintermodule.NewBalanceGatewayAdapter(balanceAccrualAPI),
 		Validator:         p.validator,
 		AccrualClient:     p.accrualClient,
@@ -247,6 +613,9 @@ func (p factoryParams) ordersParams(balanceAccrualAPI balanceapi.AccrualAPI) ord
 		BatchSize:         p.batchSize,
 		MaxWorkers:        p.maxWorkers,
 		OptimisticRetries: p.optimisticRetries,
+		PollPolicy:        p.pollPolicy,
+		LeaseOwner:        p.leaseOwner,
+		LeaseTTL:          p.leaseTTL,
 	}
 }
 
@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"gophermart/internal/gophermart/adapters/logger"
+	"gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/config"
+)
+
+// RunReconcile loads config, checks every balance account once and exits.
+// Without fix mode a found mismatch is returned as an error, so schedulers can alert on the exit code.
+func RunReconcile() error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	log, err := logger.Initialize(cfg.Logger)
+	if err != nil {
+		return fmt.Errorf("init logger: %w", err)
+	}
+	defer log.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := postgres.NewPool(ctx, cfg.DB.Pool)
+	if err != nil {
+		return fmt.Errorf("init database pool: %w", err)
+	}
+	defer pool.Close()
+
+	transactor := postgres.NewTransactor(pool,
+		postgres.WithMaxRetries(cfg.DB.Retry.MaxRetries),
+		postgres.WithExponentialBackoff(cfg.DB.Retry.BaseDelay, cfg.DB.Retry.MaxDelay),
+	)
+
+	ucFactory, err := newUseCaseFactory(cfg, log, transactor)
+	if err != nil {
+		return fmt.Errorf("init use cases: %w", err)
+	}
+
+	log.Info("balance reconciliation started", "batch_size", cfg.Reconcile.BatchSize, "fix", cfg.Reconcile.Fix)
+	drifted, err := ucFactory.ReconcileBalancesUseCase().Run(ctx)
+	if err != nil {
+		return fmt.Errorf("reconcile balances: %w", err)
+	}
+	log.Info("balance reconciliation finished", "drifted", drifted)
+
+	if drifted > 0 && !cfg.Reconcile.Fix {
+		return fmt.Errorf("%d balance accounts drifted", drifted)
+	}
+	return nil
+}
@@ -1,52 +1,82 @@
 package bootstrap
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/gin-gonic/gin"
 
 	"gophermart/internal/gophermart/application/port"
 	balancerouter "gophermart/internal/gophermart/modules/balance/presentation/http/router"
-	identityport "gophermart/internal/gophermart/modules/identity/application/port"
+	identityfactory "gophermart/internal/gophermart/modules/identity/presentation/factory"
 	identityrouter "gophermart/internal/gophermart/modules/identity/presentation/http/router"
 	ordersrouter "gophermart/internal/gophermart/modules/orders/presentation/http/router"
 	"gophermart/internal/gophermart/presentation/http/middleware"
 )
 
 type identityTokenValidatorBridge struct {
-	tokens identityport.TokenProvider
+	useCases identityfactory.UseCaseFactory
 }
 
-func (a identityTokenValidatorBridge) Validate(token string) (int64, error) {
-	userID, err := a.tokens.Validate(token)
+func (a identityTokenValidatorBridge) Validate(ctx context.Context, token string) (int64, int64, error) {
+	principal, err := a.useCases.AuthenticateUseCase().Execute(ctx, token)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
-	return int64(userID), nil
+	return int64(principal.UserID), int64(principal.SessionID), nil
 }
 
 // NewRouter builds the Gin engine with all routes and middleware (composition root).
 // Auth middleware applies only to routes registered inside the protected group.
+// The accrual webhook is registered only when webhookSecret is set,
+// the admin API only when adminToken is set.
+// Forwarding headers are trusted only from trustedProxies; with none the client IP
+// is the remote address of the connection.
 func NewRouter(
 	useCases UseCaseFactory,
-	tokens identityport.TokenProvider,
+	webhookSecret string,
+	adminToken string,
+	trustedProxies []string,
 	log port.Logger,
-) *gin.Engine {
+) (*gin.Engine, error) {
 	r := gin.New()
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, fmt.Errorf("set trusted proxies: %w", err)
+	}
 	globalParams := middleware.GlobalRegistryParams{
-		Log:    log,
-		Tokens: identityTokenValidatorBridge{tokens: tokens},
+		Log:           log,
+		Tokens:        identityTokenValidatorBridge{useCases: useCases},
+		WebhookSecret: webhookSecret,
+		AdminToken:    adminToken,
 	}
 	r.Use(middleware.BuildAppMiddleware(globalParams)...)
 
+	identityrouter.RegisterWellKnownRoutes(r.Group("/.well-known"), useCases, log)
+
 	api := r.Group("/api/user")
 	{
-		identityrouter.RegisterPublicRoutes(api, useCases, tokens, log)
+		identityrouter.RegisterPublicRoutes(api, useCases, log)
 
 		protected := api.Group("")
 		protected.Use(middleware.BuildProtectedMiddleware(globalParams)...)
 		{
+			identityrouter.RegisterProtectedRoutes(protected, useCases, log)
 			ordersrouter.RegisterProtectedRoutes(protected, useCases, log)
 			balancerouter.RegisterProtectedRoutes(protected, useCases, log)
 		}
 	}
-	return r
+
+	if webhookSecret != "" {
+		webhook := r.Group("/api/accrual")
+		webhook.Use(middleware.BuildWebhookMiddleware(globalParams)...)
+		ordersrouter.RegisterWebhookRoutes(webhook, useCases, log)
+	}
+
+	if adminToken != "" {
+		admin := r.Group("/api/admin")
+		admin.Use(middleware.BuildAdminMiddleware(globalParams)...)
+		ordersrouter.RegisterAdminRoutes(admin, useCases, log)
+		balancerouter.RegisterAdminRoutes(admin, useCases, log)
+	}
+	return r, nil
 }
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"log"
+
+	"gophermart/cmd/gophermart/bootstrap"
+)
+
+func main() {
+	if err := bootstrap.RunReconcile(); err != nil {
+		log.Fatalf("reconcile: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package accrualsim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"gophermart/internal/pkg/money"
+)
+
+// Amount is an accrual decimal served verbatim, so scripts can reproduce answers
+// with more than two fractional digits that the client has to truncate.
+type Amount json.Number
+
+// MarshalJSON encodes the amount exactly as it was scripted, e.g. 500.5 or 729.989.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a), nil
+}
+
+// UnmarshalJSON decodes any JSON number.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("accrual amount %s: %w", data, err)
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return fmt.Errorf("accrual amount %s: not a number", data)
+	}
+	*a = Amount(n)
+	return nil
+}
+
+// Duration is a time.Duration encoded in JSON as a Go duration string, e.g. "250ms".
+type Duration time.Duration
+
+// UnmarshalJSON decodes a Go duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Step is one scripted answer for an order. Steps are served one per request;
+// the last step repeats once the sequence is exhausted.
+type Step struct {
+	// Status is REGISTERED, PROCESSING, INVALID or PROCESSED.
+	Status string `json:"status,omitempty"`
+	// Accrual is reported with the status; omitted from the response when nil.
+	Accrual *Amount `json:"accrual,omitempty"`
+	// HTTPStatus, when set, answers with this code and an empty body instead of the status.
+	HTTPStatus int `json:"http_status,omitempty"`
+}
+
+// RateLimit rejects requests above Requests per Window with 429.
+type RateLimit struct {
+	// Requests per window; 0 disables the limit.
+	Requests int      `json:"requests"`
+	Window   Duration `json:"window"`
+	// RetryAfter is reported in the Retry-After header; defaults to the rest of the window.
+	RetryAfter Duration `json:"retry_after"`
+	// HTTPDate sends Retry-After as an HTTP-date instead of delay-seconds.
+	HTTPDate bool `json:"http_date"`
+}
+
+// Config scripts the simulator behaviour.
+type Config struct {
+	// Orders maps order numbers to their status sequences.
+	Orders map[string][]Step `json:"orders"`
+	// Default is served for numbers missing from Orders; empty answers 204 (not registered).
+	Default   []Step    `json:"default"`
+	RateLimit RateLimit `json:"rate_limit"`
+	// Latency delays every response.
+	Latency Duration `json:"latency"`
+	// ErrorRate is the share of requests, from 0 to 1, answered with 500.
+	ErrorRate float64 `json:"error_rate"`
+	// Seed makes error injection reproducible; 0 picks a random seed.
+	Seed uint64 `json:"seed"`
+}
+
+// LoadConfig decodes a JSON script.
+func LoadConfig(r io.Reader) (Config, error) {
+	var cfg Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("accrualsim: decode config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Processed is a shortcut for a PROCESSED step with the given accrual in hundredths.
+func Processed(accrual int64) Step {
+	a := Amount(money.Format(accrual))
+	return Step{Status: "PROCESSED", Accrual: &a}
+}
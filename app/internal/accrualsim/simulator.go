@@ -0,0 +1,173 @@
+// Package accrualsim simulates the external accrual system for local runs and tests.
+// Simulator is an http.Handler, so it can be served by cmd/accrual-sim or httptest.NewServer.
+package accrualsim
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type orderState struct {
+	steps    []Step
+	next     int
+	requests int
+}
+
+// Simulator implements GET /api/orders/{number} of the accrual system with scripted answers.
+type Simulator struct {
+	mu          sync.Mutex
+	cfg         Config
+	orders      map[string]*orderState
+	rnd         *rand.Rand
+	windowStart time.Time
+	windowCount int
+	now         func() time.Time
+	mux         *http.ServeMux
+}
+
+// New creates a simulator from the script.
+func New(cfg Config) *Simulator {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	s := &Simulator{
+		cfg:    cfg,
+		orders: make(map[string]*orderState, len(cfg.Orders)),
+		rnd:    rand.New(rand.NewPCG(seed, seed)),
+		now:    time.Now,
+		mux:    http.NewServeMux(),
+	}
+	for number, steps := range cfg.Orders {
+		s.orders[number] = &orderState{steps: steps}
+	}
+	s.mux.HandleFunc("GET /api/orders/{number}", s.getOrder)
+	return s
+}
+
+// SetOrder replaces the status sequence of an order and restarts it.
+func (s *Simulator) SetOrder(number string, steps ...Step) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[number] = &orderState{steps: steps}
+}
+
+// Requests returns how many times the order was requested, including rejected requests.
+func (s *Simulator) Requests(number string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if st, ok := s.orders[number]; ok {
+		return st.requests
+	}
+	return 0
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type orderResponse struct {
+	Order   string  `json:"order"`
+	Status  string  `json:"status"`
+	Accrual *Amount `json:"accrual,omitempty"`
+}
+
+func (s *Simulator) getOrder(w http.ResponseWriter, r *http.Request) {
+	if latency := time.Duration(s.cfg.Latency); latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(latency):
+		}
+	}
+
+	number := r.PathValue("number")
+	step, retryAfter, limited, failed := s.next(number)
+
+	switch {
+	case limited:
+		s.writeRateLimited(w, retryAfter)
+	case failed:
+		w.WriteHeader(http.StatusInternalServerError)
+	case step == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case step.HTTPStatus != 0:
+		w.WriteHeader(step.HTTPStatus)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(orderResponse{Order: number, Status: step.Status, Accrual: step.Accrual})
+	}
+}
+
+// next applies the rate limit and error injection, then advances the order script.
+// A nil step means the order is not registered.
+func (s *Simulator) next(number string) (step *Step, retryAfter time.Duration, limited, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.orders[number]
+	if !ok && len(s.cfg.Default) > 0 {
+		st = &orderState{steps: s.cfg.Default}
+		s.orders[number] = st
+	}
+	if st != nil {
+		st.requests++
+	}
+
+	if retryAfter, limited = s.rateLimited(); limited {
+		return nil, retryAfter, true, false
+	}
+	if s.cfg.ErrorRate > 0 && s.rnd.Float64() < s.cfg.ErrorRate {
+		return nil, 0, false, true
+	}
+	if st == nil || len(st.steps) == 0 {
+		return nil, 0, false, false
+	}
+
+	current := st.steps[min(st.next, len(st.steps)-1)]
+	st.next++
+	return &current, 0, false, false
+}
+
+// rateLimited counts the request in a fixed window and reports whether it exceeds the limit.
+func (s *Simulator) rateLimited() (time.Duration, bool) {
+	rl := s.cfg.RateLimit
+	if rl.Requests <= 0 || rl.Window <= 0 {
+		return 0, false
+	}
+
+	now := s.now()
+	window := time.Duration(rl.Window)
+	if now.Sub(s.windowStart) >= window {
+		s.windowStart = now
+		s.windowCount = 0
+	}
+	s.windowCount++
+	if s.windowCount <= rl.Requests {
+		return 0, false
+	}
+
+	if rl.RetryAfter > 0 {
+		return time.Duration(rl.RetryAfter), true
+	}
+	return s.windowStart.Add(window).Sub(now), true
+}
+
+func (s *Simulator) writeRateLimited(w http.ResponseWriter, retryAfter time.Duration) {
+	if s.cfg.RateLimit.HTTPDate {
+		w.Header().Set("Retry-After", s.now().Add(retryAfter).UTC().Format(http.TimeFormat))
+	} else {
+		// Round up so clients never retry before the window resets.
+		seconds := int((retryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = fmt.Fprintf(w, "No more than %d requests per %s allowed", s.cfg.RateLimit.Requests, time.Duration(s.cfg.RateLimit.Window))
+}
@@ -0,0 +1,177 @@
+package accrualsim
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gophermart/internal/gophermart/application"
+	ordersaccrual "gophermart/internal/gophermart/modules/orders/adapters/accrual"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+func newTestClient(t *testing.T, sim *Simulator) *ordersaccrual.Client {
+	t.Helper()
+	srv := httptest.NewServer(sim)
+	t.Cleanup(srv.Close)
+	return ordersaccrual.NewClient(srv.URL, srv.Client())
+}
+
+func TestSimulator_StatusSequence(t *testing.T) {
+	sim := New(Config{Orders: map[string][]Step{
+		"12345678903": {{Status: "REGISTERED"}, {Status: "PROCESSING"}, Processed(50050)},
+	}})
+	client := newTestClient(t, sim)
+	ctx := context.Background()
+
+	var statuses []string
+	for range 4 {
+		info, err := client.GetOrderAccrual(ctx, "12345678903")
+		require.NoError(t, err)
+		statuses = append(statuses, info.Status)
+	}
+
+	assert.Equal(t, []string{"REGISTERED", "PROCESSING", "PROCESSED", "PROCESSED"}, statuses)
+	info, err := client.GetOrderAccrual(ctx, "12345678903")
+	require.NoError(t, err)
+	assert.Equal(t, vo.Points(50050), *info.Accrual)
+	assert.Equal(t, 5, sim.Requests("12345678903"))
+}
+
+func TestSimulator_FractionalAccrual(t *testing.T) {
+	cfg, err := LoadConfig(strings.NewReader(`{"orders": {"12345678903": [{"status": "PROCESSED", "accrual": 729.989}]}}`))
+	require.NoError(t, err)
+	client := newTestClient(t, New(cfg))
+
+	info, err := client.GetOrderAccrual(context.Background(), "12345678903")
+
+	require.NoError(t, err)
+	assert.Equal(t, vo.Points(72998), *info.Accrual)
+}
+
+func TestSimulator_UnknownOrder(t *testing.T) {
+	client := newTestClient(t, New(Config{}))
+
+	info, err := client.GetOrderAccrual(context.Background(), "12345678903")
+
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+}
+
+func TestSimulator_DefaultScript(t *testing.T) {
+	sim := New(Config{Default: []Step{{Status: "PROCESSING"}, {Status: "INVALID"}}})
+	client := newTestClient(t, sim)
+	ctx := context.Background()
+
+	first, err := client.GetOrderAccrual(ctx, "79927398713")
+	require.NoError(t, err)
+	second, err := client.GetOrderAccrual(ctx, "79927398713")
+	require.NoError(t, err)
+	other, err := client.GetOrderAccrual(ctx, "12345678903")
+	require.NoError(t, err)
+
+	assert.Equal(t, "PROCESSING", first.Status)
+	assert.Equal(t, "INVALID", second.Status)
+	assert.Equal(t, "PROCESSING", other.Status, "each unknown number starts its own sequence")
+}
+
+func TestSimulator_RateLimit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	sim := New(Config{
+		Orders:    map[string][]Step{"12345678903": {{Status: "PROCESSING"}}},
+		RateLimit: RateLimit{Requests: 1, Window: Duration(time.Minute)},
+	})
+	sim.now = func() time.Time { return now }
+	srv := httptest.NewServer(sim)
+	defer srv.Close()
+	ctx := context.Background()
+
+	_, err := ordersaccrual.NewClient(srv.URL, srv.Client()).GetOrderAccrual(ctx, "12345678903")
+	require.NoError(t, err)
+
+	now = now.Add(15 * time.Second)
+	_, err = ordersaccrual.NewClient(srv.URL, srv.Client()).GetOrderAccrual(ctx, "12345678903")
+	var rl *application.ErrRateLimit
+	require.ErrorAs(t, err, &rl)
+	assert.Equal(t, 45*time.Second, rl.RetryAfter)
+
+	// A fresh client: the throttled one would honour the real-time pause.
+	now = now.Add(45 * time.Second)
+	_, err = ordersaccrual.NewClient(srv.URL, srv.Client()).GetOrderAccrual(ctx, "12345678903")
+	assert.NoError(t, err)
+}
+
+func TestSimulator_RateLimitHTTPDate(t *testing.T) {
+	sim := New(Config{RateLimit: RateLimit{
+		Requests: 1, Window: Duration(time.Minute), RetryAfter: Duration(30 * time.Second), HTTPDate: true,
+	}})
+	srv := httptest.NewServer(sim)
+	defer srv.Close()
+
+	for range 2 {
+		resp, err := srv.Client().Get(srv.URL + "/api/orders/12345678903")
+		require.NoError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusTooManyRequests {
+			at, err := http.ParseTime(resp.Header.Get("Retry-After"))
+			require.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(30*time.Second), at, 2*time.Second)
+		}
+	}
+}
+
+func TestSimulator_ErrorInjection(t *testing.T) {
+	t.Run("scripted status code", func(t *testing.T) {
+		sim := New(Config{Orders: map[string][]Step{
+			"12345678903": {{HTTPStatus: http.StatusInternalServerError}, {Status: "PROCESSING"}},
+		}})
+		client := newTestClient(t, sim)
+		ctx := context.Background()
+
+		_, err := client.GetOrderAccrual(ctx, "12345678903")
+		assert.ErrorContains(t, err, "500")
+
+		info, err := client.GetOrderAccrual(ctx, "12345678903")
+		require.NoError(t, err)
+		assert.Equal(t, "PROCESSING", info.Status)
+	})
+
+	t.Run("error rate", func(t *testing.T) {
+		client := newTestClient(t, New(Config{
+			Orders:    map[string][]Step{"12345678903": {{Status: "PROCESSING"}}},
+			ErrorRate: 1,
+		}))
+
+		_, err := client.GetOrderAccrual(context.Background(), "12345678903")
+
+		assert.ErrorContains(t, err, "500")
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := LoadConfig(strings.NewReader(`{
+		"orders": {"12345678903": [{"status": "PROCESSING"}, {"status": "PROCESSED", "accrual": 500.5}]},
+		"rate_limit": {"requests": 10, "window": "1m"},
+		"latency": "20ms",
+		"error_rate": 0.1
+	}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, []Step{{Status: "PROCESSING"}, Processed(50050)}, cfg.Orders["12345678903"])
+	assert.Equal(t, RateLimit{Requests: 10, Window: Duration(time.Minute)}, cfg.RateLimit)
+	assert.Equal(t, Duration(20*time.Millisecond), cfg.Latency)
+	assert.Equal(t, 0.1, cfg.ErrorRate)
+
+	cfg, err = LoadConfig(strings.NewReader(`{"orders": {"1": [{"status": "PROCESSED", "accrual": 0.001}]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, Amount("0.001"), *cfg.Orders["1"][0].Accrual)
+
+	_, err = LoadConfig(strings.NewReader(`{"orders": {"1": [{"status": "PROCESSED", "accrual": "500.5"}]}}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// minorExp is the decimal exponent of minor units (hundredths).
+const minorExp = -2
+
+// NumericFromMinor converts an amount in minor units (hundredths) into a NUMERIC value with scale 2.
+func NumericFromMinor(v int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(v), Exp: minorExp, Valid: true}
+}
+
+// MinorFromNumeric converts a NUMERIC value into minor units (hundredths).
+// Columns are declared NUMERIC(18,2), so the value always fits int64 without rounding;
+// a NULL, NaN or infinite value yields zero.
+func MinorFromNumeric(n pgtype.Numeric) int64 {
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite || n.Int == nil {
+		return 0
+	}
+
+	v := new(big.Int).Set(n.Int)
+	switch shift := n.Exp - minorExp; {
+	case shift > 0:
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	case shift < 0:
+		v.Quo(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil))
+	}
+	return v.Int64()
+}
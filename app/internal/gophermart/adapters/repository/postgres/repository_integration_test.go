@@ -13,13 +13,17 @@ import (
 	"gophermart/internal/gophermart/adapters/repository/postgres"
 	"gophermart/internal/gophermart/application"
 	balancerepopostgres "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres"
+	balanceport "gophermart/internal/gophermart/modules/balance/application/port"
 	balanceentity "gophermart/internal/gophermart/modules/balance/domain/entity"
 	balancevo "gophermart/internal/gophermart/modules/balance/domain/vo"
 	identityrepopostgres "gophermart/internal/gophermart/modules/identity/adapters/repository/postgres"
 	identityentity "gophermart/internal/gophermart/modules/identity/domain/entity"
+	identityvo "gophermart/internal/gophermart/modules/identity/domain/vo"
 	ordersrepopostgres "gophermart/internal/gophermart/modules/orders/adapters/repository/postgres"
+	ordersport "gophermart/internal/gophermart/modules/orders/application/port"
 	ordersentity "gophermart/internal/gophermart/modules/orders/domain/entity"
 	ordersvo "gophermart/internal/gophermart/modules/orders/domain/vo"
+	"gophermart/internal/pkg/cursor"
 	"gophermart/internal/pkg/testutil"
 )
 
@@ -92,6 +96,241 @@ func TestUserRepository_CreateDuplicate(t *testing.T) {
 	assert.ErrorIs(t, err, application.ErrAlreadyExists)
 }
 
+func TestUserRepository_UpdatePasswordHash(t *testing.T) {
+	tx := setupTransactor(t)
+	repo := identityrepopostgres.NewUserRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, repo, "password-user", now)
+
+	require.NoError(t, repo.UpdatePasswordHash(ctx, user.ID, user.PasswordHash, "new-hash"))
+	assert.ErrorIs(t, repo.UpdatePasswordHash(ctx, user.ID, user.PasswordHash, "other-hash"), application.ErrConflict)
+
+	found, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", found.PasswordHash)
+}
+
+// --- SessionRepository ---
+
+func TestSessionRepository_Lifecycle(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	sessionRepo := identityrepopostgres.NewSessionRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "session-user", now)
+
+	s := identityentity.NewSession(user.ID, now, time.Hour, 24*time.Hour)
+	require.NoError(t, sessionRepo.Create(ctx, s))
+	require.NotZero(t, s.ID)
+
+	require.NoError(t, sessionRepo.AddRefreshToken(ctx, identityentity.RefreshToken{Hash: "hash-1", SessionID: s.ID, IssuedAt: now}))
+	token, err := sessionRepo.FindRefreshToken(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, s.ID, token.SessionID)
+	assert.Nil(t, token.UsedAt)
+
+	require.NoError(t, sessionRepo.UseRefreshToken(ctx, "hash-1", now))
+	assert.ErrorIs(t, sessionRepo.UseRefreshToken(ctx, "hash-1", now), application.ErrConflict)
+	token, err = sessionRepo.FindRefreshToken(ctx, "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, token.UsedAt)
+
+	_, err = sessionRepo.FindRefreshToken(ctx, "unknown")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+
+	s.Extend(now, 2*time.Hour)
+	s.Revoke(now)
+	require.NoError(t, sessionRepo.Update(ctx, s))
+
+	found, err := sessionRepo.FindByID(ctx, s.ID)
+	require.NoError(t, err)
+	assert.True(t, now.Add(2*time.Hour).Equal(found.ExpiresAt))
+	assert.True(t, now.Add(24*time.Hour).Equal(found.MaxExpiresAt))
+	require.NotNil(t, found.RevokedAt)
+	assert.False(t, found.Active(now))
+
+	_, err = sessionRepo.FindByID(ctx, s.ID+1)
+	assert.ErrorIs(t, err, application.ErrNotFound)
+}
+
+func TestSessionRepository_UpdateKeepsRevocation(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	sessionRepo := identityrepopostgres.NewSessionRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "session-race-user", now)
+	s := identityentity.NewSession(user.ID, now, time.Hour, 24*time.Hour)
+	require.NoError(t, sessionRepo.Create(ctx, s))
+
+	// A refresh holding a copy read before the logout cannot clear the revocation.
+	stale := *s
+	require.NoError(t, sessionRepo.RevokeByUserID(ctx, user.ID, 0, now))
+	stale.Extend(now, 2*time.Hour)
+	assert.ErrorIs(t, sessionRepo.Update(ctx, &stale), application.ErrConflict)
+
+	found, err := sessionRepo.FindByID(ctx, s.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.RevokedAt)
+	assert.True(t, now.Add(time.Hour).Equal(found.ExpiresAt))
+
+	// Revoking again keeps the first revocation time.
+	s.Revoke(now.Add(time.Minute))
+	require.NoError(t, sessionRepo.Update(ctx, s))
+	found, err = sessionRepo.FindByID(ctx, s.ID)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(*found.RevokedAt))
+}
+
+func TestSessionRepository_DeleteStale(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	sessionRepo := identityrepopostgres.NewSessionRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "session-purge-user", now)
+
+	expired := identityentity.NewSession(user.ID, now.Add(-3*time.Hour), time.Hour, 24*time.Hour)
+	live := identityentity.NewSession(user.ID, now, time.Hour, 24*time.Hour)
+	for _, s := range []*identityentity.Session{expired, live} {
+		require.NoError(t, sessionRepo.Create(ctx, s))
+	}
+	for _, tok := range []identityentity.RefreshToken{
+		{Hash: "purge-expired", SessionID: expired.ID, IssuedAt: now.Add(-3 * time.Hour)},
+		{Hash: "purge-used", SessionID: live.ID, IssuedAt: now.Add(-2 * time.Hour)},
+		{Hash: "purge-fresh", SessionID: live.ID, IssuedAt: now},
+	} {
+		require.NoError(t, sessionRepo.AddRefreshToken(ctx, tok))
+	}
+	require.NoError(t, sessionRepo.UseRefreshToken(ctx, "purge-used", now.Add(-2*time.Hour)))
+
+	deleted, err := sessionRepo.DeleteStale(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	_, err = sessionRepo.FindByID(ctx, expired.ID)
+	assert.ErrorIs(t, err, application.ErrNotFound)
+	for _, hash := range []string{"purge-expired", "purge-used"} {
+		_, err = sessionRepo.FindRefreshToken(ctx, hash)
+		assert.ErrorIs(t, err, application.ErrNotFound, hash)
+	}
+	_, err = sessionRepo.FindRefreshToken(ctx, "purge-fresh")
+	assert.NoError(t, err)
+}
+
+func TestSessionRepository_RevokeByUserID(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	sessionRepo := identityrepopostgres.NewSessionRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "revoke-user", now)
+	other := createTestUser(t, userRepo, "revoke-other", now)
+
+	kept := identityentity.NewSession(user.ID, now, time.Hour, 24*time.Hour)
+	revoked := identityentity.NewSession(user.ID, now, time.Hour, 24*time.Hour)
+	foreign := identityentity.NewSession(other.ID, now, time.Hour, 24*time.Hour)
+	for _, s := range []*identityentity.Session{kept, revoked, foreign} {
+		require.NoError(t, sessionRepo.Create(ctx, s))
+	}
+
+	require.NoError(t, sessionRepo.RevokeByUserID(ctx, user.ID, kept.ID, now))
+
+	for _, tc := range []struct {
+		session *identityentity.Session
+		active  bool
+	}{{kept, true}, {revoked, false}, {foreign, true}} {
+		found, err := sessionRepo.FindByID(ctx, tc.session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tc.active, found.Active(now), "session %d", tc.session.ID)
+	}
+}
+
+// --- LoginFailureRepository ---
+
+func TestLoginFailureRepository_Lifecycle(t *testing.T) {
+	tx := setupTransactor(t)
+	repo := identityrepopostgres.NewLoginFailureRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	policy := identityvo.LoginThrottlePolicy{LockoutThreshold: 2, LockoutDuration: time.Hour, Window: time.Hour}
+
+	_, err := repo.Find(ctx, "login:alice")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+
+	record := func(f *identityentity.LoginFailures) { f.RecordFailure(policy, now) }
+	require.NoError(t, repo.Update(ctx, "login:alice", func(f *identityentity.LoginFailures) {
+		assert.Zero(t, f.Count, "missing key starts empty")
+		record(f)
+	}))
+	require.NoError(t, repo.Update(ctx, "login:alice", record))
+
+	found, err := repo.Find(ctx, "login:alice")
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.Count)
+	assert.True(t, now.Equal(found.LastFailedAt))
+	require.NotNil(t, found.LockedUntil)
+	assert.True(t, now.Add(time.Hour).Equal(*found.LockedUntil))
+
+	require.NoError(t, repo.Delete(ctx, "login:alice"))
+	_, err = repo.Find(ctx, "login:alice")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+}
+
+func TestLoginFailureRepository_ConcurrentUpdates(t *testing.T) {
+	tx := setupTransactor(t)
+	repo := identityrepopostgres.NewLoginFailureRepository(tx)
+	ctx := context.Background()
+
+	const n = 10
+	errs := make(chan error, n)
+	for range n {
+		go func() {
+			errs <- repo.Update(ctx, "ip:10.0.0.1", func(f *identityentity.LoginFailures) { f.Count++ })
+		}()
+	}
+	for range n {
+		require.NoError(t, <-errs)
+	}
+
+	found, err := repo.Find(ctx, "ip:10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, n, found.Count)
+}
+
+func TestLoginFailureRepository_DeleteStale(t *testing.T) {
+	tx := setupTransactor(t)
+	repo := identityrepopostgres.NewLoginFailureRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	lockedUntil := now.Add(time.Hour)
+
+	set := func(key string, last time.Time, locked *time.Time) {
+		require.NoError(t, repo.Update(ctx, key, func(f *identityentity.LoginFailures) {
+			f.Count, f.LastFailedAt, f.LockedUntil = 1, last, locked
+		}))
+	}
+	set("login:stale", now.Add(-2*time.Hour), nil)
+	set("login:recent", now, nil)
+	set("login:locked", now.Add(-2*time.Hour), &lockedUntil)
+
+	deleted, err := repo.DeleteStale(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, err = repo.Find(ctx, "login:stale")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+	_, err = repo.Find(ctx, "login:locked")
+	assert.NoError(t, err)
+}
+
 // --- OrderRepository ---
 
 func TestOrderRepository_CreateAndFindByNumber(t *testing.T) {
@@ -136,31 +375,138 @@ func TestOrderRepository_ListByUserID(t *testing.T) {
 		require.NoError(t, orderRepo.Create(context.Background(), o))
 	}
 
-	orders, err := orderRepo.ListByUserID(context.Background(), ordersvo.UserID(user.ID))
+	orders, err := orderRepo.ListByUserID(context.Background(), ordersvo.UserID(user.ID), ordersport.OrderFilter{})
 	require.NoError(t, err)
 	assert.Len(t, orders, 2)
 	// Sorted by uploaded_at DESC, so the second inserted order comes first.
 	assert.Equal(t, ordersvo.OrderNumber("22222222222"), orders[0].Number)
 }
 
-func TestOrderRepository_ListByStatuses(t *testing.T) {
+func TestOrderRepository_ListByUserID_FilterAndPage(t *testing.T) {
 	tx := setupTransactor(t)
 	userRepo := identityrepopostgres.NewUserRepository(tx)
 	orderRepo := ordersrepopostgres.NewOrderRepository(tx)
 	now := time.Now().UTC().Truncate(time.Microsecond)
+	ctx := context.Background()
+
+	user := createTestUser(t, userRepo, "page-user", now)
+	userID := ordersvo.UserID(user.ID)
+
+	// Two orders share an upload time so the order number breaks the tie.
+	for _, o := range []*ordersentity.Order{
+		{Number: "11111111111", UserID: userID, Status: ordersentity.OrderStatusNew, UploadedAt: now},
+		{Number: "22222222222", UserID: userID, Status: ordersentity.OrderStatusNew, UploadedAt: now},
+		{Number: "33333333333", UserID: userID, Status: ordersentity.OrderStatusInvalid, UploadedAt: now.Add(time.Second)},
+		{Number: "44444444444", UserID: userID, Status: ordersentity.OrderStatusNew, UploadedAt: now.Add(2 * time.Second)},
+	} {
+		require.NoError(t, orderRepo.Create(ctx, o))
+	}
 
-	user := createTestUser(t, userRepo, "status-user", now)
+	filter := ordersport.OrderFilter{Statuses: []ordersentity.OrderStatus{ordersentity.OrderStatusNew}, Limit: 2}
+	page, err := orderRepo.ListByUserID(ctx, userID, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, ordersvo.OrderNumber("44444444444"), page[0].Number)
+	assert.Equal(t, ordersvo.OrderNumber("22222222222"), page[1].Number)
 
-	newOrder := &ordersentity.Order{Number: "33333333333", UserID: ordersvo.UserID(user.ID), Status: ordersentity.OrderStatusNew, UploadedAt: now}
-	require.NoError(t, orderRepo.Create(context.Background(), newOrder))
+	filter.After = &cursor.Cursor{At: page[1].UploadedAt, Key: page[1].Number.String()}
+	page, err = orderRepo.ListByUserID(ctx, userID, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, ordersvo.OrderNumber("11111111111"), page[0].Number)
+
+	from, to := now.Add(time.Second), now.Add(2*time.Second)
+	page, err = orderRepo.ListByUserID(ctx, userID, ordersport.OrderFilter{From: &from, To: &to})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, ordersvo.OrderNumber("33333333333"), page[0].Number)
+}
+
+func TestOrderRepository_ListAccruedByUserID(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	orderRepo := ordersrepopostgres.NewOrderRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	ctx := context.Background()
+
+	user := createTestUser(t, userRepo, "accrued-user", now)
+	userID := ordersvo.UserID(user.ID)
+	later := now.Add(time.Hour)
+	trigger := ordersentity.Trigger{Source: ordersentity.StatusSourceAccrualPoll, RawStatus: "PROCESSED"}
+
+	for _, tc := range []struct {
+		number      ordersvo.OrderNumber
+		accrual     ordersvo.Points
+		processedAt time.Time
+	}{
+		{number: "11111111111", accrual: 500, processedAt: later},
+		{number: "22222222222", accrual: 300, processedAt: now},
+		{number: "33333333333", accrual: 0, processedAt: now},
+	} {
+		o := &ordersentity.Order{Number: tc.number, UserID: userID, Status: ordersentity.OrderStatusNew, UploadedAt: now}
+		require.NoError(t, orderRepo.Create(ctx, o))
+		require.NoError(t, o.MarkProcessed(tc.accrual, trigger, tc.processedAt))
+		require.NoError(t, orderRepo.Update(ctx, o))
+	}
+	pending := &ordersentity.Order{Number: "44444444444", UserID: userID, Status: ordersentity.OrderStatusNew, UploadedAt: now}
+	require.NoError(t, orderRepo.Create(ctx, pending))
 
-	procOrder := &ordersentity.Order{Number: "44444444444", UserID: ordersvo.UserID(user.ID), Status: ordersentity.OrderStatusProcessed, UploadedAt: now, Accrual: ptrFloat(100)}
-	require.NoError(t, orderRepo.Create(context.Background(), procOrder))
+	orders, err := orderRepo.ListAccruedByUserID(ctx, userID, now, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, ordersvo.OrderNumber("22222222222"), orders[0].Number)
+	assert.Equal(t, ordersvo.OrderNumber("11111111111"), orders[1].Number)
 
-	orders, err := orderRepo.ListByStatuses(context.Background(), []ordersentity.OrderStatus{ordersentity.OrderStatusNew}, 10)
+	orders, err = orderRepo.ListAccruedByUserID(ctx, userID, now, later)
 	require.NoError(t, err)
-	assert.Len(t, orders, 1)
-	assert.Equal(t, ordersentity.OrderStatusNew, orders[0].Status)
+	require.Len(t, orders, 1)
+	assert.Equal(t, ordersvo.OrderNumber("22222222222"), orders[0].Number)
+}
+
+func TestOrderRepository_ClaimByStatuses(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	orderRepo := ordersrepopostgres.NewOrderRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	ctx := context.Background()
+	statuses := []ordersentity.OrderStatus{ordersentity.OrderStatusNew}
+
+	user := createTestUser(t, userRepo, "claim-user", now)
+
+	due := ordersentity.NewOrder("77777777777", ordersvo.UserID(user.ID), now)
+	require.NoError(t, orderRepo.Create(ctx, due))
+	later := ordersentity.NewOrder("88888888888", ordersvo.UserID(user.ID), now)
+	later.NextPollAt = now.Add(time.Hour)
+	require.NoError(t, orderRepo.Create(ctx, later))
+
+	collect := func(owner string, at time.Time) []ordersentity.Order {
+		var claimed []ordersentity.Order
+		for o, err := range orderRepo.ClaimByStatuses(ctx, statuses, owner, at, time.Minute, 10) {
+			require.NoError(t, err)
+			claimed = append(claimed, o)
+		}
+		return claimed
+	}
+
+	first := collect("instance-a", now)
+	require.Len(t, first, 1)
+	assert.Equal(t, due.Number, first[0].Number)
+
+	// Leased by instance-a: another instance gets nothing until the lease expires.
+	assert.Empty(t, collect("instance-b", now))
+
+	reclaimed := collect("instance-b", now.Add(2*time.Minute))
+	require.Len(t, reclaimed, 1)
+	assert.Equal(t, due.Number, reclaimed[0].Number)
+	require.NotNil(t, reclaimed[0].Lease)
+	assert.Equal(t, "instance-b", reclaimed[0].Lease.Owner)
+
+	// instance-a lost the order to instance-b: its late update must not overwrite the new claim.
+	assert.ErrorIs(t, orderRepo.Update(ctx, &first[0]), application.ErrConflict)
+
+	// Update releases the lease.
+	require.NoError(t, orderRepo.Update(ctx, &reclaimed[0]))
+	assert.Len(t, collect("instance-a", now.Add(2*time.Minute)), 1)
 }
 
 func TestOrderRepository_Update(t *testing.T) {
@@ -175,15 +521,76 @@ func TestOrderRepository_Update(t *testing.T) {
 	require.NoError(t, orderRepo.Create(context.Background(), o))
 
 	processedAt := now.Add(time.Minute)
-	accrual := ordersvo.Points(250.5)
-	o.MarkProcessed(accrual, processedAt)
+	accrual := ordersvo.Points(25050)
+	require.NoError(t, o.MarkProcessed(accrual, ordersentity.Trigger{Source: ordersentity.StatusSourceAccrualPoll, RawStatus: "PROCESSED"}, processedAt))
 	require.NoError(t, orderRepo.Update(context.Background(), o))
 
 	found, err := orderRepo.FindByNumber(context.Background(), o.Number)
 	require.NoError(t, err)
 	assert.Equal(t, ordersentity.OrderStatusProcessed, found.Status)
 	require.NotNil(t, found.Accrual)
-	assert.InDelta(t, 250.5, float64(*found.Accrual), 0.01)
+	assert.Equal(t, ordersvo.Points(25050), *found.Accrual)
+}
+
+func TestOrderRepository_UpdateStaleStatusConflict(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	orderRepo := ordersrepopostgres.NewOrderRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "cas-user", now)
+	require.NoError(t, orderRepo.Create(ctx, ordersentity.NewOrder("12345678903", ordersvo.UserID(user.ID), now)))
+
+	// Two processors load the same NEW order.
+	first, err := orderRepo.FindByNumber(ctx, "12345678903")
+	require.NoError(t, err)
+	stale, err := orderRepo.FindByNumber(ctx, "12345678903")
+	require.NoError(t, err)
+
+	trigger := ordersentity.Trigger{Source: ordersentity.StatusSourceWebhook, RawStatus: "PROCESSED"}
+	require.NoError(t, first.MarkProcessed(100, trigger, now))
+	require.NoError(t, orderRepo.Update(ctx, first))
+
+	require.NoError(t, stale.MarkProcessing(trigger, now))
+	assert.ErrorIs(t, orderRepo.Update(ctx, stale), application.ErrConflict)
+
+	found, err := orderRepo.FindByNumber(ctx, "12345678903")
+	require.NoError(t, err)
+	assert.Equal(t, ordersentity.OrderStatusProcessed, found.Status)
+	history, err := orderRepo.StatusHistory(ctx, "12345678903")
+	require.NoError(t, err)
+	assert.Len(t, history, 2, "rejected update records no history")
+}
+
+func TestOrderRepository_StatusHistory(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	orderRepo := ordersrepopostgres.NewOrderRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "history-user", now)
+
+	o := ordersentity.NewOrder("66666666666", ordersvo.UserID(user.ID), now)
+	require.NoError(t, orderRepo.Create(context.Background(), o))
+	assert.Empty(t, o.Changes)
+
+	trigger := ordersentity.Trigger{Source: ordersentity.StatusSourceAccrualPoll, RawStatus: "REGISTERED"}
+	require.NoError(t, o.MarkProcessing(trigger, now.Add(time.Second)))
+	require.NoError(t, o.MarkProcessing(trigger, now.Add(2*time.Second)))
+	require.NoError(t, orderRepo.Update(context.Background(), o))
+
+	history, err := orderRepo.StatusHistory(context.Background(), o.Number)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, ordersentity.OrderStatus(""), history[0].From)
+	assert.Equal(t, ordersentity.OrderStatusNew, history[0].To)
+	assert.Equal(t, ordersentity.StatusSourceUpload, history[0].Source)
+	assert.True(t, now.Equal(history[0].At))
+	assert.Equal(t, ordersentity.OrderStatusNew, history[1].From)
+	assert.Equal(t, ordersentity.OrderStatusProcessing, history[1].To)
+	assert.Equal(t, "REGISTERED", history[1].RawStatus)
+	assert.True(t, now.Add(time.Second).Equal(history[1].At))
 }
 
 func TestOrderRepository_CreateDuplicate(t *testing.T) {
@@ -202,6 +609,139 @@ func TestOrderRepository_CreateDuplicate(t *testing.T) {
 	assert.ErrorIs(t, err, application.ErrAlreadyExists)
 }
 
+func TestOrderRepository_UpdateWithBonuses(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	orderRepo := ordersrepopostgres.NewOrderRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "bonus-user", now)
+	userID := ordersvo.UserID(user.ID)
+	o := ordersentity.NewOrder("12345678903", userID, now)
+	require.NoError(t, orderRepo.Create(ctx, o))
+
+	processed, err := orderRepo.HasProcessed(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	trigger := ordersentity.Trigger{Source: ordersentity.StatusSourceWebhook, RawStatus: "PROCESSED"}
+	require.NoError(t, o.MarkProcessed(10000, trigger, now))
+	bonuses := []ordersentity.OrderBonus{
+		{CampaignID: 1, CampaignName: "Double weekend", Amount: 10000},
+		{CampaignID: 2, CampaignName: "First order", Amount: 5000},
+	}
+	o.AddBonuses(bonuses)
+	require.NoError(t, orderRepo.Update(ctx, o))
+
+	found, err := orderRepo.FindByNumber(ctx, o.Number)
+	require.NoError(t, err)
+	assert.Equal(t, ptrPoints(25000), found.Accrual)
+	assert.Equal(t, bonuses, found.Bonuses)
+
+	listed, err := orderRepo.ListByUserID(ctx, userID, ordersport.OrderFilter{})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, bonuses, listed[0].Bonuses)
+
+	processed, err = orderRepo.HasProcessed(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, processed)
+}
+
+func TestOrderRepository_ClaimFirstOrder(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	orderRepo := ordersrepopostgres.NewOrderRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "first-order-user", now)
+	userID := ordersvo.UserID(user.ID)
+	first := ordersentity.NewOrder("12345678903", userID, now)
+	second := ordersentity.NewOrder("2377225624", userID, now)
+	for _, o := range []*ordersentity.Order{first, second} {
+		require.NoError(t, orderRepo.Create(ctx, o))
+	}
+
+	claimed, err := orderRepo.ClaimFirstOrder(ctx, userID, first.Number)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = orderRepo.ClaimFirstOrder(ctx, userID, second.Number)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+}
+
+// --- CampaignRepository ---
+
+func TestCampaignRepository_CRUD(t *testing.T) {
+	tx := setupTransactor(t)
+	repo := ordersrepopostgres.NewCampaignRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	c := &ordersentity.Campaign{
+		Name: "Double weekend", Kind: ordersentity.CampaignKindMultiplier, Multiplier: 200,
+		StartsAt: now, EndsAt: now.Add(48 * time.Hour), CreatedAt: now, UpdatedAt: now,
+	}
+	require.NoError(t, repo.Create(ctx, c))
+	require.NotZero(t, c.ID)
+
+	found, err := repo.FindByID(ctx, c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ordersvo.Multiplier(200), found.Multiplier)
+	assert.Zero(t, found.Bonus)
+
+	c.Kind, c.Multiplier, c.Bonus, c.FirstOrderOnly = ordersentity.CampaignKindBonus, 0, 5000, true
+	require.NoError(t, repo.Update(ctx, c))
+
+	found, err = repo.FindByID(ctx, c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ordersentity.CampaignKindBonus, found.Kind)
+	assert.Zero(t, found.Multiplier)
+	assert.Equal(t, ordersvo.Points(5000), found.Bonus)
+	assert.True(t, found.FirstOrderOnly)
+
+	require.NoError(t, repo.Delete(ctx, c.ID))
+	_, err = repo.FindByID(ctx, c.ID)
+	assert.ErrorIs(t, err, application.ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, c.ID), application.ErrNotFound)
+	assert.ErrorIs(t, repo.Update(ctx, c), application.ErrNotFound)
+}
+
+func TestCampaignRepository_ListActive(t *testing.T) {
+	tx := setupTransactor(t)
+	repo := ordersrepopostgres.NewCampaignRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	periods := []struct{ from, to time.Time }{
+		{now.Add(-time.Hour), now.Add(time.Hour)},
+		{now.Add(-2 * time.Hour), now},
+		{now.Add(time.Hour), now.Add(2 * time.Hour)},
+	}
+	ids := make([]int64, 0, len(periods))
+	for _, p := range periods {
+		c := &ordersentity.Campaign{
+			Name: "Bonus", Kind: ordersentity.CampaignKindBonus, Bonus: 100,
+			StartsAt: p.from, EndsAt: p.to, CreatedAt: now, UpdatedAt: now,
+		}
+		require.NoError(t, repo.Create(ctx, c))
+		ids = append(ids, c.ID)
+	}
+
+	active, err := repo.ListActive(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, active, 1, "period end is exclusive")
+	assert.Equal(t, ids[0], active[0].ID)
+
+	all, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, ids[2], all[0].ID, "latest start first")
+}
+
 // --- BalanceAccountRepository ---
 
 func TestBalanceAccountRepository_CreateAndFindByUserID(t *testing.T) {
@@ -214,7 +754,7 @@ func TestBalanceAccountRepository_CreateAndFindByUserID(t *testing.T) {
 
 	acc := &balanceentity.BalanceAccount{
 		UserID:    balancevo.UserID(user.ID),
-		Current:   100.5,
+		Current:   10050,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Version:   0,
@@ -224,7 +764,7 @@ func TestBalanceAccountRepository_CreateAndFindByUserID(t *testing.T) {
 	found, err := balanceRepo.FindByUserID(context.Background(), balancevo.UserID(user.ID))
 	require.NoError(t, err)
 	assert.Equal(t, balancevo.UserID(user.ID), found.UserID)
-	assert.InDelta(t, 100.5, float64(found.Current), 0.01)
+	assert.Equal(t, balancevo.Points(10050), found.Current)
 	assert.Equal(t, int64(0), found.Version)
 }
 
@@ -247,8 +787,8 @@ func TestBalanceAccountRepository_Update(t *testing.T) {
 
 	found, err := balanceRepo.FindByUserID(context.Background(), balancevo.UserID(user.ID))
 	require.NoError(t, err)
-	assert.InDelta(t, 150, float64(found.Current), 0.01)
-	assert.InDelta(t, 50, float64(found.WithdrawnTotal), 0.01)
+	assert.Equal(t, balancevo.Points(150), found.Current)
+	assert.Equal(t, balancevo.Points(50), found.WithdrawnTotal)
 	assert.Equal(t, int64(1), found.Version)
 }
 
@@ -277,6 +817,34 @@ func TestBalanceAccountRepository_OptimisticLock(t *testing.T) {
 	assert.ErrorIs(t, err, application.ErrOptimisticLock)
 }
 
+func TestBalanceAccountRepository_ListUserIDs(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	balanceRepo := balancerepopostgres.NewBalanceAccountRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	var ids []balancevo.UserID
+	for _, login := range []string{"list-ids-1", "list-ids-2", "list-ids-3"} {
+		user := createTestUser(t, userRepo, login, now)
+		acc := &balanceentity.BalanceAccount{UserID: balancevo.UserID(user.ID), CreatedAt: now, UpdatedAt: now}
+		require.NoError(t, balanceRepo.Create(ctx, acc))
+		ids = append(ids, acc.UserID)
+	}
+
+	first, err := balanceRepo.ListUserIDs(ctx, ids[0]-1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, ids[:2], first)
+
+	rest, err := balanceRepo.ListUserIDs(ctx, first[1], 2)
+	require.NoError(t, err)
+	assert.Equal(t, ids[2:], rest)
+
+	none, err := balanceRepo.ListUserIDs(ctx, ids[2], 2)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
 // --- WithdrawalRepository ---
 
 func TestWithdrawalRepository_CreateAndListByUserID(t *testing.T) {
@@ -302,7 +870,7 @@ func TestWithdrawalRepository_CreateAndListByUserID(t *testing.T) {
 	require.NoError(t, withdrawalRepo.Create(context.Background(), w1))
 	require.NoError(t, withdrawalRepo.Create(context.Background(), w2))
 
-	list, err := withdrawalRepo.ListByUserID(context.Background(), balancevo.UserID(user.ID))
+	list, err := withdrawalRepo.ListByUserID(context.Background(), balancevo.UserID(user.ID), balanceport.WithdrawalFilter{})
 	require.NoError(t, err)
 	assert.Len(t, list, 2)
 	// Sorted by processed_at DESC, so w2 comes first.
@@ -310,6 +878,81 @@ func TestWithdrawalRepository_CreateAndListByUserID(t *testing.T) {
 	assert.Equal(t, balancevo.OrderNumber("77777777777"), list[1].OrderNumber)
 }
 
+func TestWithdrawalRepository_DuplicateOrderAndRefund(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	withdrawalRepo := balancerepopostgres.NewWithdrawalRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "refund-user", now)
+	other := createTestUser(t, userRepo, "refund-other", now)
+	userID := balancevo.UserID(user.ID)
+
+	w := balanceentity.NewWithdrawal(userID, "2377225624", 100, now)
+	require.NoError(t, withdrawalRepo.Create(context.Background(), w))
+
+	err := withdrawalRepo.Create(context.Background(), w)
+	assert.ErrorIs(t, err, application.ErrAlreadyExists)
+
+	// Uniqueness is per user: another user's number does not conflict.
+	require.NoError(t, withdrawalRepo.Create(context.Background(),
+		balanceentity.NewWithdrawal(balancevo.UserID(other.ID), "2377225624", 10, now)))
+
+	require.NoError(t, w.Refund(40, now.Add(time.Hour)))
+	require.NoError(t, withdrawalRepo.Update(context.Background(), w))
+
+	found, err := withdrawalRepo.FindByOrderNumber(context.Background(), userID, "2377225624")
+	require.NoError(t, err)
+	assert.Equal(t, balancevo.Points(40), found.RefundedAmount)
+	require.NotNil(t, found.RefundedAt)
+	assert.True(t, found.RefundedAt.Equal(*w.RefundedAt))
+	assert.Equal(t, balanceentity.RefundStatusPartial, found.RefundStatus())
+
+	// The database refuses to refund more than was withdrawn.
+	found.RefundedAmount = 101
+	assert.Error(t, withdrawalRepo.Update(context.Background(), found))
+
+	_, err = withdrawalRepo.FindByOrderNumber(context.Background(), userID, "79927398713")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+}
+
+func TestWithdrawalRepository_ListByUserID_FilterAndPage(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	withdrawalRepo := balancerepopostgres.NewWithdrawalRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	ctx := context.Background()
+
+	user := createTestUser(t, userRepo, "page-wd-user", now)
+	userID := balancevo.UserID(user.ID)
+
+	for i, num := range []balancevo.OrderNumber{"11111111111", "22222222222", "33333333333"} {
+		w := balanceentity.NewWithdrawal(userID, num, 100, now.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, withdrawalRepo.Create(ctx, w))
+	}
+	refunded, err := withdrawalRepo.FindByOrderNumber(ctx, userID, "22222222222")
+	require.NoError(t, err)
+	require.NoError(t, refunded.Refund(100, now.Add(time.Hour)))
+	require.NoError(t, withdrawalRepo.Update(ctx, refunded))
+
+	filter := balanceport.WithdrawalFilter{RefundStatuses: []balanceentity.RefundStatus{balanceentity.RefundStatusNone}, Limit: 1}
+	list, err := withdrawalRepo.ListByUserID(ctx, userID, filter)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, balancevo.OrderNumber("33333333333"), list[0].OrderNumber)
+
+	filter.After = &cursor.Cursor{At: list[0].ProcessedAt, Key: list[0].OrderNumber.String()}
+	list, err = withdrawalRepo.ListByUserID(ctx, userID, filter)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, balancevo.OrderNumber("11111111111"), list[0].OrderNumber)
+
+	from := now.Add(time.Minute)
+	list, err = withdrawalRepo.ListByUserID(ctx, userID, balanceport.WithdrawalFilter{From: &from})
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
 func TestWithdrawalRepository_ListByUserID_Empty(t *testing.T) {
 	tx := setupTransactor(t)
 	userRepo := identityrepopostgres.NewUserRepository(tx)
@@ -318,12 +961,324 @@ func TestWithdrawalRepository_ListByUserID_Empty(t *testing.T) {
 
 	user := createTestUser(t, userRepo, "empty-wd-user", now)
 
-	list, err := withdrawalRepo.ListByUserID(context.Background(), balancevo.UserID(user.ID))
+	list, err := withdrawalRepo.ListByUserID(context.Background(), balancevo.UserID(user.ID), balanceport.WithdrawalFilter{})
 	require.NoError(t, err)
 	assert.Empty(t, list)
 }
 
-func ptrFloat(v float64) *ordersvo.Points {
+// --- AccrualCreditRepository ---
+
+func TestAccrualCreditRepository_CreateDuplicate(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	creditRepo := balancerepopostgres.NewAccrualCreditRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "credit-user", now)
+
+	credit := balanceentity.NewAccrualCredit(balancevo.UserID(user.ID), "12345678903", 100, now)
+	require.NoError(t, creditRepo.Create(context.Background(), credit))
+
+	err := creditRepo.Create(context.Background(), credit)
+	assert.ErrorIs(t, err, application.ErrAlreadyExists)
+}
+
+// --- LedgerRepository ---
+
+func TestLedgerRepository_AppendAndListByUserID(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	ledgerRepo := balancerepopostgres.NewLedgerRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "ledger-user", now)
+	other := createTestUser(t, userRepo, "ledger-other", now)
+	userID := balancevo.UserID(user.ID)
+
+	accrual := balanceentity.NewAccrualEntry(userID, "12345678903", 100, now)
+	require.NoError(t, ledgerRepo.Append(context.Background(), accrual))
+	assert.NotZero(t, accrual.ID)
+
+	withdrawal := balanceentity.NewWithdrawalEntry(userID, "2377225624", 30, now.Add(time.Minute))
+	require.NoError(t, ledgerRepo.Append(context.Background(), withdrawal))
+
+	otherEntry := balanceentity.NewAccrualEntry(balancevo.UserID(other.ID), "79927398713", 5, now)
+	require.NoError(t, ledgerRepo.Append(context.Background(), otherEntry))
+
+	entries, err := ledgerRepo.ListByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, withdrawal.ID, entries[0].ID)
+	assert.Equal(t, withdrawal.Postings, entries[0].Postings)
+	assert.Equal(t, balancevo.Points(-30), entries[0].PointsDelta(userID))
+	assert.Equal(t, balancevo.Points(100), entries[1].PointsDelta(userID))
+}
+
+func TestLedgerRepository_AppendOnly(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	ledgerRepo := balancerepopostgres.NewLedgerRepository(tx)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "ledger-append-only", now)
+	entry := balanceentity.NewAccrualEntry(balancevo.UserID(user.ID), "12345678903", 100, now)
+	require.NoError(t, ledgerRepo.Append(ctx, entry))
+
+	q := tx.GetQuerier(ctx)
+	for _, stmt := range []string{
+		`UPDATE ledger_entries SET reference = 'changed' WHERE id = $1`,
+		`UPDATE ledger_postings SET amount = 1 WHERE entry_id = $1`,
+		`DELETE FROM ledger_postings WHERE entry_id = $1`,
+		`DELETE FROM ledger_entries WHERE id = $1`,
+	} {
+		_, err := q.Exec(ctx, stmt, entry.ID)
+		assert.ErrorContains(t, err, "append-only", stmt)
+	}
+}
+
+func TestLedgerRepository_BalanceAt(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	ledgerRepo := balancerepopostgres.NewLedgerRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	ctx := context.Background()
+
+	user := createTestUser(t, userRepo, "ledger-balance-user", now)
+	userID := balancevo.UserID(user.ID)
+
+	require.NoError(t, ledgerRepo.Append(ctx, balanceentity.NewAccrualEntry(userID, "12345678903", 10050, now)))
+	require.NoError(t, ledgerRepo.Append(ctx, balanceentity.NewWithdrawalEntry(userID, "2377225624", 3000, now.Add(time.Minute))))
+
+	for _, tc := range []struct {
+		at   time.Time
+		want balancevo.Points
+	}{
+		{at: now, want: 0},
+		{at: now.Add(time.Second), want: 10050},
+		{at: now.Add(time.Hour), want: 7050},
+	} {
+		balance, err := ledgerRepo.BalanceAt(ctx, userID, tc.at)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, balance)
+	}
+}
+
+func TestLedgerRepository_ListByUserIDBetween(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	ledgerRepo := balancerepopostgres.NewLedgerRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	ctx := context.Background()
+
+	user := createTestUser(t, userRepo, "ledger-between-user", now)
+	other := createTestUser(t, userRepo, "ledger-between-other", now)
+	userID := balancevo.UserID(user.ID)
+
+	require.NoError(t, ledgerRepo.Append(ctx, balanceentity.NewAccrualEntry(userID, "12345678903", 10050, now)))
+	require.NoError(t, ledgerRepo.Append(ctx, balanceentity.NewPeerTransferEntry(
+		balancevo.UserID(other.ID), userID, "gift", 500, now.Add(time.Minute))))
+	require.NoError(t, ledgerRepo.Append(ctx, balanceentity.NewWithdrawalEntry(userID, "2377225624", 3000, now.Add(time.Hour))))
+
+	entries, err := ledgerRepo.ListByUserIDBetween(ctx, userID, now.Add(time.Second), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, balanceentity.EntryKindTransfer, entries[0].Kind)
+	assert.Equal(t, balancevo.Points(500), entries[0].PointsDelta(userID))
+
+	entries, err = ledgerRepo.ListByUserIDBetween(ctx, userID, now, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, balanceentity.EntryKindWithdrawal, entries[0].Kind)
+	assert.Equal(t, balanceentity.EntryKindAccrual, entries[2].Kind)
+}
+
+func TestLedgerRepository_AppendUnbalanced(t *testing.T) {
+	tx := setupTransactor(t)
+	ledgerRepo := balancerepopostgres.NewLedgerRepository(tx)
+
+	entry := balanceentity.NewAccrualEntry(1, "12345678903", 100, time.Now())
+	entry.Postings[0].Amount = 50
+
+	err := ledgerRepo.Append(context.Background(), entry)
+	assert.ErrorIs(t, err, balanceentity.ErrUnbalancedEntry)
+}
+
+// --- IdempotencyKeyRepository ---
+
+func TestIdempotencyKeyRepository_CreateAndFindByKey(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	keyRepo := balancerepopostgres.NewIdempotencyKeyRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "idem-user", now)
+	other := createTestUser(t, userRepo, "idem-other", now)
+
+	key := &balanceentity.IdempotencyKey{
+		UserID: balancevo.UserID(user.ID), Key: "key-1", Fingerprint: "abc", CreatedAt: now,
+	}
+	require.NoError(t, keyRepo.Create(context.Background(), key))
+
+	found, err := keyRepo.FindByKey(context.Background(), balancevo.UserID(user.ID), "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", found.Fingerprint)
+
+	err = keyRepo.Create(context.Background(), key)
+	assert.ErrorIs(t, err, application.ErrAlreadyExists)
+
+	// Keys are scoped per user.
+	_, err = keyRepo.FindByKey(context.Background(), balancevo.UserID(other.ID), "key-1")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+}
+
+func TestIdempotencyKeyRepository_DeleteCreatedBefore(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	keyRepo := balancerepopostgres.NewIdempotencyKeyRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "idem-purge", now)
+	userID := balancevo.UserID(user.ID)
+	require.NoError(t, keyRepo.Create(context.Background(), &balanceentity.IdempotencyKey{
+		UserID: userID, Key: "old", Fingerprint: "a", CreatedAt: now.Add(-48 * time.Hour),
+	}))
+	require.NoError(t, keyRepo.Create(context.Background(), &balanceentity.IdempotencyKey{
+		UserID: userID, Key: "fresh", Fingerprint: "b", CreatedAt: now,
+	}))
+
+	n, err := keyRepo.DeleteCreatedBefore(context.Background(), now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	_, err = keyRepo.FindByKey(context.Background(), userID, "old")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+	_, err = keyRepo.FindByKey(context.Background(), userID, "fresh")
+	assert.NoError(t, err)
+}
+
+func TestAccrualLotRepository_ListActiveAndDue(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	lotRepo := balancerepopostgres.NewAccrualLotRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "lot-user", now)
+	userID := balancevo.UserID(user.ID)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	expired := balanceentity.NewAccrualLot(userID, "111", 100, now.Add(-48*time.Hour), &past)
+	active := balanceentity.NewAccrualLot(userID, "222", 200, now.Add(-24*time.Hour), &future)
+	forever := balanceentity.NewAccrualLot(userID, "333", 300, now, nil)
+	for _, l := range []*balanceentity.AccrualLot{expired, active, forever} {
+		require.NoError(t, lotRepo.Create(context.Background(), l))
+		require.NotZero(t, l.ID)
+	}
+
+	lots, err := lotRepo.ListActiveByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, lots, 3)
+	assert.Equal(t, expired.ID, lots[0].ID)
+	assert.Equal(t, active.ID, lots[1].ID)
+	assert.Equal(t, forever.ID, lots[2].ID)
+	assert.Nil(t, lots[2].ExpiresAt)
+
+	due, err := lotRepo.ListDue(context.Background(), now, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, expired.ID, due[0].ID)
+
+	// The keyset cursor steps past lots already listed.
+	due, err = lotRepo.ListDue(context.Background(), now,
+		&balanceport.DueCursor{ExpiresAt: *expired.ExpiresAt, ID: expired.ID}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	// Spent lots are neither active nor due.
+	expired.Consume(expired.Remaining)
+	require.NoError(t, lotRepo.Update(context.Background(), expired))
+
+	due, err = lotRepo.ListDue(context.Background(), now, nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	found, err := lotRepo.FindByID(context.Background(), expired.ID)
+	require.NoError(t, err)
+	assert.Equal(t, balancevo.Points(0), found.Remaining)
+	assert.Equal(t, balancevo.Points(100), found.Amount)
+
+	_, err = lotRepo.FindByID(context.Background(), 0)
+	assert.ErrorIs(t, err, application.ErrNotFound)
+}
+
+func TestHoldRepository_CreateAndListExpired(t *testing.T) {
+	tx := setupTransactor(t)
+	userRepo := identityrepopostgres.NewUserRepository(tx)
+	holdRepo := balancerepopostgres.NewHoldRepository(tx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := createTestUser(t, userRepo, "hold-user", now)
+	other := createTestUser(t, userRepo, "hold-other", now)
+	userID := balancevo.UserID(user.ID)
+
+	stale := balanceentity.NewHold(userID, "111", 100, now.Add(-time.Hour), now.Add(-time.Minute))
+	fresh := balanceentity.NewHold(userID, "222", 200, now, now.Add(time.Hour))
+	for _, h := range []*balanceentity.Hold{stale, fresh} {
+		require.NoError(t, holdRepo.Create(context.Background(), h))
+		require.NotZero(t, h.ID)
+	}
+
+	// Only one active hold per user and order; another user's number does not conflict.
+	err := holdRepo.Create(context.Background(), balanceentity.NewHold(userID, "111", 50, now, now.Add(time.Hour)))
+	assert.ErrorIs(t, err, application.ErrAlreadyExists)
+	require.NoError(t, holdRepo.Create(context.Background(),
+		balanceentity.NewHold(balancevo.UserID(other.ID), "111", 50, now, now.Add(time.Hour))))
+
+	active, err := holdRepo.FindActiveByOrderNumber(context.Background(), userID, "111")
+	require.NoError(t, err)
+	assert.Equal(t, stale.ID, active.ID)
+	_, err = holdRepo.FindActiveByOrderNumber(context.Background(), userID, "333")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+
+	holds, err := holdRepo.ListByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, holds, 2)
+
+	expired, err := holdRepo.ListExpired(context.Background(), now, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, stale.ID, expired[0].ID)
+
+	require.NoError(t, stale.Void(now))
+	require.NoError(t, holdRepo.Update(context.Background(), stale))
+
+	_, err = holdRepo.FindActiveByOrderNumber(context.Background(), userID, "111")
+	assert.ErrorIs(t, err, application.ErrNotFound)
+
+	expired, err = holdRepo.ListExpired(context.Background(), now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+
+	activeHolds, err := holdRepo.ListActiveByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, activeHolds, 1)
+	assert.Equal(t, fresh.ID, activeHolds[0].ID)
+
+	found, err := holdRepo.FindByID(context.Background(), stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, balanceentity.HoldStatusVoided, found.Status)
+	require.NotNil(t, found.ResolvedAt)
+	assert.Equal(t, now, *found.ResolvedAt)
+
+	// A voided hold frees the order for a new authorization.
+	require.NoError(t, holdRepo.Create(context.Background(), balanceentity.NewHold(userID, "111", 50, now, now.Add(time.Hour))))
+
+	_, err = holdRepo.FindByID(context.Background(), 0)
+	assert.ErrorIs(t, err, application.ErrNotFound)
+}
+
+func ptrPoints(v int64) *ordersvo.Points {
 	p := ordersvo.Points(v)
 	return &p
 }
@@ -19,6 +19,9 @@ var (
 	// ErrInvalidCredentials — wrong login or password.
 	ErrInvalidCredentials = errors.New("invalid credentials")
 
+	// ErrRefreshTokenReused — an already rotated refresh token was presented again; its session is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+
 	// ErrInsufficientBalance — not enough points on the balance account.
 	ErrInsufficientBalance = errors.New("insufficient balance")
 
@@ -27,6 +30,30 @@ var (
 
 	// ErrOptimisticLock — concurrent modification detected, operation should be retried.
 	ErrOptimisticLock = errors.New("optimistic lock conflict")
+
+	// ErrRefundExceedsWithdrawal — refund amount is not positive or exceeds the unrefunded part of the withdrawal.
+	ErrRefundExceedsWithdrawal = errors.New("refund exceeds withdrawn amount")
+
+	// ErrHoldNotActive — hold was already captured or voided, or expired before capture.
+	ErrHoldNotActive = errors.New("hold is not active")
+
+	// ErrOrderHeld — the order has an active hold, it is paid by capturing the hold.
+	ErrOrderHeld = errors.New("order has an active hold")
+
+	// ErrAccountNotFound — the acting user has no balance account.
+	ErrAccountNotFound = errors.New("balance account not found")
+
+	// ErrSelfTransfer — points transfer names the sender as the recipient.
+	ErrSelfTransfer = errors.New("cannot transfer points to yourself")
+
+	// ErrInvalidCursor — pagination cursor is malformed or was not issued by the service.
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrInvalidCampaign — campaign settings are inconsistent (name, period or rule).
+	ErrInvalidCampaign = errors.New("invalid campaign")
+
+	// ErrIdempotencyKeyReused — idempotency key was already used for a different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
 )
 
 // ErrRateLimit — external system requested to slow down.
@@ -37,3 +64,47 @@ type ErrRateLimit struct {
 func (e *ErrRateLimit) Error() string {
 	return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
 }
+
+// ErrWithdrawalLimit — withdrawal breaks the configured withdrawal limit named by Rule.
+type ErrWithdrawalLimit struct {
+	Rule string
+	// RetryAfter is how long until the limit allows the withdrawal; 0 if waiting alone does not help.
+	RetryAfter time.Duration
+}
+
+func (e *ErrWithdrawalLimit) Error() string {
+	return fmt.Sprintf("withdrawal limit %s exceeded", e.Rule)
+}
+
+// ErrLoginThrottled — too many failed logins for the login or client IP; the next attempt
+// is accepted after RetryAfter.
+type ErrLoginThrottled struct {
+	RetryAfter time.Duration
+}
+
+func (e *ErrLoginThrottled) Error() string {
+	return fmt.Sprintf("too many failed logins, retry after %s", e.RetryAfter)
+}
+
+// ErrUnavailable — external system is failing, calls are suspended for RetryAfter.
+type ErrUnavailable struct {
+	RetryAfter time.Duration
+}
+
+func (e *ErrUnavailable) Error() string {
+	return fmt.Sprintf("external system unavailable, retry after %s", e.RetryAfter)
+}
+
+// RetryAfter reports how long to pause when err asks the caller to back off
+// (ErrRateLimit or ErrUnavailable).
+func RetryAfter(err error) (time.Duration, bool) {
+	var rl *ErrRateLimit
+	if errors.As(err, &rl) {
+		return rl.RetryAfter, true
+	}
+	var ua *ErrUnavailable
+	if errors.As(err, &ua) {
+		return ua.RetryAfter, true
+	}
+	return 0, false
+}
@@ -0,0 +1,49 @@
+package application
+
+import "gophermart/internal/pkg/cursor"
+
+// DefaultPageLimit is the page size of a paginated listing requested without an explicit limit.
+const DefaultPageLimit = 50
+
+// PageRequest is the keyset pagination part of a listing request.
+type PageRequest struct {
+	// Cursor continues the listing after a previous page; empty for the first page.
+	Cursor string
+	// Limit is the page size; 0 with an empty Cursor lists everything.
+	Limit int
+}
+
+// PageLimit returns the page size, or 0 when the request asks for the whole listing.
+func (p PageRequest) PageLimit() int {
+	switch {
+	case p.Limit > 0:
+		return p.Limit
+	case p.Cursor != "":
+		return DefaultPageLimit
+	default:
+		return 0
+	}
+}
+
+// After returns the position to continue from, or nil for the first page.
+// Returns ErrInvalidCursor if the cursor is malformed.
+func (p PageRequest) After() (*cursor.Cursor, error) {
+	if p.Cursor == "" {
+		return nil, nil
+	}
+	c, err := cursor.Decode(p.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// TrimPage cuts items fetched with limit+1 rows down to limit and returns the cursor of the next page,
+// or an empty cursor if items hold the last page. A zero limit leaves items as they are.
+func TrimPage[T any](items []T, limit int, position func(T) cursor.Cursor) ([]T, string) {
+	if limit <= 0 || len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	return items, position(items[limit-1]).Encode()
+}
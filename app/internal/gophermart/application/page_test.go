@@ -0,0 +1,53 @@
+package application
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gophermart/internal/pkg/cursor"
+)
+
+func TestPageRequest(t *testing.T) {
+	t.Run("no cursor and limit lists everything", func(t *testing.T) {
+		after, err := PageRequest{}.After()
+		require.NoError(t, err)
+		assert.Nil(t, after)
+		assert.Equal(t, 0, PageRequest{}.PageLimit())
+	})
+
+	t.Run("cursor without limit uses default", func(t *testing.T) {
+		c := cursor.Cursor{At: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), Key: "1"}
+		p := PageRequest{Cursor: c.Encode()}
+
+		after, err := p.After()
+		require.NoError(t, err)
+		assert.Equal(t, "1", after.Key)
+		assert.Equal(t, DefaultPageLimit, p.PageLimit())
+	})
+
+	t.Run("malformed cursor", func(t *testing.T) {
+		_, err := PageRequest{Cursor: "garbage"}.After()
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestTrimPage(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	position := func(n int) cursor.Cursor { return cursor.Cursor{At: at, Key: strconv.Itoa(n)} }
+
+	items, next := TrimPage([]int{1, 2, 3}, 2, position)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, position(2).Encode(), next)
+
+	items, next = TrimPage([]int{1, 2}, 2, position)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Empty(t, next)
+
+	items, next = TrimPage([]int{1, 2, 3}, 0, position)
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.Empty(t, next)
+}
@@ -4,4 +4,7 @@ package port
 type PasswordHasher interface {
 	Hash(plain string) (hash string, err error)
 	Compare(plain, hash string) bool
+	// NeedsRehash reports whether the hash was made with other parameters or another algorithm
+	// than Hash uses now, so it should be replaced once the plain password is known.
+	NeedsRehash(hash string) bool
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -12,7 +13,11 @@ import (
 
 	"gophermart/internal/gophermart/adapters/logger"
 	"gophermart/internal/gophermart/adapters/repository/postgres"
+	balancevo "gophermart/internal/gophermart/modules/balance/domain/vo"
+	identityauth "gophermart/internal/gophermart/modules/identity/adapters/auth"
+	identityvo "gophermart/internal/gophermart/modules/identity/domain/vo"
 	ordersaccrual "gophermart/internal/gophermart/modules/orders/adapters/accrual"
+	ordersvo "gophermart/internal/gophermart/modules/orders/domain/vo"
 )
 
 // Config holds the grouped application configuration.
@@ -22,6 +27,17 @@ type Config struct {
 	Logger  logger.Config
 	DB      postgres.Config
 	Accrual AccrualConfig
+	Points  PointsConfig
+	Holds   HoldsConfig
+	// Idempotency groups Idempotency-Key retention settings.
+	Idempotency IdempotencyConfig
+	// Reconcile groups balance reconciliation settings.
+	Reconcile ReconcileConfig
+	Admin     AdminConfig
+	// LoginThrottle groups brute-force protection of the login endpoint.
+	LoginThrottle LoginThrottleConfig
+	// WithdrawalLimits bounds user withdrawals; zero values disable a limit.
+	WithdrawalLimits balancevo.WithdrawalLimits
 	// OptimisticRetries controls use-case retries on optimistic lock conflicts.
 	OptimisticRetries int
 }
@@ -30,13 +46,52 @@ type Config struct {
 type ServerConfig struct {
 	Address         string
 	ShutdownTimeout time.Duration
+	// TrustedProxies are the proxy IPs and CIDRs whose forwarding headers are trusted;
+	// when empty the client IP is the remote address of the connection.
+	TrustedProxies []string
 }
 
 // AuthConfig holds authentication settings.
 type AuthConfig struct {
-	JWTSecret  string
-	JWTTTL     time.Duration
-	BCryptCost int
+	// JWTSecret is the shared HS256 key; optional when JWTKeys are set.
+	JWTSecret string
+	// JWTKeys are the PEM key files of the JWT key set; every key verifies tokens.
+	JWTKeys []identityauth.KeyFile
+	// JWTSigningKey is the kid that signs new tokens; empty selects the first of JWTKeys.
+	JWTSigningKey string
+	// JWTTTL is the access token lifetime.
+	JWTTTL time.Duration
+	// RefreshTTL is how long a session lasts without a refresh.
+	RefreshTTL time.Duration
+	// SessionMaxLifetime is how long a session lasts from login, however often it is refreshed.
+	SessionMaxLifetime time.Duration
+	// SessionPurgeInterval is the ended session purge worker period; 0 disables the worker.
+	SessionPurgeInterval time.Duration
+	// PasswordHasher names the algorithm of new password hashes; hashes of the other algorithm stay valid.
+	PasswordHasher string
+	BCryptCost     int
+	Argon2         identityauth.Argon2Params
+	// Argon2Concurrency bounds the Argon2id computations run at once, and so their memory.
+	Argon2Concurrency int
+}
+
+// Login throttle stores.
+const (
+	// LoginThrottleStorePostgres keeps failed logins in the database, shared by all replicas.
+	LoginThrottleStorePostgres = "postgres"
+	// LoginThrottleStoreMemory keeps failed logins in the instance memory.
+	LoginThrottleStoreMemory = "memory"
+)
+
+// LoginThrottleConfig holds failed login throttling settings.
+type LoginThrottleConfig struct {
+	// Store is LoginThrottleStorePostgres or LoginThrottleStoreMemory.
+	Store string
+	// Login throttles failures per login, IP per client IP.
+	Login identityvo.LoginThrottlePolicy
+	IP    identityvo.LoginThrottlePolicy
+	// PurgeInterval is the stale record purge worker period; 0 disables the worker.
+	PurgeInterval time.Duration
 }
 
 // AccrualConfig groups adapter and worker settings for accrual processing.
@@ -45,6 +100,52 @@ type AccrualConfig struct {
 	PollInterval time.Duration
 	BatchSize    int
 	MaxWorkers   int
+	PollPolicy   ordersvo.PollPolicy
+	// InstanceID identifies this replica as the owner of claimed poll leases.
+	InstanceID string
+	LeaseTTL   time.Duration
+	// WebhookSecret signs status pushes from the accrual system; empty disables the webhook.
+	WebhookSecret string
+}
+
+// PointsConfig groups points expiry policy and worker settings.
+type PointsConfig struct {
+	ExpiryPolicy balancevo.ExpiryPolicy
+	// ExpiryInterval is the expiry worker period; 0 disables the worker.
+	ExpiryInterval  time.Duration
+	ExpiryBatchSize int
+}
+
+// HoldsConfig groups two-phase withdrawal hold settings.
+type HoldsConfig struct {
+	// TTL is how long a hold reserves points before it is voided automatically.
+	TTL time.Duration
+	// ExpiryInterval is the hold auto-void worker period; 0 disables the worker.
+	ExpiryInterval  time.Duration
+	ExpiryBatchSize int
+}
+
+// IdempotencyConfig groups Idempotency-Key retention settings.
+type IdempotencyConfig struct {
+	// KeyTTL is how long a key is remembered; a retry after that is executed again.
+	KeyTTL time.Duration
+	// PurgeInterval is the expired key purge worker period; 0 disables the worker.
+	PurgeInterval time.Duration
+}
+
+// ReconcileConfig groups balance reconciliation settings.
+type ReconcileConfig struct {
+	// Interval is the reconciliation worker period; 0 disables the worker.
+	Interval  time.Duration
+	BatchSize int
+	// Fix corrects drifted accounts with adjustments instead of only reporting them.
+	Fix bool
+}
+
+// AdminConfig holds admin API settings.
+type AdminConfig struct {
+	// Token is expected in the X-Admin-Token header; empty disables the admin API.
+	Token string
 }
 
 // LoadConfig loads config from flags/env/file/defaults.
@@ -57,9 +158,13 @@ func LoadConfig() (Config, error) {
 	fs.StringP("database-uri", "d", "", "database dsn")
 	fs.StringP("accrual-address", "r", "", "accrual system address")
 	fs.StringP("jwt-secret", "s", "", "JWT signing secret")
-	fs.StringP("jwt-ttl", "t", "", "JWT token TTL (e.g. 24h or 86400)")
+	fs.String("jwt-keys", "", "JWT key files as kid:path[,kid:path]")
+	fs.String("jwt-signing-key", "", "kid of the JWT signing key")
+	fs.StringP("jwt-ttl", "t", "", "JWT access token TTL (e.g. 15m or 900)")
 	fs.StringP("log-level", "l", "", "logging level")
 	fs.Int("bcrypt-cost", 0, "bcrypt cost factor (4-31)")
+	fs.String("password-hasher", "", "password hashing algorithm (bcrypt or argon2id)")
+	fs.Bool("reconcile-fix", false, "correct drifted balances during reconciliation")
 	fs.String("config", "app/configs/gophermart.yaml", "path to YAML config")
 
 	if err := fs.Parse(os.Args[1:]); err != nil {
@@ -118,6 +223,24 @@ func LoadConfig() (Config, error) {
 	if err != nil {
 		return Config{}, fmt.Errorf("invalid JWT_TTL: %w", err)
 	}
+	refreshTTL, err := parseDuration(v.Get("auth.refresh_ttl"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid REFRESH_TOKEN_TTL: %w", err)
+	}
+	if refreshTTL <= 0 {
+		return Config{}, fmt.Errorf("invalid REFRESH_TOKEN_TTL: must be positive")
+	}
+	sessionMaxLifetime, err := parseDuration(v.Get("auth.session_max_lifetime"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid SESSION_MAX_LIFETIME: %w", err)
+	}
+	if sessionMaxLifetime <= 0 {
+		return Config{}, fmt.Errorf("invalid SESSION_MAX_LIFETIME: must be positive")
+	}
+	sessionPurgeInterval, err := parseDuration(v.Get("auth.session_purge_interval"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid SESSION_PURGE_INTERVAL: %w", err)
+	}
 	dbMaxConnLife, err := parseDuration(v.Get("database.max_conn_life"))
 	if err != nil {
 		return Config{}, fmt.Errorf("invalid DB_MAX_CONN_LIFE: %w", err)
@@ -138,6 +261,75 @@ func LoadConfig() (Config, error) {
 	if err != nil {
 		return Config{}, fmt.Errorf("invalid ACCRUAL_HTTP_TIMEOUT: %w", err)
 	}
+	accrualPollTTL, err := parseDuration(v.Get("accrual.poll_ttl"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid ACCRUAL_POLL_TTL: %w", err)
+	}
+	accrualPollBackoffBase, err := parseDuration(v.Get("accrual.poll_backoff_base"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid ACCRUAL_POLL_BACKOFF_BASE: %w", err)
+	}
+	accrualPollBackoffMax, err := parseDuration(v.Get("accrual.poll_backoff_max"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid ACCRUAL_POLL_BACKOFF_MAX: %w", err)
+	}
+	accrualLeaseTTL, err := parseDuration(v.Get("accrual.lease_ttl"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid ACCRUAL_LEASE_TTL: %w", err)
+	}
+	if accrualLeaseTTL <= 0 {
+		return Config{}, fmt.Errorf("invalid ACCRUAL_LEASE_TTL: must be positive")
+	}
+	accrualBreakerTimeout, err := parseDuration(v.Get("accrual.breaker_timeout"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid ACCRUAL_BREAKER_TIMEOUT: %w", err)
+	}
+	pointsTTL, err := parseDuration(v.Get("points.ttl"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid POINTS_TTL: %w", err)
+	}
+	pointsExpiringSoon, err := parseDuration(v.Get("points.expiring_soon"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid POINTS_EXPIRING_SOON: %w", err)
+	}
+	pointsExpiryInterval, err := parseDuration(v.Get("points.expiry_interval"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid POINTS_EXPIRY_INTERVAL: %w", err)
+	}
+	holdTTL, err := parseDuration(v.Get("holds.ttl"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid HOLD_TTL: %w", err)
+	}
+	if holdTTL <= 0 {
+		return Config{}, fmt.Errorf("invalid HOLD_TTL: must be positive")
+	}
+	holdExpiryInterval, err := parseDuration(v.Get("holds.expiry_interval"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid HOLD_EXPIRY_INTERVAL: %w", err)
+	}
+	idempotencyKeyTTL, err := parseDuration(v.Get("idempotency.key_ttl"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL: %w", err)
+	}
+	if idempotencyKeyTTL <= 0 {
+		return Config{}, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL: must be positive")
+	}
+	idempotencyPurgeInterval, err := parseDuration(v.Get("idempotency.purge_interval"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid IDEMPOTENCY_PURGE_INTERVAL: %w", err)
+	}
+	reconcileInterval, err := parseDuration(v.Get("reconcile.interval"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid RECONCILE_INTERVAL: %w", err)
+	}
+	withdrawalLimits, err := parseWithdrawalLimits(v)
+	if err != nil {
+		return Config{}, err
+	}
+	instanceID := strings.TrimSpace(v.GetString("accrual.instance_id"))
+	if instanceID == "" {
+		instanceID = defaultInstanceID()
+	}
 	retryBaseDelay, err := parseDuration(v.Get("database.retry.base_delay"))
 	if err != nil {
 		return Config{}, fmt.Errorf("invalid DB_RETRY_BASE_DELAY: %w", err)
@@ -150,13 +342,33 @@ func LoadConfig() (Config, error) {
 	if err != nil {
 		return Config{}, fmt.Errorf("invalid SERVER_SHUTDOWN_TIMEOUT: %w", err)
 	}
+	trustedProxies, err := parseTrustedProxies(v.GetString("server.trusted_proxies"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
 	bcryptCost, err := parseBCryptCost(v.Get("auth.bcrypt_cost"))
 	if err != nil {
 		return Config{}, fmt.Errorf("invalid BCRYPT_COST: %w", err)
 	}
+	passwordHasher, argon2Params, err := parsePasswordHasher(v)
+	if err != nil {
+		return Config{}, err
+	}
+	argon2Concurrency := v.GetInt("auth.argon2.concurrency")
+	if argon2Concurrency < 1 {
+		return Config{}, fmt.Errorf("invalid ARGON2_CONCURRENCY: must be at least 1")
+	}
+	loginThrottle, err := parseLoginThrottle(v)
+	if err != nil {
+		return Config{}, err
+	}
 	jwtSecret := strings.TrimSpace(v.GetString("auth.jwt_secret"))
-	if jwtSecret == "" {
-		return Config{}, fmt.Errorf("JWT_SECRET is required")
+	jwtKeys, err := identityauth.ParseKeyFiles(v.GetString("auth.jwt_keys"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid JWT_KEYS: %w", err)
+	}
+	if jwtSecret == "" && len(jwtKeys) == 0 {
+		return Config{}, fmt.Errorf("JWT_SECRET or JWT_KEYS is required")
 	}
 	databaseURI := strings.TrimSpace(v.GetString("database.uri"))
 	if databaseURI == "" {
@@ -168,11 +380,20 @@ func LoadConfig() (Config, error) {
 		Server: ServerConfig{
 			Address:         serverAddr,
 			ShutdownTimeout: shutdownTimeout,
+			TrustedProxies:  trustedProxies,
 		},
 		Auth: AuthConfig{
-			JWTSecret:  jwtSecret,
-			JWTTTL:     jwtTTL,
-			BCryptCost: bcryptCost,
+			JWTSecret:            jwtSecret,
+			JWTKeys:              jwtKeys,
+			JWTSigningKey:        strings.TrimSpace(v.GetString("auth.jwt_signing_key")),
+			JWTTTL:               jwtTTL,
+			RefreshTTL:           refreshTTL,
+			SessionMaxLifetime:   sessionMaxLifetime,
+			SessionPurgeInterval: sessionPurgeInterval,
+			PasswordHasher:       passwordHasher,
+			BCryptCost:           bcryptCost,
+			Argon2:               argon2Params,
+			Argon2Concurrency:    argon2Concurrency,
 		},
 		Logger: logger.Config{
 			Level: v.GetString("logger.level"),
@@ -194,13 +415,53 @@ func LoadConfig() (Config, error) {
 		},
 		Accrual: AccrualConfig{
 			Client: ordersaccrual.Config{
-				Address:     accrualURL,
-				HTTPTimeout: accrualHTTPTimeout,
+				Address:          accrualURL,
+				HTTPTimeout:      accrualHTTPTimeout,
+				RateLimit:        v.GetFloat64("accrual.rate_limit"),
+				RateBurst:        v.GetInt("accrual.rate_burst"),
+				BreakerThreshold: v.GetInt("accrual.breaker_threshold"),
+				BreakerTimeout:   accrualBreakerTimeout,
 			},
 			PollInterval: accrualPollInterval,
 			BatchSize:    v.GetInt("accrual.batch_size"),
 			MaxWorkers:   v.GetInt("accrual.max_workers"),
+			PollPolicy: ordersvo.PollPolicy{
+				MaxAttempts: v.GetInt("accrual.poll_max_attempts"),
+				TTL:         accrualPollTTL,
+				BaseDelay:   accrualPollBackoffBase,
+				MaxDelay:    accrualPollBackoffMax,
+			},
+			InstanceID:    instanceID,
+			LeaseTTL:      accrualLeaseTTL,
+			WebhookSecret: strings.TrimSpace(v.GetString("accrual.webhook_secret")),
+		},
+		Points: PointsConfig{
+			ExpiryPolicy: balancevo.ExpiryPolicy{
+				TTL:        pointsTTL,
+				SoonWindow: pointsExpiringSoon,
+			},
+			ExpiryInterval:  pointsExpiryInterval,
+			ExpiryBatchSize: v.GetInt("points.expiry_batch_size"),
+		},
+		Holds: HoldsConfig{
+			TTL:             holdTTL,
+			ExpiryInterval:  holdExpiryInterval,
+			ExpiryBatchSize: v.GetInt("holds.expiry_batch_size"),
 		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL:        idempotencyKeyTTL,
+			PurgeInterval: idempotencyPurgeInterval,
+		},
+		Reconcile: ReconcileConfig{
+			Interval:  reconcileInterval,
+			BatchSize: v.GetInt("reconcile.batch_size"),
+			Fix:       v.GetBool("reconcile.fix"),
+		},
+		Admin: AdminConfig{
+			Token: strings.TrimSpace(v.GetString("admin.token")),
+		},
+		LoginThrottle:     loginThrottle,
+		WithdrawalLimits:  withdrawalLimits,
 		OptimisticRetries: v.GetInt("optimistic_retries"),
 	}, nil
 }
@@ -220,6 +481,7 @@ func loadDotEnv() (string, bool, error) {
 func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.address", "127.0.0.1:8080")
 	v.SetDefault("server.shutdown_timeout", "5s")
+	v.SetDefault("server.trusted_proxies", "")
 
 	v.SetDefault("database.uri", "")
 	v.SetDefault("database.max_conns", 25)
@@ -232,29 +494,89 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.retry.max_delay", "2s")
 
 	v.SetDefault("auth.jwt_secret", "")
-	v.SetDefault("auth.jwt_ttl", "24h")
+	v.SetDefault("auth.jwt_keys", "")
+	v.SetDefault("auth.jwt_signing_key", "")
+	v.SetDefault("auth.jwt_ttl", "15m")
+	v.SetDefault("auth.refresh_ttl", "720h")
+	v.SetDefault("auth.session_max_lifetime", "2160h")
+	v.SetDefault("auth.session_purge_interval", "1h")
 	v.SetDefault("auth.bcrypt_cost", 10)
+	v.SetDefault("auth.password_hasher", identityauth.AlgorithmBCrypt)
+	v.SetDefault("auth.argon2.memory", identityauth.DefaultArgon2Params.Memory)
+	v.SetDefault("auth.argon2.time", identityauth.DefaultArgon2Params.Time)
+	v.SetDefault("auth.argon2.parallelism", identityauth.DefaultArgon2Params.Parallelism)
+	v.SetDefault("auth.argon2.concurrency", identityauth.DefaultArgon2Concurrency)
+
+	v.SetDefault("login_throttle.store", LoginThrottleStorePostgres)
+	v.SetDefault("login_throttle.free_attempts", 3)
+	v.SetDefault("login_throttle.base_delay", "1s")
+	v.SetDefault("login_throttle.max_delay", "1m")
+	v.SetDefault("login_throttle.lockout_threshold", 10)
+	v.SetDefault("login_throttle.lockout_duration", "15m")
+	v.SetDefault("login_throttle.window", "1h")
+	v.SetDefault("login_throttle.ip_free_attempts", 20)
+	v.SetDefault("login_throttle.ip_lockout_threshold", 100)
+	v.SetDefault("login_throttle.purge_interval", "10m")
 
 	v.SetDefault("logger.level", "info")
 
 	v.SetDefault("accrual.address", "127.0.0.1:8081")
 	v.SetDefault("accrual.poll_interval", "2s")
 	v.SetDefault("accrual.http_timeout", "10s")
+	v.SetDefault("accrual.rate_limit", 50)
+	v.SetDefault("accrual.rate_burst", 10)
+	v.SetDefault("accrual.breaker_threshold", 5)
+	v.SetDefault("accrual.breaker_timeout", "30s")
 	v.SetDefault("accrual.batch_size", 50)
 	v.SetDefault("accrual.max_workers", 5)
+	v.SetDefault("accrual.poll_max_attempts", 50)
+	v.SetDefault("accrual.poll_ttl", "72h")
+	v.SetDefault("accrual.poll_backoff_base", "2s")
+	v.SetDefault("accrual.poll_backoff_max", "10m")
+	v.SetDefault("accrual.instance_id", "")
+	v.SetDefault("accrual.lease_ttl", "1m")
+	v.SetDefault("accrual.webhook_secret", "")
+
+	v.SetDefault("points.ttl", "8760h")
+	v.SetDefault("points.expiring_soon", "720h")
+	v.SetDefault("points.expiry_interval", "1m")
+	v.SetDefault("points.expiry_batch_size", 100)
+
+	v.SetDefault("holds.ttl", "15m")
+	v.SetDefault("holds.expiry_interval", "30s")
+	v.SetDefault("holds.expiry_batch_size", 100)
+
+	v.SetDefault("idempotency.key_ttl", "24h")
+	v.SetDefault("idempotency.purge_interval", "1h")
+
+	v.SetDefault("reconcile.interval", "24h")
+	v.SetDefault("reconcile.batch_size", 100)
+	v.SetDefault("reconcile.fix", false)
+
+	v.SetDefault("admin.token", "")
+
+	v.SetDefault("withdrawals.min_sum", 0)
+	v.SetDefault("withdrawals.max_sum", 0)
+	v.SetDefault("withdrawals.daily_cap", 0)
+	v.SetDefault("withdrawals.monthly_cap", 0)
+	v.SetDefault("withdrawals.cooldown", "0s")
 
 	v.SetDefault("optimistic_retries", 3)
 }
 
 func bindFlags(v *viper.Viper, fs *pflag.FlagSet) error {
 	bindings := map[string]string{
-		"server.address":   "address",
-		"database.uri":     "database-uri",
-		"accrual.address":  "accrual-address",
-		"auth.jwt_secret":  "jwt-secret",
-		"auth.jwt_ttl":     "jwt-ttl",
-		"logger.level":     "log-level",
-		"auth.bcrypt_cost": "bcrypt-cost",
+		"server.address":       "address",
+		"database.uri":         "database-uri",
+		"accrual.address":      "accrual-address",
+		"auth.jwt_secret":      "jwt-secret",
+		"auth.jwt_keys":        "jwt-keys",
+		"auth.jwt_signing_key": "jwt-signing-key",
+		"auth.jwt_ttl":         "jwt-ttl",
+		"logger.level":         "log-level",
+		"auth.bcrypt_cost":     "bcrypt-cost",
+		"auth.password_hasher": "password-hasher",
+		"reconcile.fix":        "reconcile-fix",
 	}
 	for key, flagName := range bindings {
 		f := fs.Lookup(flagName)
@@ -273,12 +595,34 @@ func bindEnv(v *viper.Viper) {
 	v.AutomaticEnv()
 
 	_ = v.BindEnv("server.address", "RUN_ADDRESS")
+	_ = v.BindEnv("server.trusted_proxies", "TRUSTED_PROXIES")
 	_ = v.BindEnv("database.uri", "DATABASE_URI")
 	_ = v.BindEnv("accrual.address", "ACCRUAL_SYSTEM_ADDRESS")
 	_ = v.BindEnv("auth.jwt_secret", "JWT_SECRET")
+	_ = v.BindEnv("auth.jwt_keys", "JWT_KEYS")
+	_ = v.BindEnv("auth.jwt_signing_key", "JWT_SIGNING_KEY")
 	_ = v.BindEnv("auth.jwt_ttl", "JWT_TTL")
+	_ = v.BindEnv("auth.refresh_ttl", "REFRESH_TOKEN_TTL")
+	_ = v.BindEnv("auth.session_max_lifetime", "SESSION_MAX_LIFETIME")
+	_ = v.BindEnv("auth.session_purge_interval", "SESSION_PURGE_INTERVAL")
 	_ = v.BindEnv("logger.level", "LOG_LEVEL")
 	_ = v.BindEnv("auth.bcrypt_cost", "BCRYPT_COST")
+	_ = v.BindEnv("auth.password_hasher", "PASSWORD_HASHER")
+	_ = v.BindEnv("auth.argon2.memory", "ARGON2_MEMORY")
+	_ = v.BindEnv("auth.argon2.time", "ARGON2_TIME")
+	_ = v.BindEnv("auth.argon2.parallelism", "ARGON2_PARALLELISM")
+	_ = v.BindEnv("auth.argon2.concurrency", "ARGON2_CONCURRENCY")
+
+	_ = v.BindEnv("login_throttle.store", "LOGIN_THROTTLE_STORE")
+	_ = v.BindEnv("login_throttle.free_attempts", "LOGIN_THROTTLE_FREE_ATTEMPTS")
+	_ = v.BindEnv("login_throttle.base_delay", "LOGIN_THROTTLE_BASE_DELAY")
+	_ = v.BindEnv("login_throttle.max_delay", "LOGIN_THROTTLE_MAX_DELAY")
+	_ = v.BindEnv("login_throttle.lockout_threshold", "LOGIN_LOCKOUT_THRESHOLD")
+	_ = v.BindEnv("login_throttle.lockout_duration", "LOGIN_LOCKOUT_DURATION")
+	_ = v.BindEnv("login_throttle.window", "LOGIN_THROTTLE_WINDOW")
+	_ = v.BindEnv("login_throttle.ip_free_attempts", "LOGIN_IP_FREE_ATTEMPTS")
+	_ = v.BindEnv("login_throttle.ip_lockout_threshold", "LOGIN_IP_LOCKOUT_THRESHOLD")
+	_ = v.BindEnv("login_throttle.purge_interval", "LOGIN_THROTTLE_PURGE_INTERVAL")
 
 	_ = v.BindEnv("database.max_conns", "DB_MAX_CONNS")
 	_ = v.BindEnv("database.min_conns", "DB_MIN_CONNS")
@@ -291,12 +635,55 @@ func bindEnv(v *viper.Viper) {
 
 	_ = v.BindEnv("accrual.poll_interval", "ACCRUAL_POLL_INTERVAL")
 	_ = v.BindEnv("accrual.http_timeout", "ACCRUAL_HTTP_TIMEOUT")
+	_ = v.BindEnv("accrual.rate_limit", "ACCRUAL_RATE_LIMIT")
+	_ = v.BindEnv("accrual.rate_burst", "ACCRUAL_RATE_BURST")
+	_ = v.BindEnv("accrual.breaker_threshold", "ACCRUAL_BREAKER_THRESHOLD")
+	_ = v.BindEnv("accrual.breaker_timeout", "ACCRUAL_BREAKER_TIMEOUT")
 	_ = v.BindEnv("accrual.batch_size", "ACCRUAL_BATCH_SIZE")
 	_ = v.BindEnv("accrual.max_workers", "ACCRUAL_MAX_WORKERS")
+	_ = v.BindEnv("accrual.poll_max_attempts", "ACCRUAL_POLL_MAX_ATTEMPTS")
+	_ = v.BindEnv("accrual.poll_ttl", "ACCRUAL_POLL_TTL")
+	_ = v.BindEnv("accrual.poll_backoff_base", "ACCRUAL_POLL_BACKOFF_BASE")
+	_ = v.BindEnv("accrual.poll_backoff_max", "ACCRUAL_POLL_BACKOFF_MAX")
+	_ = v.BindEnv("accrual.instance_id", "ACCRUAL_INSTANCE_ID")
+	_ = v.BindEnv("accrual.lease_ttl", "ACCRUAL_LEASE_TTL")
+	_ = v.BindEnv("accrual.webhook_secret", "ACCRUAL_WEBHOOK_SECRET")
+
+	_ = v.BindEnv("points.ttl", "POINTS_TTL")
+	_ = v.BindEnv("points.expiring_soon", "POINTS_EXPIRING_SOON")
+	_ = v.BindEnv("points.expiry_interval", "POINTS_EXPIRY_INTERVAL")
+	_ = v.BindEnv("points.expiry_batch_size", "POINTS_EXPIRY_BATCH_SIZE")
+
+	_ = v.BindEnv("holds.ttl", "HOLD_TTL")
+	_ = v.BindEnv("holds.expiry_interval", "HOLD_EXPIRY_INTERVAL")
+	_ = v.BindEnv("holds.expiry_batch_size", "HOLD_EXPIRY_BATCH_SIZE")
+	_ = v.BindEnv("idempotency.key_ttl", "IDEMPOTENCY_KEY_TTL")
+	_ = v.BindEnv("idempotency.purge_interval", "IDEMPOTENCY_PURGE_INTERVAL")
+
+	_ = v.BindEnv("reconcile.interval", "RECONCILE_INTERVAL")
+	_ = v.BindEnv("reconcile.batch_size", "RECONCILE_BATCH_SIZE")
+	_ = v.BindEnv("reconcile.fix", "RECONCILE_FIX")
+
+	_ = v.BindEnv("admin.token", "ADMIN_TOKEN")
+
+	_ = v.BindEnv("withdrawals.min_sum", "WITHDRAWAL_MIN_SUM")
+	_ = v.BindEnv("withdrawals.max_sum", "WITHDRAWAL_MAX_SUM")
+	_ = v.BindEnv("withdrawals.daily_cap", "WITHDRAWAL_DAILY_CAP")
+	_ = v.BindEnv("withdrawals.monthly_cap", "WITHDRAWAL_MONTHLY_CAP")
+	_ = v.BindEnv("withdrawals.cooldown", "WITHDRAWAL_COOLDOWN")
 
 	_ = v.BindEnv("optimistic_retries", "OPTIMISTIC_RETRIES")
 }
 
+// defaultInstanceID builds a replica identifier from hostname and PID.
+func defaultInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "gophermart"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
 func parseAddress(raw string) (string, error) {
 	var addr Address
 	if err := addr.Set(raw); err != nil {
@@ -313,6 +700,24 @@ func parseURLAddress(raw string) (string, error) {
 	return addr.URL(), nil
 }
 
+// parseTrustedProxies splits a comma-separated list of IPs and CIDRs.
+func parseTrustedProxies(raw string) ([]string, error) {
+	var proxies []string
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if net.ParseIP(item) == nil {
+			if _, _, err := net.ParseCIDR(item); err != nil {
+				return nil, fmt.Errorf("%q is neither an IP nor a CIDR", item)
+			}
+		}
+		proxies = append(proxies, item)
+	}
+	return proxies, nil
+}
+
 func parseDuration(raw any) (time.Duration, error) {
 	var d Duration
 	switch v := raw.(type) {
@@ -334,6 +739,142 @@ func parseDuration(raw any) (time.Duration, error) {
 	}
 }
 
+// parseWithdrawalLimits reads the withdrawals group; amounts are decimal points, e.g. 100.5.
+func parseWithdrawalLimits(v *viper.Viper) (balancevo.WithdrawalLimits, error) {
+	var limits balancevo.WithdrawalLimits
+	amounts := []struct {
+		key string
+		env string
+		dst *balancevo.Points
+	}{
+		{"withdrawals.min_sum", "WITHDRAWAL_MIN_SUM", &limits.MinSum},
+		{"withdrawals.max_sum", "WITHDRAWAL_MAX_SUM", &limits.MaxSum},
+		{"withdrawals.daily_cap", "WITHDRAWAL_DAILY_CAP", &limits.DailyCap},
+		{"withdrawals.monthly_cap", "WITHDRAWAL_MONTHLY_CAP", &limits.MonthlyCap},
+	}
+	for _, a := range amounts {
+		p, err := parsePoints(v.Get(a.key))
+		if err != nil {
+			return balancevo.WithdrawalLimits{}, fmt.Errorf("invalid %s: %w", a.env, err)
+		}
+		*a.dst = p
+	}
+	if limits.MaxSum > 0 && limits.MinSum > limits.MaxSum {
+		return balancevo.WithdrawalLimits{}, fmt.Errorf("invalid WITHDRAWAL_MIN_SUM: exceeds WITHDRAWAL_MAX_SUM")
+	}
+
+	cooldown, err := parseDuration(v.Get("withdrawals.cooldown"))
+	if err != nil {
+		return balancevo.WithdrawalLimits{}, fmt.Errorf("invalid WITHDRAWAL_COOLDOWN: %w", err)
+	}
+	limits.Cooldown = cooldown
+	return limits, nil
+}
+
+func parsePoints(raw any) (balancevo.Points, error) {
+	p, err := balancevo.ParsePoints(fmt.Sprint(raw))
+	if err != nil {
+		return 0, err
+	}
+	if p < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return p, nil
+}
+
+// parsePasswordHasher reads the password hashing algorithm and Argon2id parameters;
+// the parameters are validated only when Argon2id creates new hashes.
+func parsePasswordHasher(v *viper.Viper) (string, identityauth.Argon2Params, error) {
+	algorithm := strings.ToLower(strings.TrimSpace(v.GetString("auth.password_hasher")))
+	if algorithm != identityauth.AlgorithmBCrypt && algorithm != identityauth.AlgorithmArgon2id {
+		return "", identityauth.Argon2Params{}, fmt.Errorf(
+			"invalid PASSWORD_HASHER: must be %s or %s", identityauth.AlgorithmBCrypt, identityauth.AlgorithmArgon2id,
+		)
+	}
+
+	params := identityauth.DefaultArgon2Params
+	params.Memory = v.GetUint32("auth.argon2.memory")
+	params.Time = v.GetUint32("auth.argon2.time")
+	parallelism := v.GetUint32("auth.argon2.parallelism")
+	if parallelism > 255 {
+		return "", identityauth.Argon2Params{}, fmt.Errorf("invalid ARGON2_PARALLELISM: must be at most 255")
+	}
+	params.Parallelism = uint8(parallelism)
+
+	if algorithm == identityauth.AlgorithmArgon2id {
+		if err := params.Validate(); err != nil {
+			return "", identityauth.Argon2Params{}, fmt.Errorf("invalid ARGON2 parameters: %w", err)
+		}
+	}
+	return algorithm, params, nil
+}
+
+// parseLoginThrottle reads the login_throttle group. The per-IP policy shares delays, lockout
+// duration and window with the per-login one and has its own attempt counts, since many users
+// may share an address.
+func parseLoginThrottle(v *viper.Viper) (LoginThrottleConfig, error) {
+	store := strings.ToLower(strings.TrimSpace(v.GetString("login_throttle.store")))
+	if store != LoginThrottleStorePostgres && store != LoginThrottleStoreMemory {
+		return LoginThrottleConfig{}, fmt.Errorf(
+			"invalid LOGIN_THROTTLE_STORE: must be %s or %s", LoginThrottleStorePostgres, LoginThrottleStoreMemory,
+		)
+	}
+
+	var policy identityvo.LoginThrottlePolicy
+	var purgeInterval time.Duration
+	durations := []struct {
+		key string
+		env string
+		dst *time.Duration
+	}{
+		{"login_throttle.base_delay", "LOGIN_THROTTLE_BASE_DELAY", &policy.BaseDelay},
+		{"login_throttle.max_delay", "LOGIN_THROTTLE_MAX_DELAY", &policy.MaxDelay},
+		{"login_throttle.lockout_duration", "LOGIN_LOCKOUT_DURATION", &policy.LockoutDuration},
+		{"login_throttle.window", "LOGIN_THROTTLE_WINDOW", &policy.Window},
+		{"login_throttle.purge_interval", "LOGIN_THROTTLE_PURGE_INTERVAL", &purgeInterval},
+	}
+	for _, d := range durations {
+		dur, err := parseDuration(v.Get(d.key))
+		if err != nil {
+			return LoginThrottleConfig{}, fmt.Errorf("invalid %s: %w", d.env, err)
+		}
+		if dur < 0 {
+			return LoginThrottleConfig{}, fmt.Errorf("invalid %s: must not be negative", d.env)
+		}
+		*d.dst = dur
+	}
+
+	ipPolicy := policy
+	counts := []struct {
+		key string
+		env string
+		dst *int
+	}{
+		{"login_throttle.free_attempts", "LOGIN_THROTTLE_FREE_ATTEMPTS", &policy.FreeAttempts},
+		{"login_throttle.lockout_threshold", "LOGIN_LOCKOUT_THRESHOLD", &policy.LockoutThreshold},
+		{"login_throttle.ip_free_attempts", "LOGIN_IP_FREE_ATTEMPTS", &ipPolicy.FreeAttempts},
+		{"login_throttle.ip_lockout_threshold", "LOGIN_IP_LOCKOUT_THRESHOLD", &ipPolicy.LockoutThreshold},
+	}
+	for _, c := range counts {
+		n := v.GetInt(c.key)
+		if n < 0 {
+			return LoginThrottleConfig{}, fmt.Errorf("invalid %s: must not be negative", c.env)
+		}
+		*c.dst = n
+	}
+
+	if (policy.LockoutThreshold > 0 || ipPolicy.LockoutThreshold > 0) && policy.LockoutDuration <= 0 {
+		return LoginThrottleConfig{}, fmt.Errorf("invalid LOGIN_LOCKOUT_DURATION: must be positive when lockout is enabled")
+	}
+
+	return LoginThrottleConfig{
+		Store:         store,
+		Login:         policy,
+		IP:            ipPolicy,
+		PurgeInterval: purgeInterval,
+	}, nil
+}
+
 func parseBCryptCost(raw any) (int, error) {
 	var cost BCryptCost
 	if err := cost.Set(fmt.Sprint(raw)); err != nil {
@@ -0,0 +1,25 @@
+package intermodule
+
+import (
+	"context"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+	identityapi "gophermart/internal/gophermart/modules/identity/application/api"
+)
+
+// IdentityGatewayAdapter bridges balance module to identity module user directory API.
+type IdentityGatewayAdapter struct {
+	api identityapi.UserDirectoryAPI
+}
+
+func NewIdentityGatewayAdapter(api identityapi.UserDirectoryAPI) *IdentityGatewayAdapter {
+	return &IdentityGatewayAdapter{api: api}
+}
+
+func (a *IdentityGatewayAdapter) FindUserIDByLogin(ctx context.Context, login string) (vo.UserID, error) {
+	ref, err := a.api.FindByLogin(ctx, login)
+	if err != nil {
+		return 0, err
+	}
+	return vo.UserID(ref.UserID), nil
+}
@@ -0,0 +1,40 @@
+package intermodule
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+	ordersapi "gophermart/internal/gophermart/modules/orders/application/api"
+)
+
+// OrdersGatewayAdapter bridges balance module to orders module accrual history API.
+type OrdersGatewayAdapter struct {
+	api ordersapi.AccrualHistoryAPI
+}
+
+func NewOrdersGatewayAdapter(api ordersapi.AccrualHistoryAPI) *OrdersGatewayAdapter {
+	return &OrdersGatewayAdapter{api: api}
+}
+
+func (a *OrdersGatewayAdapter) ListAccruals(ctx context.Context, userID vo.UserID, from, to time.Time) ([]port.OrderAccrual, error) {
+	records, err := a.api.ListAccruals(ctx, ordersapi.ListAccrualsInput{
+		UserID: int64(userID),
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]port.OrderAccrual, 0, len(records))
+	for _, r := range records {
+		result = append(result, port.OrderAccrual{
+			OrderNumber: vo.OrderNumber(r.OrderNumber),
+			Amount:      vo.Points(r.Amount),
+			ProcessedAt: r.ProcessedAt,
+		})
+	}
+	return result, nil
+}
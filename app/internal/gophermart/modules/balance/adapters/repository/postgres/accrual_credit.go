@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+// AccrualCreditRepository is a PostgreSQL implementation of port.AccrualCreditRepository.
+type AccrualCreditRepository struct {
+	transactor *postgreskit.Transactor
+	conv       converter.AccrualCreditConverter
+}
+
+// NewAccrualCreditRepository creates a new AccrualCreditRepository.
+func NewAccrualCreditRepository(transactor *postgreskit.Transactor) *AccrualCreditRepository {
+	return &AccrualCreditRepository{
+		transactor: transactor,
+		conv:       &converter.AccrualCreditConverterImpl{},
+	}
+}
+
+// Create inserts a credit record or returns application.ErrAlreadyExists for an already credited order.
+// ON CONFLICT keeps the surrounding transaction usable, unlike a raised unique violation.
+func (r *AccrualCreditRepository) Create(ctx context.Context, c *entity.AccrualCredit) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbCredit := r.conv.ToModel(*c)
+
+		tag, err := q.Exec(ctx, `
+			INSERT INTO accrual_credits (user_id, order_number, amount, credited_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (order_number) DO NOTHING
+		`, dbCredit.UserID, dbCredit.OrderNumber, dbCredit.Amount, dbCredit.CreditedAt)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrAlreadyExists
+		}
+
+		return nil
+	})
+}
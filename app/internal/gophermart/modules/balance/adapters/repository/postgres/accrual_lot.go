@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// AccrualLotRepository is a PostgreSQL implementation of port.AccrualLotRepository.
+type AccrualLotRepository struct {
+	transactor *postgreskit.Transactor
+	conv       converter.AccrualLotConverter
+}
+
+// NewAccrualLotRepository creates a new AccrualLotRepository.
+func NewAccrualLotRepository(transactor *postgreskit.Transactor) *AccrualLotRepository {
+	return &AccrualLotRepository{
+		transactor: transactor,
+		conv:       &converter.AccrualLotConverterImpl{},
+	}
+}
+
+// Create inserts the lot and sets l.ID.
+func (r *AccrualLotRepository) Create(ctx context.Context, l *entity.AccrualLot) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbLot := r.conv.ToModel(*l)
+
+		var id int64
+		err := q.QueryRow(ctx, `
+			INSERT INTO accrual_lots (user_id, reference, amount, remaining, accrued_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`, dbLot.UserID, dbLot.Reference, dbLot.Amount, dbLot.Remaining, dbLot.AccruedAt, dbLot.ExpiresAt).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		l.ID = id
+		return nil
+	})
+}
+
+// Update persists the remaining points of the lot.
+func (r *AccrualLotRepository) Update(ctx context.Context, l *entity.AccrualLot) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbLot := r.conv.ToModel(*l)
+
+		tag, err := q.Exec(ctx, `
+			UPDATE accrual_lots SET remaining = $1 WHERE id = $2
+		`, dbLot.Remaining, dbLot.ID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// FindByID returns the lot or application.ErrNotFound.
+func (r *AccrualLotRepository) FindByID(ctx context.Context, id int64) (*entity.AccrualLot, error) {
+	lots, err := r.list(ctx, `
+		SELECT id, user_id, reference, amount, remaining, accrued_at, expires_at
+		FROM accrual_lots
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(lots) == 0 {
+		return nil, application.ErrNotFound
+	}
+	return &lots[0], nil
+}
+
+// ListActiveByUserID returns the user's lots with remaining points, oldest first.
+func (r *AccrualLotRepository) ListActiveByUserID(ctx context.Context, userID vo.UserID) ([]entity.AccrualLot, error) {
+	return r.list(ctx, `
+		SELECT id, user_id, reference, amount, remaining, accrued_at, expires_at
+		FROM accrual_lots
+		WHERE user_id = $1 AND remaining > 0
+		ORDER BY accrued_at, id
+	`, userID)
+}
+
+// ListDue returns up to limit lots with remaining points expired by now, earliest expiry first,
+// starting after the given keyset position.
+func (r *AccrualLotRepository) ListDue(
+	ctx context.Context,
+	now time.Time,
+	after *port.DueCursor,
+	limit int,
+) ([]entity.AccrualLot, error) {
+	var afterAt *time.Time
+	var afterID int64
+	if after != nil {
+		afterAt, afterID = &after.ExpiresAt, after.ID
+	}
+
+	return r.list(ctx, `
+		SELECT id, user_id, reference, amount, remaining, accrued_at, expires_at
+		FROM accrual_lots
+		WHERE remaining > 0 AND expires_at <= $1
+		  AND ($2::TIMESTAMPTZ IS NULL OR (expires_at, id) > ($2, $3))
+		ORDER BY expires_at, id
+		LIMIT $4
+	`, now, afterAt, afterID, limit)
+}
+
+func (r *AccrualLotRepository) list(ctx context.Context, query string, args ...any) ([]entity.AccrualLot, error) {
+	var result []entity.AccrualLot
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRows, err := pgx.CollectRows(rows, pgx.RowToStructByPos[model.AccrualLot])
+		if err != nil {
+			return err
+		}
+
+		result = result[:0]
+		for _, dbRow := range dbRows {
+			result = append(result, r.conv.ToEntity(dbRow))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -35,9 +35,9 @@ func (r *BalanceAccountRepository) Create(ctx context.Context, acc *entity.Balan
 		dbAcc := r.conv.ToModel(*acc)
 
 		_, err := q.Exec(ctx, `
-			INSERT INTO balance_accounts (user_id, current, withdrawn_total, created_at, updated_at, version)
-			VALUES ($1, $2, $3, $4, $5, $6)
-		`, dbAcc.UserID, dbAcc.Current, dbAcc.WithdrawnTotal, dbAcc.CreatedAt, dbAcc.UpdatedAt, dbAcc.Version)
+			INSERT INTO balance_accounts (user_id, current, withdrawn_total, held, created_at, updated_at, version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, dbAcc.UserID, dbAcc.Current, dbAcc.WithdrawnTotal, dbAcc.Held, dbAcc.CreatedAt, dbAcc.UpdatedAt, dbAcc.Version)
 
 		return err
 	})
@@ -51,7 +51,7 @@ func (r *BalanceAccountRepository) FindByUserID(ctx context.Context, userID vo.U
 		q := r.transactor.GetQuerier(ctx)
 
 		rows, err := q.Query(ctx, `
-			SELECT user_id, current, withdrawn_total, created_at, updated_at, version
+			SELECT user_id, current, withdrawn_total, held, created_at, updated_at, version
 			FROM balance_accounts
 			WHERE user_id = $1
 		`, userID)
@@ -79,6 +79,34 @@ func (r *BalanceAccountRepository) FindByUserID(ctx context.Context, userID vo.U
 	return &acc, nil
 }
 
+// ListUserIDs returns up to limit user IDs of accounts greater than after, in ascending order.
+func (r *BalanceAccountRepository) ListUserIDs(ctx context.Context, after vo.UserID, limit int) ([]vo.UserID, error) {
+	var ids []vo.UserID
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, `
+			SELECT user_id
+			FROM balance_accounts
+			WHERE user_id > $1
+			ORDER BY user_id
+			LIMIT $2
+		`, after, limit)
+		if err != nil {
+			return err
+		}
+
+		ids, err = pgx.CollectRows(rows, pgx.RowTo[vo.UserID])
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // Update updates the balance account with optimistic locking.
 // Returns application.ErrOptimisticLock if the version in DB does not match acc.Version.
 func (r *BalanceAccountRepository) Update(ctx context.Context, acc *entity.BalanceAccount) error {
@@ -89,10 +117,10 @@ func (r *BalanceAccountRepository) Update(ctx context.Context, acc *entity.Balan
 		var newVersion int64
 		err := q.QueryRow(ctx, `
 			UPDATE balance_accounts
-			SET current = $1, withdrawn_total = $2, updated_at = $3, version = version + 1
-			WHERE user_id = $4 AND version = $5
+			SET current = $1, withdrawn_total = $2, held = $3, updated_at = $4, version = version + 1
+			WHERE user_id = $5 AND version = $6
 			RETURNING version
-		`, dbAcc.Current, dbAcc.WithdrawnTotal, dbAcc.UpdatedAt, dbAcc.UserID, dbAcc.Version).Scan(&newVersion)
+		`, dbAcc.Current, dbAcc.WithdrawnTotal, dbAcc.Held, dbAcc.UpdatedAt, dbAcc.UserID, dbAcc.Version).Scan(&newVersion)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return application.ErrOptimisticLock
@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+//go:generate goverter gen .
+
+// goverter:converter
+// goverter:output:file accrual_credit_gen.go
+// goverter:output:package converter
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:CopyTime
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsFromDB
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsToDB
+type AccrualCreditConverter interface {
+	ToEntity(source model.AccrualCredit) entity.AccrualCredit
+	ToModel(source entity.AccrualCredit) model.AccrualCredit
+}
@@ -0,0 +1,30 @@
+// Code generated by github.com/jmattheis/goverter, DO NOT EDIT.
+//go:build !goverter
+
+package converter
+
+import (
+	convext "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext"
+	model "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	entity "gophermart/internal/gophermart/modules/balance/domain/entity"
+	vo "gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+type AccrualCreditConverterImpl struct{}
+
+func (c *AccrualCreditConverterImpl) ToEntity(source model.AccrualCredit) entity.AccrualCredit {
+	var entityAccrualCredit entity.AccrualCredit
+	entityAccrualCredit.UserID = vo.UserID(source.UserID)
+	entityAccrualCredit.OrderNumber = vo.OrderNumber(source.OrderNumber)
+	entityAccrualCredit.Amount = convext.PointsFromDB(source.Amount)
+	entityAccrualCredit.CreditedAt = convext.CopyTime(source.CreditedAt)
+	return entityAccrualCredit
+}
+func (c *AccrualCreditConverterImpl) ToModel(source entity.AccrualCredit) model.AccrualCredit {
+	var modelAccrualCredit model.AccrualCredit
+	modelAccrualCredit.UserID = int64(source.UserID)
+	modelAccrualCredit.OrderNumber = string(source.OrderNumber)
+	modelAccrualCredit.Amount = convext.PointsToDB(source.Amount)
+	modelAccrualCredit.CreditedAt = convext.CopyTime(source.CreditedAt)
+	return modelAccrualCredit
+}
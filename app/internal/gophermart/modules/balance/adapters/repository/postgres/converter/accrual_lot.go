@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+//go:generate goverter gen .
+
+// goverter:converter
+// goverter:output:file accrual_lot_gen.go
+// goverter:output:package converter
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:CopyTime
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsFromDB
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsToDB
+type AccrualLotConverter interface {
+	ToEntity(source model.AccrualLot) entity.AccrualLot
+	ToModel(source entity.AccrualLot) model.AccrualLot
+}
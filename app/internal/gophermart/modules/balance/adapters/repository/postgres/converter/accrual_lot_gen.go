@@ -0,0 +1,45 @@
+// Code generated by github.com/jmattheis/goverter, DO NOT EDIT.
+//go:build !goverter
+
+package converter
+
+import (
+	convext "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext"
+	model "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	entity "gophermart/internal/gophermart/modules/balance/domain/entity"
+	vo "gophermart/internal/gophermart/modules/balance/domain/vo"
+	"time"
+)
+
+type AccrualLotConverterImpl struct{}
+
+func (c *AccrualLotConverterImpl) ToEntity(source model.AccrualLot) entity.AccrualLot {
+	var entityAccrualLot entity.AccrualLot
+	entityAccrualLot.ID = source.ID
+	entityAccrualLot.UserID = vo.UserID(source.UserID)
+	entityAccrualLot.Reference = source.Reference
+	entityAccrualLot.Amount = convext.PointsFromDB(source.Amount)
+	entityAccrualLot.Remaining = convext.PointsFromDB(source.Remaining)
+	entityAccrualLot.AccruedAt = convext.CopyTime(source.AccruedAt)
+	entityAccrualLot.ExpiresAt = c.pTimeTimeToPTimeTime(source.ExpiresAt)
+	return entityAccrualLot
+}
+func (c *AccrualLotConverterImpl) ToModel(source entity.AccrualLot) model.AccrualLot {
+	var modelAccrualLot model.AccrualLot
+	modelAccrualLot.ID = source.ID
+	modelAccrualLot.UserID = int64(source.UserID)
+	modelAccrualLot.Reference = source.Reference
+	modelAccrualLot.Amount = convext.PointsToDB(source.Amount)
+	modelAccrualLot.Remaining = convext.PointsToDB(source.Remaining)
+	modelAccrualLot.AccruedAt = convext.CopyTime(source.AccruedAt)
+	modelAccrualLot.ExpiresAt = c.pTimeTimeToPTimeTime(source.ExpiresAt)
+	return modelAccrualLot
+}
+func (c *AccrualLotConverterImpl) pTimeTimeToPTimeTime(source *time.Time) *time.Time {
+	var pTimeTime *time.Time
+	if source != nil {
+		timeTime := convext.CopyTime((*source))
+		pTimeTime = &timeTime
+	}
+	return pTimeTime
+}
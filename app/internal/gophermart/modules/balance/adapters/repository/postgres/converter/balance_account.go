@@ -11,6 +11,8 @@ import (
 // goverter:output:file balance_account_gen.go
 // goverter:output:package converter
 // goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:CopyTime
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsFromDB
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsToDB
 type BalanceAccountConverter interface {
 	ToEntity(source model.BalanceAccount) entity.BalanceAccount
 	ToModel(source entity.BalanceAccount) model.BalanceAccount
@@ -15,8 +15,9 @@ type BalanceAccountConverterImpl struct{}
 func (c *BalanceAccountConverterImpl) ToEntity(source model.BalanceAccount) entity.BalanceAccount {
 	var entityBalanceAccount entity.BalanceAccount
 	entityBalanceAccount.UserID = vo.UserID(source.UserID)
-	entityBalanceAccount.Current = vo.Points(source.Current)
-	entityBalanceAccount.WithdrawnTotal = vo.Points(source.WithdrawnTotal)
+	entityBalanceAccount.Current = convext.PointsFromDB(source.Current)
+	entityBalanceAccount.WithdrawnTotal = convext.PointsFromDB(source.WithdrawnTotal)
+	entityBalanceAccount.Held = convext.PointsFromDB(source.Held)
 	entityBalanceAccount.CreatedAt = convext.CopyTime(source.CreatedAt)
 	entityBalanceAccount.UpdatedAt = convext.CopyTime(source.UpdatedAt)
 	entityBalanceAccount.Version = source.Version
@@ -25,13 +26,11 @@ func (c *BalanceAccountConverterImpl) ToEntity(source model.BalanceAccount) enti
 func (c *BalanceAccountConverterImpl) ToModel(source entity.BalanceAccount) model.BalanceAccount {
 	var modelBalanceAccount model.BalanceAccount
 	modelBalanceAccount.UserID = int64(source.UserID)
-	modelBalanceAccount.Current = c.voPointsToFloat64(source.Current)
-	modelBalanceAccount.WithdrawnTotal = c.voPointsToFloat64(source.WithdrawnTotal)
+	modelBalanceAccount.Current = convext.PointsToDB(source.Current)
+	modelBalanceAccount.WithdrawnTotal = convext.PointsToDB(source.WithdrawnTotal)
+	modelBalanceAccount.Held = convext.PointsToDB(source.Held)
 	modelBalanceAccount.CreatedAt = convext.CopyTime(source.CreatedAt)
 	modelBalanceAccount.UpdatedAt = convext.CopyTime(source.UpdatedAt)
 	modelBalanceAccount.Version = source.Version
 	return modelBalanceAccount
 }
-func (c *BalanceAccountConverterImpl) voPointsToFloat64(source vo.Points) float64 {
-	return float64(source)
-}
@@ -0,0 +1,20 @@
+package convext
+
+import "gophermart/internal/gophermart/modules/balance/domain/vo"
+
+// UserIDFromNullable maps NULL (system account owner) to zero UserID.
+func UserIDFromNullable(v *int64) vo.UserID {
+	if v == nil {
+		return 0
+	}
+	return vo.UserID(*v)
+}
+
+// UserIDToNullable maps zero UserID (system account owner) to NULL.
+func UserIDToNullable(v vo.UserID) *int64 {
+	if v == 0 {
+		return nil
+	}
+	id := int64(v)
+	return &id
+}
@@ -0,0 +1,16 @@
+package convext
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+func PointsFromDB(v pgtype.Numeric) vo.Points {
+	return vo.Points(postgreskit.MinorFromNumeric(v))
+}
+
+func PointsToDB(v vo.Points) pgtype.Numeric {
+	return postgreskit.NumericFromMinor(int64(v))
+}
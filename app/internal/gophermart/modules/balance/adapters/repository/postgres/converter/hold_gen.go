@@ -0,0 +1,47 @@
+// Code generated by github.com/jmattheis/goverter, DO NOT EDIT.
+//go:build !goverter
+
+package converter
+
+import (
+	convext "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext"
+	model "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	entity "gophermart/internal/gophermart/modules/balance/domain/entity"
+	vo "gophermart/internal/gophermart/modules/balance/domain/vo"
+	"time"
+)
+
+type HoldConverterImpl struct{}
+
+func (c *HoldConverterImpl) ToEntity(source model.Hold) entity.Hold {
+	var entityHold entity.Hold
+	entityHold.ID = source.ID
+	entityHold.UserID = vo.UserID(source.UserID)
+	entityHold.OrderNumber = vo.OrderNumber(source.OrderNumber)
+	entityHold.Amount = convext.PointsFromDB(source.Amount)
+	entityHold.Status = entity.HoldStatus(source.Status)
+	entityHold.CreatedAt = convext.CopyTime(source.CreatedAt)
+	entityHold.ExpiresAt = convext.CopyTime(source.ExpiresAt)
+	entityHold.ResolvedAt = c.pTimeTimeToPTimeTime(source.ResolvedAt)
+	return entityHold
+}
+func (c *HoldConverterImpl) ToModel(source entity.Hold) model.Hold {
+	var modelHold model.Hold
+	modelHold.ID = source.ID
+	modelHold.UserID = int64(source.UserID)
+	modelHold.OrderNumber = string(source.OrderNumber)
+	modelHold.Amount = convext.PointsToDB(source.Amount)
+	modelHold.Status = string(source.Status)
+	modelHold.CreatedAt = convext.CopyTime(source.CreatedAt)
+	modelHold.ExpiresAt = convext.CopyTime(source.ExpiresAt)
+	modelHold.ResolvedAt = c.pTimeTimeToPTimeTime(source.ResolvedAt)
+	return modelHold
+}
+func (c *HoldConverterImpl) pTimeTimeToPTimeTime(source *time.Time) *time.Time {
+	var pTimeTime *time.Time
+	if source != nil {
+		timeTime := convext.CopyTime((*source))
+		pTimeTime = &timeTime
+	}
+	return pTimeTime
+}
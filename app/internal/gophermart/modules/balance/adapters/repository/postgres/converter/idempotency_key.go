@@ -0,0 +1,17 @@
+package converter
+
+import (
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+//go:generate goverter gen .
+
+// goverter:converter
+// goverter:output:file idempotency_key_gen.go
+// goverter:output:package converter
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:CopyTime
+type IdempotencyKeyConverter interface {
+	ToEntity(source model.IdempotencyKey) entity.IdempotencyKey
+	ToModel(source entity.IdempotencyKey) model.IdempotencyKey
+}
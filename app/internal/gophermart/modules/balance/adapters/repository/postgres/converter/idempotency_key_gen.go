@@ -0,0 +1,30 @@
+// Code generated by github.com/jmattheis/goverter, DO NOT EDIT.
+//go:build !goverter
+
+package converter
+
+import (
+	convext "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext"
+	model "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	entity "gophermart/internal/gophermart/modules/balance/domain/entity"
+	vo "gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+type IdempotencyKeyConverterImpl struct{}
+
+func (c *IdempotencyKeyConverterImpl) ToEntity(source model.IdempotencyKey) entity.IdempotencyKey {
+	var entityIdempotencyKey entity.IdempotencyKey
+	entityIdempotencyKey.UserID = vo.UserID(source.UserID)
+	entityIdempotencyKey.Key = source.Key
+	entityIdempotencyKey.Fingerprint = source.Fingerprint
+	entityIdempotencyKey.CreatedAt = convext.CopyTime(source.CreatedAt)
+	return entityIdempotencyKey
+}
+func (c *IdempotencyKeyConverterImpl) ToModel(source entity.IdempotencyKey) model.IdempotencyKey {
+	var modelIdempotencyKey model.IdempotencyKey
+	modelIdempotencyKey.UserID = int64(source.UserID)
+	modelIdempotencyKey.Key = source.Key
+	modelIdempotencyKey.Fingerprint = source.Fingerprint
+	modelIdempotencyKey.CreatedAt = convext.CopyTime(source.CreatedAt)
+	return modelIdempotencyKey
+}
@@ -0,0 +1,25 @@
+package converter
+
+import (
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+//go:generate goverter gen .
+
+// goverter:converter
+// goverter:output:file ledger_gen.go
+// goverter:output:package converter
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:CopyTime
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsFromDB
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsToDB
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:UserIDFromNullable
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:UserIDToNullable
+type LedgerConverter interface {
+	// goverter:ignore Postings
+	EntryToEntity(source model.LedgerEntry) entity.JournalEntry
+	EntryToModel(source entity.JournalEntry) model.LedgerEntry
+	PostingToEntity(source model.LedgerPosting) entity.Posting
+	// goverter:ignore EntryID
+	PostingToModel(source entity.Posting) model.LedgerPosting
+}
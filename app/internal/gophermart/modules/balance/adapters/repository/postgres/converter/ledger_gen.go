@@ -0,0 +1,48 @@
+// Code generated by github.com/jmattheis/goverter, DO NOT EDIT.
+//go:build !goverter
+
+package converter
+
+import (
+	convext "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext"
+	model "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	entity "gophermart/internal/gophermart/modules/balance/domain/entity"
+	vo "gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+type LedgerConverterImpl struct{}
+
+func (c *LedgerConverterImpl) EntryToEntity(source model.LedgerEntry) entity.JournalEntry {
+	var entityJournalEntry entity.JournalEntry
+	entityJournalEntry.ID = source.ID
+	entityJournalEntry.UserID = vo.UserID(source.UserID)
+	entityJournalEntry.Kind = entity.EntryKind(source.Kind)
+	entityJournalEntry.Reference = source.Reference
+	entityJournalEntry.CreatedAt = convext.CopyTime(source.CreatedAt)
+	return entityJournalEntry
+}
+func (c *LedgerConverterImpl) EntryToModel(source entity.JournalEntry) model.LedgerEntry {
+	var modelLedgerEntry model.LedgerEntry
+	modelLedgerEntry.ID = source.ID
+	modelLedgerEntry.UserID = int64(source.UserID)
+	modelLedgerEntry.Kind = string(source.Kind)
+	modelLedgerEntry.Reference = source.Reference
+	modelLedgerEntry.CreatedAt = convext.CopyTime(source.CreatedAt)
+	return modelLedgerEntry
+}
+func (c *LedgerConverterImpl) PostingToEntity(source model.LedgerPosting) entity.Posting {
+	var entityPosting entity.Posting
+	entityPosting.Account = entity.LedgerAccount(source.Account)
+	entityPosting.UserID = convext.UserIDFromNullable(source.UserID)
+	entityPosting.Side = entity.PostingSide(source.Side)
+	entityPosting.Amount = convext.PointsFromDB(source.Amount)
+	return entityPosting
+}
+func (c *LedgerConverterImpl) PostingToModel(source entity.Posting) model.LedgerPosting {
+	var modelLedgerPosting model.LedgerPosting
+	modelLedgerPosting.Account = string(source.Account)
+	modelLedgerPosting.UserID = convext.UserIDToNullable(source.UserID)
+	modelLedgerPosting.Side = string(source.Side)
+	modelLedgerPosting.Amount = convext.PointsToDB(source.Amount)
+	return modelLedgerPosting
+}
@@ -11,6 +11,8 @@ import (
 // goverter:output:file withdrawal_gen.go
 // goverter:output:package converter
 // goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:CopyTime
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsFromDB
+// goverter:extend gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter/convext:PointsToDB
 type WithdrawalConverter interface {
 	ToEntity(source model.Withdrawal) entity.Withdrawal
 	ToModel(source entity.Withdrawal) model.Withdrawal
@@ -8,6 +8,7 @@ import (
 	model "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
 	entity "gophermart/internal/gophermart/modules/balance/domain/entity"
 	vo "gophermart/internal/gophermart/modules/balance/domain/vo"
+	"time"
 )
 
 type WithdrawalConverterImpl struct{}
@@ -16,15 +17,27 @@ func (c *WithdrawalConverterImpl) ToEntity(source model.Withdrawal) entity.Withd
 	var entityWithdrawal entity.Withdrawal
 	entityWithdrawal.UserID = vo.UserID(source.UserID)
 	entityWithdrawal.OrderNumber = vo.OrderNumber(source.OrderNumber)
-	entityWithdrawal.Amount = vo.Points(source.Amount)
+	entityWithdrawal.Amount = convext.PointsFromDB(source.Amount)
 	entityWithdrawal.ProcessedAt = convext.CopyTime(source.ProcessedAt)
+	entityWithdrawal.RefundedAmount = convext.PointsFromDB(source.RefundedAmount)
+	entityWithdrawal.RefundedAt = c.pTimeTimeToPTimeTime(source.RefundedAt)
 	return entityWithdrawal
 }
 func (c *WithdrawalConverterImpl) ToModel(source entity.Withdrawal) model.Withdrawal {
 	var modelWithdrawal model.Withdrawal
 	modelWithdrawal.UserID = int64(source.UserID)
 	modelWithdrawal.OrderNumber = string(source.OrderNumber)
-	modelWithdrawal.Amount = float64(source.Amount)
+	modelWithdrawal.Amount = convext.PointsToDB(source.Amount)
 	modelWithdrawal.ProcessedAt = convext.CopyTime(source.ProcessedAt)
+	modelWithdrawal.RefundedAmount = convext.PointsToDB(source.RefundedAmount)
+	modelWithdrawal.RefundedAt = c.pTimeTimeToPTimeTime(source.RefundedAt)
 	return modelWithdrawal
 }
+func (c *WithdrawalConverterImpl) pTimeTimeToPTimeTime(source *time.Time) *time.Time {
+	var pTimeTime *time.Time
+	if source != nil {
+		timeTime := convext.CopyTime((*source))
+		pTimeTime = &timeTime
+	}
+	return pTimeTime
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// HoldRepository is a PostgreSQL implementation of port.HoldRepository.
+type HoldRepository struct {
+	transactor *postgreskit.Transactor
+	conv       converter.HoldConverter
+}
+
+// NewHoldRepository creates a new HoldRepository.
+func NewHoldRepository(transactor *postgreskit.Transactor) *HoldRepository {
+	return &HoldRepository{
+		transactor: transactor,
+		conv:       &converter.HoldConverterImpl{},
+	}
+}
+
+// Create inserts the hold and sets h.ID, or returns application.ErrAlreadyExists
+// if the user already has an active hold for the order; the surrounding transaction stays usable.
+func (r *HoldRepository) Create(ctx context.Context, h *entity.Hold) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbHold := r.conv.ToModel(*h)
+
+		rows, err := q.Query(ctx, `
+			INSERT INTO holds (user_id, order_number, amount, status, created_at, expires_at, resolved_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (user_id, order_number) WHERE status = 'ACTIVE' DO NOTHING
+			RETURNING id
+		`, dbHold.UserID, dbHold.OrderNumber, dbHold.Amount, dbHold.Status,
+			dbHold.CreatedAt, dbHold.ExpiresAt, dbHold.ResolvedAt)
+		if err != nil {
+			return err
+		}
+
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return application.ErrAlreadyExists
+		}
+
+		h.ID = ids[0]
+		return nil
+	})
+}
+
+// Update persists the status and resolution time of the hold.
+func (r *HoldRepository) Update(ctx context.Context, h *entity.Hold) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbHold := r.conv.ToModel(*h)
+
+		tag, err := q.Exec(ctx, `
+			UPDATE holds SET status = $1, resolved_at = $2 WHERE id = $3
+		`, dbHold.Status, dbHold.ResolvedAt, dbHold.ID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// FindByID returns the hold or application.ErrNotFound.
+func (r *HoldRepository) FindByID(ctx context.Context, id int64) (*entity.Hold, error) {
+	holds, err := r.list(ctx, `
+		SELECT id, user_id, order_number, amount, status, created_at, expires_at, resolved_at
+		FROM holds
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(holds) == 0 {
+		return nil, application.ErrNotFound
+	}
+	return &holds[0], nil
+}
+
+// FindActiveByOrderNumber returns the user's active hold for the order or application.ErrNotFound.
+func (r *HoldRepository) FindActiveByOrderNumber(
+	ctx context.Context,
+	userID vo.UserID,
+	orderNumber vo.OrderNumber,
+) (*entity.Hold, error) {
+	holds, err := r.list(ctx, `
+		SELECT id, user_id, order_number, amount, status, created_at, expires_at, resolved_at
+		FROM holds
+		WHERE user_id = $1 AND order_number = $2 AND status = 'ACTIVE'
+	`, userID, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(holds) == 0 {
+		return nil, application.ErrNotFound
+	}
+	return &holds[0], nil
+}
+
+// ListByUserID returns the user's holds, newest first.
+func (r *HoldRepository) ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Hold, error) {
+	return r.list(ctx, `
+		SELECT id, user_id, order_number, amount, status, created_at, expires_at, resolved_at
+		FROM holds
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+}
+
+// ListActiveByUserID returns the user's active holds, oldest first.
+func (r *HoldRepository) ListActiveByUserID(ctx context.Context, userID vo.UserID) ([]entity.Hold, error) {
+	return r.list(ctx, `
+		SELECT id, user_id, order_number, amount, status, created_at, expires_at, resolved_at
+		FROM holds
+		WHERE user_id = $1 AND status = 'ACTIVE'
+		ORDER BY created_at, id
+	`, userID)
+}
+
+// ListExpired returns up to limit active holds expired by now, earliest expiry first.
+func (r *HoldRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]entity.Hold, error) {
+	return r.list(ctx, `
+		SELECT id, user_id, order_number, amount, status, created_at, expires_at, resolved_at
+		FROM holds
+		WHERE status = 'ACTIVE' AND expires_at <= $1
+		ORDER BY expires_at, id
+		LIMIT $2
+	`, now, limit)
+}
+
+func (r *HoldRepository) list(ctx context.Context, query string, args ...any) ([]entity.Hold, error) {
+	var result []entity.Hold
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRows, err := pgx.CollectRows(rows, pgx.RowToStructByPos[model.Hold])
+		if err != nil {
+			return err
+		}
+
+		result = result[:0]
+		for _, dbRow := range dbRows {
+			result = append(result, r.conv.ToEntity(dbRow))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
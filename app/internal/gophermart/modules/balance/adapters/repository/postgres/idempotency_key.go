@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// IdempotencyKeyRepository is a PostgreSQL implementation of port.IdempotencyKeyRepository.
+type IdempotencyKeyRepository struct {
+	transactor *postgreskit.Transactor
+	conv       converter.IdempotencyKeyConverter
+}
+
+// NewIdempotencyKeyRepository creates a new IdempotencyKeyRepository.
+func NewIdempotencyKeyRepository(transactor *postgreskit.Transactor) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{
+		transactor: transactor,
+		conv:       &converter.IdempotencyKeyConverterImpl{},
+	}
+}
+
+// Create inserts the key or returns application.ErrAlreadyExists if the user already used it.
+// ON CONFLICT waits for a concurrent insert of the same key to commit or roll back
+// and keeps the surrounding transaction usable, unlike a raised unique violation.
+func (r *IdempotencyKeyRepository) Create(ctx context.Context, k *entity.IdempotencyKey) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbKey := r.conv.ToModel(*k)
+
+		tag, err := q.Exec(ctx, `
+			INSERT INTO idempotency_keys (user_id, key, fingerprint, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, key) DO NOTHING
+		`, dbKey.UserID, dbKey.Key, dbKey.Fingerprint, dbKey.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrAlreadyExists
+		}
+
+		return nil
+	})
+}
+
+// FindByKey returns the stored key of the user or application.ErrNotFound.
+func (r *IdempotencyKeyRepository) FindByKey(ctx context.Context, userID vo.UserID, key string) (*entity.IdempotencyKey, error) {
+	var k entity.IdempotencyKey
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, `
+			SELECT user_id, key, fingerprint, created_at
+			FROM idempotency_keys
+			WHERE user_id = $1 AND key = $2
+		`, userID, key)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRow, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[model.IdempotencyKey])
+		if err != nil {
+			return err
+		}
+
+		k = r.conv.ToEntity(dbRow)
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, application.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &k, nil
+}
+
+// DeleteCreatedBefore removes keys created before t and returns the number of removed keys.
+func (r *IdempotencyKeyRepository) DeleteCreatedBefore(ctx context.Context, t time.Time) (int, error) {
+	var deleted int
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		tag, err := q.Exec(ctx, `DELETE FROM idempotency_keys WHERE created_at < $1`, t)
+		if err != nil {
+			return err
+		}
+
+		deleted = int(tag.RowsAffected())
+		return nil
+	})
+
+	return deleted, err
+}
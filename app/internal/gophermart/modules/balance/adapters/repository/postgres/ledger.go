@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter"
+	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// LedgerRepository is a PostgreSQL implementation of port.LedgerRepository.
+type LedgerRepository struct {
+	transactor *postgreskit.Transactor
+	conv       converter.LedgerConverter
+}
+
+// NewLedgerRepository creates a new LedgerRepository.
+func NewLedgerRepository(transactor *postgreskit.Transactor) *LedgerRepository {
+	return &LedgerRepository{
+		transactor: transactor,
+		conv:       &converter.LedgerConverterImpl{},
+	}
+}
+
+// Append inserts the journal entry and its postings and sets e.ID.
+// Should run inside a transaction so a partially written entry is never visible.
+func (r *LedgerRepository) Append(ctx context.Context, e *entity.JournalEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbEntry := r.conv.EntryToModel(*e)
+
+		var id int64
+		err := q.QueryRow(ctx, `
+			INSERT INTO ledger_entries (user_id, kind, reference, created_at)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`, dbEntry.UserID, dbEntry.Kind, dbEntry.Reference, dbEntry.CreatedAt).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		batch := &pgx.Batch{}
+		for _, p := range e.Postings {
+			dbPosting := r.conv.PostingToModel(p)
+			batch.Queue(`
+				INSERT INTO ledger_postings (entry_id, account, user_id, side, amount)
+				VALUES ($1, $2, $3, $4, $5)
+			`, id, dbPosting.Account, dbPosting.UserID, dbPosting.Side, dbPosting.Amount)
+		}
+		if err := q.SendBatch(ctx, batch).Close(); err != nil {
+			return err
+		}
+
+		e.ID = id
+		return nil
+	})
+}
+
+// ListByUserID returns entries having a posting on the user's accounts, newest first.
+func (r *LedgerRepository) ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.JournalEntry, error) {
+	return r.listEntries(ctx, `
+		SELECT e.id, e.user_id, e.kind, e.reference, e.created_at
+		FROM ledger_entries e
+		WHERE e.user_id = $1
+		   OR EXISTS (SELECT 1 FROM ledger_postings p WHERE p.entry_id = e.id AND p.user_id = $1)
+		ORDER BY e.created_at DESC, e.id DESC
+	`, userID)
+}
+
+// ListByUserIDBetween returns entries created in [from, to) having a posting on the user's accounts,
+// newest first.
+func (r *LedgerRepository) ListByUserIDBetween(
+	ctx context.Context,
+	userID vo.UserID,
+	from, to time.Time,
+) ([]entity.JournalEntry, error) {
+	return r.listEntries(ctx, `
+		SELECT e.id, e.user_id, e.kind, e.reference, e.created_at
+		FROM ledger_entries e
+		WHERE (e.user_id = $1
+		       OR EXISTS (SELECT 1 FROM ledger_postings p WHERE p.entry_id = e.id AND p.user_id = $1))
+		  AND e.created_at >= $2 AND e.created_at < $3
+		ORDER BY e.created_at DESC, e.id DESC
+	`, userID, from, to)
+}
+
+// listEntries runs an entry query and loads the postings of the returned entries.
+func (r *LedgerRepository) listEntries(ctx context.Context, query string, args ...any) ([]entity.JournalEntry, error) {
+	var result []entity.JournalEntry
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		dbEntries, err := pgx.CollectRows(rows, pgx.RowToStructByPos[model.LedgerEntry])
+		if err != nil {
+			return err
+		}
+
+		result = result[:0]
+		if len(dbEntries) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(dbEntries))
+		index := make(map[int64]int, len(dbEntries))
+		for i, dbEntry := range dbEntries {
+			ids[i] = dbEntry.ID
+			index[dbEntry.ID] = i
+			result = append(result, r.conv.EntryToEntity(dbEntry))
+		}
+
+		rows, err = q.Query(ctx, `
+			SELECT entry_id, account, user_id, side, amount
+			FROM ledger_postings
+			WHERE entry_id = ANY($1)
+			ORDER BY id
+		`, ids)
+		if err != nil {
+			return err
+		}
+		dbPostings, err := pgx.CollectRows(rows, pgx.RowToStructByPos[model.LedgerPosting])
+		if err != nil {
+			return err
+		}
+
+		for _, dbPosting := range dbPostings {
+			i := index[dbPosting.EntryID]
+			result[i].Postings = append(result[i].Postings, r.conv.PostingToEntity(dbPosting))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BalanceAt sums the user's points postings of entries created before at: credits add, debits subtract.
+func (r *LedgerRepository) BalanceAt(ctx context.Context, userID vo.UserID, at time.Time) (vo.Points, error) {
+	var balance pgtype.Numeric
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		return q.QueryRow(ctx, `
+			SELECT COALESCE(SUM(CASE WHEN p.side = $3 THEN p.amount ELSE -p.amount END), 0)::NUMERIC(18,2)
+			FROM ledger_postings p
+			JOIN ledger_entries e ON e.id = p.entry_id
+			WHERE p.user_id = $1 AND p.account = $2 AND e.created_at < $4
+		`, userID, string(entity.LedgerAccountUserPoints), string(entity.PostingSideCredit), at).Scan(&balance)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return vo.Points(postgreskit.MinorFromNumeric(balance)), nil
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AccrualCredit is the DB projection of the accrual_credits table row.
+type AccrualCredit struct {
+	UserID      int64
+	OrderNumber string
+	Amount      pgtype.Numeric
+	CreditedAt  time.Time
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AccrualLot is the DB projection of the accrual_lots table row.
+type AccrualLot struct {
+	ID        int64
+	UserID    int64
+	Reference string
+	Amount    pgtype.Numeric
+	Remaining pgtype.Numeric
+	AccruedAt time.Time
+	ExpiresAt *time.Time
+}
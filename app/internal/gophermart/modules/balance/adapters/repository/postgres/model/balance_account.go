@@ -1,12 +1,17 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
 
 // BalanceAccount is the DB projection of the balance_accounts table row.
 type BalanceAccount struct {
 	UserID         int64
-	Current        float64
-	WithdrawnTotal float64
+	Current        pgtype.Numeric
+	WithdrawnTotal pgtype.Numeric
+	Held           pgtype.Numeric
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	Version        int64
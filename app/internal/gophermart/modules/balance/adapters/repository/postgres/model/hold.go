@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Hold is the DB projection of the holds table row.
+type Hold struct {
+	ID          int64
+	UserID      int64
+	OrderNumber string
+	Amount      pgtype.Numeric
+	Status      string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	ResolvedAt  *time.Time
+}
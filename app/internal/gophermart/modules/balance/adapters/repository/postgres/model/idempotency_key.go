@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// IdempotencyKey is the DB projection of the idempotency_keys table row.
+type IdempotencyKey struct {
+	UserID      int64
+	Key         string
+	Fingerprint string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LedgerEntry is the DB projection of the ledger_entries table row.
+type LedgerEntry struct {
+	ID        int64
+	UserID    int64
+	Kind      string
+	Reference string
+	CreatedAt time.Time
+}
+
+// LedgerPosting is the DB projection of the ledger_postings table row.
+type LedgerPosting struct {
+	EntryID int64
+	Account string
+	UserID  *int64
+	Side    string
+	Amount  pgtype.Numeric
+}
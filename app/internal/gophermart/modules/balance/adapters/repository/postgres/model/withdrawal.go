@@ -1,11 +1,17 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
 
 // Withdrawal is the DB projection of the withdrawals table row.
 type Withdrawal struct {
-	UserID      int64
-	OrderNumber string
-	Amount      float64
-	ProcessedAt time.Time
+	UserID         int64
+	OrderNumber    string
+	Amount         pgtype.Numeric
+	ProcessedAt    time.Time
+	RefundedAmount pgtype.Numeric
+	RefundedAt     *time.Time
 }
@@ -2,12 +2,16 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
 	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application"
 	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/converter"
 	"gophermart/internal/gophermart/modules/balance/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/balance/application/port"
 	"gophermart/internal/gophermart/modules/balance/domain/entity"
 	"gophermart/internal/gophermart/modules/balance/domain/vo"
 )
@@ -26,34 +30,138 @@ func NewWithdrawalRepository(transactor *postgreskit.Transactor) *WithdrawalRepo
 	}
 }
 
-// Create inserts a new withdrawal record.
+// Create inserts a new withdrawal record or returns application.ErrAlreadyExists
+// if the user already has a withdrawal for the order; the surrounding transaction stays usable.
 func (r *WithdrawalRepository) Create(ctx context.Context, w *entity.Withdrawal) error {
 	return r.transactor.DoWithRetry(ctx, func() error {
 		q := r.transactor.GetQuerier(ctx)
 		dbWithdrawal := r.conv.ToModel(*w)
 
-		_, err := q.Exec(ctx, `
+		tag, err := q.Exec(ctx, `
 			INSERT INTO withdrawals (user_id, order_number, amount, processed_at)
 			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, order_number) DO NOTHING
 		`, dbWithdrawal.UserID, dbWithdrawal.OrderNumber, dbWithdrawal.Amount, dbWithdrawal.ProcessedAt)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrAlreadyExists
+		}
+
+		return nil
+	})
+}
+
+// Update persists the refunded amount and time of the withdrawal.
+func (r *WithdrawalRepository) Update(ctx context.Context, w *entity.Withdrawal) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbWithdrawal := r.conv.ToModel(*w)
+
+		tag, err := q.Exec(ctx, `
+			UPDATE withdrawals
+			SET refunded_amount = $1, refunded_at = $2
+			WHERE user_id = $3 AND order_number = $4
+		`, dbWithdrawal.RefundedAmount, dbWithdrawal.RefundedAt, dbWithdrawal.UserID, dbWithdrawal.OrderNumber)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// FindByOrderNumber returns the user's withdrawal for the order or application.ErrNotFound.
+func (r *WithdrawalRepository) FindByOrderNumber(
+	ctx context.Context,
+	userID vo.UserID,
+	orderNumber vo.OrderNumber,
+) (*entity.Withdrawal, error) {
+	var w entity.Withdrawal
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, `
+			SELECT user_id, order_number, amount, processed_at, refunded_amount, refunded_at
+			FROM withdrawals
+			WHERE user_id = $1 AND order_number = $2
+		`, userID, orderNumber)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRow, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[model.Withdrawal])
+		if err != nil {
+			return err
+		}
 
-		return err
+		w = r.conv.ToEntity(dbRow)
+		return nil
 	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, application.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &w, nil
 }
 
-// ListByUserID returns all withdrawals for the given user, sorted by processed_at DESC.
-func (r *WithdrawalRepository) ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Withdrawal, error) {
+// ListByUserID returns the user's withdrawals matching the filter,
+// sorted by processed_at DESC with the order number as a tie-breaker.
+func (r *WithdrawalRepository) ListByUserID(
+	ctx context.Context,
+	userID vo.UserID,
+	filter port.WithdrawalFilter,
+) ([]entity.Withdrawal, error) {
+	refundStatuses := make([]string, len(filter.RefundStatuses))
+	for i, s := range filter.RefundStatuses {
+		refundStatuses[i] = string(s)
+	}
+
+	var afterAt *time.Time
+	var afterNumber *string
+	if filter.After != nil {
+		afterAt, afterNumber = &filter.After.At, &filter.After.Key
+	}
+	var limit *int
+	if filter.Limit > 0 {
+		limit = &filter.Limit
+	}
+
 	var result []entity.Withdrawal
 
 	err := r.transactor.DoWithRetry(ctx, func() error {
 		q := r.transactor.GetQuerier(ctx)
 
+		// NULL parameters disable their condition; LIMIT NULL returns all rows.
+		// The refund status mirrors entity.Withdrawal.RefundStatus.
 		rows, err := q.Query(ctx, `
-			SELECT user_id, order_number, amount, processed_at
+			SELECT user_id, order_number, amount, processed_at, refunded_amount, refunded_at
 			FROM withdrawals
 			WHERE user_id = $1
-			ORDER BY processed_at DESC
-		`, userID)
+			  AND (cardinality($2::TEXT[]) = 0 OR
+			       CASE
+			           WHEN refunded_amount = 0 THEN 'NONE'
+			           WHEN refunded_amount < amount THEN 'PARTIAL'
+			           ELSE 'FULL'
+			       END = ANY($2))
+			  AND ($3::TIMESTAMPTZ IS NULL OR processed_at >= $3)
+			  AND ($4::TIMESTAMPTZ IS NULL OR processed_at < $4)
+			  AND ($5::TIMESTAMPTZ IS NULL OR (processed_at, order_number) < ($5, $6::TEXT))
+			ORDER BY processed_at DESC, order_number DESC
+			LIMIT $7
+		`, userID, refundStatuses, filter.From, filter.To, afterAt, afterNumber, limit)
 		if err != nil {
 			return err
 		}
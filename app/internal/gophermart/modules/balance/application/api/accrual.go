@@ -6,9 +6,12 @@ import (
 )
 
 // ApplyAccrualInput is a module API input for crediting user balance from processed orders.
+// OrderNumber is the idempotency key: an order is credited at most once.
 type ApplyAccrualInput struct {
 	UserID      int64
-	Amount      float64
+	OrderNumber string
+	// Amount is in minor units (hundredths of a point).
+	Amount      int64
 	ProcessedAt time.Time
 }
 
@@ -4,13 +4,20 @@ import "gophermart/internal/gophermart/modules/balance/domain/vo"
 
 // BalanceOutput is the output for the user's current balance.
 type BalanceOutput struct {
-	Current   float64
-	Withdrawn float64
+	Current   vo.Points
+	Withdrawn vo.Points
+	// Available is the part of Current not reserved by active holds.
+	Available vo.Points
+	Held      vo.Points
+	// ExpiringSoon is the part of Current that expires within the expiry policy's soon window.
+	ExpiringSoon vo.Points
 }
 
 // WithdrawInput is the input for a withdrawal request.
 type WithdrawInput struct {
 	UserID      vo.UserID
 	OrderNumber string
-	Sum         float64
+	Sum         vo.Points
+	// IdempotencyKey is optional; a repeated request with the same key replays the original result.
+	IdempotencyKey string
 }
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// AuthorizeHoldInput is the input for reserving points for an order.
+type AuthorizeHoldInput struct {
+	UserID      vo.UserID
+	OrderNumber string
+	Sum         vo.Points
+}
+
+// HoldActionInput addresses a hold of the user to capture or void.
+type HoldActionInput struct {
+	UserID vo.UserID
+	HoldID int64
+}
+
+// HoldOutput is the output for a single hold.
+type HoldOutput struct {
+	ID          int64
+	OrderNumber string
+	Sum         vo.Points
+	Status      string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	ResolvedAt  *time.Time
+}
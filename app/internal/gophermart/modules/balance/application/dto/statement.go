@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// StatementInput is the input for an account statement over [From, To).
+type StatementInput struct {
+	UserID vo.UserID
+	From   time.Time
+	To     time.Time
+}
+
+// StatementOutput is an account statement: balances at the range bounds
+// and the ledger entries in between, oldest first.
+type StatementOutput struct {
+	From           time.Time
+	To             time.Time
+	OpeningBalance vo.Points
+	ClosingBalance vo.Points
+	Lines          []StatementLineOutput
+}
+
+// StatementLineOutput is a single ledger entry of a statement.
+type StatementLineOutput struct {
+	At   time.Time
+	Kind string
+	// Reference is the order number for accruals, withdrawals and refunds.
+	Reference string
+	// Amount is the change of the user's points: positive for credits, negative for debits.
+	Amount vo.Points
+}
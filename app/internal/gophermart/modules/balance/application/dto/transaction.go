@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// TransactionOutput is the output for a single ledger entry of the user's transaction history.
+type TransactionOutput struct {
+	ID        int64
+	Kind      string
+	Reference string
+	// Amount is the signed change of the user's spendable points.
+	Amount    vo.Points
+	CreatedAt time.Time
+}
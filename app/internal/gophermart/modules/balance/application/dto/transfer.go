@@ -0,0 +1,10 @@
+package dto
+
+import "gophermart/internal/gophermart/modules/balance/domain/vo"
+
+// TransferInput is the input for a points transfer to another user.
+type TransferInput struct {
+	UserID         vo.UserID
+	RecipientLogin string
+	Sum            vo.Points
+}
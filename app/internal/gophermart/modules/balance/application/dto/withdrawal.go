@@ -1,10 +1,46 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// ListWithdrawalsInput is the input for listing the user's withdrawals.
+type ListWithdrawalsInput struct {
+	UserID vo.UserID
+	// RefundStatuses keeps withdrawals in any of the refund statuses; empty keeps all.
+	RefundStatuses []string
+	// From (inclusive) and To (exclusive) bound the processing time.
+	From *time.Time
+	To   *time.Time
+	// Cursor continues a previous page; Limit is the page size.
+	// Without both the whole history is listed.
+	Cursor string
+	Limit  int
+}
+
+// WithdrawalPageOutput is a page of the user's withdrawals.
+type WithdrawalPageOutput struct {
+	Withdrawals []WithdrawalOutput
+	// NextCursor continues the listing; empty on the last page.
+	NextCursor string
+}
 
 // WithdrawalOutput is the output for a single withdrawal record.
 type WithdrawalOutput struct {
+	OrderNumber  string
+	Sum          vo.Points
+	ProcessedAt  time.Time
+	Refunded     vo.Points
+	RefundStatus string
+	RefundedAt   *time.Time
+}
+
+// RefundInput is the input for a withdrawal refund request.
+type RefundInput struct {
+	UserID      vo.UserID
 	OrderNumber string
-	Sum         float64
-	ProcessedAt time.Time
+	// Sum is the amount to refund; nil refunds everything not refunded yet.
+	Sum *vo.Points
 }
@@ -1,6 +1,8 @@
 package factory
 
 import (
+	"time"
+
 	appport "gophermart/internal/gophermart/application/port"
 	"gophermart/internal/gophermart/modules/balance/application/api"
 	"gophermart/internal/gophermart/modules/balance/application/dto"
@@ -14,29 +16,73 @@ import (
 type Params struct {
 	BalanceRepo       port.BalanceAccountRepository
 	WithdrawalRepo    port.WithdrawalRepository
+	AccrualCreditRepo port.AccrualCreditRepository
+	LedgerRepo        port.LedgerRepository
+	IdempotencyRepo   port.IdempotencyKeyRepository
+	LotRepo           port.AccrualLotRepository
+	HoldRepo          port.HoldRepository
+	IdentityGateway   port.IdentityGateway
+	OrdersGateway     port.OrdersGateway
 	Transactor        appport.Transactor
 	Validator         vo.OrderNumberValidator
 	Clock             appport.Clock
 	BalanceSvc        service.BalanceService
+	Log               appport.Logger
+	ExpiryPolicy      vo.ExpiryPolicy
+	WithdrawalPolicy  service.WithdrawalPolicy
+	ExpiryBatchSize   int
+	HoldTTL           time.Duration
+	HoldBatchSize     int
+	// ReconcileBatchSize is the number of accounts read per reconciliation batch.
+	ReconcileBatchSize int
+	// ReconcileFix makes reconciliation correct drifted accounts instead of only reporting them.
+	ReconcileFix bool
+	// IdempotencyKeyTTL is how long idempotency keys are kept before the purge removes them.
+	IdempotencyKeyTTL time.Duration
 	OptimisticRetries int
 }
 
 // UseCases holds balance module use cases exposed to composition root.
 type UseCases struct {
-	GetBalance      appport.UseCase[vo.UserID, dto.BalanceOutput]
-	Withdraw        appport.UseCase[dto.WithdrawInput, struct{}]
-	ListWithdrawals appport.UseCase[vo.UserID, []dto.WithdrawalOutput]
-	ApplyAccrual    api.AccrualAPI
-	OpenAccount     api.AccountAPI
+	GetBalance        appport.UseCase[vo.UserID, dto.BalanceOutput]
+	Withdraw          appport.UseCase[dto.WithdrawInput, struct{}]
+	ListWithdrawals   appport.UseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput]
+	RefundWithdrawal  appport.UseCase[dto.RefundInput, dto.WithdrawalOutput]
+	ListTransactions  appport.UseCase[vo.UserID, []dto.TransactionOutput]
+	GetStatement      appport.UseCase[dto.StatementInput, dto.StatementOutput]
+	Transfer          appport.UseCase[dto.TransferInput, struct{}]
+	AuthorizeHold     appport.UseCase[dto.AuthorizeHoldInput, dto.HoldOutput]
+	CaptureHold       appport.UseCase[dto.HoldActionInput, dto.HoldOutput]
+	VoidHold          appport.UseCase[dto.HoldActionInput, dto.HoldOutput]
+	ListHolds         appport.UseCase[vo.UserID, []dto.HoldOutput]
+	ApplyAccrual      api.AccrualAPI
+	OpenAccount       api.AccountAPI
+	ExpirePoints      appport.BackgroundRunner
+	ExpireHolds       appport.BackgroundRunner
+	ReconcileBalances appport.BackgroundRunner
+	// PurgeIdempotencyKeys removes idempotency keys older than IdempotencyKeyTTL.
+	PurgeIdempotencyKeys appport.BackgroundRunner
 }
 
 // NewUseCases builds balance module use cases.
 func NewUseCases(p Params) UseCases {
 	return UseCases{
-		GetBalance:      usecase.NewGetBalance(p.BalanceRepo),
-		Withdraw:        usecase.NewWithdraw(p.BalanceRepo, p.BalanceRepo, p.WithdrawalRepo, p.Transactor, p.Validator, p.Clock, p.OptimisticRetries),
-		ListWithdrawals: usecase.NewListWithdrawals(p.WithdrawalRepo),
-		ApplyAccrual:    usecase.NewApplyAccrual(p.BalanceRepo, p.BalanceRepo),
-		OpenAccount:     usecase.NewOpenAccount(p.BalanceRepo, p.BalanceSvc),
+		GetBalance:           usecase.NewGetBalance(p.BalanceRepo, p.LotRepo, p.ExpiryPolicy, p.Clock),
+		Withdraw:             usecase.NewWithdraw(p.BalanceRepo, p.BalanceRepo, p.WithdrawalRepo, p.WithdrawalRepo, p.LedgerRepo, p.LedgerRepo, p.LotRepo, p.HoldRepo, p.IdempotencyRepo, p.WithdrawalPolicy, p.Transactor, p.Validator, p.Clock, p.OptimisticRetries),
+		ListWithdrawals:      usecase.NewListWithdrawals(p.WithdrawalRepo),
+		RefundWithdrawal:     usecase.NewRefundWithdrawal(p.BalanceRepo, p.BalanceRepo, p.WithdrawalRepo, p.WithdrawalRepo, p.LedgerRepo, p.LotRepo, p.ExpiryPolicy, p.Transactor, p.Validator, p.Clock, p.OptimisticRetries),
+		ListTransactions:     usecase.NewListTransactions(p.LedgerRepo),
+		GetStatement:         usecase.NewGetStatement(p.LedgerRepo),
+		Transfer:             usecase.NewTransfer(p.BalanceRepo, p.BalanceRepo, p.WithdrawalRepo, p.HoldRepo, p.LedgerRepo, p.LedgerRepo, p.LotRepo, p.IdentityGateway, p.WithdrawalPolicy, p.ExpiryPolicy, p.Transactor, p.Clock, p.OptimisticRetries),
+		AuthorizeHold:        usecase.NewAuthorizeHold(p.BalanceRepo, p.BalanceRepo, p.WithdrawalRepo, p.HoldRepo, p.HoldRepo, p.LedgerRepo, p.WithdrawalPolicy, p.Transactor, p.Validator, p.Clock, p.HoldTTL, p.OptimisticRetries),
+		CaptureHold:          usecase.NewCaptureHold(p.BalanceRepo, p.BalanceRepo, p.HoldRepo, p.WithdrawalRepo, p.LedgerRepo, p.LotRepo, p.Transactor, p.Clock, p.OptimisticRetries),
+		VoidHold:             usecase.NewVoidHold(p.BalanceRepo, p.BalanceRepo, p.HoldRepo, p.Transactor, p.Clock, p.OptimisticRetries),
+		ListHolds:            usecase.NewListHolds(p.HoldRepo),
+		ApplyAccrual:         usecase.NewApplyAccrual(p.BalanceRepo, p.BalanceRepo, p.AccrualCreditRepo, p.LedgerRepo, p.LotRepo, p.ExpiryPolicy),
+		OpenAccount:          usecase.NewOpenAccount(p.BalanceRepo, p.BalanceSvc),
+		ExpirePoints:         usecase.NewExpirePoints(p.BalanceRepo, p.BalanceRepo, p.LotRepo, p.LedgerRepo, p.Transactor, p.Clock, p.Log, p.ExpiryBatchSize, p.OptimisticRetries),
+		ExpireHolds:          usecase.NewExpireHolds(p.BalanceRepo, p.BalanceRepo, p.HoldRepo, p.Transactor, p.Clock, p.Log, p.HoldBatchSize, p.OptimisticRetries),
+		ReconcileBalances:    usecase.NewReconcileBalances(p.BalanceRepo, p.BalanceRepo, p.WithdrawalRepo, p.LedgerRepo, p.LedgerRepo, p.LotRepo, p.OrdersGateway, p.BalanceSvc, p.ExpiryPolicy, p.Transactor, p.Clock, p.Log, p.ReconcileBatchSize, p.ReconcileFix),
+		PurgeIdempotencyKeys: usecase.NewPurgeIdempotencyKeys(p.IdempotencyRepo, p.Clock, p.IdempotencyKeyTTL),
 	}
 }
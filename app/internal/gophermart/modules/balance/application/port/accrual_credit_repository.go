@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+// AccrualCreditWriter provides write access to accrual credits for balance module.
+type AccrualCreditWriter interface {
+	// Create records the credit or returns application.ErrAlreadyExists
+	// if the order has already been credited.
+	Create(ctx context.Context, c *entity.AccrualCredit) error
+}
+
+// AccrualCreditRepository combines accrual credit access for balance DI wiring.
+type AccrualCreditRepository interface {
+	AccrualCreditWriter
+}
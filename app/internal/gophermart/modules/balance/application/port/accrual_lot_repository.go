@@ -0,0 +1,40 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// AccrualLotReader provides read access to accrual lots for balance module.
+type AccrualLotReader interface {
+	// FindByID returns application.ErrNotFound if the lot does not exist.
+	FindByID(ctx context.Context, id int64) (*entity.AccrualLot, error)
+	// ListActiveByUserID returns the user's lots with remaining points, oldest first.
+	ListActiveByUserID(ctx context.Context, userID vo.UserID) ([]entity.AccrualLot, error)
+	// ListDue returns up to limit lots with remaining points that expired by now, earliest expiry first,
+	// starting after the given position; nil starts from the earliest lot.
+	ListDue(ctx context.Context, now time.Time, after *DueCursor, limit int) ([]entity.AccrualLot, error)
+}
+
+// DueCursor is the keyset position of the last lot of a ListDue page.
+type DueCursor struct {
+	ExpiresAt time.Time
+	ID        int64
+}
+
+// AccrualLotWriter provides write access to accrual lots for balance module.
+type AccrualLotWriter interface {
+	// Create inserts the lot and sets its ID.
+	Create(ctx context.Context, l *entity.AccrualLot) error
+	// Update persists the remaining points of the lot.
+	Update(ctx context.Context, l *entity.AccrualLot) error
+}
+
+// AccrualLotRepository combines reader and writer for balance DI wiring.
+type AccrualLotRepository interface {
+	AccrualLotReader
+	AccrualLotWriter
+}
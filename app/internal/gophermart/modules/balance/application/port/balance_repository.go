@@ -10,6 +10,8 @@ import (
 // BalanceAccountReader provides read-only access to balance accounts for balance module.
 type BalanceAccountReader interface {
 	FindByUserID(ctx context.Context, userID vo.UserID) (*entity.BalanceAccount, error)
+	// ListUserIDs returns up to limit user IDs of accounts greater than after, in ascending order.
+	ListUserIDs(ctx context.Context, after vo.UserID, limit int) ([]vo.UserID, error)
 }
 
 // BalanceAccountWriter provides write access to balance accounts for balance module.
@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// HoldReader provides read access to holds for balance module.
+type HoldReader interface {
+	// FindByID returns application.ErrNotFound if the hold does not exist.
+	FindByID(ctx context.Context, id int64) (*entity.Hold, error)
+	// FindActiveByOrderNumber returns application.ErrNotFound if the user has no active hold for the order.
+	FindActiveByOrderNumber(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) (*entity.Hold, error)
+	// ListByUserID returns the user's holds, newest first.
+	ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Hold, error)
+	// ListActiveByUserID returns the user's active holds, including expired ones not yet released.
+	ListActiveByUserID(ctx context.Context, userID vo.UserID) ([]entity.Hold, error)
+	// ListExpired returns up to limit active holds that expired by now, earliest expiry first.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]entity.Hold, error)
+}
+
+// HoldWriter provides write access to holds for balance module.
+type HoldWriter interface {
+	// Create inserts the hold and sets its ID; returns application.ErrAlreadyExists
+	// if the user already has an active hold for the order.
+	Create(ctx context.Context, h *entity.Hold) error
+	// Update persists the status and resolution time of the hold.
+	Update(ctx context.Context, h *entity.Hold) error
+}
+
+// HoldRepository combines reader and writer for balance DI wiring.
+type HoldRepository interface {
+	HoldReader
+	HoldWriter
+}
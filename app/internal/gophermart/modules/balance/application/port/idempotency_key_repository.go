@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// IdempotencyKeyReader provides read access to stored idempotency keys for balance module.
+type IdempotencyKeyReader interface {
+	// FindByKey returns application.ErrNotFound if the user has no such key.
+	FindByKey(ctx context.Context, userID vo.UserID, key string) (*entity.IdempotencyKey, error)
+}
+
+// IdempotencyKeyWriter provides write access to idempotency keys for balance module.
+type IdempotencyKeyWriter interface {
+	// Create stores the key or returns application.ErrAlreadyExists if the user already used it.
+	// A concurrent insert of the same key waits until the other transaction finishes.
+	Create(ctx context.Context, k *entity.IdempotencyKey) error
+	// DeleteCreatedBefore removes keys created before t and returns the number of removed keys.
+	DeleteCreatedBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+// IdempotencyKeyRepository combines reader and writer for balance DI wiring.
+type IdempotencyKeyRepository interface {
+	IdempotencyKeyReader
+	IdempotencyKeyWriter
+}
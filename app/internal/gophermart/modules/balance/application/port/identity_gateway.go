@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// IdentityGateway is a balance-module port for resolving users of the identity module.
+type IdentityGateway interface {
+	// FindUserIDByLogin returns application.ErrNotFound if no user has the login.
+	FindUserIDByLogin(ctx context.Context, login string) (vo.UserID, error)
+}
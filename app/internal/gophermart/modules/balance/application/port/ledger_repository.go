@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// LedgerReader provides read-only access to the points ledger for balance module.
+type LedgerReader interface {
+	// ListByUserID returns entries touching the user's accounts with all their postings,
+	// newest first.
+	ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.JournalEntry, error)
+	// ListByUserIDBetween returns entries created in [from, to) touching the user's accounts
+	// with all their postings, newest first.
+	ListByUserIDBetween(ctx context.Context, userID vo.UserID, from, to time.Time) ([]entity.JournalEntry, error)
+	// BalanceAt returns the user's points balance from entries created before at.
+	BalanceAt(ctx context.Context, userID vo.UserID, at time.Time) (vo.Points, error)
+}
+
+// LedgerWriter provides append-only write access to the points ledger for balance module.
+type LedgerWriter interface {
+	// Append stores the entry with its postings and sets its ID.
+	Append(ctx context.Context, e *entity.JournalEntry) error
+}
+
+// LedgerRepository combines reader and writer for balance DI wiring.
+type LedgerRepository interface {
+	LedgerReader
+	LedgerWriter
+}
@@ -57,6 +57,21 @@ func (mr *MockBalanceAccountReaderMockRecorder) FindByUserID(ctx, userID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockBalanceAccountReader)(nil).FindByUserID), ctx, userID)
 }
 
+// ListUserIDs mocks base method.
+func (m *MockBalanceAccountReader) ListUserIDs(ctx context.Context, after vo.UserID, limit int) ([]vo.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserIDs", ctx, after, limit)
+	ret0, _ := ret[0].([]vo.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserIDs indicates an expected call of ListUserIDs.
+func (mr *MockBalanceAccountReaderMockRecorder) ListUserIDs(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserIDs", reflect.TypeOf((*MockBalanceAccountReader)(nil).ListUserIDs), ctx, after, limit)
+}
+
 // MockBalanceAccountWriter is a mock of BalanceAccountWriter interface.
 type MockBalanceAccountWriter struct {
 	ctrl     *gomock.Controller
@@ -162,6 +177,21 @@ func (mr *MockBalanceAccountRepositoryMockRecorder) FindByUserID(ctx, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockBalanceAccountRepository)(nil).FindByUserID), ctx, userID)
 }
 
+// ListUserIDs mocks base method.
+func (m *MockBalanceAccountRepository) ListUserIDs(ctx context.Context, after vo.UserID, limit int) ([]vo.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserIDs", ctx, after, limit)
+	ret0, _ := ret[0].([]vo.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserIDs indicates an expected call of ListUserIDs.
+func (mr *MockBalanceAccountRepositoryMockRecorder) ListUserIDs(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserIDs", reflect.TypeOf((*MockBalanceAccountRepository)(nil).ListUserIDs), ctx, after, limit)
+}
+
 // Update mocks base method.
 func (m *MockBalanceAccountRepository) Update(ctx context.Context, acc *entity.BalanceAccount) error {
 	m.ctrl.T.Helper()
@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	port "gophermart/internal/gophermart/modules/balance/application/port"
 	entity "gophermart/internal/gophermart/modules/balance/domain/entity"
 	vo "gophermart/internal/gophermart/modules/balance/domain/vo"
 	reflect "reflect"
@@ -42,19 +43,34 @@ func (m *MockWithdrawalReader) EXPECT() *MockWithdrawalReaderMockRecorder {
 	return m.recorder
 }
 
+// FindByOrderNumber mocks base method.
+func (m *MockWithdrawalReader) FindByOrderNumber(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) (*entity.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrderNumber", ctx, userID, orderNumber)
+	ret0, _ := ret[0].(*entity.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrderNumber indicates an expected call of FindByOrderNumber.
+func (mr *MockWithdrawalReaderMockRecorder) FindByOrderNumber(ctx, userID, orderNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderNumber", reflect.TypeOf((*MockWithdrawalReader)(nil).FindByOrderNumber), ctx, userID, orderNumber)
+}
+
 // ListByUserID mocks base method.
-func (m *MockWithdrawalReader) ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Withdrawal, error) {
+func (m *MockWithdrawalReader) ListByUserID(ctx context.Context, userID vo.UserID, filter port.WithdrawalFilter) ([]entity.Withdrawal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID, filter)
 	ret0, _ := ret[0].([]entity.Withdrawal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByUserID indicates an expected call of ListByUserID.
-func (mr *MockWithdrawalReaderMockRecorder) ListByUserID(ctx, userID any) *gomock.Call {
+func (mr *MockWithdrawalReaderMockRecorder) ListByUserID(ctx, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockWithdrawalReader)(nil).ListByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockWithdrawalReader)(nil).ListByUserID), ctx, userID, filter)
 }
 
 // MockWithdrawalWriter is a mock of WithdrawalWriter interface.
@@ -95,6 +111,20 @@ func (mr *MockWithdrawalWriterMockRecorder) Create(ctx, w any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWithdrawalWriter)(nil).Create), ctx, w)
 }
 
+// Update mocks base method.
+func (m *MockWithdrawalWriter) Update(ctx context.Context, w *entity.Withdrawal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWithdrawalWriterMockRecorder) Update(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWithdrawalWriter)(nil).Update), ctx, w)
+}
+
 // MockWithdrawalRepository is a mock of WithdrawalRepository interface.
 type MockWithdrawalRepository struct {
 	ctrl     *gomock.Controller
@@ -133,17 +163,46 @@ func (mr *MockWithdrawalRepositoryMockRecorder) Create(ctx, w any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWithdrawalRepository)(nil).Create), ctx, w)
 }
 
+// FindByOrderNumber mocks base method.
+func (m *MockWithdrawalRepository) FindByOrderNumber(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) (*entity.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrderNumber", ctx, userID, orderNumber)
+	ret0, _ := ret[0].(*entity.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrderNumber indicates an expected call of FindByOrderNumber.
+func (mr *MockWithdrawalRepositoryMockRecorder) FindByOrderNumber(ctx, userID, orderNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderNumber", reflect.TypeOf((*MockWithdrawalRepository)(nil).FindByOrderNumber), ctx, userID, orderNumber)
+}
+
 // ListByUserID mocks base method.
-func (m *MockWithdrawalRepository) ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Withdrawal, error) {
+func (m *MockWithdrawalRepository) ListByUserID(ctx context.Context, userID vo.UserID, filter port.WithdrawalFilter) ([]entity.Withdrawal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID, filter)
 	ret0, _ := ret[0].([]entity.Withdrawal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByUserID indicates an expected call of ListByUserID.
-func (mr *MockWithdrawalRepositoryMockRecorder) ListByUserID(ctx, userID any) *gomock.Call {
+func (mr *MockWithdrawalRepositoryMockRecorder) ListByUserID(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockWithdrawalRepository)(nil).ListByUserID), ctx, userID, filter)
+}
+
+// Update mocks base method.
+func (m *MockWithdrawalRepository) Update(ctx context.Context, w *entity.Withdrawal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWithdrawalRepositoryMockRecorder) Update(ctx, w any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockWithdrawalRepository)(nil).ListByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWithdrawalRepository)(nil).Update), ctx, w)
 }
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// OrderAccrual is points accrued for a processed order of the orders module.
+type OrderAccrual struct {
+	OrderNumber vo.OrderNumber
+	Amount      vo.Points
+	ProcessedAt time.Time
+}
+
+// OrdersGateway is a balance-module port for reading the accrual history of the orders module.
+type OrdersGateway interface {
+	// ListAccruals returns accruals of the user's orders processed in [from, to), oldest first.
+	ListAccruals(ctx context.Context, userID vo.UserID, from, to time.Time) ([]OrderAccrual, error)
+}
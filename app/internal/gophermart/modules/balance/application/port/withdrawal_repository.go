@@ -2,19 +2,40 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"gophermart/internal/gophermart/modules/balance/domain/entity"
 	"gophermart/internal/gophermart/modules/balance/domain/vo"
+	"gophermart/internal/pkg/cursor"
 )
 
+// WithdrawalFilter narrows a user's withdrawal listing; the zero value selects the whole history.
+type WithdrawalFilter struct {
+	// RefundStatuses keeps withdrawals in any of the refund statuses; empty keeps all.
+	RefundStatuses []entity.RefundStatus
+	// From (inclusive) and To (exclusive) bound the processing time.
+	From *time.Time
+	To   *time.Time
+	// After continues the listing after the withdrawal at this position (processing time, order number).
+	After *cursor.Cursor
+	// Limit caps the number of withdrawals; 0 means no limit.
+	Limit int
+}
+
 // WithdrawalReader provides read-only access to withdrawals for balance module.
 type WithdrawalReader interface {
-	ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Withdrawal, error)
+	// ListByUserID returns the user's withdrawals matching the filter, newest first.
+	ListByUserID(ctx context.Context, userID vo.UserID, filter WithdrawalFilter) ([]entity.Withdrawal, error)
+	// FindByOrderNumber returns application.ErrNotFound if the user has no withdrawal for the order.
+	FindByOrderNumber(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) (*entity.Withdrawal, error)
 }
 
 // WithdrawalWriter provides write access to withdrawals for balance module.
 type WithdrawalWriter interface {
+	// Create returns application.ErrAlreadyExists if the order already has a withdrawal.
 	Create(ctx context.Context, w *entity.Withdrawal) error
+	// Update persists the refund state of the withdrawal.
+	Update(ctx context.Context, w *entity.Withdrawal) error
 }
 
 // WithdrawalRepository combines reader and writer for balance DI wiring.
@@ -2,36 +2,79 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
+	"gophermart/internal/gophermart/application"
 	"gophermart/internal/gophermart/modules/balance/application/api"
 	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
 	"gophermart/internal/gophermart/modules/balance/domain/vo"
 )
 
-// ApplyAccrual credits user balance for a processed order.
+// ApplyAccrual credits user balance for a processed order, at most once per order number.
 type ApplyAccrual struct {
 	balanceReader port.BalanceAccountReader
 	balanceWriter port.BalanceAccountWriter
+	creditWriter  port.AccrualCreditWriter
+	ledgerWriter  port.LedgerWriter
+	lotWriter     port.AccrualLotWriter
+	expiryPolicy  vo.ExpiryPolicy
 }
 
 // NewApplyAccrual returns balance module API for accrual crediting.
 func NewApplyAccrual(
 	balanceReader port.BalanceAccountReader,
 	balanceWriter port.BalanceAccountWriter,
+	creditWriter port.AccrualCreditWriter,
+	ledgerWriter port.LedgerWriter,
+	lotWriter port.AccrualLotWriter,
+	expiryPolicy vo.ExpiryPolicy,
 ) api.AccrualAPI {
 	return &ApplyAccrual{
 		balanceReader: balanceReader,
 		balanceWriter: balanceWriter,
+		creditWriter:  creditWriter,
+		ledgerWriter:  ledgerWriter,
+		lotWriter:     lotWriter,
+		expiryPolicy:  expiryPolicy,
 	}
 }
 
-// ApplyAccrual loads account, adds accrual and persists it.
+// ApplyAccrual records the order credit, posts an ACCRUAL journal entry to the account and ledger,
+// opens an accrual lot expiring by the expiry policy, and persists the account. A replayed credit for an already credited order is a no-op.
+// Must run inside the caller's transaction so the credit record, ledger entry and balance change commit together.
 func (uc *ApplyAccrual) ApplyAccrual(ctx context.Context, in api.ApplyAccrualInput) error {
+	amount := vo.Points(in.Amount)
+	if amount <= 0 {
+		return nil
+	}
+
+	credit := entity.NewAccrualCredit(vo.UserID(in.UserID), vo.OrderNumber(in.OrderNumber), amount, in.ProcessedAt)
+	if err := uc.creditWriter.Create(ctx, credit); err != nil {
+		if errors.Is(err, application.ErrAlreadyExists) {
+			return nil
+		}
+		return err
+	}
+
 	acc, err := uc.balanceReader.FindByUserID(ctx, vo.UserID(in.UserID))
 	if err != nil {
 		return err
 	}
 
-	acc.AddAccrual(vo.Points(in.Amount), in.ProcessedAt)
+	entry := entity.NewAccrualEntry(acc.UserID, credit.OrderNumber, amount, in.ProcessedAt)
+	if err := acc.Post(*entry); err != nil {
+		return err
+	}
+	if err := uc.ledgerWriter.Append(ctx, entry); err != nil {
+		return err
+	}
+
+	lot := entity.NewAccrualLot(acc.UserID, credit.OrderNumber.String(), amount, in.ProcessedAt,
+		uc.expiryPolicy.ExpiresAt(in.ProcessedAt))
+	if err := uc.lotWriter.Create(ctx, lot); err != nil {
+		return err
+	}
+
 	return uc.balanceWriter.Update(ctx, acc)
 }
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/balance/application/api"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplyAccrual_ApplyAccrual(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := vo.ExpiryPolicy{TTL: 365 * 24 * time.Hour}
+	expiresAt := fixedTime.Add(policy.TTL)
+	in := api.ApplyAccrualInput{UserID: 1, OrderNumber: "12345678903", Amount: 150, ProcessedAt: fixedTime}
+
+	t.Run("credits account once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		creditWriter := balanceportmocks.NewMockAccrualCreditWriter(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		lotWriter := balanceportmocks.NewMockAccrualLotWriter(ctrl)
+
+		creditWriter.EXPECT().Create(ctx, &entity.AccrualCredit{
+			UserID: 1, OrderNumber: "12345678903", Amount: 150, CreditedAt: fixedTime,
+		}).Return(nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 50,
+		}, nil)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewAccrualEntry(1, "12345678903", 150, fixedTime)).Return(nil)
+		lotWriter.EXPECT().Create(ctx, entity.NewAccrualLot(1, "12345678903", 150, fixedTime, &expiresAt)).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(200), acc.Current)
+				return nil
+			},
+		)
+
+		uc := NewApplyAccrual(balanceReader, balanceWriter, creditWriter, ledgerWriter, lotWriter, policy)
+		err := uc.ApplyAccrual(ctx, in)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("replayed credit is a no-op", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		creditWriter := balanceportmocks.NewMockAccrualCreditWriter(ctrl)
+		creditWriter.EXPECT().Create(ctx, gomock.Any()).Return(application.ErrAlreadyExists)
+
+		uc := NewApplyAccrual(nil, nil, creditWriter, nil, nil, policy)
+		err := uc.ApplyAccrual(ctx, in)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("zero amount skipped", func(t *testing.T) {
+		uc := NewApplyAccrual(nil, nil, nil, nil, nil, policy)
+		err := uc.ApplyAccrual(ctx, api.ApplyAccrualInput{UserID: 1, OrderNumber: "12345678903"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("credit error propagated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		dbErr := errors.New("db down")
+		creditWriter := balanceportmocks.NewMockAccrualCreditWriter(ctrl)
+		creditWriter.EXPECT().Create(ctx, gomock.Any()).Return(dbErr)
+
+		uc := NewApplyAccrual(nil, nil, creditWriter, nil, nil, policy)
+		err := uc.ApplyAccrual(ctx, in)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("ledger error propagated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		dbErr := errors.New("db down")
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		creditWriter := balanceportmocks.NewMockAccrualCreditWriter(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+
+		creditWriter.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{UserID: 1}, nil)
+		ledgerWriter.EXPECT().Append(ctx, gomock.Any()).Return(dbErr)
+
+		uc := NewApplyAccrual(balanceReader, nil, creditWriter, ledgerWriter, nil, policy)
+		err := uc.ApplyAccrual(ctx, in)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
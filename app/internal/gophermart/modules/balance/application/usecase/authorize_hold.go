@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/service"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// AuthorizeHold reserves points for an order at checkout, the first phase of a two-phase withdrawal.
+type AuthorizeHold struct {
+	balanceReader     port.BalanceAccountReader
+	balanceWriter     port.BalanceAccountWriter
+	withdrawalReader  port.WithdrawalReader
+	holdReader        port.HoldReader
+	holdWriter        port.HoldWriter
+	ledgerReader      port.LedgerReader
+	policy            service.WithdrawalPolicy
+	transactor        appport.Transactor
+	validator         vo.OrderNumberValidator
+	clock             appport.Clock
+	holdTTL           time.Duration
+	optimisticRetries int
+}
+
+// NewAuthorizeHold returns the authorize hold use case.
+func NewAuthorizeHold(
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	withdrawalReader port.WithdrawalReader,
+	holdReader port.HoldReader,
+	holdWriter port.HoldWriter,
+	ledgerReader port.LedgerReader,
+	policy service.WithdrawalPolicy,
+	transactor appport.Transactor,
+	validator vo.OrderNumberValidator,
+	clock appport.Clock,
+	holdTTL time.Duration,
+	optimisticRetries int,
+) appport.UseCase[dto.AuthorizeHoldInput, dto.HoldOutput] {
+	return &AuthorizeHold{
+		balanceReader:     balanceReader,
+		balanceWriter:     balanceWriter,
+		withdrawalReader:  withdrawalReader,
+		holdReader:        holdReader,
+		holdWriter:        holdWriter,
+		ledgerReader:      ledgerReader,
+		policy:            policy,
+		transactor:        transactor,
+		validator:         validator,
+		clock:             clock,
+		holdTTL:           holdTTL,
+		optimisticRetries: optimisticRetries,
+	}
+}
+
+// Execute validates the order number, moves the sum from available to held points and creates
+// an active hold expiring after the hold TTL, in one transaction. No journal entry is posted:
+// points leave the account only when the hold is captured.
+// The withdrawal limits are checked here, counting the user's other active holds, since capturing
+// the hold later creates the withdrawal without checking them again.
+//
+// Errors:
+//   - application.ErrInvalidOrderNumber — order number failed Luhn check
+//   - application.ErrInsufficientBalance — not enough available points on the account
+//   - application.ErrNotFound — balance account does not exist
+//   - application.ErrAlreadyExists — the order already has an active hold or a withdrawal
+//   - *application.ErrWithdrawalLimit — the hold breaks a configured withdrawal limit
+func (uc *AuthorizeHold) Execute(ctx context.Context, in dto.AuthorizeHoldInput) (dto.HoldOutput, error) {
+	orderNumber, err := vo.NewOrderNumber(uc.validator, in.OrderNumber)
+	if err != nil {
+		return dto.HoldOutput{}, application.ErrInvalidOrderNumber
+	}
+
+	var hold *entity.Hold
+	err = application.WithOptimisticRetry(uc.optimisticRetries, func() error {
+		return uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+			_, err := uc.withdrawalReader.FindByOrderNumber(ctx, in.UserID, orderNumber)
+			if err == nil {
+				return application.ErrAlreadyExists
+			}
+			if !errors.Is(err, application.ErrNotFound) {
+				return err
+			}
+
+			acc, err := uc.balanceReader.FindByUserID(ctx, in.UserID)
+			if err != nil {
+				return err
+			}
+
+			now := uc.clock.Now()
+			err = checkWithdrawalLimits(ctx, uc.policy, uc.withdrawalReader, uc.holdReader, uc.ledgerReader, in.UserID, in.Sum, now)
+			if err != nil {
+				return err
+			}
+
+			if err := acc.Hold(in.Sum, now); err != nil {
+				return err
+			}
+
+			h := entity.NewHold(in.UserID, orderNumber, in.Sum, now, now.Add(uc.holdTTL))
+			if err := uc.holdWriter.Create(ctx, h); err != nil {
+				return err
+			}
+
+			if err := uc.balanceWriter.Update(ctx, acc); err != nil {
+				return err
+			}
+
+			hold = h
+			return nil
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, entity.ErrInsufficientBalance) {
+			return dto.HoldOutput{}, application.ErrInsufficientBalance
+		}
+		var limitErr *service.WithdrawalLimitError
+		if errors.As(err, &limitErr) {
+			return dto.HoldOutput{}, &application.ErrWithdrawalLimit{Rule: string(limitErr.Rule), RetryAfter: limitErr.RetryAfter}
+		}
+		return dto.HoldOutput{}, err
+	}
+
+	return newHoldOutput(*hold), nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/service"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorizeHold_Execute(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	number := vo.OrderNumber("2377225624")
+	in := dto.AuthorizeHoldInput{UserID: 1, OrderNumber: number.String(), Sum: 200}
+
+	passthrough := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+
+	t.Run("reserves available points", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		withdrawals := balanceportmocks.NewMockWithdrawalReader(ctrl)
+		holds := balanceportmocks.NewMockHoldWriter(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(nil, application.ErrNotFound)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 500, Held: 100,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		holds.EXPECT().Create(ctx, entity.NewHold(1, number, 200, fixedTime, fixedTime.Add(15*time.Minute))).DoAndReturn(
+			func(_ context.Context, h *entity.Hold) error {
+				h.ID = 7
+				return nil
+			},
+		)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(500), acc.Current)
+				assert.Equal(t, vo.Points(300), acc.Held)
+				return nil
+			},
+		)
+
+		uc := NewAuthorizeHold(balanceReader, balanceWriter, withdrawals, nil, holds, nil, service.WithdrawalPolicy{}, transactor,
+			stubOrderNumberValidator{valid: true}, clk, 15*time.Minute, 3)
+		out, err := uc.Execute(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), out.ID)
+		assert.Equal(t, "ACTIVE", out.Status)
+		assert.Equal(t, fixedTime.Add(15*time.Minute), out.ExpiresAt)
+	})
+
+	t.Run("held points are not available", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		withdrawals := balanceportmocks.NewMockWithdrawalReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(nil, application.ErrNotFound)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 500, Held: 400,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+
+		uc := NewAuthorizeHold(balanceReader, nil, withdrawals, nil, nil, nil, service.WithdrawalPolicy{}, transactor,
+			stubOrderNumberValidator{valid: true}, clk, 15*time.Minute, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrInsufficientBalance)
+	})
+
+	t.Run("limit counts active holds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		withdrawals := balanceportmocks.NewMockWithdrawalReader(ctrl)
+		holds := balanceportmocks.NewMockHoldReader(ctrl)
+		ledgerReader := balanceportmocks.NewMockLedgerReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		policy := service.WithdrawalPolicy{Limits: vo.WithdrawalLimits{DailyCap: 500}}
+		dayStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(nil, application.ErrNotFound)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 1000, Held: 400,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		withdrawals.EXPECT().ListByUserID(ctx, vo.UserID(1), port.WithdrawalFilter{From: &dayStart}).Return(nil, nil)
+		holds.EXPECT().ListActiveByUserID(ctx, vo.UserID(1)).Return([]entity.Hold{
+			{UserID: 1, OrderNumber: "12345678903", Amount: 400, Status: entity.HoldStatusActive, CreatedAt: fixedTime.Add(-time.Hour)},
+		}, nil)
+		ledgerReader.EXPECT().ListByUserIDBetween(ctx, vo.UserID(1), dayStart, fixedTime).Return(nil, nil)
+
+		uc := NewAuthorizeHold(balanceReader, nil, withdrawals, holds, nil, ledgerReader, policy, transactor,
+			stubOrderNumberValidator{valid: true}, clk, 15*time.Minute, 3)
+		_, err := uc.Execute(ctx, in)
+
+		var limitErr *application.ErrWithdrawalLimit
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, "DAILY_CAP", limitErr.Rule)
+	})
+
+	t.Run("order with withdrawal rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		withdrawals := balanceportmocks.NewMockWithdrawalReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(&entity.Withdrawal{OrderNumber: number}, nil)
+
+		uc := NewAuthorizeHold(nil, nil, withdrawals, nil, nil, nil, service.WithdrawalPolicy{}, transactor,
+			stubOrderNumberValidator{valid: true}, nil, 15*time.Minute, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrAlreadyExists)
+	})
+
+	t.Run("invalid order number", func(t *testing.T) {
+		uc := NewAuthorizeHold(nil, nil, nil, nil, nil, nil, service.WithdrawalPolicy{}, nil, stubOrderNumberValidator{valid: false}, nil, 15*time.Minute, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrInvalidOrderNumber)
+	})
+}
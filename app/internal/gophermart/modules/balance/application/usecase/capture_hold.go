@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+// CaptureHold turns an active hold into a withdrawal once the checkout payment succeeds.
+type CaptureHold struct {
+	balanceReader     port.BalanceAccountReader
+	balanceWriter     port.BalanceAccountWriter
+	holds             port.HoldRepository
+	withdrawalWriter  port.WithdrawalWriter
+	ledgerWriter      port.LedgerWriter
+	lots              port.AccrualLotRepository
+	transactor        appport.Transactor
+	clock             appport.Clock
+	optimisticRetries int
+}
+
+// NewCaptureHold returns the capture hold use case.
+func NewCaptureHold(
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	holds port.HoldRepository,
+	withdrawalWriter port.WithdrawalWriter,
+	ledgerWriter port.LedgerWriter,
+	lots port.AccrualLotRepository,
+	transactor appport.Transactor,
+	clock appport.Clock,
+	optimisticRetries int,
+) appport.UseCase[dto.HoldActionInput, dto.HoldOutput] {
+	return &CaptureHold{
+		balanceReader:     balanceReader,
+		balanceWriter:     balanceWriter,
+		holds:             holds,
+		withdrawalWriter:  withdrawalWriter,
+		ledgerWriter:      ledgerWriter,
+		lots:              lots,
+		transactor:        transactor,
+		clock:             clock,
+		optimisticRetries: optimisticRetries,
+	}
+}
+
+// Execute releases the held points and withdraws them for the hold's order: it creates the withdrawal,
+// posts a WITHDRAWAL journal entry and spends the oldest accrual lots first, all in one transaction.
+// A concurrent capture and void of the same hold are serialized by the account version.
+//
+// Errors:
+//   - application.ErrNotFound — the user has no such hold
+//   - application.ErrHoldNotActive — the hold is already captured or voided, or has expired
+//   - application.ErrAlreadyExists — the order already has a withdrawal
+func (uc *CaptureHold) Execute(ctx context.Context, in dto.HoldActionInput) (dto.HoldOutput, error) {
+	var captured *entity.Hold
+	err := application.WithOptimisticRetry(uc.optimisticRetries, func() error {
+		return uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+			h, err := uc.holds.FindByID(ctx, in.HoldID)
+			if err != nil {
+				return err
+			}
+			if h.UserID != in.UserID {
+				return application.ErrNotFound
+			}
+
+			acc, err := uc.balanceReader.FindByUserID(ctx, in.UserID)
+			if err != nil {
+				return err
+			}
+
+			now := uc.clock.Now()
+			if err := h.Capture(now); err != nil {
+				return err
+			}
+			acc.Release(h.Amount, now)
+
+			entry := entity.NewWithdrawalEntry(in.UserID, h.OrderNumber, h.Amount, now)
+			if err := acc.Post(*entry); err != nil {
+				return err
+			}
+
+			w := entity.NewWithdrawal(in.UserID, h.OrderNumber, h.Amount, now)
+			if err := uc.withdrawalWriter.Create(ctx, w); err != nil {
+				return err
+			}
+
+			if err := uc.holds.Update(ctx, h); err != nil {
+				return err
+			}
+
+			if err := uc.ledgerWriter.Append(ctx, entry); err != nil {
+				return err
+			}
+
+			if err := consumeLots(ctx, uc.lots, in.UserID, h.Amount); err != nil {
+				return err
+			}
+
+			if err := uc.balanceWriter.Update(ctx, acc); err != nil {
+				return err
+			}
+
+			captured = h
+			return nil
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, entity.ErrHoldNotActive) {
+			return dto.HoldOutput{}, application.ErrHoldNotActive
+		}
+		if errors.Is(err, entity.ErrInsufficientBalance) {
+			return dto.HoldOutput{}, application.ErrInsufficientBalance
+		}
+		return dto.HoldOutput{}, err
+	}
+
+	return newHoldOutput(*captured), nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCaptureHold_Execute(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	number := vo.OrderNumber("2377225624")
+	in := dto.HoldActionInput{UserID: 1, HoldID: 7}
+
+	passthrough := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+	activeHold := func() *entity.Hold {
+		return &entity.Hold{
+			ID: 7, UserID: 1, OrderNumber: number, Amount: 200, Status: entity.HoldStatusActive,
+			CreatedAt: fixedTime.Add(-time.Minute), ExpiresAt: fixedTime.Add(time.Minute),
+		}
+	}
+
+	t.Run("withdraws held points", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		holds := balanceportmocks.NewMockHoldRepository(ctrl)
+		withdrawalWriter := balanceportmocks.NewMockWithdrawalWriter(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		holds.EXPECT().FindByID(ctx, int64(7)).Return(activeHold(), nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 500, Held: 200,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		withdrawalWriter.EXPECT().Create(ctx, entity.NewWithdrawal(1, number, 200, fixedTime)).Return(nil)
+		holds.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, h *entity.Hold) error {
+				assert.Equal(t, entity.HoldStatusCaptured, h.Status)
+				return nil
+			},
+		)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewWithdrawalEntry(1, number, 200, fixedTime)).Return(nil)
+		lots.EXPECT().ListActiveByUserID(ctx, vo.UserID(1)).Return([]entity.AccrualLot{
+			{ID: 1, UserID: 1, Amount: 500, Remaining: 500},
+		}, nil)
+		lots.EXPECT().Update(ctx, &entity.AccrualLot{ID: 1, UserID: 1, Amount: 500, Remaining: 300}).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(300), acc.Current)
+				assert.Equal(t, vo.Points(0), acc.Held)
+				assert.Equal(t, vo.Points(200), acc.WithdrawnTotal)
+				return nil
+			},
+		)
+
+		uc := NewCaptureHold(balanceReader, balanceWriter, holds, withdrawalWriter, ledgerWriter, lots, transactor, clk, 3)
+		out, err := uc.Execute(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CAPTURED", out.Status)
+		assert.Equal(t, &fixedTime, out.ResolvedAt)
+	})
+
+	t.Run("expired hold rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		holds := balanceportmocks.NewMockHoldRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		holds.EXPECT().FindByID(ctx, int64(7)).Return(activeHold(), nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 500, Held: 200,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime.Add(time.Hour))
+
+		uc := NewCaptureHold(balanceReader, nil, holds, nil, nil, nil, transactor, clk, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrHoldNotActive)
+	})
+
+	t.Run("hold of another user is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		holds := balanceportmocks.NewMockHoldRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		holds.EXPECT().FindByID(ctx, int64(7)).Return(activeHold(), nil)
+
+		uc := NewCaptureHold(nil, nil, holds, nil, nil, nil, transactor, nil, 3)
+		_, err := uc.Execute(ctx, dto.HoldActionInput{UserID: 2, HoldID: 7})
+
+		assert.ErrorIs(t, err, application.ErrNotFound)
+	})
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+)
+
+// errHoldNotExpired aborts auto-void of a hold that was captured or voided concurrently.
+var errHoldNotExpired = errors.New("hold is not expired")
+
+// ExpireHolds voids active holds past their expiry time, releasing points of abandoned checkouts.
+type ExpireHolds struct {
+	balanceReader     port.BalanceAccountReader
+	balanceWriter     port.BalanceAccountWriter
+	holds             port.HoldRepository
+	transactor        appport.Transactor
+	clock             appport.Clock
+	log               appport.Logger
+	batchSize         int
+	optimisticRetries int
+}
+
+// NewExpireHolds returns the expire holds use case.
+func NewExpireHolds(
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	holds port.HoldRepository,
+	transactor appport.Transactor,
+	clock appport.Clock,
+	log appport.Logger,
+	batchSize int,
+	optimisticRetries int,
+) *ExpireHolds {
+	return &ExpireHolds{
+		balanceReader:     balanceReader,
+		balanceWriter:     balanceWriter,
+		holds:             holds,
+		transactor:        transactor,
+		clock:             clock,
+		log:               log,
+		batchSize:         batchSize,
+		optimisticRetries: optimisticRetries,
+	}
+}
+
+// Run voids a batch of expired holds, each in its own transaction, and returns the number of voided holds.
+// A failed hold is logged and retried on the next run; holds resolved concurrently are skipped.
+func (uc *ExpireHolds) Run(ctx context.Context) (int, error) {
+	now := uc.clock.Now()
+	expired, err := uc.holds.ListExpired(ctx, now, uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	voided := 0
+	for _, h := range expired {
+		if ctx.Err() != nil {
+			return voided, ctx.Err()
+		}
+
+		err := application.WithOptimisticRetry(uc.optimisticRetries, func() error {
+			return uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+				return uc.voidExpired(ctx, h.ID)
+			})
+		})
+		switch {
+		case err == nil:
+			voided++
+		case errors.Is(err, errHoldNotExpired):
+		default:
+			uc.log.Error("void expired hold failed", "hold_id", h.ID, "error", err)
+		}
+	}
+
+	return voided, nil
+}
+
+// voidExpired re-reads the hold inside the transaction and voids it if it is still active and expired.
+func (uc *ExpireHolds) voidExpired(ctx context.Context, id int64) error {
+	h, err := uc.holds.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := uc.clock.Now()
+	if !h.Expired(now) {
+		return errHoldNotExpired
+	}
+
+	return voidHold(ctx, uc.balanceReader, uc.balanceWriter, uc.holds, h, now)
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpireHolds_Run(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	passthrough := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+
+	t.Run("voids expired hold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		holds := balanceportmocks.NewMockHoldRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		hold := entity.Hold{ID: 7, UserID: 1, Amount: 200, Status: entity.HoldStatusActive, ExpiresAt: fixedTime}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		holds.EXPECT().ListExpired(ctx, fixedTime, 10).Return([]entity.Hold{hold}, nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		holds.EXPECT().FindByID(ctx, int64(7)).Return(&hold, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 500, Held: 200,
+		}, nil)
+		holds.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, h *entity.Hold) error {
+				assert.Equal(t, entity.HoldStatusVoided, h.Status)
+				return nil
+			},
+		)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(0), acc.Held)
+				return nil
+			},
+		)
+
+		uc := NewExpireHolds(balanceReader, balanceWriter, holds, transactor, clk, nil, 10, 3)
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("hold captured concurrently is skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		holds := balanceportmocks.NewMockHoldRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		expired := entity.Hold{ID: 7, UserID: 1, Amount: 200, Status: entity.HoldStatusActive, ExpiresAt: fixedTime}
+		captured := entity.Hold{ID: 7, UserID: 1, Amount: 200, Status: entity.HoldStatusCaptured, ExpiresAt: fixedTime}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		holds.EXPECT().ListExpired(ctx, fixedTime, 10).Return([]entity.Hold{expired}, nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		holds.EXPECT().FindByID(ctx, int64(7)).Return(&captured, nil)
+
+		uc := NewExpireHolds(nil, nil, holds, transactor, clk, nil, 10, 3)
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+// errLotNotDue aborts expiry of a lot that was spent or expired concurrently.
+var errLotNotDue = errors.New("lot is not due")
+
+// ExpirePoints expires accrual lots past their expiry date and deducts their remaining points.
+type ExpirePoints struct {
+	balanceReader     port.BalanceAccountReader
+	balanceWriter     port.BalanceAccountWriter
+	lots              port.AccrualLotRepository
+	ledgerWriter      port.LedgerWriter
+	transactor        appport.Transactor
+	clock             appport.Clock
+	log               appport.Logger
+	batchSize         int
+	optimisticRetries int
+}
+
+// NewExpirePoints returns the expire points use case.
+func NewExpirePoints(
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	lots port.AccrualLotRepository,
+	ledgerWriter port.LedgerWriter,
+	transactor appport.Transactor,
+	clock appport.Clock,
+	log appport.Logger,
+	batchSize int,
+	optimisticRetries int,
+) *ExpirePoints {
+	return &ExpirePoints{
+		balanceReader:     balanceReader,
+		balanceWriter:     balanceWriter,
+		lots:              lots,
+		ledgerWriter:      ledgerWriter,
+		transactor:        transactor,
+		clock:             clock,
+		log:               log,
+		batchSize:         batchSize,
+		optimisticRetries: optimisticRetries,
+	}
+}
+
+// Run expires due lots, each in its own transaction, and returns the number of expired lots.
+// It pages through due lots until a batch of them is expired or none are left. Lots that cannot
+// expire yet (fully held, spent or expired concurrently) and failed lots stay due, so the pages
+// follow a keyset cursor past them instead of listing them first on every run.
+// A failed lot is logged and retried on the next run.
+func (uc *ExpirePoints) Run(ctx context.Context) (int, error) {
+	now := uc.clock.Now()
+	expired := 0
+	var after *port.DueCursor
+
+	for expired < uc.batchSize {
+		due, err := uc.lots.ListDue(ctx, now, after, uc.batchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		for _, lot := range due {
+			if ctx.Err() != nil {
+				return expired, ctx.Err()
+			}
+
+			err := application.WithOptimisticRetry(uc.optimisticRetries, func() error {
+				return uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+					return uc.expireLot(ctx, lot.ID)
+				})
+			})
+			switch {
+			case err == nil:
+				expired++
+			case errors.Is(err, errLotNotDue):
+			default:
+				uc.log.Error("expire lot failed", "lot_id", lot.ID, "error", err)
+			}
+		}
+
+		if len(due) < uc.batchSize {
+			break
+		}
+		last := due[len(due)-1]
+		after = &port.DueCursor{ExpiresAt: *last.ExpiresAt, ID: last.ID}
+	}
+
+	return expired, nil
+}
+
+// expireLot re-reads the lot inside the transaction and posts an EXPIRY entry for its remaining points.
+// Concurrent withdrawals and expiries of the same user are serialized by the account version.
+func (uc *ExpirePoints) expireLot(ctx context.Context, id int64) error {
+	lot, err := uc.lots.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := uc.clock.Now()
+	if !lot.Due(now) {
+		return errLotNotDue
+	}
+
+	acc, err := uc.balanceReader.FindByUserID(ctx, lot.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Held points are reserved for checkout: they expire only once the hold is voided.
+	amount := min(lot.Remaining, acc.Available())
+	if acc.Held > 0 {
+		if amount <= 0 {
+			return errLotNotDue
+		}
+		lot.Consume(amount)
+	} else {
+		// Lots never hold more than the account; drop the excess in case they drifted apart.
+		lot.Consume(lot.Remaining)
+	}
+
+	if err := uc.lots.Update(ctx, lot); err != nil {
+		return err
+	}
+	if amount <= 0 {
+		return nil
+	}
+
+	entry := entity.NewExpiryEntry(lot.UserID, fmt.Sprintf("lot-%d", lot.ID), amount, now)
+	if err := acc.Post(*entry); err != nil {
+		return err
+	}
+	if err := uc.ledgerWriter.Append(ctx, entry); err != nil {
+		return err
+	}
+
+	return uc.balanceWriter.Update(ctx, acc)
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpirePoints_Run(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	expiredAt := fixedTime.Add(-time.Hour)
+
+	passthrough := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+
+	t.Run("expires remaining points of due lot", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		lot := entity.AccrualLot{ID: 7, UserID: 1, Amount: 100, Remaining: 40, ExpiresAt: &expiredAt}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		lots.EXPECT().ListDue(ctx, fixedTime, nil, 10).Return([]entity.AccrualLot{lot}, nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		lots.EXPECT().FindByID(ctx, int64(7)).Return(&lot, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 250,
+		}, nil)
+		lots.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, l *entity.AccrualLot) error {
+				assert.Equal(t, vo.Points(0), l.Remaining)
+				return nil
+			},
+		)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewExpiryEntry(1, "lot-7", 40, fixedTime)).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(210), acc.Current)
+				return nil
+			},
+		)
+
+		uc := NewExpirePoints(balanceReader, balanceWriter, lots, ledgerWriter, transactor, clk, nil, 10, 3)
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("clamps expiry to current balance", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		lot := entity.AccrualLot{ID: 7, UserID: 1, Amount: 100, Remaining: 100, ExpiresAt: &expiredAt}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		lots.EXPECT().ListDue(ctx, fixedTime, nil, 10).Return([]entity.AccrualLot{lot}, nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		lots.EXPECT().FindByID(ctx, int64(7)).Return(&lot, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 30,
+		}, nil)
+		lots.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewExpiryEntry(1, "lot-7", 30, fixedTime)).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(0), acc.Current)
+				return nil
+			},
+		)
+
+		uc := NewExpirePoints(balanceReader, balanceWriter, lots, ledgerWriter, transactor, clk, nil, 10, 3)
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("held points are not expired", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		lot := entity.AccrualLot{ID: 7, UserID: 1, Amount: 100, Remaining: 100, ExpiresAt: &expiredAt}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		lots.EXPECT().ListDue(ctx, fixedTime, nil, 10).Return([]entity.AccrualLot{lot}, nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		lots.EXPECT().FindByID(ctx, int64(7)).Return(&lot, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 100, Held: 60,
+		}, nil)
+		lots.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, l *entity.AccrualLot) error {
+				assert.Equal(t, vo.Points(60), l.Remaining)
+				return nil
+			},
+		)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewExpiryEntry(1, "lot-7", 40, fixedTime)).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(60), acc.Current)
+				assert.Equal(t, vo.Points(60), acc.Held)
+				return nil
+			},
+		)
+
+		uc := NewExpirePoints(balanceReader, balanceWriter, lots, ledgerWriter, transactor, clk, nil, 10, 3)
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("fully held lot does not stall later lots", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		held := entity.AccrualLot{ID: 7, UserID: 1, Amount: 100, Remaining: 100, ExpiresAt: &expiredAt}
+		free := entity.AccrualLot{ID: 8, UserID: 2, Amount: 100, Remaining: 30, ExpiresAt: &expiredAt}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(3)
+		lots.EXPECT().ListDue(ctx, fixedTime, nil, 1).Return([]entity.AccrualLot{held}, nil)
+		lots.EXPECT().ListDue(ctx, fixedTime, &port.DueCursor{ExpiresAt: expiredAt, ID: 7}, 1).
+			Return([]entity.AccrualLot{free}, nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough).Times(2)
+		lots.EXPECT().FindByID(ctx, int64(7)).Return(&held, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 100, Held: 100,
+		}, nil)
+		lots.EXPECT().FindByID(ctx, int64(8)).Return(&free, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(2)).Return(&entity.BalanceAccount{
+			UserID: 2, Current: 30,
+		}, nil)
+		lots.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewExpiryEntry(2, "lot-8", 30, fixedTime)).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		uc := NewExpirePoints(balanceReader, balanceWriter, lots, ledgerWriter, transactor, clk, nil, 1, 3)
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("lot spent concurrently is skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		due := entity.AccrualLot{ID: 7, UserID: 1, Amount: 100, Remaining: 100, ExpiresAt: &expiredAt}
+		spent := entity.AccrualLot{ID: 7, UserID: 1, Amount: 100, Remaining: 0, ExpiresAt: &expiredAt}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		lots.EXPECT().ListDue(ctx, fixedTime, nil, 10).Return([]entity.AccrualLot{due}, nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		lots.EXPECT().FindByID(ctx, int64(7)).Return(&spent, nil)
+
+		uc := NewExpirePoints(nil, nil, lots, nil, transactor, clk, nil, 10, 3)
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("list error propagated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		dbErr := errors.New("db down")
+		clk.EXPECT().Now().Return(fixedTime)
+		lots.EXPECT().ListDue(ctx, fixedTime, nil, 10).Return(nil, dbErr)
+
+		uc := NewExpirePoints(nil, nil, lots, nil, nil, clk, nil, 10, 3)
+		_, err := uc.Run(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
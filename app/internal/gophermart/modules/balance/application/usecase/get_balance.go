@@ -12,14 +12,28 @@ import (
 // GetBalance returns the current balance for the given user.
 type GetBalance struct {
 	balanceReader port.BalanceAccountReader
+	lotReader     port.AccrualLotReader
+	expiryPolicy  vo.ExpiryPolicy
+	clock         appport.Clock
 }
 
 // NewGetBalance returns the get balance use case.
-func NewGetBalance(balanceReader port.BalanceAccountReader) appport.UseCase[vo.UserID, dto.BalanceOutput] {
-	return &GetBalance{balanceReader: balanceReader}
+func NewGetBalance(
+	balanceReader port.BalanceAccountReader,
+	lotReader port.AccrualLotReader,
+	expiryPolicy vo.ExpiryPolicy,
+	clock appport.Clock,
+) appport.UseCase[vo.UserID, dto.BalanceOutput] {
+	return &GetBalance{
+		balanceReader: balanceReader,
+		lotReader:     lotReader,
+		expiryPolicy:  expiryPolicy,
+		clock:         clock,
+	}
 }
 
 // Execute loads the balance account and maps it to BalanceOutput.
+// Points expiring within the policy's soon window are summed from the user's lots.
 //
 // Errors:
 //   - application.ErrNotFound — balance account does not exist
@@ -29,8 +43,29 @@ func (uc *GetBalance) Execute(ctx context.Context, userID vo.UserID) (dto.Balanc
 		return dto.BalanceOutput{}, err
 	}
 
-	return dto.BalanceOutput{
-		Current:   float64(acc.Current),
-		Withdrawn: float64(acc.WithdrawnTotal),
-	}, nil
+	out := dto.BalanceOutput{
+		Current:   acc.Current,
+		Withdrawn: acc.WithdrawnTotal,
+		Available: acc.Available(),
+		Held:      acc.Held,
+	}
+
+	if uc.expiryPolicy.TTL <= 0 || uc.expiryPolicy.SoonWindow <= 0 {
+		return out, nil
+	}
+
+	lots, err := uc.lotReader.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return dto.BalanceOutput{}, err
+	}
+
+	deadline := uc.clock.Now().Add(uc.expiryPolicy.SoonWindow)
+	for _, lot := range lots {
+		if lot.ExpiresBy(deadline) {
+			out.ExpiringSoon += lot.Remaining
+		}
+	}
+	out.ExpiringSoon = min(out.ExpiringSoon, out.Current)
+
+	return out, nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
 	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
 	"gophermart/internal/gophermart/modules/balance/domain/entity"
 	"gophermart/internal/gophermart/modules/balance/domain/vo"
@@ -25,14 +27,46 @@ func TestGetBalance_Execute(t *testing.T) {
 		balanceReader.EXPECT().FindByUserID(ctx, userID).Return(&entity.BalanceAccount{
 			Current:        vo.Points(500),
 			WithdrawnTotal: vo.Points(200),
+			Held:           vo.Points(150),
 		}, nil)
 
-		uc := NewGetBalance(balanceReader)
+		uc := NewGetBalance(balanceReader, nil, vo.ExpiryPolicy{}, nil)
 		result, err := uc.Execute(ctx, userID)
 
 		assert.NoError(t, err)
-		assert.Equal(t, float64(500), result.Current)
-		assert.Equal(t, float64(200), result.Withdrawn)
+		assert.Equal(t, vo.Points(500), result.Current)
+		assert.Equal(t, vo.Points(200), result.Withdrawn)
+		assert.Equal(t, vo.Points(350), result.Available)
+		assert.Equal(t, vo.Points(150), result.Held)
+	})
+
+	t.Run("sums points expiring within soon window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		lotReader := balanceportmocks.NewMockAccrualLotReader(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		soon := fixedTime.Add(24 * time.Hour)
+		later := fixedTime.Add(90 * 24 * time.Hour)
+
+		balanceReader.EXPECT().FindByUserID(ctx, userID).Return(&entity.BalanceAccount{
+			Current: vo.Points(500),
+		}, nil)
+		lotReader.EXPECT().ListActiveByUserID(ctx, userID).Return([]entity.AccrualLot{
+			{ID: 1, Remaining: 120, ExpiresAt: &soon},
+			{ID: 2, Remaining: 300, ExpiresAt: &later},
+			{ID: 3, Remaining: 80},
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+
+		policy := vo.ExpiryPolicy{TTL: 365 * 24 * time.Hour, SoonWindow: 30 * 24 * time.Hour}
+		uc := NewGetBalance(balanceReader, lotReader, policy, clk)
+		result, err := uc.Execute(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, vo.Points(500), result.Current)
+		assert.Equal(t, vo.Points(120), result.ExpiringSoon)
 	})
 
 	t.Run("not found", func(t *testing.T) {
@@ -41,7 +75,7 @@ func TestGetBalance_Execute(t *testing.T) {
 
 		balanceReader.EXPECT().FindByUserID(ctx, userID).Return(nil, application.ErrNotFound)
 
-		uc := NewGetBalance(balanceReader)
+		uc := NewGetBalance(balanceReader, nil, vo.ExpiryPolicy{}, nil)
 		_, err := uc.Execute(ctx, userID)
 
 		assert.ErrorIs(t, err, application.ErrNotFound)
@@ -53,7 +87,7 @@ func TestGetBalance_Execute(t *testing.T) {
 
 		balanceReader.EXPECT().FindByUserID(ctx, userID).Return(nil, errors.New("db error"))
 
-		uc := NewGetBalance(balanceReader)
+		uc := NewGetBalance(balanceReader, nil, vo.ExpiryPolicy{}, nil)
 		_, err := uc.Execute(ctx, userID)
 
 		assert.Error(t, err)
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+)
+
+// GetStatement builds an account statement of the ledger over a date range.
+type GetStatement struct {
+	ledgerReader port.LedgerReader
+}
+
+// NewGetStatement returns the get statement use case.
+func NewGetStatement(ledgerReader port.LedgerReader) appport.UseCase[dto.StatementInput, dto.StatementOutput] {
+	return &GetStatement{ledgerReader: ledgerReader}
+}
+
+// Execute lists the journal entries in [From, To) that change the user's points, oldest first,
+// between the ledger balances at the range bounds. Lines and balances come from the same postings,
+// so the opening balance plus the lines always equals the closing balance.
+func (uc *GetStatement) Execute(ctx context.Context, in dto.StatementInput) (dto.StatementOutput, error) {
+	opening, err := uc.ledgerReader.BalanceAt(ctx, in.UserID, in.From)
+	if err != nil {
+		return dto.StatementOutput{}, err
+	}
+	closing, err := uc.ledgerReader.BalanceAt(ctx, in.UserID, in.To)
+	if err != nil {
+		return dto.StatementOutput{}, err
+	}
+
+	entries, err := uc.ledgerReader.ListByUserIDBetween(ctx, in.UserID, in.From, in.To)
+	if err != nil {
+		return dto.StatementOutput{}, err
+	}
+
+	lines := make([]dto.StatementLineOutput, 0, len(entries))
+	// Entries are listed newest first.
+	for _, e := range slices.Backward(entries) {
+		amount := e.PointsDelta(in.UserID)
+		if amount == 0 {
+			continue
+		}
+		lines = append(lines, dto.StatementLineOutput{
+			At:        e.CreatedAt,
+			Kind:      string(e.Kind),
+			Reference: e.Reference,
+			Amount:    amount,
+		})
+	}
+
+	return dto.StatementOutput{
+		From:           in.From,
+		To:             in.To,
+		OpeningBalance: opening,
+		ClosingBalance: closing,
+		Lines:          lines,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetStatement_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.UserID(1)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	in := dto.StatementInput{UserID: userID, From: from, To: to}
+
+	t.Run("lists ledger entries oldest first with the user's points delta", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ledgerReader := balanceportmocks.NewMockLedgerReader(ctrl)
+
+		ledgerReader.EXPECT().BalanceAt(ctx, userID, from).Return(vo.Points(1000), nil)
+		ledgerReader.EXPECT().BalanceAt(ctx, userID, to).Return(vo.Points(48000), nil)
+		// Newest first, as listed by the repository.
+		ledgerReader.EXPECT().ListByUserIDBetween(ctx, userID, from, to).Return([]entity.JournalEntry{
+			*entity.NewExpiryEntry(userID, "lot:7", 3000, from.Add(5*time.Hour)),
+			*entity.NewWithdrawalEntry(userID, "444", 5000, from.Add(4*time.Hour)),
+			*entity.NewPeerTransferEntry(vo.UserID(2), userID, "gift", 40000, from.Add(3*time.Hour)),
+			*entity.NewRefundEntry(userID, "222", 5000, from.Add(2*time.Hour)),
+			*entity.NewAccrualEntry(userID, "111", 10000, from.Add(time.Hour)),
+		}, nil)
+
+		out, err := NewGetStatement(ledgerReader).Execute(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.StatementOutput{
+			From:           from,
+			To:             to,
+			OpeningBalance: 1000,
+			ClosingBalance: 48000,
+			Lines: []dto.StatementLineOutput{
+				{At: from.Add(time.Hour), Kind: "ACCRUAL", Reference: "111", Amount: 10000},
+				{At: from.Add(2 * time.Hour), Kind: "REFUND", Reference: "222", Amount: 5000},
+				{At: from.Add(3 * time.Hour), Kind: "TRANSFER", Reference: "gift", Amount: 40000},
+				{At: from.Add(4 * time.Hour), Kind: "WITHDRAWAL", Reference: "444", Amount: -5000},
+				{At: from.Add(5 * time.Hour), Kind: "EXPIRY", Reference: "lot:7", Amount: -3000},
+			},
+		}, out)
+	})
+
+	t.Run("skips entries without the user's points", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ledgerReader := balanceportmocks.NewMockLedgerReader(ctrl)
+
+		ledgerReader.EXPECT().BalanceAt(ctx, userID, gomock.Any()).Return(vo.Points(700), nil).Times(2)
+		ledgerReader.EXPECT().ListByUserIDBetween(ctx, userID, from, to).Return([]entity.JournalEntry{
+			*entity.NewPeerTransferEntry(vo.UserID(2), vo.UserID(3), "gift", 100, from.Add(time.Hour)),
+		}, nil)
+
+		out, err := NewGetStatement(ledgerReader).Execute(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, vo.Points(700), out.OpeningBalance)
+		assert.Equal(t, vo.Points(700), out.ClosingBalance)
+		assert.Empty(t, out.Lines)
+	})
+
+	t.Run("ledger error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ledgerReader := balanceportmocks.NewMockLedgerReader(ctrl)
+
+		ledgerReader.EXPECT().BalanceAt(ctx, userID, gomock.Any()).Return(vo.Points(0), nil).Times(2)
+		ledgerReader.EXPECT().ListByUserIDBetween(ctx, userID, from, to).Return(nil, errors.New("ledger failed"))
+
+		_, err := NewGetStatement(ledgerReader).Execute(ctx, in)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// ListHolds returns the user's holds.
+type ListHolds struct {
+	holdReader port.HoldReader
+}
+
+// NewListHolds returns the list holds use case.
+func NewListHolds(holdReader port.HoldReader) appport.UseCase[vo.UserID, []dto.HoldOutput] {
+	return &ListHolds{holdReader: holdReader}
+}
+
+// Execute returns all holds of the user, newest first.
+func (uc *ListHolds) Execute(ctx context.Context, userID vo.UserID) ([]dto.HoldOutput, error) {
+	holds, err := uc.holdReader.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.HoldOutput, 0, len(holds))
+	for _, h := range holds {
+		result = append(result, newHoldOutput(h))
+	}
+
+	return result, nil
+}
+
+func newHoldOutput(h entity.Hold) dto.HoldOutput {
+	return dto.HoldOutput{
+		ID:          h.ID,
+		OrderNumber: h.OrderNumber.String(),
+		Sum:         h.Amount,
+		Status:      string(h.Status),
+		CreatedAt:   h.CreatedAt,
+		ExpiresAt:   h.ExpiresAt,
+		ResolvedAt:  h.ResolvedAt,
+	}
+}
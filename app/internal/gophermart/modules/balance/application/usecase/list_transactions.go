@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// ListTransactions returns the full ledger history for the given user.
+type ListTransactions struct {
+	ledgerReader port.LedgerReader
+}
+
+// NewListTransactions returns the list transactions use case.
+func NewListTransactions(ledgerReader port.LedgerReader) appport.UseCase[vo.UserID, []dto.TransactionOutput] {
+	return &ListTransactions{ledgerReader: ledgerReader}
+}
+
+// Execute fetches journal entries touching the user's accounts and maps them to output DTOs,
+// newest first. Returns an empty slice if the user has no transactions.
+func (uc *ListTransactions) Execute(ctx context.Context, userID vo.UserID) ([]dto.TransactionOutput, error) {
+	entries, err := uc.ledgerReader.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.TransactionOutput, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, dto.TransactionOutput{
+			ID:        e.ID,
+			Kind:      string(e.Kind),
+			Reference: e.Reference,
+			Amount:    e.PointsDelta(userID),
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListTransactions_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.UserID(1)
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("returns signed amounts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		reader := balanceportmocks.NewMockLedgerReader(ctrl)
+
+		withdrawal := entity.NewWithdrawalEntry(userID, "2377225624", 30, fixedTime.Add(time.Hour))
+		withdrawal.ID = 2
+		accrual := entity.NewAccrualEntry(userID, "12345678903", 100, fixedTime)
+		accrual.ID = 1
+		reader.EXPECT().ListByUserID(ctx, userID).Return([]entity.JournalEntry{*withdrawal, *accrual}, nil)
+
+		uc := NewListTransactions(reader)
+		result, err := uc.Execute(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, int64(2), result[0].ID)
+		assert.Equal(t, "WITHDRAWAL", result[0].Kind)
+		assert.Equal(t, "2377225624", result[0].Reference)
+		assert.Equal(t, vo.Points(-30), result[0].Amount)
+		assert.Equal(t, "ACCRUAL", result[1].Kind)
+		assert.Equal(t, vo.Points(100), result[1].Amount)
+		assert.Equal(t, fixedTime, result[1].CreatedAt)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		reader := balanceportmocks.NewMockLedgerReader(ctrl)
+
+		reader.EXPECT().ListByUserID(ctx, userID).Return(nil, nil)
+
+		uc := NewListTransactions(reader)
+		result, err := uc.Execute(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		reader := balanceportmocks.NewMockLedgerReader(ctrl)
+
+		reader.EXPECT().ListByUserID(ctx, userID).Return(nil, errors.New("db error"))
+
+		uc := NewListTransactions(reader)
+		_, err := uc.Execute(ctx, userID)
+
+		assert.Error(t, err)
+	})
+}
@@ -3,38 +3,73 @@ package usecase
 import (
 	"context"
 
+	"gophermart/internal/gophermart/application"
 	appport "gophermart/internal/gophermart/application/port"
 	"gophermart/internal/gophermart/modules/balance/application/dto"
 	"gophermart/internal/gophermart/modules/balance/application/port"
-	"gophermart/internal/gophermart/modules/balance/domain/vo"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/pkg/cursor"
 )
 
-// ListWithdrawals returns all withdrawals for the given user.
+// ListWithdrawals returns the withdrawals of the given user.
 type ListWithdrawals struct {
 	withdrawalReader port.WithdrawalReader
 }
 
 // NewListWithdrawals returns the list withdrawals use case.
-func NewListWithdrawals(withdrawalReader port.WithdrawalReader) appport.UseCase[vo.UserID, []dto.WithdrawalOutput] {
+func NewListWithdrawals(
+	withdrawalReader port.WithdrawalReader,
+) appport.UseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput] {
 	return &ListWithdrawals{withdrawalReader: withdrawalReader}
 }
 
-// Execute fetches withdrawals and maps them to output DTOs.
-// Returns an empty slice if the user has no withdrawals.
-func (uc *ListWithdrawals) Execute(ctx context.Context, userID vo.UserID) ([]dto.WithdrawalOutput, error) {
-	withdrawals, err := uc.withdrawalReader.ListByUserID(ctx, userID)
+// Execute fetches withdrawals matching the filters, newest first, and maps them to output DTOs.
+// With a cursor or limit it returns one page and the cursor of the next one; otherwise the whole history.
+// Returns an empty slice if the user has no matching withdrawals.
+//
+// Errors:
+//   - application.ErrInvalidCursor — cursor is malformed
+func (uc *ListWithdrawals) Execute(ctx context.Context, in dto.ListWithdrawalsInput) (dto.WithdrawalPageOutput, error) {
+	page := application.PageRequest{Cursor: in.Cursor, Limit: in.Limit}
+	after, err := page.After()
 	if err != nil {
-		return nil, err
+		return dto.WithdrawalPageOutput{}, err
 	}
 
+	filter := port.WithdrawalFilter{From: in.From, To: in.To, After: after}
+	for _, s := range in.RefundStatuses {
+		filter.RefundStatuses = append(filter.RefundStatuses, entity.RefundStatus(s))
+	}
+	limit := page.PageLimit()
+	if limit > 0 {
+		// One extra row tells whether there is a next page.
+		filter.Limit = limit + 1
+	}
+
+	withdrawals, err := uc.withdrawalReader.ListByUserID(ctx, in.UserID, filter)
+	if err != nil {
+		return dto.WithdrawalPageOutput{}, err
+	}
+
+	withdrawals, next := application.TrimPage(withdrawals, limit, func(w entity.Withdrawal) cursor.Cursor {
+		return cursor.Cursor{At: w.ProcessedAt, Key: w.OrderNumber.String()}
+	})
+
 	result := make([]dto.WithdrawalOutput, 0, len(withdrawals))
 	for _, w := range withdrawals {
-		result = append(result, dto.WithdrawalOutput{
-			OrderNumber: w.OrderNumber.String(),
-			Sum:         float64(w.Amount),
-			ProcessedAt: w.ProcessedAt,
-		})
+		result = append(result, newWithdrawalOutput(w))
 	}
 
-	return result, nil
+	return dto.WithdrawalPageOutput{Withdrawals: result, NextCursor: next}, nil
+}
+
+func newWithdrawalOutput(w entity.Withdrawal) dto.WithdrawalOutput {
+	return dto.WithdrawalOutput{
+		OrderNumber:  w.OrderNumber.String(),
+		Sum:          w.Amount,
+		ProcessedAt:  w.ProcessedAt,
+		Refunded:     w.RefundedAmount,
+		RefundStatus: string(w.RefundStatus()),
+		RefundedAt:   w.RefundedAt,
+	}
 }
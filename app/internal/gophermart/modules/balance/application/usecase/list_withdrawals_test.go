@@ -6,59 +6,129 @@ import (
 	"testing"
 	"time"
 
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
 	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
 	"gophermart/internal/gophermart/modules/balance/domain/entity"
 	"gophermart/internal/gophermart/modules/balance/domain/vo"
+	"gophermart/internal/pkg/cursor"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
 func TestListWithdrawals_Execute(t *testing.T) {
 	ctx := context.Background()
 	userID := vo.UserID(1)
+	in := dto.ListWithdrawalsInput{UserID: userID}
 
 	t.Run("returns mapped withdrawals", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		reader := balanceportmocks.NewMockWithdrawalReader(ctrl)
 
 		now := time.Now()
-		reader.EXPECT().ListByUserID(ctx, userID).Return([]entity.Withdrawal{
+		reader.EXPECT().ListByUserID(ctx, userID, port.WithdrawalFilter{}).Return([]entity.Withdrawal{
 			{OrderNumber: "111", Amount: vo.Points(200), ProcessedAt: now},
-			{OrderNumber: "222", Amount: vo.Points(300), ProcessedAt: now},
+			{OrderNumber: "222", Amount: vo.Points(300), ProcessedAt: now, RefundedAmount: vo.Points(100), RefundedAt: &now},
 		}, nil)
 
 		uc := NewListWithdrawals(reader)
-		result, err := uc.Execute(ctx, userID)
+		result, err := uc.Execute(ctx, in)
 
 		assert.NoError(t, err)
-		assert.Len(t, result, 2)
-		assert.Equal(t, "111", result[0].OrderNumber)
-		assert.Equal(t, float64(200), result[0].Sum)
-		assert.Equal(t, "222", result[1].OrderNumber)
+		assert.Len(t, result.Withdrawals, 2)
+		assert.Equal(t, "111", result.Withdrawals[0].OrderNumber)
+		assert.Equal(t, vo.Points(200), result.Withdrawals[0].Sum)
+		assert.Equal(t, "NONE", result.Withdrawals[0].RefundStatus)
+		assert.Equal(t, "222", result.Withdrawals[1].OrderNumber)
+		assert.Equal(t, vo.Points(100), result.Withdrawals[1].Refunded)
+		assert.Equal(t, "PARTIAL", result.Withdrawals[1].RefundStatus)
+		assert.Equal(t, &now, result.Withdrawals[1].RefundedAt)
+		assert.Empty(t, result.NextCursor)
+	})
+
+	t.Run("pages with filters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		reader := balanceportmocks.NewMockWithdrawalReader(ctrl)
+
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		to := now.Add(24 * time.Hour)
+
+		reader.EXPECT().ListByUserID(ctx, userID, port.WithdrawalFilter{
+			RefundStatuses: []entity.RefundStatus{entity.RefundStatusNone},
+			To:             &to,
+			Limit:          2,
+		}).Return([]entity.Withdrawal{
+			{OrderNumber: "222", Amount: 100, ProcessedAt: now},
+			{OrderNumber: "111", Amount: 100, ProcessedAt: now.Add(-time.Minute)},
+		}, nil)
+
+		uc := NewListWithdrawals(reader)
+		result, err := uc.Execute(ctx, dto.ListWithdrawalsInput{
+			UserID:         userID,
+			RefundStatuses: []string{"NONE"},
+			To:             &to,
+			Limit:          1,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Withdrawals, 1)
+		assert.Equal(t, "222", result.Withdrawals[0].OrderNumber)
+
+		next, err := cursor.Decode(result.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "222", next.Key)
+		assert.True(t, now.Equal(next.At))
+	})
+
+	t.Run("cursor without limit uses default page size", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		reader := balanceportmocks.NewMockWithdrawalReader(ctrl)
+
+		after := cursor.Cursor{At: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), Key: "999"}
+		reader.EXPECT().ListByUserID(ctx, userID, port.WithdrawalFilter{
+			After: &after,
+			Limit: application.DefaultPageLimit + 1,
+		}).Return(nil, nil)
+
+		uc := NewListWithdrawals(reader)
+		result, err := uc.Execute(ctx, dto.ListWithdrawalsInput{UserID: userID, Cursor: after.Encode()})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Withdrawals)
+		assert.Empty(t, result.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		uc := NewListWithdrawals(nil)
+		_, err := uc.Execute(ctx, dto.ListWithdrawalsInput{UserID: userID, Cursor: "garbage"})
+
+		assert.ErrorIs(t, err, application.ErrInvalidCursor)
 	})
 
 	t.Run("empty list", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		reader := balanceportmocks.NewMockWithdrawalReader(ctrl)
 
-		reader.EXPECT().ListByUserID(ctx, userID).Return(nil, nil)
+		reader.EXPECT().ListByUserID(ctx, userID, port.WithdrawalFilter{}).Return(nil, nil)
 
 		uc := NewListWithdrawals(reader)
-		result, err := uc.Execute(ctx, userID)
+		result, err := uc.Execute(ctx, in)
 
 		assert.NoError(t, err)
-		assert.Empty(t, result)
+		assert.Empty(t, result.Withdrawals)
 	})
 
 	t.Run("repo error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		reader := balanceportmocks.NewMockWithdrawalReader(ctrl)
 
-		reader.EXPECT().ListByUserID(ctx, userID).Return(nil, errors.New("db error"))
+		reader.EXPECT().ListByUserID(ctx, userID, port.WithdrawalFilter{}).Return(nil, errors.New("db error"))
 
 		uc := NewListWithdrawals(reader)
-		_, err := uc.Execute(ctx, userID)
+		_, err := uc.Execute(ctx, in)
 
 		assert.Error(t, err)
 	})
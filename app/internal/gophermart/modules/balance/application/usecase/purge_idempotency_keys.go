@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+)
+
+// PurgeIdempotencyKeys removes idempotency keys older than their TTL; a request retried
+// with a purged key is executed again.
+type PurgeIdempotencyKeys struct {
+	keys  port.IdempotencyKeyRepository
+	clock appport.Clock
+	ttl   time.Duration
+}
+
+// NewPurgeIdempotencyKeys returns the purge use case keeping keys for ttl.
+func NewPurgeIdempotencyKeys(keys port.IdempotencyKeyRepository, clock appport.Clock, ttl time.Duration) *PurgeIdempotencyKeys {
+	return &PurgeIdempotencyKeys{keys: keys, clock: clock, ttl: ttl}
+}
+
+// Run removes expired keys and returns the number of removed keys.
+func (uc *PurgeIdempotencyKeys) Run(ctx context.Context) (int, error) {
+	return uc.keys.DeleteCreatedBefore(ctx, uc.clock.Now().Add(-uc.ttl))
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeIdempotencyKeys_Run(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	keys := balanceportmocks.NewMockIdempotencyKeyRepository(ctrl)
+	clk := appmocks.NewMockClock(ctrl)
+
+	clk.EXPECT().Now().Return(fixedTime)
+	keys.EXPECT().DeleteCreatedBefore(ctx, fixedTime.Add(-24*time.Hour)).Return(3, nil)
+
+	n, err := NewPurgeIdempotencyKeys(keys, clk, 24*time.Hour).Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
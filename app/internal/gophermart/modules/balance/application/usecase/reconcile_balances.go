@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/service"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// ReconcileBalances recomputes balance accounts from source records and reports the ones that drifted.
+// In fix mode it also brings drifted accounts in line with an adjustment entry.
+type ReconcileBalances struct {
+	balanceReader    port.BalanceAccountReader
+	balanceWriter    port.BalanceAccountWriter
+	withdrawalReader port.WithdrawalReader
+	ledgerReader     port.LedgerReader
+	ledgerWriter     port.LedgerWriter
+	lots             port.AccrualLotRepository
+	orders           port.OrdersGateway
+	balanceSvc       service.BalanceService
+	expiryPolicy     vo.ExpiryPolicy
+	transactor       appport.Transactor
+	clock            appport.Clock
+	log              appport.Logger
+	batchSize        int
+	fix              bool
+}
+
+// NewReconcileBalances returns the reconcile balances use case.
+func NewReconcileBalances(
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	withdrawalReader port.WithdrawalReader,
+	ledgerReader port.LedgerReader,
+	ledgerWriter port.LedgerWriter,
+	lots port.AccrualLotRepository,
+	orders port.OrdersGateway,
+	balanceSvc service.BalanceService,
+	expiryPolicy vo.ExpiryPolicy,
+	transactor appport.Transactor,
+	clock appport.Clock,
+	log appport.Logger,
+	batchSize int,
+	fix bool,
+) *ReconcileBalances {
+	return &ReconcileBalances{
+		balanceReader:    balanceReader,
+		balanceWriter:    balanceWriter,
+		withdrawalReader: withdrawalReader,
+		ledgerReader:     ledgerReader,
+		ledgerWriter:     ledgerWriter,
+		lots:             lots,
+		orders:           orders,
+		balanceSvc:       balanceSvc,
+		expiryPolicy:     expiryPolicy,
+		transactor:       transactor,
+		clock:            clock,
+		log:              log,
+		batchSize:        batchSize,
+		fix:              fix,
+	}
+}
+
+// Run checks every account in batches of user IDs and returns the number of accounts that drifted.
+// A failed account is logged and checked again on the next run.
+func (uc *ReconcileBalances) Run(ctx context.Context) (int, error) {
+	drifted := 0
+	var after vo.UserID
+	for {
+		ids, err := uc.balanceReader.ListUserIDs(ctx, after, uc.batchSize)
+		if err != nil {
+			return drifted, err
+		}
+
+		for _, userID := range ids {
+			if ctx.Err() != nil {
+				return drifted, ctx.Err()
+			}
+
+			mismatch, err := uc.reconcile(ctx, userID)
+			if err != nil {
+				uc.log.Error("reconcile account failed", "user_id", userID, "error", err)
+				continue
+			}
+			if mismatch {
+				drifted++
+			}
+		}
+
+		if len(ids) < uc.batchSize {
+			return drifted, nil
+		}
+		after = ids[len(ids)-1]
+	}
+}
+
+// reconcile compares the account with its recomputed totals and reports whether they differ.
+// Sources are read between two reads of the account; if the account changed in between,
+// the comparison may be torn and the account is left to the next run.
+// A failed correction is logged and the mismatch is still reported.
+func (uc *ReconcileBalances) reconcile(ctx context.Context, userID vo.UserID) (bool, error) {
+	acc, err := uc.balanceReader.FindByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	now := uc.clock.Now()
+	accruals, err := uc.orders.ListAccruals(ctx, userID, time.Time{}, now)
+	if err != nil {
+		return false, err
+	}
+	withdrawals, err := uc.withdrawalReader.ListByUserID(ctx, userID, port.WithdrawalFilter{})
+	if err != nil {
+		return false, err
+	}
+	entries, err := uc.ledgerReader.ListByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	check, err := uc.balanceReader.FindByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if check.Version != acc.Version {
+		uc.log.Debug("account changed during reconciliation, skipped", "user_id", userID)
+		return false, nil
+	}
+
+	var accrued vo.Points
+	for _, a := range accruals {
+		accrued += a.Amount
+	}
+	expected := uc.balanceSvc.Recompute(userID, accrued, withdrawals, entries)
+	if expected.Current == acc.Current && expected.WithdrawnTotal == acc.WithdrawnTotal {
+		return false, nil
+	}
+
+	uc.log.Error("balance mismatch",
+		"user_id", userID,
+		"current", acc.Current,
+		"expected_current", expected.Current,
+		"withdrawn", acc.WithdrawnTotal,
+		"expected_withdrawn", expected.WithdrawnTotal,
+		"fix", uc.fix,
+	)
+	if !uc.fix {
+		return true, nil
+	}
+
+	err = uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		return uc.correct(ctx, acc, expected)
+	})
+	switch {
+	case err == nil:
+		uc.log.Info("balance corrected", "user_id", userID, "current", expected.Current, "withdrawn", expected.WithdrawnTotal)
+	case errors.Is(err, application.ErrOptimisticLock):
+		uc.log.Warn("account changed before correction, skipped", "user_id", userID)
+	default:
+		uc.log.Error("correct balance failed", "user_id", userID, "error", err)
+	}
+	return true, nil
+}
+
+// correct posts the points difference as a reconciliation adjustment, keeps the lots in step with it
+// and overwrites the withdrawn total. The account version guards against concurrent changes
+// since the account was compared.
+func (uc *ReconcileBalances) correct(ctx context.Context, acc, expected *entity.BalanceAccount) error {
+	now := uc.clock.Now()
+
+	if delta := expected.Current - acc.Current; delta != 0 {
+		entry := entity.NewAdjustmentEntry(acc.UserID, entity.ReconciliationReference, delta, now)
+		if err := acc.Post(*entry); err != nil {
+			return err
+		}
+		if err := uc.ledgerWriter.Append(ctx, entry); err != nil {
+			return err
+		}
+		if err := uc.adjustLots(ctx, acc.UserID, delta, now); err != nil {
+			return err
+		}
+	}
+
+	acc.WithdrawnTotal = expected.WithdrawnTotal
+	acc.UpdatedAt = now
+	return uc.balanceWriter.Update(ctx, acc)
+}
+
+// adjustLots opens a lot for credited points and spends debited points from the oldest lots.
+func (uc *ReconcileBalances) adjustLots(ctx context.Context, userID vo.UserID, delta vo.Points, now time.Time) error {
+	if delta > 0 {
+		lot := entity.NewAccrualLot(userID, entity.ReconciliationReference, delta, now, uc.expiryPolicy.ExpiresAt(now))
+		return uc.lots.Create(ctx, lot)
+	}
+
+	active, err := uc.lots.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, lot := range entity.ConsumeFIFO(active, -delta) {
+		if err := uc.lots.Update(ctx, lot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
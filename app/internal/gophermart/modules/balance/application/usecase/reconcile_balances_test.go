@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/service"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type reconcileMocks struct {
+	balanceReader    *balanceportmocks.MockBalanceAccountReader
+	balanceWriter    *balanceportmocks.MockBalanceAccountWriter
+	withdrawalReader *balanceportmocks.MockWithdrawalReader
+	ledgerReader     *balanceportmocks.MockLedgerReader
+	ledgerWriter     *balanceportmocks.MockLedgerWriter
+	lots             *balanceportmocks.MockAccrualLotRepository
+	orders           *balanceportmocks.MockOrdersGateway
+	transactor       *appmocks.MockTransactor
+	clock            *appmocks.MockClock
+	log              *appmocks.MockLogger
+}
+
+func newTestReconcileBalances(ctrl *gomock.Controller, batchSize int, fix bool) (reconcileMocks, *ReconcileBalances) {
+	m := reconcileMocks{
+		balanceReader:    balanceportmocks.NewMockBalanceAccountReader(ctrl),
+		balanceWriter:    balanceportmocks.NewMockBalanceAccountWriter(ctrl),
+		withdrawalReader: balanceportmocks.NewMockWithdrawalReader(ctrl),
+		ledgerReader:     balanceportmocks.NewMockLedgerReader(ctrl),
+		ledgerWriter:     balanceportmocks.NewMockLedgerWriter(ctrl),
+		lots:             balanceportmocks.NewMockAccrualLotRepository(ctrl),
+		orders:           balanceportmocks.NewMockOrdersGateway(ctrl),
+		transactor:       appmocks.NewMockTransactor(ctrl),
+		clock:            appmocks.NewMockClock(ctrl),
+		log:              appmocks.NewMockLogger(ctrl),
+	}
+	uc := NewReconcileBalances(
+		m.balanceReader, m.balanceWriter, m.withdrawalReader, m.ledgerReader, m.ledgerWriter, m.lots, m.orders,
+		service.BalanceService{}, vo.ExpiryPolicy{}, m.transactor, m.clock, m.log, batchSize, fix,
+	)
+	return m, uc
+}
+
+func TestReconcileBalances_Run(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	passthrough := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+
+	// expectSources sets up an account of user 1 read twice around its sources:
+	// 100 points accrued, 30 withdrawn of which 10 refunded, 5 expired.
+	expectSources := func(m reconcileMocks, acc entity.BalanceAccount) {
+		m.clock.EXPECT().Now().Return(fixedTime).AnyTimes()
+		m.balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).DoAndReturn(
+			func(context.Context, vo.UserID) (*entity.BalanceAccount, error) {
+				a := acc
+				return &a, nil
+			},
+		).Times(2)
+		m.orders.EXPECT().ListAccruals(ctx, vo.UserID(1), time.Time{}, fixedTime).Return([]port.OrderAccrual{
+			{OrderNumber: "12345678903", Amount: 100, ProcessedAt: fixedTime},
+		}, nil)
+		m.withdrawalReader.EXPECT().ListByUserID(ctx, vo.UserID(1), port.WithdrawalFilter{}).Return([]entity.Withdrawal{
+			{UserID: 1, OrderNumber: "2377225624", Amount: 30, RefundedAmount: 10, ProcessedAt: fixedTime},
+		}, nil)
+		m.ledgerReader.EXPECT().ListByUserID(ctx, vo.UserID(1)).Return([]entity.JournalEntry{
+			*entity.NewExpiryEntry(1, "lot-1", 5, fixedTime),
+		}, nil)
+	}
+
+	t.Run("consistent account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m, uc := newTestReconcileBalances(ctrl, 10, true)
+
+		m.balanceReader.EXPECT().ListUserIDs(ctx, vo.UserID(0), 10).Return([]vo.UserID{1}, nil)
+		expectSources(m, entity.BalanceAccount{UserID: 1, Current: 75, WithdrawnTotal: 20, Version: 3})
+
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("reports mismatch without fixing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m, uc := newTestReconcileBalances(ctrl, 10, false)
+
+		m.balanceReader.EXPECT().ListUserIDs(ctx, vo.UserID(0), 10).Return([]vo.UserID{1}, nil)
+		expectSources(m, entity.BalanceAccount{UserID: 1, Current: 60, WithdrawnTotal: 20, Version: 3})
+		m.log.EXPECT().Error("balance mismatch", gomock.Any()).Do(func(_ string, args ...any) {
+			assert.Equal(t, []any{
+				"user_id", vo.UserID(1),
+				"current", vo.Points(60),
+				"expected_current", vo.Points(75),
+				"withdrawn", vo.Points(20),
+				"expected_withdrawn", vo.Points(20),
+				"fix", false,
+			}, args)
+		})
+
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("fix credits missing points", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m, uc := newTestReconcileBalances(ctrl, 10, true)
+
+		m.balanceReader.EXPECT().ListUserIDs(ctx, vo.UserID(0), 10).Return([]vo.UserID{1}, nil)
+		expectSources(m, entity.BalanceAccount{UserID: 1, Current: 60, WithdrawnTotal: 30, Version: 3})
+		m.log.EXPECT().Error("balance mismatch", gomock.Any())
+		m.transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		m.ledgerWriter.EXPECT().Append(ctx, entity.NewAdjustmentEntry(1, entity.ReconciliationReference, 15, fixedTime)).Return(nil)
+		m.lots.EXPECT().Create(ctx, entity.NewAccrualLot(1, entity.ReconciliationReference, 15, fixedTime, nil)).Return(nil)
+		m.balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(75), acc.Current)
+				assert.Equal(t, vo.Points(20), acc.WithdrawnTotal)
+				assert.Equal(t, int64(3), acc.Version)
+				return nil
+			},
+		)
+		m.log.EXPECT().Info("balance corrected", gomock.Any())
+
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("fix debits excess points from oldest lots", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m, uc := newTestReconcileBalances(ctrl, 10, true)
+
+		m.balanceReader.EXPECT().ListUserIDs(ctx, vo.UserID(0), 10).Return([]vo.UserID{1}, nil)
+		expectSources(m, entity.BalanceAccount{UserID: 1, Current: 90, WithdrawnTotal: 20, Version: 3})
+		m.log.EXPECT().Error("balance mismatch", gomock.Any())
+		m.transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		m.ledgerWriter.EXPECT().Append(ctx, entity.NewAdjustmentEntry(1, entity.ReconciliationReference, -15, fixedTime)).Return(nil)
+		m.lots.EXPECT().ListActiveByUserID(ctx, vo.UserID(1)).Return([]entity.AccrualLot{
+			{ID: 1, UserID: 1, Amount: 10, Remaining: 10},
+			{ID: 2, UserID: 1, Amount: 80, Remaining: 80},
+		}, nil)
+		m.lots.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, l *entity.AccrualLot) error {
+				assert.Equal(t, vo.Points(0), l.Remaining)
+				return nil
+			},
+		)
+		m.lots.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, l *entity.AccrualLot) error {
+				assert.Equal(t, vo.Points(75), l.Remaining)
+				return nil
+			},
+		)
+		m.balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(75), acc.Current)
+				return nil
+			},
+		)
+		m.log.EXPECT().Info("balance corrected", gomock.Any())
+
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("account changed during check is skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m, uc := newTestReconcileBalances(ctrl, 10, true)
+
+		m.clock.EXPECT().Now().Return(fixedTime)
+		m.balanceReader.EXPECT().ListUserIDs(ctx, vo.UserID(0), 10).Return([]vo.UserID{1}, nil)
+		gomock.InOrder(
+			m.balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{UserID: 1, Current: 60, Version: 3}, nil),
+			m.balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{UserID: 1, Current: 75, Version: 4}, nil),
+		)
+		m.orders.EXPECT().ListAccruals(ctx, vo.UserID(1), time.Time{}, fixedTime).Return(nil, nil)
+		m.withdrawalReader.EXPECT().ListByUserID(ctx, vo.UserID(1), port.WithdrawalFilter{}).Return(nil, nil)
+		m.ledgerReader.EXPECT().ListByUserID(ctx, vo.UserID(1)).Return(nil, nil)
+		m.log.EXPECT().Debug("account changed during reconciliation, skipped", gomock.Any())
+
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("walks accounts in batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m, uc := newTestReconcileBalances(ctrl, 1, false)
+
+		gomock.InOrder(
+			m.balanceReader.EXPECT().ListUserIDs(ctx, vo.UserID(0), 1).Return([]vo.UserID{1}, nil),
+			m.balanceReader.EXPECT().ListUserIDs(ctx, vo.UserID(1), 1).Return(nil, nil),
+		)
+		expectSources(m, entity.BalanceAccount{UserID: 1, Current: 75, WithdrawnTotal: 20, Version: 3})
+
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
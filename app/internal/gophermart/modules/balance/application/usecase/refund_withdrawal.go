@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// RefundWithdrawal reverses a withdrawal, fully or partially, when the purchase is cancelled.
+type RefundWithdrawal struct {
+	balanceReader     port.BalanceAccountReader
+	balanceWriter     port.BalanceAccountWriter
+	withdrawalReader  port.WithdrawalReader
+	withdrawalWriter  port.WithdrawalWriter
+	ledgerWriter      port.LedgerWriter
+	lotWriter         port.AccrualLotWriter
+	expiryPolicy      vo.ExpiryPolicy
+	transactor        appport.Transactor
+	validator         vo.OrderNumberValidator
+	clock             appport.Clock
+	optimisticRetries int
+}
+
+// NewRefundWithdrawal returns the refund withdrawal use case.
+func NewRefundWithdrawal(
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	withdrawalReader port.WithdrawalReader,
+	withdrawalWriter port.WithdrawalWriter,
+	ledgerWriter port.LedgerWriter,
+	lotWriter port.AccrualLotWriter,
+	expiryPolicy vo.ExpiryPolicy,
+	transactor appport.Transactor,
+	validator vo.OrderNumberValidator,
+	clock appport.Clock,
+	optimisticRetries int,
+) appport.UseCase[dto.RefundInput, dto.WithdrawalOutput] {
+	return &RefundWithdrawal{
+		balanceReader:     balanceReader,
+		balanceWriter:     balanceWriter,
+		withdrawalReader:  withdrawalReader,
+		withdrawalWriter:  withdrawalWriter,
+		ledgerWriter:      ledgerWriter,
+		lotWriter:         lotWriter,
+		expiryPolicy:      expiryPolicy,
+		transactor:        transactor,
+		validator:         validator,
+		clock:             clock,
+		optimisticRetries: optimisticRetries,
+	}
+}
+
+// Execute records the refund on the withdrawal, posts a REFUND journal entry that restores current
+// and reduces withdrawn total, and persists everything in one transaction.
+// Refunded points return as a new lot that expires as if accrued at the withdrawal time,
+// so a withdraw-and-refund cycle cannot extend the life of expiring points.
+// Concurrent refunds of one withdrawal are serialized by the account version: the loser retries
+// on a fresh copy of the withdrawal and cannot refund more than was withdrawn.
+//
+// Errors:
+//   - application.ErrInvalidOrderNumber — order number failed Luhn check
+//   - application.ErrNotFound — the user has no withdrawal for the order
+//   - application.ErrRefundExceedsWithdrawal — refund is not positive or exceeds the unrefunded amount
+func (uc *RefundWithdrawal) Execute(ctx context.Context, in dto.RefundInput) (dto.WithdrawalOutput, error) {
+	orderNumber, err := vo.NewOrderNumber(uc.validator, in.OrderNumber)
+	if err != nil {
+		return dto.WithdrawalOutput{}, application.ErrInvalidOrderNumber
+	}
+
+	var refunded *entity.Withdrawal
+	err = application.WithOptimisticRetry(uc.optimisticRetries, func() error {
+		return uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+			w, err := uc.withdrawalReader.FindByOrderNumber(ctx, in.UserID, orderNumber)
+			if err != nil {
+				return err
+			}
+
+			amount := w.Refundable()
+			if in.Sum != nil {
+				amount = *in.Sum
+			}
+
+			now := uc.clock.Now()
+			if err := w.Refund(amount, now); err != nil {
+				return err
+			}
+
+			acc, err := uc.balanceReader.FindByUserID(ctx, in.UserID)
+			if err != nil {
+				return err
+			}
+
+			entry := entity.NewRefundEntry(in.UserID, orderNumber, amount, now)
+			if err := acc.Post(*entry); err != nil {
+				return err
+			}
+
+			if err := uc.withdrawalWriter.Update(ctx, w); err != nil {
+				return err
+			}
+
+			if err := uc.ledgerWriter.Append(ctx, entry); err != nil {
+				return err
+			}
+
+			lot := entity.NewAccrualLot(in.UserID, orderNumber.String(), amount, now,
+				uc.expiryPolicy.ExpiresAt(w.ProcessedAt))
+			if err := uc.lotWriter.Create(ctx, lot); err != nil {
+				return err
+			}
+
+			if err := uc.balanceWriter.Update(ctx, acc); err != nil {
+				return err
+			}
+
+			refunded = w
+			return nil
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, entity.ErrRefundExceedsWithdrawal) {
+			return dto.WithdrawalOutput{}, application.ErrRefundExceedsWithdrawal
+		}
+		return dto.WithdrawalOutput{}, err
+	}
+
+	return newWithdrawalOutput(*refunded), nil
+}
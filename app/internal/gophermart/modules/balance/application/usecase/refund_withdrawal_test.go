@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRefundWithdrawal_Execute(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	processedAt := fixedTime.Add(-24 * time.Hour)
+	number := vo.OrderNumber("2377225624")
+	policy := vo.ExpiryPolicy{TTL: 365 * 24 * time.Hour}
+
+	passthrough := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+
+	t.Run("full refund restores balance", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		withdrawals := balanceportmocks.NewMockWithdrawalRepository(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		lotWriter := balanceportmocks.NewMockAccrualLotWriter(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(&entity.Withdrawal{
+			UserID: 1, OrderNumber: number, Amount: 200, RefundedAmount: 50, ProcessedAt: processedAt,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 100, WithdrawnTotal: 150,
+		}, nil)
+		withdrawals.EXPECT().Update(ctx, &entity.Withdrawal{
+			UserID: 1, OrderNumber: number, Amount: 200, RefundedAmount: 200, RefundedAt: &fixedTime,
+			ProcessedAt: processedAt,
+		}).Return(nil)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewRefundEntry(1, number, 150, fixedTime)).Return(nil)
+		lotWriter.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, lot *entity.AccrualLot) error {
+				expiresAt := processedAt.Add(policy.TTL)
+				assert.Equal(t, vo.Points(150), lot.Remaining)
+				assert.Equal(t, &expiresAt, lot.ExpiresAt)
+				return nil
+			},
+		)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(250), acc.Current)
+				assert.Equal(t, vo.Points(0), acc.WithdrawnTotal)
+				return nil
+			},
+		)
+
+		uc := NewRefundWithdrawal(balanceReader, balanceWriter, withdrawals, withdrawals, ledgerWriter, lotWriter,
+			policy, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		out, err := uc.Execute(ctx, dto.RefundInput{UserID: 1, OrderNumber: number.String()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, vo.Points(200), out.Refunded)
+		assert.Equal(t, "FULL", out.RefundStatus)
+		assert.Equal(t, &fixedTime, out.RefundedAt)
+	})
+
+	t.Run("partial refund", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		withdrawals := balanceportmocks.NewMockWithdrawalRepository(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		lotWriter := balanceportmocks.NewMockAccrualLotWriter(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		sum := vo.Points(30)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(&entity.Withdrawal{
+			UserID: 1, OrderNumber: number, Amount: 200,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, WithdrawnTotal: 200,
+		}, nil)
+		withdrawals.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewRefundEntry(1, number, 30, fixedTime)).Return(nil)
+		lotWriter.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		uc := NewRefundWithdrawal(balanceReader, balanceWriter, withdrawals, withdrawals, ledgerWriter, lotWriter,
+			policy, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		out, err := uc.Execute(ctx, dto.RefundInput{UserID: 1, OrderNumber: number.String(), Sum: &sum})
+
+		assert.NoError(t, err)
+		assert.Equal(t, vo.Points(30), out.Refunded)
+		assert.Equal(t, "PARTIAL", out.RefundStatus)
+	})
+
+	t.Run("refund exceeding withdrawal rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		withdrawals := balanceportmocks.NewMockWithdrawalRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		sum := vo.Points(151)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(&entity.Withdrawal{
+			UserID: 1, OrderNumber: number, Amount: 200, RefundedAmount: 50,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+
+		uc := NewRefundWithdrawal(nil, nil, withdrawals, withdrawals, nil, nil,
+			policy, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		_, err := uc.Execute(ctx, dto.RefundInput{UserID: 1, OrderNumber: number.String(), Sum: &sum})
+
+		assert.ErrorIs(t, err, application.ErrRefundExceedsWithdrawal)
+	})
+
+	t.Run("fully refunded withdrawal rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		withdrawals := balanceportmocks.NewMockWithdrawalRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(&entity.Withdrawal{
+			UserID: 1, OrderNumber: number, Amount: 200, RefundedAmount: 200,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+
+		uc := NewRefundWithdrawal(nil, nil, withdrawals, withdrawals, nil, nil,
+			policy, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		_, err := uc.Execute(ctx, dto.RefundInput{UserID: 1, OrderNumber: number.String()})
+
+		assert.ErrorIs(t, err, application.ErrRefundExceedsWithdrawal)
+	})
+
+	t.Run("withdrawal of another user is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		withdrawals := balanceportmocks.NewMockWithdrawalRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		withdrawals.EXPECT().FindByOrderNumber(ctx, vo.UserID(1), number).Return(nil, application.ErrNotFound)
+
+		uc := NewRefundWithdrawal(nil, nil, withdrawals, withdrawals, nil, nil,
+			policy, transactor, stubOrderNumberValidator{valid: true}, nil, 3)
+		_, err := uc.Execute(ctx, dto.RefundInput{UserID: 1, OrderNumber: number.String()})
+
+		assert.ErrorIs(t, err, application.ErrNotFound)
+	})
+
+	t.Run("invalid order number", func(t *testing.T) {
+		uc := NewRefundWithdrawal(nil, nil, nil, nil, nil, nil, policy, nil,
+			stubOrderNumberValidator{valid: false}, nil, 3)
+		_, err := uc.Execute(ctx, dto.RefundInput{UserID: 1, OrderNumber: "123"})
+
+		assert.ErrorIs(t, err, application.ErrInvalidOrderNumber)
+	})
+}
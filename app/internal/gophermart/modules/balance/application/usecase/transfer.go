@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/service"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// Transfer moves points from the user's balance to another user's balance.
+type Transfer struct {
+	balanceReader     port.BalanceAccountReader
+	balanceWriter     port.BalanceAccountWriter
+	withdrawalReader  port.WithdrawalReader
+	holdReader        port.HoldReader
+	ledgerReader      port.LedgerReader
+	ledgerWriter      port.LedgerWriter
+	lots              port.AccrualLotRepository
+	identity          port.IdentityGateway
+	policy            service.WithdrawalPolicy
+	expiryPolicy      vo.ExpiryPolicy
+	transactor        appport.Transactor
+	clock             appport.Clock
+	optimisticRetries int
+}
+
+// NewTransfer returns the transfer use case.
+func NewTransfer(
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	withdrawalReader port.WithdrawalReader,
+	holdReader port.HoldReader,
+	ledgerReader port.LedgerReader,
+	ledgerWriter port.LedgerWriter,
+	lots port.AccrualLotRepository,
+	identity port.IdentityGateway,
+	policy service.WithdrawalPolicy,
+	expiryPolicy vo.ExpiryPolicy,
+	transactor appport.Transactor,
+	clock appport.Clock,
+	optimisticRetries int,
+) appport.UseCase[dto.TransferInput, struct{}] {
+	return &Transfer{
+		balanceReader:     balanceReader,
+		balanceWriter:     balanceWriter,
+		withdrawalReader:  withdrawalReader,
+		holdReader:        holdReader,
+		ledgerReader:      ledgerReader,
+		ledgerWriter:      ledgerWriter,
+		lots:              lots,
+		identity:          identity,
+		policy:            policy,
+		expiryPolicy:      expiryPolicy,
+		transactor:        transactor,
+		clock:             clock,
+		optimisticRetries: optimisticRetries,
+	}
+}
+
+// Execute resolves the recipient by login and posts one TRANSFER journal entry that debits the sender
+// and credits the recipient, so the transfer shows in both users' transaction history.
+// The sender's oldest lots are spent first and move to the recipient with their expiry dates,
+// so a transfer cannot extend the life of expiring points.
+// Both accounts are updated last and in ascending user ID order: concurrent transfers in opposite
+// directions take the row locks in the same order and the loser retries instead of deadlocking.
+// The sender is held to the withdrawal limits, so points cannot leave through another account
+// past the sender's caps. The entry reference names both users and the transfer time.
+//
+// Errors:
+//   - application.ErrNotFound — no user has the recipient login, or the recipient has no balance account
+//   - application.ErrAccountNotFound — the sender has no balance account
+//   - application.ErrSelfTransfer — the recipient is the sender
+//   - application.ErrInsufficientBalance — not enough available (not held) points on the account
+//   - *application.ErrWithdrawalLimit — the transfer breaks a configured withdrawal limit
+func (uc *Transfer) Execute(ctx context.Context, in dto.TransferInput) (struct{}, error) {
+	recipientID, err := uc.identity.FindUserIDByLogin(ctx, in.RecipientLogin)
+	if err != nil {
+		return struct{}{}, err
+	}
+	if recipientID == in.UserID {
+		return struct{}{}, application.ErrSelfTransfer
+	}
+
+	err = application.WithOptimisticRetry(uc.optimisticRetries, func() error {
+		return uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+			sender, err := uc.balanceReader.FindByUserID(ctx, in.UserID)
+			if errors.Is(err, application.ErrNotFound) {
+				return application.ErrAccountNotFound
+			}
+			if err != nil {
+				return err
+			}
+			recipient, err := uc.balanceReader.FindByUserID(ctx, recipientID)
+			if err != nil {
+				return err
+			}
+
+			now := uc.clock.Now()
+			err = checkWithdrawalLimits(ctx, uc.policy, uc.withdrawalReader, uc.holdReader, uc.ledgerReader, in.UserID, in.Sum, now)
+			if err != nil {
+				return err
+			}
+
+			reference := fmt.Sprintf("transfer-%d-%d-%d", in.UserID, recipientID, now.UnixNano())
+
+			entry := entity.NewPeerTransferEntry(in.UserID, recipientID, reference, in.Sum, now)
+			if err := sender.Post(*entry); err != nil {
+				return err
+			}
+			if err := recipient.Post(*entry); err != nil {
+				return err
+			}
+
+			if err := uc.ledgerWriter.Append(ctx, entry); err != nil {
+				return err
+			}
+
+			if err := uc.moveLots(ctx, in.UserID, recipientID, in.Sum, reference, now); err != nil {
+				return err
+			}
+
+			first, second := sender, recipient
+			if second.UserID < first.UserID {
+				first, second = second, first
+			}
+			if err := uc.balanceWriter.Update(ctx, first); err != nil {
+				return err
+			}
+			return uc.balanceWriter.Update(ctx, second)
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, entity.ErrInsufficientBalance) {
+			return struct{}{}, application.ErrInsufficientBalance
+		}
+		var limitErr *service.WithdrawalLimitError
+		if errors.As(err, &limitErr) {
+			return struct{}{}, &application.ErrWithdrawalLimit{Rule: string(limitErr.Rule), RetryAfter: limitErr.RetryAfter}
+		}
+		return struct{}{}, err
+	}
+
+	return struct{}{}, nil
+}
+
+// moveLots spends amount points from the sender's lots in FIFO order and opens matching lots for the recipient.
+func (uc *Transfer) moveLots(
+	ctx context.Context,
+	from, to vo.UserID,
+	amount vo.Points,
+	reference string,
+	now time.Time,
+) error {
+	active, err := uc.lots.ListActiveByUserID(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	changed, moved := entity.MoveFIFO(active, amount, to, reference, now)
+	for _, lot := range changed {
+		if err := uc.lots.Update(ctx, lot); err != nil {
+			return err
+		}
+	}
+
+	var movedTotal vo.Points
+	for _, lot := range moved {
+		movedTotal += lot.Amount
+	}
+	// Lots never hold more than the account; credit the points they are missing as a fresh lot.
+	// It has no source lot to inherit a date from, so it gets the full TTL of points accrued now.
+	if rest := amount - movedTotal; rest > 0 {
+		fullTTL := uc.expiryPolicy.ExpiresAt(now)
+		moved = append(moved, entity.NewAccrualLot(to, reference, rest, now, fullTTL))
+	}
+
+	for _, lot := range moved {
+		if err := uc.lots.Create(ctx, lot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
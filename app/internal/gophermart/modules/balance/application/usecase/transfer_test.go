@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/service"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransfer_Execute(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := fixedTime.Add(24 * time.Hour)
+	policy := vo.ExpiryPolicy{TTL: 365 * 24 * time.Hour}
+	in := dto.TransferInput{UserID: 2, RecipientLogin: "bob", Sum: 150}
+	reference := fmt.Sprintf("transfer-2-1-%d", fixedTime.UnixNano())
+
+	passthrough := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+
+	t.Run("moves points and lots to recipient", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		identity := balanceportmocks.NewMockIdentityGateway(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		identity.EXPECT().FindUserIDByLogin(ctx, "bob").Return(vo.UserID(1), nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(2)).Return(&entity.BalanceAccount{
+			UserID: 2, Current: 500, Version: 3,
+		}, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 10, Version: 7,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewPeerTransferEntry(2, 1, reference, 150, fixedTime)).Return(nil)
+		lots.EXPECT().ListActiveByUserID(ctx, vo.UserID(2)).Return([]entity.AccrualLot{
+			{ID: 5, UserID: 2, Amount: 100, Remaining: 100, ExpiresAt: &expiresAt},
+			{ID: 6, UserID: 2, Amount: 400, Remaining: 400},
+		}, nil)
+		lots.EXPECT().Update(ctx, &entity.AccrualLot{ID: 5, UserID: 2, Amount: 100, Remaining: 0, ExpiresAt: &expiresAt}).Return(nil)
+		lots.EXPECT().Update(ctx, &entity.AccrualLot{ID: 6, UserID: 2, Amount: 400, Remaining: 350}).Return(nil)
+		lots.EXPECT().Create(ctx, entity.NewAccrualLot(1, reference, 100, fixedTime, &expiresAt)).Return(nil)
+		lots.EXPECT().Create(ctx, entity.NewAccrualLot(1, reference, 50, fixedTime, nil)).Return(nil)
+		// The account with the lower user ID is always written first.
+		gomock.InOrder(
+			balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, acc *entity.BalanceAccount) error {
+					assert.Equal(t, vo.UserID(1), acc.UserID)
+					assert.Equal(t, vo.Points(160), acc.Current)
+					return nil
+				},
+			),
+			balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, acc *entity.BalanceAccount) error {
+					assert.Equal(t, vo.UserID(2), acc.UserID)
+					assert.Equal(t, vo.Points(350), acc.Current)
+					assert.Equal(t, vo.Points(0), acc.WithdrawnTotal)
+					return nil
+				},
+			),
+		)
+
+		uc := NewTransfer(balanceReader, balanceWriter, nil, nil, nil, ledgerWriter, lots, identity, service.WithdrawalPolicy{}, policy, transactor, clk, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("points missing from lots get the full TTL", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		identity := balanceportmocks.NewMockIdentityGateway(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		identity.EXPECT().FindUserIDByLogin(ctx, "bob").Return(vo.UserID(1), nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(2)).Return(&entity.BalanceAccount{UserID: 2, Current: 500}, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{UserID: 1}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		ledgerWriter.EXPECT().Append(ctx, gomock.Any()).Return(nil)
+		lots.EXPECT().ListActiveByUserID(ctx, vo.UserID(2)).Return([]entity.AccrualLot{
+			{ID: 5, UserID: 2, Amount: 100, Remaining: 100, ExpiresAt: &expiresAt},
+		}, nil)
+		lots.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		lots.EXPECT().Create(ctx, entity.NewAccrualLot(1, reference, 100, fixedTime, &expiresAt)).Return(nil)
+		lots.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, lot *entity.AccrualLot) error {
+				assert.Equal(t, vo.Points(50), lot.Remaining)
+				require.NotNil(t, lot.ExpiresAt)
+				assert.Equal(t, fixedTime.Add(policy.TTL), *lot.ExpiresAt)
+				return nil
+			},
+		)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).Return(nil).Times(2)
+
+		uc := NewTransfer(balanceReader, balanceWriter, nil, nil, nil, ledgerWriter, lots, identity, service.WithdrawalPolicy{}, policy, transactor, clk, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("held points cannot be transferred", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		identity := balanceportmocks.NewMockIdentityGateway(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		identity.EXPECT().FindUserIDByLogin(ctx, "bob").Return(vo.UserID(1), nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(2)).Return(&entity.BalanceAccount{
+			UserID: 2, Current: 200, Held: 100,
+		}, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{UserID: 1}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+
+		uc := NewTransfer(balanceReader, nil, nil, nil, nil, nil, nil, identity, service.WithdrawalPolicy{}, policy, transactor, clk, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrInsufficientBalance)
+	})
+
+	t.Run("sender is held to withdrawal limits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		withdrawals := balanceportmocks.NewMockWithdrawalReader(ctrl)
+		holds := balanceportmocks.NewMockHoldReader(ctrl)
+		ledgerReader := balanceportmocks.NewMockLedgerReader(ctrl)
+		identity := balanceportmocks.NewMockIdentityGateway(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		limits := service.WithdrawalPolicy{Limits: vo.WithdrawalLimits{DailyCap: 300}}
+		dayStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		identity.EXPECT().FindUserIDByLogin(ctx, "bob").Return(vo.UserID(1), nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(2)).Return(&entity.BalanceAccount{UserID: 2, Current: 500}, nil)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{UserID: 1}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		withdrawals.EXPECT().ListByUserID(ctx, vo.UserID(2), port.WithdrawalFilter{From: &dayStart}).Return(nil, nil)
+		holds.EXPECT().ListActiveByUserID(ctx, vo.UserID(2)).Return(nil, nil)
+		ledgerReader.EXPECT().ListByUserIDBetween(ctx, vo.UserID(2), dayStart, fixedTime).Return([]entity.JournalEntry{
+			*entity.NewPeerTransferEntry(2, 3, "transfer-2-3", 100, fixedTime.Add(-time.Hour)),
+			// Received points do not count.
+			*entity.NewPeerTransferEntry(3, 2, "transfer-3-2", 1000, fixedTime.Add(-time.Hour)),
+		}, nil)
+
+		uc := NewTransfer(balanceReader, nil, withdrawals, holds, ledgerReader, nil, nil, identity, limits, policy, transactor, clk, 3)
+		_, err := uc.Execute(ctx, dto.TransferInput{UserID: 2, RecipientLogin: "bob", Sum: 201})
+
+		var limitErr *application.ErrWithdrawalLimit
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, "DAILY_CAP", limitErr.Rule)
+	})
+
+	t.Run("sender without balance account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		identity := balanceportmocks.NewMockIdentityGateway(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+
+		identity.EXPECT().FindUserIDByLogin(ctx, "bob").Return(vo.UserID(1), nil)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(2)).Return(nil, application.ErrNotFound)
+
+		uc := NewTransfer(balanceReader, nil, nil, nil, nil, nil, nil, identity, service.WithdrawalPolicy{}, policy, transactor, nil, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrAccountNotFound)
+		assert.NotErrorIs(t, err, application.ErrNotFound, "a missing sender is not a missing recipient")
+	})
+
+	t.Run("transfer to yourself rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		identity := balanceportmocks.NewMockIdentityGateway(ctrl)
+		identity.EXPECT().FindUserIDByLogin(ctx, "bob").Return(vo.UserID(2), nil)
+
+		uc := NewTransfer(nil, nil, nil, nil, nil, nil, nil, identity, service.WithdrawalPolicy{}, policy, nil, nil, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrSelfTransfer)
+	})
+
+	t.Run("unknown recipient", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		identity := balanceportmocks.NewMockIdentityGateway(ctrl)
+		identity.EXPECT().FindUserIDByLogin(ctx, "bob").Return(vo.UserID(0), application.ErrNotFound)
+
+		uc := NewTransfer(nil, nil, nil, nil, nil, nil, nil, identity, service.WithdrawalPolicy{}, policy, nil, nil, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrNotFound)
+	})
+}
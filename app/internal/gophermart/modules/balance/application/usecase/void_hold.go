@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+)
+
+// VoidHold cancels an active hold and returns its points to the available balance.
+type VoidHold struct {
+	balanceReader     port.BalanceAccountReader
+	balanceWriter     port.BalanceAccountWriter
+	holds             port.HoldRepository
+	transactor        appport.Transactor
+	clock             appport.Clock
+	optimisticRetries int
+}
+
+// NewVoidHold returns the void hold use case.
+func NewVoidHold(
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	holds port.HoldRepository,
+	transactor appport.Transactor,
+	clock appport.Clock,
+	optimisticRetries int,
+) appport.UseCase[dto.HoldActionInput, dto.HoldOutput] {
+	return &VoidHold{
+		balanceReader:     balanceReader,
+		balanceWriter:     balanceWriter,
+		holds:             holds,
+		transactor:        transactor,
+		clock:             clock,
+		optimisticRetries: optimisticRetries,
+	}
+}
+
+// Execute voids the hold and releases its points in one transaction.
+//
+// Errors:
+//   - application.ErrNotFound — the user has no such hold
+//   - application.ErrHoldNotActive — the hold is already captured or voided
+func (uc *VoidHold) Execute(ctx context.Context, in dto.HoldActionInput) (dto.HoldOutput, error) {
+	var voided *entity.Hold
+	err := application.WithOptimisticRetry(uc.optimisticRetries, func() error {
+		return uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+			h, err := uc.holds.FindByID(ctx, in.HoldID)
+			if err != nil {
+				return err
+			}
+			if h.UserID != in.UserID {
+				return application.ErrNotFound
+			}
+
+			if err := voidHold(ctx, uc.balanceReader, uc.balanceWriter, uc.holds, h, uc.clock.Now()); err != nil {
+				return err
+			}
+
+			voided = h
+			return nil
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, entity.ErrHoldNotActive) {
+			return dto.HoldOutput{}, application.ErrHoldNotActive
+		}
+		return dto.HoldOutput{}, err
+	}
+
+	return newHoldOutput(*voided), nil
+}
+
+// voidHold marks h voided and releases its points on the owner's account.
+func voidHold(
+	ctx context.Context,
+	balanceReader port.BalanceAccountReader,
+	balanceWriter port.BalanceAccountWriter,
+	holdWriter port.HoldWriter,
+	h *entity.Hold,
+	now time.Time,
+) error {
+	if err := h.Void(now); err != nil {
+		return err
+	}
+
+	acc, err := balanceReader.FindByUserID(ctx, h.UserID)
+	if err != nil {
+		return err
+	}
+	acc.Release(h.Amount, now)
+
+	if err := holdWriter.Update(ctx, h); err != nil {
+		return err
+	}
+
+	return balanceWriter.Update(ctx, acc)
+}
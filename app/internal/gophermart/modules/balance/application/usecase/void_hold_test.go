@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVoidHold_Execute(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	passthrough := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+
+	t.Run("releases held points", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		holds := balanceportmocks.NewMockHoldRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		holds.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Hold{
+			ID: 7, UserID: 1, Amount: 200, Status: entity.HoldStatusActive, ExpiresAt: fixedTime.Add(time.Minute),
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: 500, Held: 200,
+		}, nil)
+		holds.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(500), acc.Current)
+				assert.Equal(t, vo.Points(0), acc.Held)
+				return nil
+			},
+		)
+
+		uc := NewVoidHold(balanceReader, balanceWriter, holds, transactor, clk, 3)
+		out, err := uc.Execute(ctx, dto.HoldActionInput{UserID: 1, HoldID: 7})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "VOIDED", out.Status)
+	})
+
+	t.Run("captured hold cannot be voided", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		holds := balanceportmocks.NewMockHoldRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(passthrough)
+		holds.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Hold{
+			ID: 7, UserID: 1, Amount: 200, Status: entity.HoldStatusCaptured,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+
+		uc := NewVoidHold(nil, nil, holds, transactor, clk, 3)
+		_, err := uc.Execute(ctx, dto.HoldActionInput{UserID: 1, HoldID: 7})
+
+		assert.ErrorIs(t, err, application.ErrHoldNotActive)
+	})
+}
@@ -2,21 +2,36 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"time"
 
 	"gophermart/internal/gophermart/application"
 	appport "gophermart/internal/gophermart/application/port"
 	"gophermart/internal/gophermart/modules/balance/application/dto"
 	"gophermart/internal/gophermart/modules/balance/application/port"
 	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/service"
 	"gophermart/internal/gophermart/modules/balance/domain/vo"
 )
 
+// errIdempotentReplay aborts the transaction of a request that was already completed under the same key.
+var errIdempotentReplay = errors.New("idempotent replay")
+
 // Withdraw handles deducting points from the user's balance for an order.
 type Withdraw struct {
 	balanceReader     port.BalanceAccountReader
 	balanceWriter     port.BalanceAccountWriter
+	withdrawalReader  port.WithdrawalReader
 	withdrawalWriter  port.WithdrawalWriter
+	ledgerReader      port.LedgerReader
+	ledgerWriter      port.LedgerWriter
+	lots              port.AccrualLotRepository
+	holds             port.HoldReader
+	idempotencyKeys   port.IdempotencyKeyRepository
+	policy            service.WithdrawalPolicy
 	transactor        appport.Transactor
 	validator         vo.OrderNumberValidator
 	clock             appport.Clock
@@ -27,7 +42,14 @@ type Withdraw struct {
 func NewWithdraw(
 	balanceReader port.BalanceAccountReader,
 	balanceWriter port.BalanceAccountWriter,
+	withdrawalReader port.WithdrawalReader,
 	withdrawalWriter port.WithdrawalWriter,
+	ledgerReader port.LedgerReader,
+	ledgerWriter port.LedgerWriter,
+	lots port.AccrualLotRepository,
+	holds port.HoldReader,
+	idempotencyKeys port.IdempotencyKeyRepository,
+	policy service.WithdrawalPolicy,
 	transactor appport.Transactor,
 	validator vo.OrderNumberValidator,
 	clock appport.Clock,
@@ -36,7 +58,14 @@ func NewWithdraw(
 	return &Withdraw{
 		balanceReader:     balanceReader,
 		balanceWriter:     balanceWriter,
+		withdrawalReader:  withdrawalReader,
 		withdrawalWriter:  withdrawalWriter,
+		ledgerReader:      ledgerReader,
+		ledgerWriter:      ledgerWriter,
+		lots:              lots,
+		holds:             holds,
+		idempotencyKeys:   idempotencyKeys,
+		policy:            policy,
 		transactor:        transactor,
 		validator:         validator,
 		clock:             clock,
@@ -44,13 +73,25 @@ func NewWithdraw(
 	}
 }
 
-// Execute validates the order number, deducts points, and creates a withdrawal record in a transaction.
+// Execute validates the order number, posts a WITHDRAWAL journal entry, spends the oldest accrual lots first,
+// and creates a withdrawal record in a transaction.
 // Retries the entire transaction on optimistic lock conflicts.
+// With an idempotency key the key is claimed in the same transaction, so only a committed withdrawal
+// is remembered; a replay of the same request returns the original result without deducting again.
+// Withdrawal limits are checked against the history, active holds and sent transfers read in the same transaction;
+// concurrent withdrawals, hold authorizations and transfers of the user conflict on the account version and the retry
+// sees the other one.
+// An order with an active hold is refused: its capture would withdraw it a second time.
+// A concurrent AuthorizeHold also updates the account, so one of the two retries and sees the other.
 //
 // Errors:
 //   - application.ErrInvalidOrderNumber — order number failed Luhn check
-//   - application.ErrInsufficientBalance — not enough points on the account
+//   - application.ErrInsufficientBalance — not enough available (not held) points on the account
 //   - application.ErrNotFound — balance account does not exist
+//   - application.ErrAlreadyExists — the order already has a withdrawal
+//   - application.ErrOrderHeld — the order has an active hold, so it is paid by capturing the hold
+//   - application.ErrIdempotencyKeyReused — idempotency key was used for a different request
+//   - *application.ErrWithdrawalLimit — the withdrawal breaks a configured withdrawal limit
 func (uc *Withdraw) Execute(ctx context.Context, in dto.WithdrawInput) (struct{}, error) {
 	orderNumber, err := vo.NewOrderNumber(uc.validator, in.OrderNumber)
 	if err != nil {
@@ -66,26 +107,152 @@ func (uc *Withdraw) Execute(ctx context.Context, in dto.WithdrawInput) (struct{}
 
 			now := uc.clock.Now()
 
-			if err := acc.Withdraw(vo.Points(in.Sum), now); err != nil {
+			if in.IdempotencyKey != "" {
+				if err := uc.claimIdempotencyKey(ctx, in, now); err != nil {
+					return err
+				}
+			}
+
+			if err := checkWithdrawalLimits(ctx, uc.policy, uc.withdrawalReader, uc.holds, uc.ledgerReader, in.UserID, in.Sum, now); err != nil {
+				return err
+			}
+
+			entry := entity.NewWithdrawalEntry(in.UserID, orderNumber, in.Sum, now)
+			if err := acc.Post(*entry); err != nil {
 				return err
 			}
 
-			w := entity.NewWithdrawal(in.UserID, orderNumber, vo.Points(in.Sum), now)
+			if err := uc.checkNotHeld(ctx, in.UserID, orderNumber); err != nil {
+				return err
+			}
+
+			w := entity.NewWithdrawal(in.UserID, orderNumber, in.Sum, now)
 
 			if err := uc.withdrawalWriter.Create(ctx, w); err != nil {
 				return err
 			}
 
+			if err := uc.ledgerWriter.Append(ctx, entry); err != nil {
+				return err
+			}
+
+			if err := consumeLots(ctx, uc.lots, in.UserID, in.Sum); err != nil {
+				return err
+			}
+
 			return uc.balanceWriter.Update(ctx, acc)
 		})
 	})
 
 	if err != nil {
+		if errors.Is(err, errIdempotentReplay) {
+			return struct{}{}, nil
+		}
 		if errors.Is(err, entity.ErrInsufficientBalance) {
 			return struct{}{}, application.ErrInsufficientBalance
 		}
+		var limitErr *service.WithdrawalLimitError
+		if errors.As(err, &limitErr) {
+			return struct{}{}, &application.ErrWithdrawalLimit{Rule: string(limitErr.Rule), RetryAfter: limitErr.RetryAfter}
+		}
 		return struct{}{}, err
 	}
 
 	return struct{}{}, nil
 }
+
+// checkWithdrawalLimits applies the withdrawal policy to spending amount, reading the user's recent withdrawals,
+// active holds and sent transfers only if a limit needs them.
+func checkWithdrawalLimits(
+	ctx context.Context,
+	policy service.WithdrawalPolicy,
+	withdrawals port.WithdrawalReader,
+	holds port.HoldReader,
+	ledger port.LedgerReader,
+	userID vo.UserID,
+	amount vo.Points,
+	now time.Time,
+) error {
+	var history []entity.Withdrawal
+	var held []entity.Hold
+	var sent []entity.JournalEntry
+	if since, ok := policy.HistorySince(now); ok {
+		var err error
+		history, err = withdrawals.ListByUserID(ctx, userID, port.WithdrawalFilter{From: &since})
+		if err != nil {
+			return err
+		}
+		held, err = holds.ListActiveByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		entries, err := ledger.ListByUserIDBetween(ctx, userID, since, now)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.Kind == entity.EntryKindTransfer && e.UserID == userID {
+				sent = append(sent, e)
+			}
+		}
+	}
+	return policy.Check(amount, history, held, sent, now)
+}
+
+// checkNotHeld returns application.ErrOrderHeld if the user has an active hold for the order.
+func (uc *Withdraw) checkNotHeld(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) error {
+	_, err := uc.holds.FindActiveByOrderNumber(ctx, userID, orderNumber)
+	switch {
+	case err == nil:
+		return application.ErrOrderHeld
+	case errors.Is(err, application.ErrNotFound):
+		return nil
+	default:
+		return err
+	}
+}
+
+// consumeLots spends amount points from the user's lots in FIFO order.
+func consumeLots(ctx context.Context, lots port.AccrualLotRepository, userID vo.UserID, amount vo.Points) error {
+	active, err := lots.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, lot := range entity.ConsumeFIFO(active, amount) {
+		if err := lots.Update(ctx, lot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// claimIdempotencyKey stores the key with the request fingerprint. If the key is already taken,
+// it returns errIdempotentReplay for the same request and application.ErrIdempotencyKeyReused otherwise.
+func (uc *Withdraw) claimIdempotencyKey(ctx context.Context, in dto.WithdrawInput, now time.Time) error {
+	fingerprint := withdrawFingerprint(in)
+	err := uc.idempotencyKeys.Create(ctx, &entity.IdempotencyKey{
+		UserID:      in.UserID,
+		Key:         in.IdempotencyKey,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+	})
+	if !errors.Is(err, application.ErrAlreadyExists) {
+		return err
+	}
+
+	stored, err := uc.idempotencyKeys.FindByKey(ctx, in.UserID, in.IdempotencyKey)
+	if err != nil {
+		return err
+	}
+	if stored.Fingerprint != fingerprint {
+		return application.ErrIdempotencyKeyReused
+	}
+	return errIdempotentReplay
+}
+
+// withdrawFingerprint identifies the withdrawal payload bound to an idempotency key.
+func withdrawFingerprint(in dto.WithdrawInput) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "withdraw:%s:%d", in.OrderNumber, in.Sum))
+	return hex.EncodeToString(sum[:])
+}
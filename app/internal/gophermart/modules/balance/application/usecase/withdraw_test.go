@@ -9,11 +9,14 @@ import (
 	"gophermart/internal/gophermart/application"
 	appmocks "gophermart/internal/gophermart/application/port/mocks"
 	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/application/port"
 	balanceportmocks "gophermart/internal/gophermart/modules/balance/application/port/mocks"
 	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/service"
 	"gophermart/internal/gophermart/modules/balance/domain/vo"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -35,6 +38,9 @@ func TestWithdraw_Execute(t *testing.T) {
 		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
 		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
 		withdrawalWriter := balanceportmocks.NewMockWithdrawalWriter(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		holds := balanceportmocks.NewMockHoldReader(ctrl)
 		transactor := appmocks.NewMockTransactor(ctrl)
 		validator := stubOrderNumberValidator{valid: true}
 		clk := appmocks.NewMockClock(ctrl)
@@ -45,13 +51,27 @@ func TestWithdraw_Execute(t *testing.T) {
 			},
 		)
 		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
-			Current: vo.Points(500),
+			UserID: 1, Current: vo.Points(500),
 		}, nil)
 		clk.EXPECT().Now().Return(fixedTime)
+		holds.EXPECT().FindActiveByOrderNumber(ctx, vo.UserID(1), vo.OrderNumber("2377225624")).Return(nil, application.ErrNotFound)
 		withdrawalWriter.EXPECT().Create(ctx, gomock.Any()).Return(nil)
-		balanceWriter.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		ledgerWriter.EXPECT().Append(ctx, entity.NewWithdrawalEntry(1, "2377225624", 200, fixedTime)).Return(nil)
+		lots.EXPECT().ListActiveByUserID(ctx, vo.UserID(1)).Return([]entity.AccrualLot{
+			{ID: 1, UserID: 1, Amount: 150, Remaining: 150},
+			{ID: 2, UserID: 1, Amount: 400, Remaining: 350},
+		}, nil)
+		lots.EXPECT().Update(ctx, &entity.AccrualLot{ID: 1, UserID: 1, Amount: 150, Remaining: 0}).Return(nil)
+		lots.EXPECT().Update(ctx, &entity.AccrualLot{ID: 2, UserID: 1, Amount: 400, Remaining: 300}).Return(nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, acc *entity.BalanceAccount) error {
+				assert.Equal(t, vo.Points(300), acc.Current)
+				assert.Equal(t, vo.Points(200), acc.WithdrawnTotal)
+				return nil
+			},
+		)
 
-		uc := NewWithdraw(balanceReader, balanceWriter, withdrawalWriter, transactor, validator, clk, 3)
+		uc := NewWithdraw(balanceReader, balanceWriter, nil, withdrawalWriter, nil, ledgerWriter, lots, holds, nil, service.WithdrawalPolicy{}, transactor, validator, clk, 3)
 		_, err := uc.Execute(ctx, dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 200})
 
 		assert.NoError(t, err)
@@ -60,7 +80,7 @@ func TestWithdraw_Execute(t *testing.T) {
 	t.Run("invalid order number", func(t *testing.T) {
 		validator := stubOrderNumberValidator{valid: false}
 
-		uc := NewWithdraw(nil, nil, nil, nil, validator, nil, 3)
+		uc := NewWithdraw(nil, nil, nil, nil, nil, nil, nil, nil, nil, service.WithdrawalPolicy{}, nil, validator, nil, 3)
 		_, err := uc.Execute(ctx, dto.WithdrawInput{UserID: 1, OrderNumber: "123", Sum: 100})
 
 		assert.ErrorIs(t, err, application.ErrInvalidOrderNumber)
@@ -80,11 +100,11 @@ func TestWithdraw_Execute(t *testing.T) {
 			},
 		)
 		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
-			Current: vo.Points(50),
+			UserID: 1, Current: vo.Points(50),
 		}, nil)
 		clk.EXPECT().Now().Return(fixedTime)
 
-		uc := NewWithdraw(balanceReader, nil, nil, transactor, validator, clk, 3)
+		uc := NewWithdraw(balanceReader, nil, nil, nil, nil, nil, nil, nil, nil, service.WithdrawalPolicy{}, transactor, validator, clk, 3)
 		_, err := uc.Execute(ctx, dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 200})
 
 		assert.ErrorIs(t, err, application.ErrInsufficientBalance)
@@ -104,9 +124,203 @@ func TestWithdraw_Execute(t *testing.T) {
 		)
 		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(nil, errors.New("db error"))
 
-		uc := NewWithdraw(balanceReader, nil, nil, transactor, validator, nil, 3)
+		uc := NewWithdraw(balanceReader, nil, nil, nil, nil, nil, nil, nil, nil, service.WithdrawalPolicy{}, transactor, validator, nil, 3)
 		_, err := uc.Execute(ctx, dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 200})
 
 		assert.Error(t, err)
 	})
+
+	t.Run("order with active hold rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		holds := balanceportmocks.NewMockHoldReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: vo.Points(500), Held: vo.Points(200),
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		holds.EXPECT().FindActiveByOrderNumber(ctx, vo.UserID(1), vo.OrderNumber("2377225624")).Return(&entity.Hold{
+			ID: 7, UserID: 1, OrderNumber: "2377225624", Amount: 200, Status: entity.HoldStatusActive,
+		}, nil)
+
+		uc := NewWithdraw(balanceReader, nil, nil, nil, nil, nil, nil, holds, nil, service.WithdrawalPolicy{}, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		_, err := uc.Execute(ctx, dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 200})
+
+		assert.ErrorIs(t, err, application.ErrOrderHeld)
+	})
+
+	t.Run("idempotency key stored with withdrawal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		balanceWriter := balanceportmocks.NewMockBalanceAccountWriter(ctrl)
+		withdrawalWriter := balanceportmocks.NewMockWithdrawalWriter(ctrl)
+		ledgerWriter := balanceportmocks.NewMockLedgerWriter(ctrl)
+		lots := balanceportmocks.NewMockAccrualLotRepository(ctrl)
+		holds := balanceportmocks.NewMockHoldReader(ctrl)
+		keys := balanceportmocks.NewMockIdempotencyKeyRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		in := dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 200, IdempotencyKey: "key-1"}
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: vo.Points(500),
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		keys.EXPECT().Create(ctx, &entity.IdempotencyKey{
+			UserID: 1, Key: "key-1", Fingerprint: withdrawFingerprint(in), CreatedAt: fixedTime,
+		}).Return(nil)
+		holds.EXPECT().FindActiveByOrderNumber(ctx, vo.UserID(1), vo.OrderNumber("2377225624")).Return(nil, application.ErrNotFound)
+		withdrawalWriter.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		ledgerWriter.EXPECT().Append(ctx, gomock.Any()).Return(nil)
+		lots.EXPECT().ListActiveByUserID(ctx, vo.UserID(1)).Return(nil, nil)
+		balanceWriter.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		uc := NewWithdraw(balanceReader, balanceWriter, nil, withdrawalWriter, nil, ledgerWriter, lots, holds, keys, service.WithdrawalPolicy{}, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("replay returns original result without withdrawing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		keys := balanceportmocks.NewMockIdempotencyKeyRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		// The balance is already spent by the original request: a replay must not fail with 402.
+		in := dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 200, IdempotencyKey: "key-1"}
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: vo.Points(0),
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		keys.EXPECT().Create(ctx, gomock.Any()).Return(application.ErrAlreadyExists)
+		keys.EXPECT().FindByKey(ctx, vo.UserID(1), "key-1").Return(&entity.IdempotencyKey{
+			UserID: 1, Key: "key-1", Fingerprint: withdrawFingerprint(in),
+		}, nil)
+
+		uc := NewWithdraw(balanceReader, nil, nil, nil, nil, nil, nil, nil, keys, service.WithdrawalPolicy{}, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("key reused with different payload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		keys := balanceportmocks.NewMockIdempotencyKeyRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		in := dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 300, IdempotencyKey: "key-1"}
+		original := in
+		original.Sum = 200
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: vo.Points(500),
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		keys.EXPECT().Create(ctx, gomock.Any()).Return(application.ErrAlreadyExists)
+		keys.EXPECT().FindByKey(ctx, vo.UserID(1), "key-1").Return(&entity.IdempotencyKey{
+			UserID: 1, Key: "key-1", Fingerprint: withdrawFingerprint(original),
+		}, nil)
+
+		uc := NewWithdraw(balanceReader, nil, nil, nil, nil, nil, nil, nil, keys, service.WithdrawalPolicy{}, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("limit checked against recent withdrawals and active holds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		withdrawalReader := balanceportmocks.NewMockWithdrawalReader(ctrl)
+		holds := balanceportmocks.NewMockHoldReader(ctrl)
+		ledgerReader := balanceportmocks.NewMockLedgerReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		policy := service.WithdrawalPolicy{Limits: vo.WithdrawalLimits{DailyCap: 500}}
+		dayStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: vo.Points(1000),
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		withdrawalReader.EXPECT().ListByUserID(ctx, vo.UserID(1), port.WithdrawalFilter{From: &dayStart}).Return([]entity.Withdrawal{
+			{UserID: 1, OrderNumber: "12345678903", Amount: 200, ProcessedAt: fixedTime.Add(-time.Hour)},
+		}, nil)
+		holds.EXPECT().ListActiveByUserID(ctx, vo.UserID(1)).Return([]entity.Hold{
+			{UserID: 1, OrderNumber: "79927398713", Amount: 200, Status: entity.HoldStatusActive, CreatedAt: fixedTime.Add(-time.Hour)},
+		}, nil)
+		ledgerReader.EXPECT().ListByUserIDBetween(ctx, vo.UserID(1), dayStart, fixedTime).Return(nil, nil)
+
+		uc := NewWithdraw(balanceReader, nil, withdrawalReader, nil, ledgerReader, nil, nil, holds, nil, policy, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		_, err := uc.Execute(ctx, dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 200})
+
+		var limitErr *application.ErrWithdrawalLimit
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, "DAILY_CAP", limitErr.Rule)
+	})
+
+	t.Run("sum limit needs no history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		balanceReader := balanceportmocks.NewMockBalanceAccountReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		policy := service.WithdrawalPolicy{Limits: vo.WithdrawalLimits{MaxSum: 100}}
+
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		balanceReader.EXPECT().FindByUserID(ctx, vo.UserID(1)).Return(&entity.BalanceAccount{
+			UserID: 1, Current: vo.Points(1000),
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+
+		uc := NewWithdraw(balanceReader, nil, nil, nil, nil, nil, nil, nil, nil, policy, transactor, stubOrderNumberValidator{valid: true}, clk, 3)
+		_, err := uc.Execute(ctx, dto.WithdrawInput{UserID: 1, OrderNumber: "2377225624", Sum: 200})
+
+		var limitErr *application.ErrWithdrawalLimit
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, "MAX_SUM", limitErr.Rule)
+	})
 }
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// AccrualCredit records points credited for a processed order; one per order number.
+type AccrualCredit struct {
+	UserID      vo.UserID
+	OrderNumber vo.OrderNumber
+	Amount      vo.Points
+	CreditedAt  time.Time
+}
+
+// NewAccrualCredit creates a new AccrualCredit entity.
+func NewAccrualCredit(
+	userID vo.UserID,
+	orderNumber vo.OrderNumber,
+	amount vo.Points,
+	at time.Time,
+) *AccrualCredit {
+	return &AccrualCredit{
+		UserID:      userID,
+		OrderNumber: orderNumber,
+		Amount:      amount,
+		CreditedAt:  at,
+	}
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// AccrualLot is a portion of the user's points sharing one accrual time and expiry date.
+// The remaining amounts of the user's lots add up to the account's current points.
+type AccrualLot struct {
+	ID     int64
+	UserID vo.UserID
+	// Reference is the order number of the accrual or the refunded withdrawal that created the lot.
+	Reference string
+	Amount    vo.Points
+	Remaining vo.Points
+	AccruedAt time.Time
+	// ExpiresAt is nil for points that never expire.
+	ExpiresAt *time.Time
+}
+
+// NewAccrualLot creates a lot of amount points accrued at accruedAt.
+func NewAccrualLot(
+	userID vo.UserID,
+	reference string,
+	amount vo.Points,
+	accruedAt time.Time,
+	expiresAt *time.Time,
+) *AccrualLot {
+	return &AccrualLot{
+		UserID:    userID,
+		Reference: reference,
+		Amount:    amount,
+		Remaining: amount,
+		AccruedAt: accruedAt,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// Due reports whether the lot still holds points that expired by now.
+func (l *AccrualLot) Due(now time.Time) bool {
+	return l.Remaining > 0 && l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// ExpiresBy reports whether the lot still holds points that expire by deadline.
+func (l *AccrualLot) ExpiresBy(deadline time.Time) bool {
+	return l.Remaining > 0 && l.ExpiresAt != nil && !l.ExpiresAt.After(deadline)
+}
+
+// Consume takes up to amount points from the lot and returns how many were taken.
+func (l *AccrualLot) Consume(amount vo.Points) vo.Points {
+	taken := min(amount, l.Remaining)
+	if taken <= 0 {
+		return 0
+	}
+	l.Remaining -= taken
+	return taken
+}
+
+// ConsumeFIFO spends amount points from lots ordered oldest first and returns the lots it changed.
+// Lots that hold fewer points than amount are drained without error: the account balance, not the lots,
+// decides whether a withdrawal is allowed.
+func ConsumeFIFO(lots []AccrualLot, amount vo.Points) []*AccrualLot {
+	var changed []*AccrualLot
+	for i := range lots {
+		if amount <= 0 {
+			break
+		}
+		taken := lots[i].Consume(amount)
+		if taken == 0 {
+			continue
+		}
+		amount -= taken
+		changed = append(changed, &lots[i])
+	}
+	return changed
+}
+
+// MoveFIFO spends amount points from lots like ConsumeFIFO and returns, besides the changed lots,
+// new lots of userID holding the spent points with their original expiry dates.
+// The moved lots may hold less than amount if the lots held fewer points.
+func MoveFIFO(
+	lots []AccrualLot,
+	amount vo.Points,
+	userID vo.UserID,
+	reference string,
+	at time.Time,
+) (changed, moved []*AccrualLot) {
+	for i := range lots {
+		if amount <= 0 {
+			break
+		}
+		taken := lots[i].Consume(amount)
+		if taken == 0 {
+			continue
+		}
+		amount -= taken
+		changed = append(changed, &lots[i])
+		moved = append(moved, NewAccrualLot(userID, reference, taken, at, lots[i].ExpiresAt))
+	}
+	return changed, moved
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+func TestConsumeFIFO(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	lots := []AccrualLot{
+		{ID: 1, Amount: 100, Remaining: 30, AccruedAt: now},
+		{ID: 2, Amount: 50, Remaining: 50, AccruedAt: now.Add(time.Hour)},
+		{ID: 3, Amount: 70, Remaining: 70, AccruedAt: now.Add(2 * time.Hour)},
+	}
+
+	changed := ConsumeFIFO(lots, 60)
+
+	assert.Len(t, changed, 2)
+	assert.Equal(t, int64(1), changed[0].ID)
+	assert.Equal(t, vo.Points(0), lots[0].Remaining)
+	assert.Equal(t, int64(2), changed[1].ID)
+	assert.Equal(t, vo.Points(20), lots[1].Remaining)
+	assert.Equal(t, vo.Points(70), lots[2].Remaining)
+
+	t.Run("more than lots hold drains them", func(t *testing.T) {
+		lots := []AccrualLot{{ID: 1, Amount: 10, Remaining: 10}}
+		changed := ConsumeFIFO(lots, 25)
+		assert.Len(t, changed, 1)
+		assert.Equal(t, vo.Points(0), lots[0].Remaining)
+	})
+}
+
+func TestAccrualLot_Due(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := vo.ExpiryPolicy{TTL: 24 * time.Hour}
+
+	lot := NewAccrualLot(1, "12345678903", 100, now, policy.ExpiresAt(now))
+	assert.False(t, lot.Due(now.Add(23*time.Hour)))
+	assert.True(t, lot.Due(now.Add(24*time.Hour)))
+	assert.True(t, lot.ExpiresBy(now.Add(24*time.Hour)))
+
+	lot.Consume(100)
+	assert.False(t, lot.Due(now.Add(48*time.Hour)), "empty lot has nothing to expire")
+
+	forever := NewAccrualLot(1, "12345678903", 100, now, vo.ExpiryPolicy{}.ExpiresAt(now))
+	assert.Nil(t, forever.ExpiresAt)
+	assert.False(t, forever.Due(now.Add(100*365*24*time.Hour)))
+}
+
+func TestMoveFIFO(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(time.Hour)
+	lots := []AccrualLot{
+		{ID: 1, UserID: 1, Amount: 100, Remaining: 30, AccruedAt: now, ExpiresAt: &soon},
+		{ID: 2, UserID: 1, Amount: 50, Remaining: 50, AccruedAt: now.Add(time.Hour)},
+	}
+
+	changed, moved := MoveFIFO(lots, 60, 2, "transfer", now)
+
+	assert.Len(t, changed, 2)
+	assert.Equal(t, vo.Points(0), lots[0].Remaining)
+	assert.Equal(t, vo.Points(20), lots[1].Remaining)
+	assert.Equal(t, []*AccrualLot{
+		{UserID: 2, Reference: "transfer", Amount: 30, Remaining: 30, AccruedAt: now, ExpiresAt: &soon},
+		{UserID: 2, Reference: "transfer", Amount: 30, Remaining: 30, AccruedAt: now},
+	}, moved)
+}
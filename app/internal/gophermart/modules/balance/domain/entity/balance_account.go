@@ -14,9 +14,11 @@ type BalanceAccount struct {
 	UserID         vo.UserID
 	Current        vo.Points
 	WithdrawnTotal vo.Points
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Version        int64
+	// Held is the part of Current reserved by active holds. It is not part of the journal.
+	Held      vo.Points
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Version   int64
 }
 
 // AddAccrual adds points to the account.
@@ -28,12 +30,36 @@ func (a *BalanceAccount) AddAccrual(amount vo.Points, now time.Time) {
 	a.UpdatedAt = now
 }
 
+// Available returns the points that can be withdrawn or held.
+func (a *BalanceAccount) Available() vo.Points {
+	return a.Current - a.Held
+}
+
+// Hold reserves amount points; returns ErrInsufficientBalance if fewer points are available.
+func (a *BalanceAccount) Hold(amount vo.Points, now time.Time) error {
+	if amount <= 0 {
+		return nil
+	}
+	if a.Available() < amount {
+		return ErrInsufficientBalance
+	}
+	a.Held += amount
+	a.UpdatedAt = now
+	return nil
+}
+
+// Release returns amount held points to the available balance.
+func (a *BalanceAccount) Release(amount vo.Points, now time.Time) {
+	a.Held -= min(amount, a.Held)
+	a.UpdatedAt = now
+}
+
 // Withdraw deducts points; returns ErrInsufficientBalance if balance is too low.
 func (a *BalanceAccount) Withdraw(amount vo.Points, now time.Time) error {
 	if amount <= 0 {
 		return nil
 	}
-	if a.Current < amount {
+	if a.Available() < amount {
 		return ErrInsufficientBalance
 	}
 	a.Current -= amount
@@ -41,3 +67,25 @@ func (a *BalanceAccount) Withdraw(amount vo.Points, now time.Time) error {
 	a.UpdatedAt = now
 	return nil
 }
+
+// Post applies a journal entry to the account projection.
+// Returns ErrUnbalancedEntry for a malformed entry and ErrInsufficientBalance
+// if the entry would overdraw spendable points; held points are not spendable.
+func (a *BalanceAccount) Post(e JournalEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	delta := e.PointsDelta(a.UserID)
+	if delta < 0 && a.Available()+delta < 0 {
+		return ErrInsufficientBalance
+	}
+
+	a.Current += delta
+	// A refund gives back withdrawn points, so it reduces the withdrawn total as well.
+	if e.Kind == EntryKindWithdrawal || e.Kind == EntryKindRefund {
+		a.WithdrawnTotal -= delta
+	}
+	a.UpdatedAt = e.CreatedAt
+	return nil
+}
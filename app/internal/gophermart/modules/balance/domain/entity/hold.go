@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// ErrHoldNotActive is returned when a captured, voided or expired hold is captured or voided.
+var ErrHoldNotActive = errors.New("hold is not active")
+
+// HoldStatus is the lifecycle state of a hold.
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "ACTIVE"
+	HoldStatusCaptured HoldStatus = "CAPTURED"
+	HoldStatusVoided   HoldStatus = "VOIDED"
+)
+
+// Hold reserves points for an order until the payment is captured or the hold is voided.
+// Held points stay on the account but cannot be withdrawn or reserved again.
+type Hold struct {
+	ID          int64
+	UserID      vo.UserID
+	OrderNumber vo.OrderNumber
+	Amount      vo.Points
+	Status      HoldStatus
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	// ResolvedAt is the time of capture or void; nil while the hold is active.
+	ResolvedAt *time.Time
+}
+
+// NewHold creates an active hold of amount points that expires at expiresAt.
+func NewHold(
+	userID vo.UserID,
+	orderNumber vo.OrderNumber,
+	amount vo.Points,
+	at time.Time,
+	expiresAt time.Time,
+) *Hold {
+	return &Hold{
+		UserID:      userID,
+		OrderNumber: orderNumber,
+		Amount:      amount,
+		Status:      HoldStatusActive,
+		CreatedAt:   at,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+// Expired reports whether the hold is still active past its expiry time.
+func (h *Hold) Expired(now time.Time) bool {
+	return h.Status == HoldStatusActive && !now.Before(h.ExpiresAt)
+}
+
+// Capture marks the hold captured; returns ErrHoldNotActive if it is resolved or expired.
+func (h *Hold) Capture(now time.Time) error {
+	if h.Status != HoldStatusActive || h.Expired(now) {
+		return ErrHoldNotActive
+	}
+	h.Status = HoldStatusCaptured
+	h.ResolvedAt = &now
+	return nil
+}
+
+// Void marks the hold voided; returns ErrHoldNotActive if it is already resolved.
+// An expired hold can still be voided: that is how the expiry worker releases it.
+func (h *Hold) Void(now time.Time) error {
+	if h.Status != HoldStatusActive {
+		return ErrHoldNotActive
+	}
+	h.Status = HoldStatusVoided
+	h.ResolvedAt = &now
+	return nil
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+func TestHold_Lifecycle(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("capture active hold", func(t *testing.T) {
+		h := NewHold(1, "2377225624", 100, now, now.Add(time.Minute))
+
+		assert.NoError(t, h.Capture(now))
+		assert.Equal(t, HoldStatusCaptured, h.Status)
+		assert.Equal(t, &now, h.ResolvedAt)
+		assert.ErrorIs(t, h.Void(now), ErrHoldNotActive)
+	})
+
+	t.Run("expired hold cannot be captured but can be voided", func(t *testing.T) {
+		h := NewHold(1, "2377225624", 100, now, now.Add(time.Minute))
+		later := now.Add(time.Minute)
+
+		assert.True(t, h.Expired(later))
+		assert.ErrorIs(t, h.Capture(later), ErrHoldNotActive)
+		assert.NoError(t, h.Void(later))
+		assert.Equal(t, HoldStatusVoided, h.Status)
+		assert.False(t, h.Expired(later))
+	})
+}
+
+func TestBalanceAccount_Hold(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	acc := &BalanceAccount{UserID: 1, Current: 100}
+
+	assert.NoError(t, acc.Hold(70, now))
+	assert.Equal(t, vo.Points(30), acc.Available())
+	assert.ErrorIs(t, acc.Hold(40, now), ErrInsufficientBalance)
+
+	// Held points cannot be withdrawn.
+	err := acc.Post(*NewWithdrawalEntry(1, "2377225624", 40, now))
+	assert.ErrorIs(t, err, ErrInsufficientBalance)
+
+	acc.Release(70, now)
+	assert.Equal(t, vo.Points(0), acc.Held)
+	assert.NoError(t, acc.Post(*NewWithdrawalEntry(1, "2377225624", 40, now)))
+	assert.Equal(t, vo.Points(60), acc.Available())
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// IdempotencyKey records a completed client request, so a retry with the same key
+// succeeds without executing the operation again. The operations it guards have no result
+// besides success, so no response is stored.
+type IdempotencyKey struct {
+	UserID vo.UserID
+	Key    string
+	// Fingerprint identifies the operation and its payload; a key reused with another payload is rejected.
+	Fingerprint string
+	CreatedAt   time.Time
+}
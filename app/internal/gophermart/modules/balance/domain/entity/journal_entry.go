@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// ErrUnbalancedEntry is returned when journal entry debits and credits differ or postings are malformed.
+var ErrUnbalancedEntry = errors.New("unbalanced journal entry")
+
+// EntryKind classifies journal entries by business operation.
+type EntryKind string
+
+const (
+	EntryKindAccrual    EntryKind = "ACCRUAL"
+	EntryKindWithdrawal EntryKind = "WITHDRAWAL"
+	EntryKindRefund     EntryKind = "REFUND"
+	EntryKindAdjustment EntryKind = "ADJUSTMENT"
+	EntryKindExpiry     EntryKind = "EXPIRY"
+	EntryKindTransfer   EntryKind = "TRANSFER"
+)
+
+// ReconciliationReference is the reference of adjustments posted by balance reconciliation.
+const ReconciliationReference = "reconciliation"
+
+// PostingSide is the side of a double-entry posting.
+type PostingSide string
+
+const (
+	PostingSideDebit  PostingSide = "DEBIT"
+	PostingSideCredit PostingSide = "CREDIT"
+)
+
+// LedgerAccount identifies a ledger account; user accounts are scoped by Posting.UserID.
+type LedgerAccount string
+
+const (
+	// LedgerAccountUserPoints holds user's spendable points; credits increase it.
+	LedgerAccountUserPoints LedgerAccount = "USER_POINTS"
+	// LedgerAccountAccruals is the system source of points issued for processed orders.
+	LedgerAccountAccruals LedgerAccount = "SYSTEM_ACCRUALS"
+	// LedgerAccountRedemptions is the system sink of points spent on withdrawals.
+	LedgerAccountRedemptions LedgerAccount = "SYSTEM_REDEMPTIONS"
+	// LedgerAccountAdjustments is the system counterpart of manual and reconciliation corrections.
+	LedgerAccountAdjustments LedgerAccount = "SYSTEM_ADJUSTMENTS"
+	// LedgerAccountExpirations is the system sink of expired points.
+	LedgerAccountExpirations LedgerAccount = "SYSTEM_EXPIRATIONS"
+)
+
+// Posting is a single debit or credit line of a journal entry.
+type Posting struct {
+	Account LedgerAccount
+	// UserID owns user accounts; zero for system accounts.
+	UserID vo.UserID
+	Side   PostingSide
+	Amount vo.Points
+}
+
+// JournalEntry is an immutable, balanced set of postings describing one business operation.
+type JournalEntry struct {
+	ID int64
+	// UserID is the user who initiated the operation.
+	UserID vo.UserID
+	Kind   EntryKind
+	// Reference is the business key of the operation (order number, reason).
+	Reference string
+	Postings  []Posting
+	CreatedAt time.Time
+}
+
+// NewAccrualEntry credits user points from the system accruals account.
+func NewAccrualEntry(userID vo.UserID, orderNumber vo.OrderNumber, amount vo.Points, at time.Time) *JournalEntry {
+	return newTransferEntry(EntryKindAccrual, userID, orderNumber.String(), amount, at,
+		Posting{Account: LedgerAccountAccruals},
+		Posting{Account: LedgerAccountUserPoints, UserID: userID},
+	)
+}
+
+// NewWithdrawalEntry moves user points to the system redemptions account.
+func NewWithdrawalEntry(userID vo.UserID, orderNumber vo.OrderNumber, amount vo.Points, at time.Time) *JournalEntry {
+	return newTransferEntry(EntryKindWithdrawal, userID, orderNumber.String(), amount, at,
+		Posting{Account: LedgerAccountUserPoints, UserID: userID},
+		Posting{Account: LedgerAccountRedemptions},
+	)
+}
+
+// NewRefundEntry returns refunded withdrawal points from the system redemptions account to the user.
+func NewRefundEntry(userID vo.UserID, orderNumber vo.OrderNumber, amount vo.Points, at time.Time) *JournalEntry {
+	return newTransferEntry(EntryKindRefund, userID, orderNumber.String(), amount, at,
+		Posting{Account: LedgerAccountRedemptions},
+		Posting{Account: LedgerAccountUserPoints, UserID: userID},
+	)
+}
+
+// NewExpiryEntry moves expired user points to the system expirations account.
+func NewExpiryEntry(userID vo.UserID, reference string, amount vo.Points, at time.Time) *JournalEntry {
+	return newTransferEntry(EntryKindExpiry, userID, reference, amount, at,
+		Posting{Account: LedgerAccountUserPoints, UserID: userID},
+		Posting{Account: LedgerAccountExpirations},
+	)
+}
+
+// NewPeerTransferEntry moves points from one user to another; the entry belongs to the sender.
+func NewPeerTransferEntry(from, to vo.UserID, reference string, amount vo.Points, at time.Time) *JournalEntry {
+	return newTransferEntry(EntryKindTransfer, from, reference, amount, at,
+		Posting{Account: LedgerAccountUserPoints, UserID: from},
+		Posting{Account: LedgerAccountUserPoints, UserID: to},
+	)
+}
+
+// NewAdjustmentEntry corrects user points by delta: positive credits, negative debits the user.
+func NewAdjustmentEntry(userID vo.UserID, reason string, delta vo.Points, at time.Time) *JournalEntry {
+	user := Posting{Account: LedgerAccountUserPoints, UserID: userID}
+	system := Posting{Account: LedgerAccountAdjustments}
+	if delta < 0 {
+		return newTransferEntry(EntryKindAdjustment, userID, reason, -delta, at, user, system)
+	}
+	return newTransferEntry(EntryKindAdjustment, userID, reason, delta, at, system, user)
+}
+
+// newTransferEntry builds a two-posting entry debiting from and crediting to by amount.
+func newTransferEntry(
+	kind EntryKind,
+	userID vo.UserID,
+	reference string,
+	amount vo.Points,
+	at time.Time,
+	from, to Posting,
+) *JournalEntry {
+	from.Side, from.Amount = PostingSideDebit, amount
+	to.Side, to.Amount = PostingSideCredit, amount
+	return &JournalEntry{
+		UserID:    userID,
+		Kind:      kind,
+		Reference: reference,
+		Postings:  []Posting{from, to},
+		CreatedAt: at,
+	}
+}
+
+// Validate checks that the entry has positive postings and its debits equal its credits.
+func (e JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return ErrUnbalancedEntry
+	}
+	var debit, credit vo.Points
+	for _, p := range e.Postings {
+		if p.Amount <= 0 {
+			return ErrUnbalancedEntry
+		}
+		switch p.Side {
+		case PostingSideDebit:
+			debit += p.Amount
+		case PostingSideCredit:
+			credit += p.Amount
+		default:
+			return ErrUnbalancedEntry
+		}
+	}
+	if debit != credit {
+		return ErrUnbalancedEntry
+	}
+	return nil
+}
+
+// PointsDelta returns the net change of the user's spendable points caused by the entry.
+func (e JournalEntry) PointsDelta(userID vo.UserID) vo.Points {
+	var delta vo.Points
+	for _, p := range e.Postings {
+		if p.Account != LedgerAccountUserPoints || p.UserID != userID {
+			continue
+		}
+		if p.Side == PostingSideCredit {
+			delta += p.Amount
+		} else {
+			delta -= p.Amount
+		}
+	}
+	return delta
+}
@@ -1,17 +1,33 @@
 package entity
 
 import (
+	"errors"
 	"time"
 
 	"gophermart/internal/gophermart/modules/balance/domain/vo"
 )
 
+// ErrRefundExceedsWithdrawal is returned when a refund is not positive or exceeds the unrefunded amount.
+var ErrRefundExceedsWithdrawal = errors.New("refund exceeds withdrawn amount")
+
+// RefundStatus describes how much of a withdrawal was refunded.
+type RefundStatus string
+
+const (
+	RefundStatusNone    RefundStatus = "NONE"
+	RefundStatusPartial RefundStatus = "PARTIAL"
+	RefundStatusFull    RefundStatus = "FULL"
+)
+
 // Withdrawal is points deduction event.
 type Withdrawal struct {
-	UserID      vo.UserID
-	OrderNumber vo.OrderNumber
-	Amount      vo.Points
-	ProcessedAt time.Time
+	UserID         vo.UserID
+	OrderNumber    vo.OrderNumber
+	Amount         vo.Points
+	ProcessedAt    time.Time
+	RefundedAmount vo.Points
+	// RefundedAt is the time of the last refund; nil if nothing was refunded.
+	RefundedAt *time.Time
 }
 
 // NewWithdrawal creates a new Withdrawal entity.
@@ -28,3 +44,31 @@ func NewWithdrawal(
 		ProcessedAt: at,
 	}
 }
+
+// Refundable returns the amount that can still be refunded.
+func (w *Withdrawal) Refundable() vo.Points {
+	return w.Amount - w.RefundedAmount
+}
+
+// Refund records a full or partial refund; returns ErrRefundExceedsWithdrawal
+// if amount is not positive or more than Refundable.
+func (w *Withdrawal) Refund(amount vo.Points, now time.Time) error {
+	if amount <= 0 || amount > w.Refundable() {
+		return ErrRefundExceedsWithdrawal
+	}
+	w.RefundedAmount += amount
+	w.RefundedAt = &now
+	return nil
+}
+
+// RefundStatus reports whether the withdrawal is not, partially or fully refunded.
+func (w *Withdrawal) RefundStatus() RefundStatus {
+	switch {
+	case w.RefundedAmount == 0:
+		return RefundStatusNone
+	case w.RefundedAmount < w.Amount:
+		return RefundStatusPartial
+	default:
+		return RefundStatusFull
+	}
+}
@@ -28,3 +28,42 @@ func (BalanceService) ApplyAccrual(acc *entity.BalanceAccount, amount vo.Points,
 func (BalanceService) Withdraw(acc *entity.BalanceAccount, amount vo.Points, now time.Time) error {
 	return acc.Withdraw(amount, now)
 }
+
+// Derive rebuilds the account totals of userID by replaying its journal entries in order.
+func (BalanceService) Derive(userID vo.UserID, entries []entity.JournalEntry) (*entity.BalanceAccount, error) {
+	acc := &entity.BalanceAccount{UserID: userID}
+	for _, e := range entries {
+		if err := acc.Post(e); err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
+
+// Recompute rebuilds the account totals of userID from source records: points accrued for processed orders,
+// withdrawals net of their refunds and the journal entries no other module records (expiries, transfers
+// and manual adjustments). Reconciliation adjustments are left out, so a corrected account stays corrected.
+func (BalanceService) Recompute(
+	userID vo.UserID,
+	accrued vo.Points,
+	withdrawals []entity.Withdrawal,
+	entries []entity.JournalEntry,
+) *entity.BalanceAccount {
+	acc := &entity.BalanceAccount{UserID: userID, Current: accrued}
+	for _, w := range withdrawals {
+		spent := w.Amount - w.RefundedAmount
+		acc.Current -= spent
+		acc.WithdrawnTotal += spent
+	}
+	for _, e := range entries {
+		switch e.Kind {
+		case entity.EntryKindExpiry, entity.EntryKindTransfer:
+			acc.Current += e.PointsDelta(userID)
+		case entity.EntryKindAdjustment:
+			if e.Reference != entity.ReconciliationReference {
+				acc.Current += e.PointsDelta(userID)
+			}
+		}
+	}
+	return acc
+}
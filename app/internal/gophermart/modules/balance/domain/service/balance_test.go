@@ -39,3 +39,86 @@ func TestBalanceService_Withdraw(t *testing.T) {
 	assert.Equal(t, vo.Points(60), acc.Current)
 	assert.Equal(t, vo.Points(40), acc.WithdrawnTotal)
 }
+
+func TestBalanceService_Derive(t *testing.T) {
+	svc := BalanceService{}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	entries := []entity.JournalEntry{
+		*entity.NewAccrualEntry(1, "12345678903", 100, now),
+		*entity.NewWithdrawalEntry(1, "2377225624", 30, now.Add(time.Minute)),
+		*entity.NewAdjustmentEntry(1, "manual", -5, now.Add(2*time.Minute)),
+		*entity.NewExpiryEntry(1, "lot-1", 15, now.Add(3*time.Minute)),
+	}
+
+	acc, err := svc.Derive(1, entries)
+
+	assert.NoError(t, err)
+	assert.Equal(t, vo.Points(50), acc.Current)
+	assert.Equal(t, vo.Points(30), acc.WithdrawnTotal)
+	assert.Equal(t, now.Add(3*time.Minute), acc.UpdatedAt)
+
+	t.Run("overdraft rejected", func(t *testing.T) {
+		_, err := svc.Derive(1, []entity.JournalEntry{*entity.NewWithdrawalEntry(1, "2377225624", 1, now)})
+		assert.ErrorIs(t, err, entity.ErrInsufficientBalance)
+	})
+
+	t.Run("refund restores current and reduces withdrawn", func(t *testing.T) {
+		acc, err := svc.Derive(1, []entity.JournalEntry{
+			*entity.NewAccrualEntry(1, "12345678903", 100, now),
+			*entity.NewWithdrawalEntry(1, "2377225624", 60, now),
+			*entity.NewRefundEntry(1, "2377225624", 20, now),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, vo.Points(60), acc.Current)
+		assert.Equal(t, vo.Points(40), acc.WithdrawnTotal)
+	})
+
+	t.Run("transfer moves points between users", func(t *testing.T) {
+		entries := []entity.JournalEntry{
+			*entity.NewAccrualEntry(1, "12345678903", 100, now),
+			*entity.NewPeerTransferEntry(1, 2, "transfer-1-2", 40, now),
+		}
+
+		sender, err := svc.Derive(1, entries)
+		assert.NoError(t, err)
+		assert.Equal(t, vo.Points(60), sender.Current)
+		assert.Equal(t, vo.Points(0), sender.WithdrawnTotal)
+
+		recipient, err := svc.Derive(2, entries)
+		assert.NoError(t, err)
+		assert.Equal(t, vo.Points(40), recipient.Current)
+	})
+
+	t.Run("unbalanced entry rejected", func(t *testing.T) {
+		e := entity.NewAccrualEntry(1, "12345678903", 100, now)
+		e.Postings[0].Amount = 90
+		_, err := svc.Derive(1, []entity.JournalEntry{*e})
+		assert.ErrorIs(t, err, entity.ErrUnbalancedEntry)
+	})
+}
+
+func TestBalanceService_Recompute(t *testing.T) {
+	svc := BalanceService{}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	withdrawals := []entity.Withdrawal{
+		{UserID: 1, OrderNumber: "2377225624", Amount: 30, ProcessedAt: now},
+		{UserID: 1, OrderNumber: "12345678903", Amount: 20, ProcessedAt: now, RefundedAmount: 5},
+	}
+	entries := []entity.JournalEntry{
+		*entity.NewAccrualEntry(1, "79927398713", 100, now),
+		*entity.NewWithdrawalEntry(1, "2377225624", 30, now),
+		*entity.NewExpiryEntry(1, "lot-1", 10, now),
+		*entity.NewPeerTransferEntry(1, 2, "transfer-1-2", 15, now),
+		*entity.NewPeerTransferEntry(3, 1, "transfer-3-1", 7, now),
+		*entity.NewAdjustmentEntry(1, "manual", 3, now),
+		*entity.NewAdjustmentEntry(1, entity.ReconciliationReference, 50, now),
+	}
+
+	acc := svc.Recompute(1, 100, withdrawals, entries)
+
+	assert.Equal(t, vo.UserID(1), acc.UserID)
+	assert.Equal(t, vo.Points(100-30-15-10-15+7+3), acc.Current)
+	assert.Equal(t, vo.Points(45), acc.WithdrawnTotal)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// WithdrawalLimitError reports the limit a withdrawal breaks.
+type WithdrawalLimitError struct {
+	Rule vo.WithdrawalRule
+	// RetryAfter is how long until the cooldown allows the next withdrawal; 0 for other rules.
+	RetryAfter time.Duration
+}
+
+func (e *WithdrawalLimitError) Error() string {
+	return fmt.Sprintf("withdrawal limit %s exceeded", e.Rule)
+}
+
+// WithdrawalPolicy checks withdrawals against the configured limits.
+type WithdrawalPolicy struct {
+	Limits vo.WithdrawalLimits
+}
+
+// HistorySince returns the earliest processing time of the user's withdrawals and transfers that can affect
+// a withdrawal at now.
+// It returns false if no limit depends on the withdrawal history or active holds.
+func (p WithdrawalPolicy) HistorySince(now time.Time) (time.Time, bool) {
+	l := p.Limits
+	if l.DailyCap <= 0 && l.MonthlyCap <= 0 && l.Cooldown <= 0 {
+		return time.Time{}, false
+	}
+
+	since := now
+	if l.DailyCap > 0 {
+		since = dayStart(now)
+	}
+	if l.MonthlyCap > 0 {
+		since = monthStart(now)
+	}
+	if l.Cooldown > 0 {
+		since = minTime(since, now.Add(-l.Cooldown))
+	}
+	return since, true
+}
+
+// Check returns a *WithdrawalLimitError if withdrawing, holding or transferring amount at now breaks a limit.
+// history holds the user's withdrawals processed since HistorySince(now), held the user's active holds
+// and sent the TRANSFER entries the user sent since then.
+// An active hold is a withdrawal in flight: its amount counts toward the caps of the current period
+// and its authorization starts the cooldown. A sent transfer counts like a withdrawal: points moved
+// to another account leave the user as surely as withdrawn ones.
+func (p WithdrawalPolicy) Check(
+	amount vo.Points,
+	history []entity.Withdrawal,
+	held []entity.Hold,
+	sent []entity.JournalEntry,
+	now time.Time,
+) error {
+	l := p.Limits
+	if l.MinSum > 0 && amount < l.MinSum {
+		return &WithdrawalLimitError{Rule: vo.WithdrawalRuleMinSum}
+	}
+	if l.MaxSum > 0 && amount > l.MaxSum {
+		return &WithdrawalLimitError{Rule: vo.WithdrawalRuleMaxSum}
+	}
+
+	if l.Cooldown > 0 {
+		var last time.Time
+		for _, w := range history {
+			if w.ProcessedAt.After(last) {
+				last = w.ProcessedAt
+			}
+		}
+		for _, h := range held {
+			if h.CreatedAt.After(last) {
+				last = h.CreatedAt
+			}
+		}
+		for _, e := range sent {
+			if e.CreatedAt.After(last) {
+				last = e.CreatedAt
+			}
+		}
+		if next := last.Add(l.Cooldown); now.Before(next) {
+			return &WithdrawalLimitError{Rule: vo.WithdrawalRuleCooldown, RetryAfter: next.Sub(now)}
+		}
+	}
+
+	day, month := dayStart(now), monthStart(now)
+	var daily, monthly vo.Points
+	for _, w := range history {
+		spent := w.Amount - w.RefundedAmount
+		if !w.ProcessedAt.Before(month) {
+			monthly += spent
+		}
+		if !w.ProcessedAt.Before(day) {
+			daily += spent
+		}
+	}
+	for _, h := range held {
+		daily += h.Amount
+		monthly += h.Amount
+	}
+	for _, e := range sent {
+		spent := -e.PointsDelta(e.UserID)
+		if !e.CreatedAt.Before(month) {
+			monthly += spent
+		}
+		if !e.CreatedAt.Before(day) {
+			daily += spent
+		}
+	}
+	if l.DailyCap > 0 && daily+amount > l.DailyCap {
+		return &WithdrawalLimitError{Rule: vo.WithdrawalRuleDailyCap}
+	}
+	if l.MonthlyCap > 0 && monthly+amount > l.MonthlyCap {
+		return &WithdrawalLimitError{Rule: vo.WithdrawalRuleMonthlyCap}
+	}
+	return nil
+}
+
+func dayStart(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func monthStart(t time.Time) time.Time {
+	y, m, _ := t.UTC().Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
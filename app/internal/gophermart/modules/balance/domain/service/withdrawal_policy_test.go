@@ -0,0 +1,139 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gophermart/internal/gophermart/modules/balance/domain/entity"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+func TestWithdrawalPolicy_Check(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	limits := vo.WithdrawalLimits{
+		MinSum:     1000,
+		MaxSum:     50000,
+		DailyCap:   60000,
+		MonthlyCap: 100000,
+		Cooldown:   time.Minute,
+	}
+	withdrawal := func(amount, refunded vo.Points, at time.Time) entity.Withdrawal {
+		return entity.Withdrawal{UserID: 1, Amount: amount, RefundedAmount: refunded, ProcessedAt: at}
+	}
+	hold := func(amount vo.Points, at time.Time) entity.Hold {
+		return entity.Hold{UserID: 1, Amount: amount, Status: entity.HoldStatusActive, CreatedAt: at}
+	}
+	transfer := func(amount vo.Points, at time.Time) entity.JournalEntry {
+		return *entity.NewPeerTransferEntry(1, 2, "transfer", amount, at)
+	}
+
+	tests := []struct {
+		name       string
+		amount     vo.Points
+		history    []entity.Withdrawal
+		held       []entity.Hold
+		sent       []entity.JournalEntry
+		wantRule   vo.WithdrawalRule
+		retryAfter time.Duration
+	}{
+		{name: "within limits", amount: 10000, history: []entity.Withdrawal{
+			withdrawal(40000, 0, now.Add(-time.Hour)),
+		}},
+		{name: "below minimum", amount: 999, wantRule: vo.WithdrawalRuleMinSum},
+		{name: "above maximum", amount: 50001, wantRule: vo.WithdrawalRuleMaxSum},
+		{name: "cooldown", amount: 1000, history: []entity.Withdrawal{
+			withdrawal(1000, 0, now.Add(-2*time.Minute)),
+			withdrawal(1000, 0, now.Add(-20*time.Second)),
+		}, wantRule: vo.WithdrawalRuleCooldown, retryAfter: 40 * time.Second},
+		{name: "daily cap", amount: 20001, history: []entity.Withdrawal{
+			withdrawal(40000, 0, now.Add(-time.Hour)),
+		}, wantRule: vo.WithdrawalRuleDailyCap},
+		{name: "active holds count towards the daily cap", amount: 20001, history: []entity.Withdrawal{
+			withdrawal(30000, 0, now.Add(-time.Hour)),
+		}, held: []entity.Hold{
+			hold(10000, now.Add(-2*time.Hour)),
+		}, wantRule: vo.WithdrawalRuleDailyCap},
+		{name: "active holds count towards the monthly cap", amount: 20000, history: []entity.Withdrawal{
+			withdrawal(50000, 0, now.Add(-5*24*time.Hour)),
+		}, held: []entity.Hold{
+			hold(40000, now.Add(-24*time.Hour)),
+		}, wantRule: vo.WithdrawalRuleMonthlyCap},
+		{name: "hold authorization starts the cooldown", amount: 1000, held: []entity.Hold{
+			hold(1000, now.Add(-30*time.Second)),
+		}, wantRule: vo.WithdrawalRuleCooldown, retryAfter: 30 * time.Second},
+		{name: "sent transfers count towards the daily cap", amount: 20001, history: []entity.Withdrawal{
+			withdrawal(30000, 0, now.Add(-time.Hour)),
+		}, sent: []entity.JournalEntry{
+			transfer(10000, now.Add(-2*time.Hour)),
+		}, wantRule: vo.WithdrawalRuleDailyCap},
+		{name: "sent transfers count towards the monthly cap", amount: 20000, sent: []entity.JournalEntry{
+			transfer(90000, now.Add(-5*24*time.Hour)),
+		}, wantRule: vo.WithdrawalRuleMonthlyCap},
+		{name: "sent transfer starts the cooldown", amount: 1000, sent: []entity.JournalEntry{
+			transfer(1000, now.Add(-10*time.Second)),
+		}, wantRule: vo.WithdrawalRuleCooldown, retryAfter: 50 * time.Second},
+		{name: "refunds free the daily cap", amount: 30000, history: []entity.Withdrawal{
+			withdrawal(40000, 10000, now.Add(-time.Hour)),
+		}},
+		{name: "yesterday does not count towards the daily cap", amount: 30000, history: []entity.Withdrawal{
+			withdrawal(40000, 0, now.Add(-13*time.Hour)),
+		}},
+		{name: "monthly cap", amount: 20000, history: []entity.Withdrawal{
+			withdrawal(50000, 0, now.Add(-5*24*time.Hour)),
+			withdrawal(40000, 0, now.Add(-24*time.Hour)),
+		}, wantRule: vo.WithdrawalRuleMonthlyCap},
+		{name: "previous month does not count towards the monthly cap", amount: 20000, history: []entity.Withdrawal{
+			withdrawal(50000, 0, now.Add(-15*24*time.Hour)),
+			withdrawal(40000, 0, now.Add(-24*time.Hour)),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WithdrawalPolicy{Limits: limits}.Check(tt.amount, tt.history, tt.held, tt.sent, now)
+			if tt.wantRule == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var limitErr *WithdrawalLimitError
+			require.ErrorAs(t, err, &limitErr)
+			assert.Equal(t, tt.wantRule, limitErr.Rule)
+			assert.Equal(t, tt.retryAfter, limitErr.RetryAfter)
+		})
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		err := WithdrawalPolicy{}.Check(1, []entity.Withdrawal{withdrawal(1, 0, now)}, []entity.Hold{hold(1, now)},
+			[]entity.JournalEntry{transfer(1, now)}, now)
+		assert.NoError(t, err)
+	})
+}
+
+func TestWithdrawalPolicy_HistorySince(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		limits vo.WithdrawalLimits
+		want   time.Time
+		ok     bool
+	}{
+		{name: "sum limits only", limits: vo.WithdrawalLimits{MinSum: 100, MaxSum: 1000}},
+		{name: "daily cap", limits: vo.WithdrawalLimits{DailyCap: 100}, want: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), ok: true},
+		{name: "monthly cap", limits: vo.WithdrawalLimits{DailyCap: 100, MonthlyCap: 1000}, want: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), ok: true},
+		{name: "cooldown", limits: vo.WithdrawalLimits{Cooldown: time.Hour}, want: now.Add(-time.Hour), ok: true},
+		{name: "cooldown reaching past the day", limits: vo.WithdrawalLimits{DailyCap: 100, Cooldown: 24 * time.Hour}, want: now.Add(-24 * time.Hour), ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, ok := WithdrawalPolicy{Limits: tt.limits}.HistorySince(now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, since)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package vo
+
+import "time"
+
+// ExpiryPolicy controls when accrued points expire.
+type ExpiryPolicy struct {
+	// TTL is the lifetime of accrued points (0 — points never expire).
+	TTL time.Duration
+	// SoonWindow is how far ahead expiring points are reported as expiring soon.
+	SoonWindow time.Duration
+}
+
+// ExpiresAt returns the expiry time of points accrued at accruedAt, or nil if they never expire.
+func (p ExpiryPolicy) ExpiresAt(accruedAt time.Time) *time.Time {
+	if p.TTL <= 0 {
+		return nil
+	}
+	at := accruedAt.Add(p.TTL)
+	return &at
+}
@@ -0,0 +1,26 @@
+package vo
+
+import "time"
+
+// WithdrawalRule names a withdrawal limit.
+type WithdrawalRule string
+
+const (
+	WithdrawalRuleMinSum     WithdrawalRule = "MIN_SUM"
+	WithdrawalRuleMaxSum     WithdrawalRule = "MAX_SUM"
+	WithdrawalRuleDailyCap   WithdrawalRule = "DAILY_CAP"
+	WithdrawalRuleMonthlyCap WithdrawalRule = "MONTHLY_CAP"
+	WithdrawalRuleCooldown   WithdrawalRule = "COOLDOWN"
+)
+
+// WithdrawalLimits bounds the withdrawals of a user; a zero value disables the limit.
+type WithdrawalLimits struct {
+	// MinSum and MaxSum bound a single withdrawal.
+	MinSum Points
+	MaxSum Points
+	// DailyCap and MonthlyCap bound the points withdrawn per calendar day and month in UTC, net of refunds.
+	DailyCap   Points
+	MonthlyCap Points
+	// Cooldown is the minimum time between two withdrawals of a user.
+	Cooldown time.Duration
+}
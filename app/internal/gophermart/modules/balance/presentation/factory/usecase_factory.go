@@ -10,5 +10,17 @@ import (
 type UseCaseFactory interface {
 	GetBalanceUseCase() port.UseCase[vo.UserID, dto.BalanceOutput]
 	WithdrawUseCase() port.UseCase[dto.WithdrawInput, struct{}]
-	ListWithdrawalsUseCase() port.UseCase[vo.UserID, []dto.WithdrawalOutput]
+	ListWithdrawalsUseCase() port.UseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput]
+	RefundWithdrawalUseCase() port.UseCase[dto.RefundInput, dto.WithdrawalOutput]
+	ListTransactionsUseCase() port.UseCase[vo.UserID, []dto.TransactionOutput]
+	GetStatementUseCase() port.UseCase[dto.StatementInput, dto.StatementOutput]
+	TransferUseCase() port.UseCase[dto.TransferInput, struct{}]
+	AuthorizeHoldUseCase() port.UseCase[dto.AuthorizeHoldInput, dto.HoldOutput]
+	CaptureHoldUseCase() port.UseCase[dto.HoldActionInput, dto.HoldOutput]
+	VoidHoldUseCase() port.UseCase[dto.HoldActionInput, dto.HoldOutput]
+	ListHoldsUseCase() port.UseCase[vo.UserID, []dto.HoldOutput]
+	ExpirePointsUseCase() port.BackgroundRunner
+	ExpireHoldsUseCase() port.BackgroundRunner
+	ReconcileBalancesUseCase() port.BackgroundRunner
+	PurgeIdempotencyKeysUseCase() port.BackgroundRunner
 }
@@ -1,13 +1,24 @@
 package dto
 
+import "gophermart/internal/gophermart/modules/balance/domain/vo"
+
 // BalanceResponse is the HTTP response body for the user balance.
 type BalanceResponse struct {
-	Current   float64 `json:"current"`
-	Withdrawn float64 `json:"withdrawn"`
+	Current      vo.Points `json:"current"`
+	Withdrawn    vo.Points `json:"withdrawn"`
+	Available    vo.Points `json:"available"`
+	Held         vo.Points `json:"held"`
+	ExpiringSoon vo.Points `json:"expiring_soon"`
 }
 
 // WithdrawRequest is the HTTP request body for a withdrawal.
 type WithdrawRequest struct {
-	Order string  `json:"order" binding:"required"`
-	Sum   float64 `json:"sum" binding:"required,gt=0"`
+	Order string    `json:"order" binding:"required"`
+	Sum   vo.Points `json:"sum" binding:"required,gt=0"`
+}
+
+// TransferRequest is the HTTP request body for a points transfer to another user.
+type TransferRequest struct {
+	Login string    `json:"login" binding:"required"`
+	Sum   vo.Points `json:"sum" binding:"required,gt=0"`
 }
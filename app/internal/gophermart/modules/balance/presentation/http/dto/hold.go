@@ -0,0 +1,20 @@
+package dto
+
+import "gophermart/internal/gophermart/modules/balance/domain/vo"
+
+// HoldRequest is the HTTP request body for reserving points for an order.
+type HoldRequest struct {
+	Order string    `json:"order" binding:"required"`
+	Sum   vo.Points `json:"sum" binding:"required,gt=0"`
+}
+
+// HoldResponse is the HTTP response body for a single hold.
+type HoldResponse struct {
+	ID         int64     `json:"id"`
+	Order      string    `json:"order"`
+	Sum        vo.Points `json:"sum"`
+	Status     string    `json:"status"`
+	CreatedAt  string    `json:"created_at"`
+	ExpiresAt  string    `json:"expires_at"`
+	ResolvedAt string    `json:"resolved_at,omitempty"`
+}
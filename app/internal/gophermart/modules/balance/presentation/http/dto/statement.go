@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+// StatementQuery is the query string of the account statement; From is inclusive, To is exclusive.
+type StatementQuery struct {
+	From   *time.Time `form:"from" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     *time.Time `form:"to" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	Format string     `form:"format" binding:"omitempty,oneof=csv json"`
+}
+
+// StatementResponse is the HTTP response body for the account statement in JSON.
+type StatementResponse struct {
+	From           string                  `json:"from"`
+	To             string                  `json:"to"`
+	OpeningBalance vo.Points               `json:"opening_balance"`
+	ClosingBalance vo.Points               `json:"closing_balance"`
+	Lines          []StatementLineResponse `json:"lines"`
+}
+
+// StatementLineResponse is a single ledger entry of the statement.
+type StatementLineResponse struct {
+	At        string    `json:"at"`
+	Kind      string    `json:"kind"`
+	Reference string    `json:"reference"`
+	Amount    vo.Points `json:"amount"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "gophermart/internal/gophermart/modules/balance/domain/vo"
+
+// TransactionResponse is the HTTP response body for a single ledger entry.
+type TransactionResponse struct {
+	ID        int64     `json:"id"`
+	Kind      string    `json:"kind"`
+	Reference string    `json:"reference"`
+	Amount    vo.Points `json:"amount"`
+	CreatedAt string    `json:"created_at"`
+}
@@ -1,8 +1,27 @@
 package dto
 
+import (
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+	"gophermart/internal/gophermart/presentation/http/pagination"
+)
+
+// ListWithdrawalsQuery is the query string of the withdrawal history.
+type ListWithdrawalsQuery struct {
+	pagination.Query
+	RefundStatus []string `form:"refund_status" binding:"omitempty,dive,oneof=NONE PARTIAL FULL"`
+}
+
 // WithdrawalResponse is the HTTP response body for a single withdrawal record.
 type WithdrawalResponse struct {
-	Order       string  `json:"order"`
-	Sum         float64 `json:"sum"`
-	ProcessedAt string  `json:"processed_at"`
+	Order        string    `json:"order"`
+	Sum          vo.Points `json:"sum"`
+	ProcessedAt  string    `json:"processed_at"`
+	Refunded     vo.Points `json:"refunded,omitempty"`
+	RefundStatus string    `json:"refund_status"`
+	RefundedAt   string    `json:"refunded_at,omitempty"`
+}
+
+// RefundRequest is the HTTP request body for a withdrawal refund; an empty body refunds the rest.
+type RefundRequest struct {
+	Sum *vo.Points `json:"sum" binding:"omitempty,gt=0"`
 }
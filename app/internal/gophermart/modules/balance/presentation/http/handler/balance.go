@@ -2,7 +2,10 @@ package handler
 
 import (
 	"errors"
+	"io"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +17,15 @@ import (
 	"gophermart/internal/gophermart/modules/balance/presentation/factory"
 	httpdto "gophermart/internal/gophermart/modules/balance/presentation/http/dto"
 	"gophermart/internal/gophermart/presentation/http/httpcontext"
+	"gophermart/internal/gophermart/presentation/http/pagination"
+)
+
+const (
+	// IdempotencyKeyHeader makes a withdrawal safe to retry: a repeated request with the same key
+	// returns the original result instead of withdrawing again.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	maxIdempotencyKeyLen = 255
 )
 
 // BalanceHandler manages balance and withdrawal requests.
@@ -46,12 +58,17 @@ func (h *BalanceHandler) Get(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, httpdto.BalanceResponse{
-		Current:   balance.Current,
-		Withdrawn: balance.Withdrawn,
+		Current:      balance.Current,
+		Withdrawn:    balance.Withdrawn,
+		Available:    balance.Available,
+		Held:         balance.Held,
+		ExpiringSoon: balance.ExpiringSoon,
 	})
 }
 
 // Withdraw deducts loyalty points from the user's balance.
+// Honours the optional Idempotency-Key header; reusing a key with another body returns 422.
+// A withdrawal breaking a limit policy returns 403 naming the rule, with Retry-After during a cooldown.
 func (h *BalanceHandler) Withdraw(c *gin.Context) {
 	userID, ok := httpcontext.UserID(c)
 	if !ok {
@@ -65,16 +82,36 @@ func (h *BalanceHandler) Withdraw(c *gin.Context) {
 		return
 	}
 
+	idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLen {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "idempotency key too long"})
+		return
+	}
+
 	_, err := h.useCases.WithdrawUseCase().Execute(
 		c.Request.Context(),
-		dto.WithdrawInput{UserID: vo.UserID(userID), OrderNumber: req.Order, Sum: req.Sum},
+		dto.WithdrawInput{
+			UserID:         vo.UserID(userID),
+			OrderNumber:    req.Order,
+			Sum:            req.Sum,
+			IdempotencyKey: idempotencyKey,
+		},
 	)
 	if err != nil {
+		var limitErr *application.ErrWithdrawalLimit
 		switch {
 		case errors.Is(err, application.ErrInsufficientBalance):
 			c.AbortWithStatus(http.StatusPaymentRequired)
+		case errors.As(err, &limitErr):
+			abortWithdrawalLimit(c, limitErr)
 		case errors.Is(err, application.ErrInvalidOrderNumber):
 			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid order number"})
+		case errors.Is(err, application.ErrAlreadyExists):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "order already has a withdrawal"})
+		case errors.Is(err, application.ErrOrderHeld):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "order has an active hold"})
+		case errors.Is(err, application.ErrIdempotencyKeyReused):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "idempotency key reused with a different request"})
 		default:
 			h.log.Error("withdraw failed", "error", err)
 			c.AbortWithStatus(http.StatusInternalServerError)
@@ -85,7 +122,53 @@ func (h *BalanceHandler) Withdraw(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// Transfer moves loyalty points from the user's balance to the user with the given login.
+func (h *BalanceHandler) Transfer(c *gin.Context) {
+	userID, ok := httpcontext.UserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var req httpdto.TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	_, err := h.useCases.TransferUseCase().Execute(
+		c.Request.Context(),
+		dto.TransferInput{
+			UserID:         vo.UserID(userID),
+			RecipientLogin: req.Login,
+			Sum:            req.Sum,
+		},
+	)
+	if err != nil {
+		var limitErr *application.ErrWithdrawalLimit
+		switch {
+		case errors.Is(err, application.ErrInsufficientBalance):
+			c.AbortWithStatus(http.StatusPaymentRequired)
+		case errors.As(err, &limitErr):
+			abortWithdrawalLimit(c, limitErr)
+		case errors.Is(err, application.ErrNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "recipient not found"})
+		case errors.Is(err, application.ErrAccountNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "balance account not found"})
+		case errors.Is(err, application.ErrSelfTransfer):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "cannot transfer points to yourself"})
+		default:
+			h.log.Error("transfer failed", "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
 // ListWithdrawals returns the withdrawal history of the authenticated user.
+// Optional query: refund_status (repeatable), from/to (RFC 3339), cursor and limit; a next page is advertised in Link.
 func (h *BalanceHandler) ListWithdrawals(c *gin.Context) {
 	userID, ok := httpcontext.UserID(c)
 	if !ok {
@@ -93,26 +176,142 @@ func (h *BalanceHandler) ListWithdrawals(c *gin.Context) {
 		return
 	}
 
-	withdrawals, err := h.useCases.ListWithdrawalsUseCase().Execute(c.Request.Context(), vo.UserID(userID))
+	var query httpdto.ListWithdrawalsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid query"})
+		return
+	}
+
+	page, err := h.useCases.ListWithdrawalsUseCase().Execute(c.Request.Context(), dto.ListWithdrawalsInput{
+		UserID:         vo.UserID(userID),
+		RefundStatuses: query.RefundStatus,
+		From:           query.From,
+		To:             query.To,
+		Cursor:         query.Cursor,
+		Limit:          query.Limit,
+	})
 	if err != nil {
+		if errors.Is(err, application.ErrInvalidCursor) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+			return
+		}
 		h.log.Error("list withdrawals failed", "error", err)
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
 
-	if len(withdrawals) == 0 {
+	if len(page.Withdrawals) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	pagination.SetNext(c, page.NextCursor)
+
+	resp := make([]httpdto.WithdrawalResponse, 0, len(page.Withdrawals))
+	for _, w := range page.Withdrawals {
+		resp = append(resp, newWithdrawalResponse(w))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RefundWithdrawal returns points of a cancelled purchase: the whole unrefunded rest
+// with an empty body, or the given sum. It is an admin endpoint: the user comes from the path.
+func (h *BalanceHandler) RefundWithdrawal(c *gin.Context) {
+	userID, ok := pathUserID(c)
+	if !ok {
+		return
+	}
+
+	var req httpdto.RefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	withdrawal, err := h.useCases.RefundWithdrawalUseCase().Execute(
+		c.Request.Context(),
+		dto.RefundInput{UserID: userID, OrderNumber: c.Param("number"), Sum: req.Sum},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
+		case errors.Is(err, application.ErrInvalidOrderNumber):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid order number"})
+		case errors.Is(err, application.ErrRefundExceedsWithdrawal):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "refund exceeds withdrawn amount"})
+		default:
+			h.log.Error("refund withdrawal failed", "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, newWithdrawalResponse(withdrawal))
+}
+
+func pathUserID(c *gin.Context) (vo.UserID, bool) {
+	id, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return 0, false
+	}
+	return vo.UserID(id), true
+}
+
+func newWithdrawalResponse(w dto.WithdrawalOutput) httpdto.WithdrawalResponse {
+	resp := httpdto.WithdrawalResponse{
+		Order:        w.OrderNumber,
+		Sum:          w.Sum,
+		ProcessedAt:  w.ProcessedAt.Format(time.RFC3339),
+		Refunded:     w.Refunded,
+		RefundStatus: w.RefundStatus,
+	}
+	if w.RefundedAt != nil {
+		resp.RefundedAt = w.RefundedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// ListTransactions returns the ledger history of the authenticated user, newest first.
+func (h *BalanceHandler) ListTransactions(c *gin.Context) {
+	userID, ok := httpcontext.UserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	transactions, err := h.useCases.ListTransactionsUseCase().Execute(c.Request.Context(), vo.UserID(userID))
+	if err != nil {
+		h.log.Error("list transactions failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	if len(transactions) == 0 {
 		c.Status(http.StatusNoContent)
 		return
 	}
 
-	resp := make([]httpdto.WithdrawalResponse, 0, len(withdrawals))
-	for _, w := range withdrawals {
-		resp = append(resp, httpdto.WithdrawalResponse{
-			Order:       w.OrderNumber,
-			Sum:         w.Sum,
-			ProcessedAt: w.ProcessedAt.Format(time.RFC3339),
+	resp := make([]httpdto.TransactionResponse, 0, len(transactions))
+	for _, tx := range transactions {
+		resp = append(resp, httpdto.TransactionResponse{
+			ID:        tx.ID,
+			Kind:      tx.Kind,
+			Reference: tx.Reference,
+			Amount:    tx.Amount,
+			CreatedAt: tx.CreatedAt.Format(time.RFC3339),
 		})
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// abortWithdrawalLimit responds 403 with the broken rule and, for the cooldown, a Retry-After header.
+func abortWithdrawalLimit(c *gin.Context, limitErr *application.ErrWithdrawalLimit) {
+	if limitErr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(limitErr.RetryAfter.Seconds()))))
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "withdrawal limit exceeded", "rule": limitErr.Rule})
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,10 +33,29 @@ func (s *stubUseCase[In, Out]) Execute(_ context.Context, _ In) (Out, error) {
 	return s.out, s.err
 }
 
+type spyUseCase[In, Out any] struct {
+	in  In
+	out Out
+	err error
+}
+
+func (s *spyUseCase[In, Out]) Execute(_ context.Context, in In) (Out, error) {
+	s.in = in
+	return s.out, s.err
+}
+
 type testBalanceFactory struct {
 	getBalanceUC      port.UseCase[vo.UserID, dto.BalanceOutput]
 	withdrawUC        port.UseCase[dto.WithdrawInput, struct{}]
-	listWithdrawalsUC port.UseCase[vo.UserID, []dto.WithdrawalOutput]
+	listWithdrawalsUC port.UseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput]
+	refundUC          port.UseCase[dto.RefundInput, dto.WithdrawalOutput]
+	listTxUC          port.UseCase[vo.UserID, []dto.TransactionOutput]
+	statementUC       port.UseCase[dto.StatementInput, dto.StatementOutput]
+	transferUC        port.UseCase[dto.TransferInput, struct{}]
+	authorizeHoldUC   port.UseCase[dto.AuthorizeHoldInput, dto.HoldOutput]
+	captureHoldUC     port.UseCase[dto.HoldActionInput, dto.HoldOutput]
+	voidHoldUC        port.UseCase[dto.HoldActionInput, dto.HoldOutput]
+	listHoldsUC       port.UseCase[vo.UserID, []dto.HoldOutput]
 }
 
 func (f *testBalanceFactory) GetBalanceUseCase() port.UseCase[vo.UserID, dto.BalanceOutput] {
@@ -46,10 +66,58 @@ func (f *testBalanceFactory) WithdrawUseCase() port.UseCase[dto.WithdrawInput, s
 	return f.withdrawUC
 }
 
-func (f *testBalanceFactory) ListWithdrawalsUseCase() port.UseCase[vo.UserID, []dto.WithdrawalOutput] {
+func (f *testBalanceFactory) ListWithdrawalsUseCase() port.UseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput] {
 	return f.listWithdrawalsUC
 }
 
+func (f *testBalanceFactory) RefundWithdrawalUseCase() port.UseCase[dto.RefundInput, dto.WithdrawalOutput] {
+	return f.refundUC
+}
+
+func (f *testBalanceFactory) ListTransactionsUseCase() port.UseCase[vo.UserID, []dto.TransactionOutput] {
+	return f.listTxUC
+}
+
+func (f *testBalanceFactory) GetStatementUseCase() port.UseCase[dto.StatementInput, dto.StatementOutput] {
+	return f.statementUC
+}
+
+func (f *testBalanceFactory) TransferUseCase() port.UseCase[dto.TransferInput, struct{}] {
+	return f.transferUC
+}
+
+func (f *testBalanceFactory) AuthorizeHoldUseCase() port.UseCase[dto.AuthorizeHoldInput, dto.HoldOutput] {
+	return f.authorizeHoldUC
+}
+
+func (f *testBalanceFactory) CaptureHoldUseCase() port.UseCase[dto.HoldActionInput, dto.HoldOutput] {
+	return f.captureHoldUC
+}
+
+func (f *testBalanceFactory) VoidHoldUseCase() port.UseCase[dto.HoldActionInput, dto.HoldOutput] {
+	return f.voidHoldUC
+}
+
+func (f *testBalanceFactory) ListHoldsUseCase() port.UseCase[vo.UserID, []dto.HoldOutput] {
+	return f.listHoldsUC
+}
+
+func (f *testBalanceFactory) ExpirePointsUseCase() port.BackgroundRunner {
+	return nil
+}
+
+func (f *testBalanceFactory) ExpireHoldsUseCase() port.BackgroundRunner {
+	return nil
+}
+
+func (f *testBalanceFactory) ReconcileBalancesUseCase() port.BackgroundRunner {
+	return nil
+}
+
+func (f *testBalanceFactory) PurgeIdempotencyKeysUseCase() port.BackgroundRunner {
+	return nil
+}
+
 func setupBalanceRouter(t *testing.T) (*gomock.Controller, *testBalanceFactory, *gin.Engine) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
@@ -72,7 +140,17 @@ func setupBalanceRouter(t *testing.T) (*gomock.Controller, *testBalanceFactory,
 	protected := r.Group("", authSim(1))
 	protected.GET("/api/user/balance", h.Get)
 	protected.POST("/api/user/balance/withdraw", h.Withdraw)
+	protected.GET("/api/user/balance/transactions", h.ListTransactions)
+	protected.GET("/api/user/balance/statement", h.Statement)
+	protected.POST("/api/user/balance/transfer", h.Transfer)
 	protected.GET("/api/user/withdrawals", h.ListWithdrawals)
+	protected.POST("/api/user/balance/holds", h.AuthorizeHold)
+	protected.GET("/api/user/balance/holds", h.ListHolds)
+
+	admin := r.Group("/api/admin")
+	admin.POST("/users/:user_id/withdrawals/:number/refund", h.RefundWithdrawal)
+	admin.POST("/users/:user_id/holds/:id/capture", h.CaptureHold)
+	admin.POST("/users/:user_id/holds/:id/void", h.VoidHold)
 
 	return ctrl, factory, r
 }
@@ -81,7 +159,7 @@ func TestBalanceHandler_Get_Success(t *testing.T) {
 	_, factory, router := setupBalanceRouter(t)
 
 	factory.getBalanceUC = &stubUseCase[vo.UserID, dto.BalanceOutput]{
-		out: dto.BalanceOutput{Current: 500.5, Withdrawn: 42},
+		out: dto.BalanceOutput{Current: 50050, Withdrawn: 4200, Available: 45050, Held: 5000, ExpiringSoon: 1000},
 	}
 
 	w := httptest.NewRecorder()
@@ -90,11 +168,7 @@ func TestBalanceHandler_Get_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var resp map[string]float64
-	err := json.Unmarshal(w.Body.Bytes(), &resp)
-	require.NoError(t, err)
-	assert.Equal(t, 500.5, resp["current"])
-	assert.Equal(t, float64(42), resp["withdrawn"])
+	assert.JSONEq(t, `{"current":500.5,"withdrawn":42,"available":450.5,"held":50,"expiring_soon":10}`, w.Body.String())
 }
 
 func TestBalanceHandler_Withdraw_Success(t *testing.T) {
@@ -125,6 +199,44 @@ func TestBalanceHandler_Withdraw_InsufficientBalance(t *testing.T) {
 	assert.Equal(t, http.StatusPaymentRequired, w.Code)
 }
 
+func TestBalanceHandler_Withdraw_LimitExceeded(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		body       string
+		retryAfter string
+	}{
+		{
+			name: "daily cap",
+			err:  &application.ErrWithdrawalLimit{Rule: "DAILY_CAP"},
+			body: `{"error":"withdrawal limit exceeded","rule":"DAILY_CAP"}`,
+		},
+		{
+			name:       "cooldown",
+			err:        &application.ErrWithdrawalLimit{Rule: "COOLDOWN", RetryAfter: 1500 * time.Millisecond},
+			body:       `{"error":"withdrawal limit exceeded","rule":"COOLDOWN"}`,
+			retryAfter: "2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupBalanceRouter(t)
+			factory.withdrawUC = &stubUseCase[dto.WithdrawInput, struct{}]{err: tt.err}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw",
+				bytes.NewReader([]byte(`{"order":"12345678903","sum":10}`)))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.JSONEq(t, tt.body, w.Body.String())
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
 func TestBalanceHandler_Withdraw_InvalidOrder(t *testing.T) {
 	_, factory, router := setupBalanceRouter(t)
 	factory.withdrawUC = &stubUseCase[dto.WithdrawInput, struct{}]{err: application.ErrInvalidOrderNumber}
@@ -150,6 +262,103 @@ func TestBalanceHandler_Withdraw_BadJSON(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestBalanceHandler_Withdraw_ExactSum(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	spy := &spyUseCase[dto.WithdrawInput, struct{}]{}
+	factory.withdrawUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw",
+		bytes.NewReader([]byte(`{"order":"12345678903","sum":0.3}`)))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, vo.Points(30), spy.in.Sum)
+}
+
+func TestBalanceHandler_Withdraw_SubHundredthSum(t *testing.T) {
+	_, _, router := setupBalanceRouter(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw",
+		bytes.NewReader([]byte(`{"order":"12345678903","sum":0.001}`)))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBalanceHandler_Withdraw_DuplicateOrder(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	factory.withdrawUC = &stubUseCase[dto.WithdrawInput, struct{}]{err: application.ErrAlreadyExists}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw",
+		bytes.NewReader([]byte(`{"order":"12345678903","sum":10}`)))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestBalanceHandler_Withdraw_OrderHeld(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	factory.withdrawUC = &stubUseCase[dto.WithdrawInput, struct{}]{err: application.ErrOrderHeld}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw",
+		bytes.NewReader([]byte(`{"order":"12345678903","sum":10}`)))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"error":"order has an active hold"}`, w.Body.String())
+}
+
+func TestBalanceHandler_Withdraw_IdempotencyKey(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	spy := &spyUseCase[dto.WithdrawInput, struct{}]{}
+	factory.withdrawUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw",
+		bytes.NewReader([]byte(`{"order":"12345678903","sum":10}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(handler.IdempotencyKeyHeader, "key-1")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "key-1", spy.in.IdempotencyKey)
+}
+
+func TestBalanceHandler_Withdraw_IdempotencyKeyReused(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	factory.withdrawUC = &stubUseCase[dto.WithdrawInput, struct{}]{err: application.ErrIdempotencyKeyReused}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw",
+		bytes.NewReader([]byte(`{"order":"12345678903","sum":10}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(handler.IdempotencyKeyHeader, "key-1")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestBalanceHandler_Withdraw_IdempotencyKeyTooLong(t *testing.T) {
+	_, _, router := setupBalanceRouter(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw",
+		bytes.NewReader([]byte(`{"order":"12345678903","sum":10}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(handler.IdempotencyKeyHeader, strings.Repeat("k", 256))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestBalanceHandler_ListWithdrawals_Success(t *testing.T) {
 	_, factory, router := setupBalanceRouter(t)
 
@@ -157,7 +366,9 @@ func TestBalanceHandler_ListWithdrawals_Success(t *testing.T) {
 		{OrderNumber: "12345678903", Sum: 100, ProcessedAt: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)},
 		{OrderNumber: "99999999927", Sum: 50, ProcessedAt: time.Date(2026, 1, 21, 8, 0, 0, 0, time.UTC)},
 	}
-	factory.listWithdrawalsUC = &stubUseCase[vo.UserID, []dto.WithdrawalOutput]{out: withdrawals}
+	factory.listWithdrawalsUC = &stubUseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput]{
+		out: dto.WithdrawalPageOutput{Withdrawals: withdrawals},
+	}
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/api/user/withdrawals", nil)
@@ -172,9 +383,110 @@ func TestBalanceHandler_ListWithdrawals_Success(t *testing.T) {
 	assert.Equal(t, "12345678903", resp[0]["order"])
 }
 
+func TestBalanceHandler_ListWithdrawals_RefundStatus(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+
+	refundedAt := time.Date(2026, 1, 22, 9, 0, 0, 0, time.UTC)
+	factory.listWithdrawalsUC = &stubUseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput]{out: dto.WithdrawalPageOutput{
+		Withdrawals: []dto.WithdrawalOutput{
+			{
+				OrderNumber: "12345678903", Sum: 10000, ProcessedAt: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC),
+				Refunded: 2550, RefundStatus: "PARTIAL", RefundedAt: &refundedAt,
+			},
+			{OrderNumber: "99999999927", Sum: 50, ProcessedAt: time.Date(2026, 1, 21, 8, 0, 0, 0, time.UTC), RefundStatus: "NONE"},
+		},
+	}}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/withdrawals", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[
+		{"order":"12345678903","sum":100,"processed_at":"2026-01-20T12:00:00Z",
+		 "refunded":25.5,"refund_status":"PARTIAL","refunded_at":"2026-01-22T09:00:00Z"},
+		{"order":"99999999927","sum":0.5,"processed_at":"2026-01-21T08:00:00Z","refund_status":"NONE"}
+	]`, w.Body.String())
+}
+
+func TestBalanceHandler_RefundWithdrawal_Full(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	spy := &spyUseCase[dto.RefundInput, dto.WithdrawalOutput]{out: dto.WithdrawalOutput{
+		OrderNumber: "12345678903", Sum: 100, Refunded: 100, RefundStatus: "FULL",
+	}}
+	factory.refundUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/1/withdrawals/12345678903/refund", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, dto.RefundInput{UserID: 1, OrderNumber: "12345678903"}, spy.in)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "FULL", resp["refund_status"])
+}
+
+func TestBalanceHandler_RefundWithdrawal_Partial(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	spy := &spyUseCase[dto.RefundInput, dto.WithdrawalOutput]{}
+	factory.refundUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/1/withdrawals/12345678903/refund",
+		bytes.NewReader([]byte(`{"sum":0.3}`)))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, spy.in.Sum)
+	assert.Equal(t, vo.Points(30), *spy.in.Sum)
+}
+
+func TestBalanceHandler_RefundWithdrawal_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+		want int
+	}{
+		{name: "not found", err: application.ErrNotFound, want: http.StatusNotFound},
+		{name: "invalid order", err: application.ErrInvalidOrderNumber, want: http.StatusUnprocessableEntity},
+		{name: "exceeds withdrawal", err: application.ErrRefundExceedsWithdrawal, want: http.StatusUnprocessableEntity},
+		{name: "non-positive sum", body: `{"sum":0}`, want: http.StatusBadRequest},
+		{name: "broken body", body: `broken`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupBalanceRouter(t)
+			factory.refundUC = &stubUseCase[dto.RefundInput, dto.WithdrawalOutput]{err: tt.err}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/users/1/withdrawals/12345678903/refund",
+				strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestBalanceHandler_RefundWithdrawal_InvalidUserID(t *testing.T) {
+	_, _, router := setupBalanceRouter(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/abc/withdrawals/12345678903/refund", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestBalanceHandler_ListWithdrawals_Empty(t *testing.T) {
 	_, factory, router := setupBalanceRouter(t)
-	factory.listWithdrawalsUC = &stubUseCase[vo.UserID, []dto.WithdrawalOutput]{out: []dto.WithdrawalOutput{}}
+	factory.listWithdrawalsUC = &stubUseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput]{}
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/api/user/withdrawals", nil)
@@ -182,3 +494,144 @@ func TestBalanceHandler_ListWithdrawals_Empty(t *testing.T) {
 
 	assert.Equal(t, http.StatusNoContent, w.Code)
 }
+
+func TestBalanceHandler_ListWithdrawals_Page(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	spy := &spyUseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput]{out: dto.WithdrawalPageOutput{
+		Withdrawals: []dto.WithdrawalOutput{{OrderNumber: "12345678903", Sum: 100, RefundStatus: "FULL"}},
+		NextCursor:  "next-token",
+	}}
+	factory.listWithdrawalsUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/withdrawals?refund_status=FULL&cursor=prev-token&limit=1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"FULL"}, spy.in.RefundStatuses)
+	assert.Equal(t, "prev-token", spy.in.Cursor)
+	assert.Equal(t, 1, spy.in.Limit)
+	assert.Equal(t, `</api/user/withdrawals?cursor=next-token&limit=1&refund_status=FULL>; rel="next"`, w.Header().Get("Link"))
+	assert.Equal(t, "next-token", w.Header().Get("X-Next-Cursor"))
+}
+
+func TestBalanceHandler_ListWithdrawals_BadRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		err   error
+	}{
+		{name: "unknown refund status", query: "refund_status=SOME"},
+		{name: "limit too large", query: "limit=500"},
+		{name: "malformed date", query: "to=2026-01-01"},
+		{name: "invalid cursor", query: "cursor=garbage", err: application.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupBalanceRouter(t)
+			factory.listWithdrawalsUC = &stubUseCase[dto.ListWithdrawalsInput, dto.WithdrawalPageOutput]{err: tt.err}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/user/withdrawals?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestBalanceHandler_ListTransactions_Success(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+
+	transactions := []dto.TransactionOutput{
+		{ID: 2, Kind: "WITHDRAWAL", Reference: "2377225624", Amount: -3000, CreatedAt: time.Date(2026, 1, 21, 8, 0, 0, 0, time.UTC)},
+		{ID: 1, Kind: "ACCRUAL", Reference: "12345678903", Amount: 10000, CreatedAt: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)},
+	}
+	factory.listTxUC = &stubUseCase[vo.UserID, []dto.TransactionOutput]{out: transactions}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/balance/transactions", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp []map[string]any
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp, 2)
+	assert.Equal(t, "WITHDRAWAL", resp[0]["kind"])
+	assert.Equal(t, float64(-30), resp[0]["amount"])
+	assert.Equal(t, "2026-01-21T08:00:00Z", resp[0]["created_at"])
+}
+
+func TestBalanceHandler_ListTransactions_Empty(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	factory.listTxUC = &stubUseCase[vo.UserID, []dto.TransactionOutput]{out: []dto.TransactionOutput{}}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/balance/transactions", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestBalanceHandler_Transfer_Success(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	spy := &spyUseCase[dto.TransferInput, struct{}]{}
+	factory.transferUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/transfer",
+		strings.NewReader(`{"login":"bob","sum":12.5}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, dto.TransferInput{UserID: 1, RecipientLogin: "bob", Sum: 1250}, spy.in)
+}
+
+func TestBalanceHandler_Transfer_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		err       error
+		want      int
+		wantError string
+	}{
+		{name: "insufficient balance", err: application.ErrInsufficientBalance, want: http.StatusPaymentRequired},
+		{name: "recipient not found", err: application.ErrNotFound, want: http.StatusNotFound, wantError: "recipient not found"},
+		{
+			name: "sender account not found", err: application.ErrAccountNotFound,
+			want: http.StatusNotFound, wantError: "balance account not found",
+		},
+		{
+			name: "withdrawal limit", err: &application.ErrWithdrawalLimit{Rule: "DAILY_CAP"},
+			want: http.StatusForbidden, wantError: "withdrawal limit exceeded",
+		},
+		{name: "self transfer", err: application.ErrSelfTransfer, want: http.StatusUnprocessableEntity},
+		{name: "non-positive sum", body: `{"login":"bob","sum":0}`, want: http.StatusBadRequest},
+		{name: "missing login", body: `{"sum":10}`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupBalanceRouter(t)
+			factory.transferUC = &stubUseCase[dto.TransferInput, struct{}]{err: tt.err}
+
+			body := tt.body
+			if body == "" {
+				body = `{"login":"bob","sum":10}`
+			}
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/user/balance/transfer", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+	httpdto "gophermart/internal/gophermart/modules/balance/presentation/http/dto"
+	"gophermart/internal/gophermart/presentation/http/httpcontext"
+)
+
+// AuthorizeHold reserves loyalty points for an order until the payment is captured or voided.
+func (h *BalanceHandler) AuthorizeHold(c *gin.Context) {
+	userID, ok := httpcontext.UserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var req httpdto.HoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	hold, err := h.useCases.AuthorizeHoldUseCase().Execute(
+		c.Request.Context(),
+		dto.AuthorizeHoldInput{UserID: vo.UserID(userID), OrderNumber: req.Order, Sum: req.Sum},
+	)
+	if err != nil {
+		var limitErr *application.ErrWithdrawalLimit
+		switch {
+		case errors.Is(err, application.ErrInsufficientBalance):
+			c.AbortWithStatus(http.StatusPaymentRequired)
+		case errors.As(err, &limitErr):
+			abortWithdrawalLimit(c, limitErr)
+		case errors.Is(err, application.ErrInvalidOrderNumber):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid order number"})
+		case errors.Is(err, application.ErrAlreadyExists):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "order already has a hold or a withdrawal"})
+		default:
+			h.log.Error("authorize hold failed", "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, newHoldResponse(hold))
+}
+
+// ListHolds returns the holds of the authenticated user.
+func (h *BalanceHandler) ListHolds(c *gin.Context) {
+	userID, ok := httpcontext.UserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	holds, err := h.useCases.ListHoldsUseCase().Execute(c.Request.Context(), vo.UserID(userID))
+	if err != nil {
+		h.log.Error("list holds failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	if len(holds) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	resp := make([]httpdto.HoldResponse, 0, len(holds))
+	for _, hold := range holds {
+		resp = append(resp, newHoldResponse(hold))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CaptureHold withdraws the held points once the checkout payment succeeded.
+// Capture and void are admin endpoints called by the shop backend: the user comes from the path.
+func (h *BalanceHandler) CaptureHold(c *gin.Context) {
+	h.resolveHold(c, h.useCases.CaptureHoldUseCase(), "capture hold failed")
+}
+
+// VoidHold releases the held points of a cancelled checkout.
+func (h *BalanceHandler) VoidHold(c *gin.Context) {
+	h.resolveHold(c, h.useCases.VoidHoldUseCase(), "void hold failed")
+}
+
+func (h *BalanceHandler) resolveHold(
+	c *gin.Context,
+	uc port.UseCase[dto.HoldActionInput, dto.HoldOutput],
+	failure string,
+) {
+	userID, ok := pathUserID(c)
+	if !ok {
+		return
+	}
+
+	holdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid hold id"})
+		return
+	}
+
+	hold, err := uc.Execute(c.Request.Context(), dto.HoldActionInput{UserID: userID, HoldID: holdID})
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
+		case errors.Is(err, application.ErrHoldNotActive):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "hold is not active"})
+		case errors.Is(err, application.ErrAlreadyExists):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "order already has a withdrawal"})
+		case errors.Is(err, application.ErrInsufficientBalance):
+			c.AbortWithStatus(http.StatusPaymentRequired)
+		default:
+			h.log.Error(failure, "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, newHoldResponse(hold))
+}
+
+func newHoldResponse(h dto.HoldOutput) httpdto.HoldResponse {
+	resp := httpdto.HoldResponse{
+		ID:        h.ID,
+		Order:     h.OrderNumber,
+		Sum:       h.Sum,
+		Status:    h.Status,
+		CreatedAt: h.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: h.ExpiresAt.Format(time.RFC3339),
+	}
+	if h.ResolvedAt != nil {
+		resp.ResolvedAt = h.ResolvedAt.Format(time.RFC3339)
+	}
+	return resp
+}
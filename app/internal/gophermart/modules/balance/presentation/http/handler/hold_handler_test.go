@@ -0,0 +1,125 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+)
+
+func TestBalanceHandler_AuthorizeHold_Success(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	createdAt := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+	spy := &spyUseCase[dto.AuthorizeHoldInput, dto.HoldOutput]{out: dto.HoldOutput{
+		ID: 7, OrderNumber: "12345678903", Sum: 10050, Status: "ACTIVE",
+		CreatedAt: createdAt, ExpiresAt: createdAt.Add(15 * time.Minute),
+	}}
+	factory.authorizeHoldUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/holds",
+		strings.NewReader(`{"order":"12345678903","sum":100.5}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, dto.AuthorizeHoldInput{UserID: 1, OrderNumber: "12345678903", Sum: 10050}, spy.in)
+	assert.JSONEq(t, `{"id":7,"order":"12345678903","sum":100.5,"status":"ACTIVE",
+		"created_at":"2026-01-20T12:00:00Z","expires_at":"2026-01-20T12:15:00Z"}`, w.Body.String())
+}
+
+func TestBalanceHandler_AuthorizeHold_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+		want int
+	}{
+		{name: "insufficient balance", err: application.ErrInsufficientBalance, want: http.StatusPaymentRequired},
+		{name: "invalid order", err: application.ErrInvalidOrderNumber, want: http.StatusUnprocessableEntity},
+		{name: "order already held", err: application.ErrAlreadyExists, want: http.StatusConflict},
+		{name: "withdrawal limit", err: &application.ErrWithdrawalLimit{Rule: "DAILY_CAP"}, want: http.StatusForbidden},
+		{name: "non-positive sum", body: `{"order":"12345678903","sum":0}`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupBalanceRouter(t)
+			factory.authorizeHoldUC = &stubUseCase[dto.AuthorizeHoldInput, dto.HoldOutput]{err: tt.err}
+
+			body := tt.body
+			if body == "" {
+				body = `{"order":"12345678903","sum":100}`
+			}
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/user/balance/holds", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestBalanceHandler_CaptureHold(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	resolvedAt := time.Date(2026, 1, 20, 12, 5, 0, 0, time.UTC)
+	spy := &spyUseCase[dto.HoldActionInput, dto.HoldOutput]{out: dto.HoldOutput{
+		ID: 7, OrderNumber: "12345678903", Sum: 100, Status: "CAPTURED", ResolvedAt: &resolvedAt,
+	}}
+	factory.captureHoldUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/1/holds/7/capture", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, dto.HoldActionInput{UserID: 1, HoldID: 7}, spy.in)
+	assert.Contains(t, w.Body.String(), `"status":"CAPTURED"`)
+	assert.Contains(t, w.Body.String(), `"resolved_at":"2026-01-20T12:05:00Z"`)
+}
+
+func TestBalanceHandler_VoidHold_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		err  error
+		want int
+	}{
+		{name: "not found", path: "/api/admin/users/1/holds/7/void", err: application.ErrNotFound, want: http.StatusNotFound},
+		{name: "not active", path: "/api/admin/users/1/holds/7/void", err: application.ErrHoldNotActive, want: http.StatusConflict},
+		{name: "invalid id", path: "/api/admin/users/1/holds/abc/void", want: http.StatusBadRequest},
+		{name: "invalid user id", path: "/api/admin/users/0/holds/7/void", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupBalanceRouter(t)
+			factory.voidHoldUC = &stubUseCase[dto.HoldActionInput, dto.HoldOutput]{err: tt.err}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestBalanceHandler_ListHolds_Empty(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	factory.listHoldsUC = &stubUseCase[vo.UserID, []dto.HoldOutput]{}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/balance/holds", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
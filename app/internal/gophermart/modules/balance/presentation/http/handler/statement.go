@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+	"gophermart/internal/gophermart/modules/balance/domain/vo"
+	httpdto "gophermart/internal/gophermart/modules/balance/presentation/http/dto"
+	"gophermart/internal/gophermart/presentation/http/httpcontext"
+)
+
+const (
+	statementFormatCSV  = "csv"
+	statementFormatJSON = "json"
+
+	// Rows bracketing the CSV statement lines.
+	statementOpeningKind = "OPENING_BALANCE"
+	statementClosingKind = "CLOSING_BALANCE"
+
+	// maxStatementRange bounds the period of one statement, which is built in memory.
+	maxStatementRange = 366 * 24 * time.Hour
+)
+
+// Statement exports the ledger entries of the authenticated user over [from, to)
+// with opening and closing balances as a downloadable CSV or JSON (default) document.
+// The period is at most maxStatementRange.
+func (h *BalanceHandler) Statement(c *gin.Context) {
+	userID, ok := httpcontext.UserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var query httpdto.StatementQuery
+	if err := c.ShouldBindQuery(&query); err != nil || !query.To.After(*query.From) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid query"})
+		return
+	}
+	if query.To.Sub(*query.From) > maxStatementRange {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "statement period is too long"})
+		return
+	}
+
+	statement, err := h.useCases.GetStatementUseCase().Execute(c.Request.Context(), dto.StatementInput{
+		UserID: vo.UserID(userID),
+		From:   *query.From,
+		To:     *query.To,
+	})
+	if err != nil {
+		h.log.Error("get statement failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	format := query.Format
+	if format == "" {
+		format = statementFormatJSON
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="statement-%s-%s.%s"`,
+		statement.From.UTC().Format(time.DateOnly), statement.To.UTC().Format(time.DateOnly), format))
+
+	if format == statementFormatCSV {
+		h.writeStatementCSV(c, statement)
+		return
+	}
+
+	resp := httpdto.StatementResponse{
+		From:           statement.From.Format(time.RFC3339),
+		To:             statement.To.Format(time.RFC3339),
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          make([]httpdto.StatementLineResponse, 0, len(statement.Lines)),
+	}
+	for _, l := range statement.Lines {
+		resp.Lines = append(resp.Lines, httpdto.StatementLineResponse{
+			At:        l.At.Format(time.RFC3339),
+			Kind:      l.Kind,
+			Reference: l.Reference,
+			Amount:    l.Amount,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// writeStatementCSV writes the statement as CSV: the opening balance row, the lines
+// and the closing balance row.
+func (h *BalanceHandler) writeStatementCSV(c *gin.Context, statement dto.StatementOutput) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	rows := [][]string{
+		{"at", "kind", "reference", "amount"},
+		{statement.From.Format(time.RFC3339), statementOpeningKind, "", statement.OpeningBalance.String()},
+	}
+	for _, l := range statement.Lines {
+		rows = append(rows, []string{l.At.Format(time.RFC3339), l.Kind, l.Reference, l.Amount.String()})
+	}
+	rows = append(rows, []string{statement.To.Format(time.RFC3339), statementClosingKind, "", statement.ClosingBalance.String()})
+
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			h.log.Error("write statement failed", "error", err)
+			return
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		h.log.Error("write statement failed", "error", err)
+	}
+}
@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gophermart/internal/gophermart/modules/balance/application/dto"
+)
+
+func newTestStatement() dto.StatementOutput {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return dto.StatementOutput{
+		From:           from,
+		To:             from.AddDate(0, 1, 0),
+		OpeningBalance: 1000,
+		ClosingBalance: 51050,
+		Lines: []dto.StatementLineOutput{
+			{At: from.Add(time.Hour), Kind: "ACCRUAL", Reference: "12345678903", Amount: 60050},
+			{At: from.Add(2 * time.Hour), Kind: "WITHDRAWAL", Reference: "2377225624", Amount: -10000},
+		},
+	}
+}
+
+func TestBalanceHandler_Statement_JSON(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	spy := &spyUseCase[dto.StatementInput, dto.StatementOutput]{out: newTestStatement()}
+	factory.statementUC = spy
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/user/balance/statement?from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, dto.StatementInput{
+		UserID: 1,
+		From:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, spy.in)
+	assert.Equal(t, `attachment; filename="statement-2026-01-01-2026-02-01.json"`, w.Header().Get("Content-Disposition"))
+	assert.JSONEq(t, `{
+		"from": "2026-01-01T00:00:00Z",
+		"to": "2026-02-01T00:00:00Z",
+		"opening_balance": 10,
+		"closing_balance": 510.5,
+		"lines": [
+			{"at": "2026-01-01T01:00:00Z", "kind": "ACCRUAL", "reference": "12345678903", "amount": 600.5},
+			{"at": "2026-01-01T02:00:00Z", "kind": "WITHDRAWAL", "reference": "2377225624", "amount": -100}
+		]
+	}`, w.Body.String())
+}
+
+func TestBalanceHandler_Statement_CSV(t *testing.T) {
+	_, factory, router := setupBalanceRouter(t)
+	factory.statementUC = &stubUseCase[dto.StatementInput, dto.StatementOutput]{out: newTestStatement()}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/user/balance/statement?from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z&format=csv", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="statement-2026-01-01-2026-02-01.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "at,kind,reference,amount\n"+
+		"2026-01-01T00:00:00Z,OPENING_BALANCE,,10\n"+
+		"2026-01-01T01:00:00Z,ACCRUAL,12345678903,600.5\n"+
+		"2026-01-01T02:00:00Z,WITHDRAWAL,2377225624,-100\n"+
+		"2026-02-01T00:00:00Z,CLOSING_BALANCE,,510.5\n", w.Body.String())
+}
+
+func TestBalanceHandler_Statement_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		err   error
+		want  int
+	}{
+		{name: "missing range", query: "format=csv", want: http.StatusBadRequest},
+		{name: "empty range", query: "from=2026-02-01T00:00:00Z&to=2026-01-01T00:00:00Z", want: http.StatusBadRequest},
+		{name: "range too long", query: "from=2025-01-01T00:00:00Z&to=2026-02-01T00:00:00Z", want: http.StatusBadRequest},
+		{name: "unknown format", query: "from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z&format=xml", want: http.StatusBadRequest},
+		{
+			name:  "use case error",
+			query: "from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z",
+			err:   errors.New("db error"),
+			want:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupBalanceRouter(t)
+			factory.statementUC = &stubUseCase[dto.StatementInput, dto.StatementOutput]{err: tt.err}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/user/balance/statement?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
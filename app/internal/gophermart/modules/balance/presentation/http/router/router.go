@@ -17,5 +17,23 @@ func RegisterProtectedRoutes(
 	balanceHandler := handler.NewBalanceHandler(useCases, log)
 	protected.GET("/balance", balanceHandler.Get)
 	protected.POST("/balance/withdraw", balanceHandler.Withdraw)
+	protected.GET("/balance/transactions", balanceHandler.ListTransactions)
+	protected.GET("/balance/statement", balanceHandler.Statement)
+	protected.POST("/balance/transfer", balanceHandler.Transfer)
+	protected.POST("/balance/holds", balanceHandler.AuthorizeHold)
+	protected.GET("/balance/holds", balanceHandler.ListHolds)
 	protected.GET("/withdrawals", balanceHandler.ListWithdrawals)
 }
+
+// RegisterAdminRoutes registers balance operations performed on behalf of a user by the shop backend:
+// withdrawal refunds and the capture or void of a checkout hold.
+func RegisterAdminRoutes(
+	admin *gin.RouterGroup,
+	useCases factory.UseCaseFactory,
+	log port.Logger,
+) {
+	balanceHandler := handler.NewBalanceHandler(useCases, log)
+	admin.POST("/users/:user_id/withdrawals/:number/refund", balanceHandler.RefundWithdrawal)
+	admin.POST("/users/:user_id/holds/:id/capture", balanceHandler.CaptureHold)
+	admin.POST("/users/:user_id/holds/:id/void", balanceHandler.VoidHold)
+}
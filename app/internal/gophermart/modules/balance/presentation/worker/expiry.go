@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/presentation/factory"
+)
+
+// ExpiryWorker periodically expires overdue accrual lots.
+type ExpiryWorker struct {
+	expirePoints port.BackgroundRunner
+	log          port.Logger
+	interval     time.Duration
+}
+
+// NewExpiryWorker creates a new points expiry background worker.
+func NewExpiryWorker(useCases factory.UseCaseFactory, log port.Logger, interval time.Duration) *ExpiryWorker {
+	return &ExpiryWorker{
+		expirePoints: useCases.ExpirePointsUseCase(),
+		log:          log,
+		interval:     interval,
+	}
+}
+
+// Start runs the worker loop in a goroutine. Cancel ctx to stop.
+func (w *ExpiryWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+func (w *ExpiryWorker) run(ctx context.Context) {
+	w.log.Info("points expiry worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("points expiry worker stopped")
+			return
+		case <-ticker.C:
+			w.expire(ctx)
+		}
+	}
+}
+
+func (w *ExpiryWorker) expire(ctx context.Context) {
+	expired, err := w.expirePoints.Run(ctx)
+	if err != nil {
+		w.log.Error("points expiry failed", "error", err)
+		return
+	}
+
+	if expired > 0 {
+		w.log.Info("accrual lots expired", "count", expired)
+	}
+}
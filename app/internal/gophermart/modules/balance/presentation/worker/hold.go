@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/presentation/factory"
+)
+
+// HoldWorker periodically voids holds past their expiry time.
+type HoldWorker struct {
+	expireHolds port.BackgroundRunner
+	log         port.Logger
+	interval    time.Duration
+}
+
+// NewHoldWorker creates a new hold auto-void background worker.
+func NewHoldWorker(useCases factory.UseCaseFactory, log port.Logger, interval time.Duration) *HoldWorker {
+	return &HoldWorker{
+		expireHolds: useCases.ExpireHoldsUseCase(),
+		log:         log,
+		interval:    interval,
+	}
+}
+
+// Start runs the worker loop in a goroutine. Cancel ctx to stop.
+func (w *HoldWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+func (w *HoldWorker) run(ctx context.Context) {
+	w.log.Info("hold expiry worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("hold expiry worker stopped")
+			return
+		case <-ticker.C:
+			w.expire(ctx)
+		}
+	}
+}
+
+func (w *HoldWorker) expire(ctx context.Context) {
+	voided, err := w.expireHolds.Run(ctx)
+	if err != nil {
+		w.log.Error("hold expiry failed", "error", err)
+		return
+	}
+
+	if voided > 0 {
+		w.log.Info("expired holds voided", "count", voided)
+	}
+}
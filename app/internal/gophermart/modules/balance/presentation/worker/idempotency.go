@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/presentation/factory"
+)
+
+// IdempotencyKeyWorker periodically removes idempotency keys older than their TTL.
+type IdempotencyKeyWorker struct {
+	purgeKeys port.BackgroundRunner
+	log       port.Logger
+	interval  time.Duration
+}
+
+// NewIdempotencyKeyWorker creates a new idempotency key purge background worker.
+func NewIdempotencyKeyWorker(useCases factory.UseCaseFactory, log port.Logger, interval time.Duration) *IdempotencyKeyWorker {
+	return &IdempotencyKeyWorker{
+		purgeKeys: useCases.PurgeIdempotencyKeysUseCase(),
+		log:       log,
+		interval:  interval,
+	}
+}
+
+// Start runs the worker loop in a goroutine. Cancel ctx to stop.
+func (w *IdempotencyKeyWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+func (w *IdempotencyKeyWorker) run(ctx context.Context) {
+	w.log.Info("idempotency key purge worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("idempotency key purge worker stopped")
+			return
+		case <-ticker.C:
+			w.purge(ctx)
+		}
+	}
+}
+
+func (w *IdempotencyKeyWorker) purge(ctx context.Context) {
+	purged, err := w.purgeKeys.Run(ctx)
+	if err != nil {
+		w.log.Error("idempotency key purge failed", "error", err)
+		return
+	}
+
+	if purged > 0 {
+		w.log.Info("expired idempotency keys purged", "count", purged)
+	}
+}
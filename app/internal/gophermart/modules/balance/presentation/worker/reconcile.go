@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/presentation/factory"
+)
+
+// ReconcileWorker periodically recomputes balance accounts from source records.
+type ReconcileWorker struct {
+	reconcile port.BackgroundRunner
+	log       port.Logger
+	interval  time.Duration
+}
+
+// NewReconcileWorker creates a new balance reconciliation background worker.
+func NewReconcileWorker(useCases factory.UseCaseFactory, log port.Logger, interval time.Duration) *ReconcileWorker {
+	return &ReconcileWorker{
+		reconcile: useCases.ReconcileBalancesUseCase(),
+		log:       log,
+		interval:  interval,
+	}
+}
+
+// Start runs the worker loop in a goroutine. Cancel ctx to stop.
+func (w *ReconcileWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+func (w *ReconcileWorker) run(ctx context.Context) {
+	w.log.Info("reconcile worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("reconcile worker stopped")
+			return
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+func (w *ReconcileWorker) check(ctx context.Context) {
+	drifted, err := w.reconcile.Run(ctx)
+	if err != nil {
+		w.log.Error("balance reconciliation failed", "error", err)
+		return
+	}
+
+	if drifted > 0 {
+		w.log.Warn("balance reconciliation found mismatches", "count", drifted)
+	}
+}
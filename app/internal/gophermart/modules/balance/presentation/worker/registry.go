@@ -1,6 +1,12 @@
 package worker
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/balance/presentation/factory"
+)
 
 // Starter describes a background worker that can be started with context.
 type Starter interface {
@@ -8,9 +14,33 @@ type Starter interface {
 }
 
 // RegistryParams contains dependencies required to build balance workers.
-type RegistryParams struct{}
+type RegistryParams struct {
+	UseCases factory.UseCaseFactory
+	Log      port.Logger
+	// ExpiryInterval is the points expiry period; 0 disables the expiry worker.
+	ExpiryInterval time.Duration
+	// HoldExpiryInterval is the hold auto-void period; 0 disables the hold worker.
+	HoldExpiryInterval time.Duration
+	// ReconcileInterval is the balance reconciliation period; 0 disables the reconciliation worker.
+	ReconcileInterval time.Duration
+	// IdempotencyPurgeInterval is the idempotency key purge period; 0 disables the purge worker.
+	IdempotencyPurgeInterval time.Duration
+}
 
 // BuildWorkers builds all balance module background workers.
-func BuildWorkers(_ RegistryParams) []Starter {
-	return []Starter{}
+func BuildWorkers(p RegistryParams) []Starter {
+	workers := []Starter{}
+	if p.ExpiryInterval > 0 {
+		workers = append(workers, NewExpiryWorker(p.UseCases, p.Log, p.ExpiryInterval))
+	}
+	if p.HoldExpiryInterval > 0 {
+		workers = append(workers, NewHoldWorker(p.UseCases, p.Log, p.HoldExpiryInterval))
+	}
+	if p.ReconcileInterval > 0 {
+		workers = append(workers, NewReconcileWorker(p.UseCases, p.Log, p.ReconcileInterval))
+	}
+	if p.IdempotencyPurgeInterval > 0 {
+		workers = append(workers, NewIdempotencyKeyWorker(p.UseCases, p.Log, p.IdempotencyPurgeInterval))
+	}
+	return workers
 }
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// Argon2Params are Argon2id cost parameters.
+type Argon2Params struct {
+	// Memory is the memory cost in KiB.
+	Memory      uint32
+	Time        uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follow the second recommended option of RFC 9106 (64 MiB, t=3)
+// with 2 lanes instead of 4.
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Time:        3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Validate checks the parameters are accepted by Argon2id.
+func (p Argon2Params) Validate() error {
+	switch {
+	case p.Time < 1:
+		return errors.New("time must be at least 1")
+	case p.Parallelism < 1:
+		return errors.New("parallelism must be at least 1")
+	case p.Memory < 8*uint32(p.Parallelism):
+		return fmt.Errorf("memory must be at least %d KiB for parallelism %d", 8*uint32(p.Parallelism), p.Parallelism)
+	case p.SaltLength < 8:
+		return errors.New("salt length must be at least 8 bytes")
+	case p.KeyLength < 16:
+		return errors.New("key length must be at least 16 bytes")
+	}
+	return nil
+}
+
+// DefaultArgon2Concurrency is the default number of Argon2id computations run at once.
+const DefaultArgon2Concurrency = 4
+
+// Argon2idHasher hashes and verifies passwords with Argon2id. Hashes are stored in PHC string format
+// ($argon2id$v=19$m=65536,t=3,p=2$salt$hash), so old hashes are verified with their own parameters.
+// Every computation allocates its memory cost, so at most concurrency of them run at once
+// and a burst of logins waits instead of exhausting the process memory.
+type Argon2idHasher struct {
+	params Argon2Params
+	slots  chan struct{}
+}
+
+// NewArgon2idHasher returns a new Argon2id hasher running at most concurrency computations at once;
+// params must pass Validate. A concurrency below 1 is treated as 1.
+func NewArgon2idHasher(params Argon2Params, concurrency int) *Argon2idHasher {
+	return &Argon2idHasher{params: params, slots: make(chan struct{}, max(concurrency, 1))}
+}
+
+// Hash hashes the plain password with a random salt.
+func (h *Argon2idHasher) Hash(plain string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := h.idKey(plain, salt, h.params)
+	return encodeArgon2id(h.params, salt, key), nil
+}
+
+// Compare returns true if plain matches hash.
+func (h *Argon2idHasher) Compare(plain, hash string) bool {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return false
+	}
+	other := h.idKey(plain, salt, params)
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// idKey derives the key once a computation slot is free.
+func (h *Argon2idHasher) idKey(plain string, salt []byte, p Argon2Params) []byte {
+	h.slots <- struct{}{}
+	defer func() { <-h.slots }()
+	return argon2.IDKey([]byte(plain), salt, p.Time, p.Memory, p.Parallelism, p.KeyLength)
+}
+
+// NeedsRehash returns true if hash is not an Argon2id hash of the configured parameters.
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+	params, _, _, err := decodeArgon2id(hash)
+	return err != nil || params != h.params
+}
+
+// Identifies reports whether hash is an Argon2id PHC string.
+func (h *Argon2idHasher) Identifies(hash string) bool {
+	return strings.HasPrefix(hash, argon2idPrefix)
+}
+
+func encodeArgon2id(p Argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, p.Memory, p.Time, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// decodeArgon2id parses a PHC string; salt and key lengths are taken from the encoded values.
+func decodeArgon2id(hash string) (Argon2Params, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return Argon2Params{}, nil, nil, errors.New("not an argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("parse version: %w", err)
+	}
+	if version != argon2.Version {
+		return Argon2Params{}, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	var p Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Parallelism); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("parse parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("decode salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("decode key: %w", err)
+	}
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+
+	if err := p.Validate(); err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	return p, salt, key, nil
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testArgon2Params keep tests fast.
+var testArgon2Params = Argon2Params{Memory: 64, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestArgon2idHasher(t *testing.T) {
+	h := NewArgon2idHasher(testArgon2Params, 1)
+
+	t.Run("hash and compare", func(t *testing.T) {
+		hash, err := h.Hash("password123")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+		assert.True(t, h.Identifies(hash))
+
+		assert.True(t, h.Compare("password123", hash))
+		assert.False(t, h.Compare("wrong", hash))
+	})
+
+	t.Run("salted", func(t *testing.T) {
+		first, err := h.Hash("same")
+		require.NoError(t, err)
+		second, err := h.Hash("same")
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("long password", func(t *testing.T) {
+		long := strings.Repeat("a", 100)
+		hash, err := h.Hash(long)
+		require.NoError(t, err)
+
+		assert.True(t, h.Compare(long, hash))
+		assert.False(t, h.Compare(long[:72], hash))
+	})
+
+	t.Run("verifies with stored parameters", func(t *testing.T) {
+		old := NewArgon2idHasher(Argon2Params{Memory: 32, Time: 2, Parallelism: 1, SaltLength: 8, KeyLength: 16}, 1)
+		hash, err := old.Hash("password123")
+		require.NoError(t, err)
+
+		assert.True(t, h.Compare("password123", hash))
+		assert.True(t, h.NeedsRehash(hash))
+		assert.False(t, old.NeedsRehash(hash))
+	})
+
+	t.Run("malformed hash", func(t *testing.T) {
+		for _, hash := range []string{
+			"",
+			"$2a$10$abcdefghijklmnopqrstuu",
+			"$argon2id$v=18$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+			"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+			"$argon2id$v=19$m=64,t=1,p=1$!!!$aGFzaGhhc2hoYXNoaGFzaA",
+		} {
+			assert.False(t, h.Compare("password123", hash), hash)
+			assert.True(t, h.NeedsRehash(hash), hash)
+		}
+	})
+
+	t.Run("waits for a free computation slot", func(t *testing.T) {
+		limited := NewArgon2idHasher(testArgon2Params, 1)
+		limited.slots <- struct{}{}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = limited.Hash("password123")
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("hash computed while every slot was taken")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		<-limited.slots
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("hash not computed after the slot was freed")
+		}
+	})
+}
+
+func TestArgon2Params_Validate(t *testing.T) {
+	assert.NoError(t, DefaultArgon2Params.Validate())
+	assert.NoError(t, testArgon2Params.Validate())
+
+	invalid := []Argon2Params{
+		{Memory: 64, Time: 0, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		{Memory: 64, Time: 1, Parallelism: 0, SaltLength: 16, KeyLength: 32},
+		{Memory: 8, Time: 1, Parallelism: 2, SaltLength: 16, KeyLength: 32},
+		{Memory: 64, Time: 1, Parallelism: 1, SaltLength: 4, KeyLength: 32},
+		{Memory: 64, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 8},
+	}
+	for _, p := range invalid {
+		assert.Error(t, p.Validate(), "%+v", p)
+	}
+}
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"strings"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -27,3 +29,19 @@ func (h *BCryptHasher) Hash(plain string) (string, error) {
 func (h *BCryptHasher) Compare(plain, hash string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain)) == nil
 }
+
+// NeedsRehash returns true if hash is not a bcrypt hash of the configured cost.
+func (h *BCryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
+
+// Identifies reports whether hash is a bcrypt hash ($2a$, $2b$ or $2y$).
+func (h *BCryptHasher) Identifies(hash string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -31,4 +31,20 @@ func TestBCryptHasher(t *testing.T) {
 		assert.True(t, h.Compare("", hash))
 		assert.False(t, h.Compare("notempty", hash))
 	})
+
+	t.Run("needs rehash", func(t *testing.T) {
+		hash, err := h.Hash("password123")
+		require.NoError(t, err)
+		assert.False(t, h.NeedsRehash(hash))
+
+		assert.True(t, NewBCryptHasher(5).NeedsRehash(hash))
+		assert.True(t, h.NeedsRehash("$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA"))
+	})
+
+	t.Run("identifies bcrypt hashes", func(t *testing.T) {
+		hash, err := h.Hash("password123")
+		require.NoError(t, err)
+		assert.True(t, h.Identifies(hash))
+		assert.False(t, h.Identifies("$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA"))
+	})
 }
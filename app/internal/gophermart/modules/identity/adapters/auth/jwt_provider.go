@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"gophermart/internal/gophermart/modules/identity/application/port"
@@ -12,53 +13,88 @@ import (
 
 var errInvalidToken = errors.New("invalid token")
 
-// JWTProvider issues and validates JWT tokens.
+// JWTProvider issues and validates JWT tokens with a key set.
+// New tokens carry the kid of the signing key; any key of the set verifies.
 type JWTProvider struct {
-	secret []byte
-	ttl    time.Duration
+	keys *KeySet
+	ttl  time.Duration
 }
 
 // NewJWTProvider returns a new JWT token provider.
-func NewJWTProvider(secret string, ttl time.Duration) *JWTProvider {
-	return &JWTProvider{secret: []byte(secret), ttl: ttl}
+func NewJWTProvider(keys *KeySet, ttl time.Duration) *JWTProvider {
+	return &JWTProvider{keys: keys, ttl: ttl}
 }
 
 type claims struct {
-	UserID int64 `json:"sub"`
+	UserID    int64 `json:"sub"`
+	SessionID int64 `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-// Issue issues a JWT for the given user ID.
-func (p *JWTProvider) Issue(userID vo.UserID) (string, error) {
+// Issue issues a JWT for the given user and session.
+func (p *JWTProvider) Issue(userID vo.UserID, sessionID vo.SessionID) (string, error) {
 	now := time.Now()
 	c := claims{
-		UserID: int64(userID),
+		UserID:    int64(userID),
+		SessionID: int64(sessionID),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(p.ttl)),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
-	unsignedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
-	signedToken, err := unsignedToken.SignedString(p.secret)
+	signing := p.keys.signing
+	unsignedToken := jwt.NewWithClaims(signing.method, c)
+	unsignedToken.Header["kid"] = signing.ID
+	signedToken, err := unsignedToken.SignedString(signing.signKey)
 	if err != nil {
 		return "", err
 	}
 	return signedToken, nil
 }
 
-// Validate parses the token and returns the user ID.
-func (p *JWTProvider) Validate(tokenString string) (vo.UserID, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &claims{}, func(t *jwt.Token) (interface{}, error) {
-		return p.secret, nil
-	})
+// Validate parses the token and returns the user and session IDs.
+func (p *JWTProvider) Validate(tokenString string) (vo.UserID, vo.SessionID, error) {
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		&claims{},
+		p.verificationKey,
+		jwt.WithValidMethods(p.keys.algorithms()),
+	)
 	if err != nil || !token.Valid {
-		return 0, errInvalidToken
+		return 0, 0, errInvalidToken
 	}
 	c, ok := token.Claims.(*claims)
 	if !ok {
-		return 0, errInvalidToken
+		return 0, 0, errInvalidToken
 	}
-	return vo.UserID(c.UserID), nil
+	return vo.UserID(c.UserID), vo.SessionID(c.SessionID), nil
+}
+
+// verificationKey picks the key by kid header. The token algorithm must match the key,
+// so a public key is never accepted as an HMAC secret.
+func (p *JWTProvider) verificationKey(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	k, ok := p.keys.lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if t.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("algorithm %s does not match key %q", t.Method.Alg(), k.ID)
+	}
+	return k.verifyKey, nil
+}
+
+// PublicKeys returns the RS256 and EdDSA verification keys in configuration order.
+func (p *JWTProvider) PublicKeys() []port.PublicKey {
+	var result []port.PublicKey
+	for _, id := range p.keys.order {
+		k := p.keys.keys[id]
+		if _, ok := k.method.(*jwt.SigningMethodHMAC); ok {
+			continue
+		}
+		result = append(result, port.PublicKey{ID: k.ID, Algorithm: k.method.Alg(), Key: k.verifyKey})
+	}
+	return result
 }
 
 var _ port.TokenProvider = (*JWTProvider)(nil)
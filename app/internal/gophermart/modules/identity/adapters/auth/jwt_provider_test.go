@@ -1,47 +1,145 @@
 package auth
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
 	"testing"
 	"time"
 
 	"gophermart/internal/gophermart/modules/identity/domain/vo"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func newTestKeySet(t *testing.T, signingKeyID string, keys ...Key) *KeySet {
+	t.Helper()
+	s, err := NewKeySet(signingKeyID, keys...)
+	require.NoError(t, err)
+	return s
+}
+
+func newTestEdKey(t *testing.T, id string) Key {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k, err := NewKey(id, priv)
+	require.NoError(t, err)
+	return k
+}
+
 func TestJWTProvider_IssueAndValidate(t *testing.T) {
-	p := NewJWTProvider("test-secret", time.Hour)
+	p := NewJWTProvider(newTestKeySet(t, "", NewHMACKey(LegacyKeyID, []byte("test-secret"))), time.Hour)
 
 	t.Run("round trip", func(t *testing.T) {
-		token, err := p.Issue(vo.UserID(42))
+		token, err := p.Issue(vo.UserID(42), vo.SessionID(7))
 		assert.NoError(t, err)
 		assert.NotEmpty(t, token)
 
-		id, err := p.Validate(token)
+		id, sessionID, err := p.Validate(token)
 		assert.NoError(t, err)
 		assert.Equal(t, vo.UserID(42), id)
+		assert.Equal(t, vo.SessionID(7), sessionID)
 	})
 
 	t.Run("expired token", func(t *testing.T) {
-		expired := NewJWTProvider("test-secret", -time.Hour)
-		token, err := expired.Issue(vo.UserID(1))
+		expired := NewJWTProvider(p.keys, -time.Hour)
+		token, err := expired.Issue(vo.UserID(1), vo.SessionID(1))
 		assert.NoError(t, err)
 
-		_, err = p.Validate(token)
+		_, _, err = p.Validate(token)
 		assert.Error(t, err)
 	})
 
 	t.Run("invalid token string", func(t *testing.T) {
-		_, err := p.Validate("not-a-jwt")
+		_, _, err := p.Validate("not-a-jwt")
 		assert.Error(t, err)
 	})
 
 	t.Run("wrong secret", func(t *testing.T) {
-		other := NewJWTProvider("other-secret", time.Hour)
-		token, err := other.Issue(vo.UserID(1))
+		other := NewJWTProvider(newTestKeySet(t, "", NewHMACKey(LegacyKeyID, []byte("other-secret"))), time.Hour)
+		token, err := other.Issue(vo.UserID(1), vo.SessionID(1))
+		assert.NoError(t, err)
+
+		_, _, err = p.Validate(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("token without kid uses legacy key", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+			UserID:    5,
+			SessionID: 6,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+
+		id, sessionID, err := p.Validate(token)
+		assert.NoError(t, err)
+		assert.Equal(t, vo.UserID(5), id)
+		assert.Equal(t, vo.SessionID(6), sessionID)
+	})
+}
+
+func TestJWTProvider_Rotation(t *testing.T) {
+	oldKey := newTestEdKey(t, "2025-07")
+	newKey := newTestEdKey(t, "2026-01")
+
+	before := NewJWTProvider(newTestKeySet(t, "2025-07", oldKey), time.Hour)
+	after := NewJWTProvider(newTestKeySet(t, "2026-01", newKey, oldKey), time.Hour)
+
+	oldToken, err := before.Issue(vo.UserID(1), vo.SessionID(2))
+	require.NoError(t, err)
+	newToken, err := after.Issue(vo.UserID(3), vo.SessionID(4))
+	require.NoError(t, err)
+
+	t.Run("new tokens carry the signing kid", func(t *testing.T) {
+		parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &claims{})
+		require.NoError(t, err)
+		assert.Equal(t, "2026-01", parsed.Header["kid"])
+		assert.Equal(t, "EdDSA", parsed.Header["alg"])
+	})
+
+	t.Run("tokens of the previous key stay valid", func(t *testing.T) {
+		id, _, err := after.Validate(oldToken)
 		assert.NoError(t, err)
+		assert.Equal(t, vo.UserID(1), id)
+	})
 
-		_, err = p.Validate(token)
+	t.Run("unknown kid", func(t *testing.T) {
+		_, _, err := before.Validate(newToken)
 		assert.Error(t, err)
 	})
 }
+
+func TestJWTProvider_RejectsAlgorithmMismatch(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaKey, err := NewKey("rsa", &priv.PublicKey)
+	require.NoError(t, err)
+	p := NewJWTProvider(newTestKeySet(t, LegacyKeyID, rsaKey, NewHMACKey(LegacyKeyID, []byte("test-secret"))), time.Hour)
+
+	// An HS256 token claiming the RSA kid must not be verified with the public key bytes.
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{UserID: 1, SessionID: 1})
+	token.Header["kid"] = "rsa"
+	signed, err := token.SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+
+	_, _, err = p.Validate(signed)
+	assert.Error(t, err)
+}
+
+func TestJWTProvider_PublicKeys(t *testing.T) {
+	edKey := newTestEdKey(t, "ed")
+	p := NewJWTProvider(newTestKeySet(t, "ed", edKey, NewHMACKey(LegacyKeyID, []byte("test-secret"))), time.Hour)
+
+	keys := p.PublicKeys()
+
+	require.Len(t, keys, 1)
+	assert.Equal(t, "ed", keys[0].ID)
+	assert.Equal(t, "EdDSA", keys[0].Algorithm)
+	assert.IsType(t, ed25519.PublicKey{}, keys[0].Key)
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// LegacyKeyID is the kid of the HS256 key built from the shared JWT secret.
+// Tokens without a kid header were issued before key rotation and are verified with it.
+const LegacyKeyID = "default"
+
+// minRSAKeyBits is the smallest RSA modulus accepted for RS256 keys.
+const minRSAKeyBits = 2048
+
+// Key is a JWT signing or verification key identified by kid.
+type Key struct {
+	ID     string
+	method jwt.SigningMethod
+	// signKey is nil for verify-only keys loaded from public key files.
+	signKey   any
+	verifyKey any
+}
+
+// NewHMACKey returns an HS256 key for the shared secret.
+func NewHMACKey(id string, secret []byte) Key {
+	return Key{ID: id, method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+}
+
+// NewKey returns an RS256 or EdDSA key. Private keys can sign, public keys only verify.
+func NewKey(id string, key any) (Key, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return Key{}, fmt.Errorf("RSA key must be at least %d bits, got %d", minRSAKeyBits, k.N.BitLen())
+		}
+		return Key{ID: id, method: jwt.SigningMethodRS256, signKey: k, verifyKey: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return Key{}, fmt.Errorf("RSA key must be at least %d bits, got %d", minRSAKeyBits, k.N.BitLen())
+		}
+		return Key{ID: id, method: jwt.SigningMethodRS256, verifyKey: k}, nil
+	case ed25519.PrivateKey:
+		return Key{ID: id, method: jwt.SigningMethodEdDSA, signKey: k, verifyKey: k.Public()}, nil
+	case ed25519.PublicKey:
+		return Key{ID: id, method: jwt.SigningMethodEdDSA, verifyKey: k}, nil
+	default:
+		return Key{}, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// ParsePEMKey parses a PEM encoded RSA or Ed25519 key in PKCS#1, PKCS#8 or PKIX form.
+func ParsePEMKey(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, errors.New("no PEM block found")
+	}
+
+	var (
+		parsed any
+		err    error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	return NewKey(id, parsed)
+}
+
+// KeyFile points to a PEM file of the JWT key set.
+type KeyFile struct {
+	ID   string
+	Path string
+}
+
+// ParseKeyFiles parses a comma-separated list of kid:path entries, e.g.
+// "2026-01:/etc/gophermart/jwt-2026-01.pem,2025-07:/etc/gophermart/jwt-2025-07.pub.pem".
+func ParseKeyFiles(spec string) ([]KeyFile, error) {
+	var files []KeyFile
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, path, ok := strings.Cut(entry, ":")
+		id, path = strings.TrimSpace(id), strings.TrimSpace(path)
+		if !ok || id == "" || path == "" {
+			return nil, fmt.Errorf("invalid key entry %q: want kid:path", entry)
+		}
+		if id == LegacyKeyID {
+			return nil, fmt.Errorf("kid %q is reserved for the JWT secret", LegacyKeyID)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate kid %q", id)
+		}
+		seen[id] = true
+		files = append(files, KeyFile{ID: id, Path: path})
+	}
+	return files, nil
+}
+
+// KeySet holds the keys that verify tokens and the one that signs new tokens.
+type KeySet struct {
+	signing Key
+	keys    map[string]Key
+	// order keeps keys in configuration order for publishing.
+	order []string
+}
+
+// NewKeySet returns a key set signing with the key signingKeyID.
+// An empty signingKeyID selects the first key.
+func NewKeySet(signingKeyID string, keys ...Key) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("key set is empty")
+	}
+	if signingKeyID == "" {
+		signingKeyID = keys[0].ID
+	}
+
+	s := &KeySet{keys: make(map[string]Key, len(keys))}
+	for _, k := range keys {
+		if _, ok := s.keys[k.ID]; ok {
+			return nil, fmt.Errorf("duplicate kid %q", k.ID)
+		}
+		s.keys[k.ID] = k
+		s.order = append(s.order, k.ID)
+	}
+
+	signing, ok := s.keys[signingKeyID]
+	if !ok {
+		return nil, fmt.Errorf("signing key %q not found", signingKeyID)
+	}
+	if signing.signKey == nil {
+		return nil, fmt.Errorf("signing key %q has no private key", signingKeyID)
+	}
+	s.signing = signing
+
+	return s, nil
+}
+
+// LoadKeySet builds the key set from key files and the optional shared secret,
+// which is added as an HS256 key under LegacyKeyID.
+func LoadKeySet(secret string, files []KeyFile, signingKeyID string) (*KeySet, error) {
+	keys := make([]Key, 0, len(files)+1)
+	for _, f := range files {
+		data, err := os.ReadFile(f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("read key %q: %w", f.ID, err)
+		}
+		k, err := ParsePEMKey(f.ID, data)
+		if err != nil {
+			return nil, fmt.Errorf("parse key %q: %w", f.ID, err)
+		}
+		keys = append(keys, k)
+	}
+	if secret != "" {
+		keys = append(keys, NewHMACKey(LegacyKeyID, []byte(secret)))
+	}
+	return NewKeySet(signingKeyID, keys...)
+}
+
+// lookup returns the key for the kid header; an empty kid selects the legacy key.
+func (s *KeySet) lookup(kid string) (Key, bool) {
+	if kid == "" {
+		kid = LegacyKeyID
+	}
+	k, ok := s.keys[kid]
+	return k, ok
+}
+
+// algorithms returns the algorithms of all keys in the set.
+func (s *KeySet) algorithms() []string {
+	seen := make(map[string]bool)
+	var algs []string
+	for _, id := range s.order {
+		alg := s.keys[id].method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func TestParseKeyFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []KeyFile
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"two keys", "a:/keys/a.pem, b:/keys/b.pub.pem", []KeyFile{{"a", "/keys/a.pem"}, {"b", "/keys/b.pub.pem"}}, false},
+		{"path with colon", "a:C:/keys/a.pem", []KeyFile{{"a", "C:/keys/a.pem"}}, false},
+		{"missing path", "a", nil, true},
+		{"empty kid", ":/keys/a.pem", nil, true},
+		{"duplicate kid", "a:/x.pem,a:/y.pem", nil, true},
+		{"reserved kid", LegacyKeyID + ":/x.pem", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKeyFiles(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPrivDER, err := x509.MarshalPKCS8PrivateKey(edPriv)
+	require.NoError(t, err)
+	edPubDER, err := x509.MarshalPKIXPublicKey(edPub)
+	require.NoError(t, err)
+
+	rsaPath := writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPriv))
+	edPath := writePEM(t, "ed.pem", "PRIVATE KEY", edPrivDER)
+	edPubPath := writePEM(t, "ed.pub.pem", "PUBLIC KEY", edPubDER)
+
+	t.Run("first key signs by default", func(t *testing.T) {
+		s, err := LoadKeySet("secret", []KeyFile{{"rsa", rsaPath}, {"ed", edPath}}, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "rsa", s.signing.ID)
+		assert.Equal(t, []string{"rsa", "ed", LegacyKeyID}, s.order)
+		assert.Equal(t, []string{"RS256", "EdDSA", "HS256"}, s.algorithms())
+	})
+
+	t.Run("secret only", func(t *testing.T) {
+		s, err := LoadKeySet("secret", nil, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, LegacyKeyID, s.signing.ID)
+	})
+
+	t.Run("public key is verify only", func(t *testing.T) {
+		s, err := LoadKeySet("", []KeyFile{{"ed", edPath}, {"old", edPubPath}}, "ed")
+		require.NoError(t, err)
+		k, ok := s.lookup("old")
+		require.True(t, ok)
+		assert.Nil(t, k.signKey)
+
+		_, err = LoadKeySet("", []KeyFile{{"old", edPubPath}}, "old")
+		assert.ErrorContains(t, err, "no private key")
+	})
+
+	t.Run("unknown signing key", func(t *testing.T) {
+		_, err := LoadKeySet("secret", []KeyFile{{"ed", edPath}}, "missing")
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		_, err := LoadKeySet("", nil, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadKeySet("", []KeyFile{{"ed", filepath.Join(t.TempDir(), "none.pem")}}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("not a PEM file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bad.pem")
+		require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+
+		_, err := LoadKeySet("", []KeyFile{{"bad", path}}, "")
+		assert.ErrorContains(t, err, "no PEM block")
+	})
+}
+
+func TestNewKey_RejectsWeakRSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	_, err = NewKey("weak", priv)
+	assert.Error(t, err)
+}
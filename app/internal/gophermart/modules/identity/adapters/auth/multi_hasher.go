@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"fmt"
+
+	appport "gophermart/internal/gophermart/application/port"
+)
+
+// Names of the password hashing algorithms selectable in config.
+const (
+	AlgorithmBCrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// Algorithm is a password hasher that recognizes its own hashes.
+type Algorithm interface {
+	appport.PasswordHasher
+	Identifies(hash string) bool
+}
+
+// MultiHasher hashes new passwords with the primary algorithm and verifies stored hashes
+// with whichever configured algorithm made them. Hashes of other algorithms need a rehash,
+// so users move to the primary algorithm on their next login.
+type MultiHasher struct {
+	primary Algorithm
+	others  []Algorithm
+}
+
+// NewMultiHasher returns a hasher creating hashes with primary and also verifying hashes of others.
+func NewMultiHasher(primary Algorithm, others ...Algorithm) *MultiHasher {
+	return &MultiHasher{primary: primary, others: others}
+}
+
+// NewPasswordHasher returns a MultiHasher hashing with the named algorithm
+// and verifying hashes of both bcrypt and Argon2id, so switching the algorithm keeps old hashes valid.
+// argon2Concurrency bounds the Argon2id computations run at once.
+func NewPasswordHasher(
+	algorithm string,
+	bcryptCost int,
+	argon2Params Argon2Params,
+	argon2Concurrency int,
+) (*MultiHasher, error) {
+	bcryptHasher := NewBCryptHasher(bcryptCost)
+	argon2Hasher := NewArgon2idHasher(argon2Params, argon2Concurrency)
+
+	switch algorithm {
+	case AlgorithmBCrypt:
+		return NewMultiHasher(bcryptHasher, argon2Hasher), nil
+	case AlgorithmArgon2id:
+		if err := argon2Params.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+		}
+		return NewMultiHasher(argon2Hasher, bcryptHasher), nil
+	default:
+		return nil, fmt.Errorf("unknown password hashing algorithm %q", algorithm)
+	}
+}
+
+// Hash hashes the plain password with the primary algorithm.
+func (h *MultiHasher) Hash(plain string) (string, error) {
+	return h.primary.Hash(plain)
+}
+
+// Compare returns true if plain matches hash; false if no algorithm recognizes the hash.
+func (h *MultiHasher) Compare(plain, hash string) bool {
+	if h.primary.Identifies(hash) {
+		return h.primary.Compare(plain, hash)
+	}
+	for _, a := range h.others {
+		if a.Identifies(hash) {
+			return a.Compare(plain, hash)
+		}
+	}
+	return false
+}
+
+// NeedsRehash returns true unless hash was made by the primary algorithm with its current parameters.
+func (h *MultiHasher) NeedsRehash(hash string) bool {
+	return h.primary.NeedsRehash(hash)
+}
+
+var (
+	_ Algorithm              = (*BCryptHasher)(nil)
+	_ Algorithm              = (*Argon2idHasher)(nil)
+	_ appport.PasswordHasher = (*MultiHasher)(nil)
+)
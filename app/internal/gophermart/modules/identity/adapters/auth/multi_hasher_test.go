@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiHasher(t *testing.T) {
+	bcryptHasher := NewBCryptHasher(4)
+	argonHasher := NewArgon2idHasher(testArgon2Params, 1)
+	h := NewMultiHasher(argonHasher, bcryptHasher)
+
+	bcryptHash, err := bcryptHasher.Hash("password123")
+	require.NoError(t, err)
+
+	t.Run("new hashes use the primary algorithm", func(t *testing.T) {
+		hash, err := h.Hash("password123")
+		require.NoError(t, err)
+
+		assert.True(t, argonHasher.Identifies(hash))
+		assert.True(t, h.Compare("password123", hash))
+		assert.False(t, h.NeedsRehash(hash))
+	})
+
+	t.Run("hashes of other algorithms keep working", func(t *testing.T) {
+		assert.True(t, h.Compare("password123", bcryptHash))
+		assert.False(t, h.Compare("wrong", bcryptHash))
+		assert.True(t, h.NeedsRehash(bcryptHash))
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		assert.False(t, h.Compare("password123", "plain:password123"))
+		assert.True(t, h.NeedsRehash("plain:password123"))
+	})
+
+	t.Run("hashes of unconfigured algorithms are rejected", func(t *testing.T) {
+		argonOnly := NewMultiHasher(argonHasher)
+		assert.False(t, argonOnly.Compare("password123", bcryptHash))
+	})
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	t.Run("bcrypt", func(t *testing.T) {
+		h, err := NewPasswordHasher(AlgorithmBCrypt, 4, testArgon2Params, 1)
+		require.NoError(t, err)
+
+		hash, err := h.Hash("password123")
+		require.NoError(t, err)
+		assert.True(t, NewBCryptHasher(4).Identifies(hash))
+	})
+
+	t.Run("argon2id", func(t *testing.T) {
+		h, err := NewPasswordHasher(AlgorithmArgon2id, 4, testArgon2Params, 1)
+		require.NoError(t, err)
+
+		hash, err := h.Hash("password123")
+		require.NoError(t, err)
+		assert.True(t, NewArgon2idHasher(testArgon2Params, 1).Identifies(hash))
+	})
+
+	t.Run("invalid argon2id parameters", func(t *testing.T) {
+		_, err := NewPasswordHasher(AlgorithmArgon2id, 4, Argon2Params{}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		_, err := NewPasswordHasher("scrypt", 4, testArgon2Params, 1)
+		assert.Error(t, err)
+	})
+}
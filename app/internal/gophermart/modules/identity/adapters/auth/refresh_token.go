@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"gophermart/internal/gophermart/modules/identity/application/port"
+)
+
+const refreshTokenBytes = 32
+
+// OpaqueTokenGenerator creates random refresh tokens and hashes them with SHA-256.
+// The tokens carry enough entropy that an unsalted fast hash is safe to store.
+type OpaqueTokenGenerator struct{}
+
+// NewOpaqueTokenGenerator returns a new refresh token generator.
+func NewOpaqueTokenGenerator() *OpaqueTokenGenerator {
+	return &OpaqueTokenGenerator{}
+}
+
+// Generate returns a new random URL-safe token.
+func (g *OpaqueTokenGenerator) Generate() (string, error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Hash returns the hex SHA-256 of the token.
+func (g *OpaqueTokenGenerator) Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ port.RefreshTokenGenerator = (*OpaqueTokenGenerator)(nil)
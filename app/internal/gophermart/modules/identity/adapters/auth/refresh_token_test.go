@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpaqueTokenGenerator(t *testing.T) {
+	g := NewOpaqueTokenGenerator()
+
+	first, err := g.Generate()
+	require.NoError(t, err)
+	second, err := g.Generate()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, g.Hash(first), g.Hash(first))
+	assert.NotEqual(t, g.Hash(first), g.Hash(second))
+	assert.Len(t, g.Hash(first), 64)
+}
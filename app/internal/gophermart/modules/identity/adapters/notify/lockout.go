@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// LogLockoutNotifier reports account lockouts to the service log for alerting.
+type LogLockoutNotifier struct {
+	log appport.Logger
+}
+
+// NewLogLockoutNotifier creates a new LogLockoutNotifier.
+func NewLogLockoutNotifier(log appport.Logger) *LogLockoutNotifier {
+	return &LogLockoutNotifier{log: log}
+}
+
+// AccountLocked logs the lockout with the affected user.
+func (n *LogLockoutNotifier) AccountLocked(_ context.Context, userID vo.UserID, login string, until time.Time) error {
+	n.log.Warn("account locked after failed logins", "user_id", userID, "login", login, "until", until)
+	return nil
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+)
+
+// LoginFailureRepository is an in-memory implementation of port.LoginFailureRepository.
+// Failures are local to the instance and lost on restart; use it for a single instance.
+type LoginFailureRepository struct {
+	mu       sync.Mutex
+	failures map[string]entity.LoginFailures
+}
+
+// NewLoginFailureRepository creates an empty LoginFailureRepository.
+func NewLoginFailureRepository() *LoginFailureRepository {
+	return &LoginFailureRepository{failures: make(map[string]entity.LoginFailures)}
+}
+
+// Find returns a copy of the failures recorded for the key or application.ErrNotFound.
+func (r *LoginFailureRepository) Find(_ context.Context, key string) (*entity.LoginFailures, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, ok := r.failures[key]
+	if !ok {
+		return nil, application.ErrNotFound
+	}
+	return &f, nil
+}
+
+// Update applies fn to the failures of the key while holding the repository lock.
+func (r *LoginFailureRepository) Update(_ context.Context, key string, fn func(f *entity.LoginFailures)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, ok := r.failures[key]
+	if !ok {
+		f = entity.LoginFailures{Key: key}
+	}
+	fn(&f)
+	r.failures[key] = f
+	return nil
+}
+
+// Delete forgets the failures of the key.
+func (r *LoginFailureRepository) Delete(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.failures, key)
+	return nil
+}
+
+// DeleteStale removes records whose last failure and lockout end are both before t.
+func (r *LoginFailureRepository) DeleteStale(_ context.Context, t time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for key, f := range r.failures {
+		if f.LastFailedAt.Before(t) && (f.LockedUntil == nil || f.LockedUntil.Before(t)) {
+			delete(r.failures, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+)
+
+func TestLoginFailureRepository(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("update creates and find copies", func(t *testing.T) {
+		r := NewLoginFailureRepository()
+
+		_, err := r.Find(ctx, "login:alice")
+		assert.ErrorIs(t, err, application.ErrNotFound)
+
+		require.NoError(t, r.Update(ctx, "login:alice", func(f *entity.LoginFailures) {
+			assert.Equal(t, entity.LoginFailures{Key: "login:alice"}, *f)
+			f.Count, f.LastFailedAt = 1, now
+		}))
+
+		f, err := r.Find(ctx, "login:alice")
+		require.NoError(t, err)
+		f.Count = 100
+
+		f, err = r.Find(ctx, "login:alice")
+		require.NoError(t, err)
+		assert.Equal(t, 1, f.Count)
+
+		require.NoError(t, r.Delete(ctx, "login:alice"))
+		_, err = r.Find(ctx, "login:alice")
+		assert.ErrorIs(t, err, application.ErrNotFound)
+	})
+
+	t.Run("concurrent updates are serialized", func(t *testing.T) {
+		r := NewLoginFailureRepository()
+
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = r.Update(ctx, "ip:10.0.0.1", func(f *entity.LoginFailures) { f.Count++ })
+			}()
+		}
+		wg.Wait()
+
+		f, err := r.Find(ctx, "ip:10.0.0.1")
+		require.NoError(t, err)
+		assert.Equal(t, 50, f.Count)
+	})
+
+	t.Run("delete stale keeps recent and locked records", func(t *testing.T) {
+		r := NewLoginFailureRepository()
+		lockedUntil := now.Add(time.Hour)
+		set := func(key string, last time.Time, locked *time.Time) {
+			require.NoError(t, r.Update(ctx, key, func(f *entity.LoginFailures) {
+				f.Count, f.LastFailedAt, f.LockedUntil = 1, last, locked
+			}))
+		}
+		set("login:stale", now.Add(-2*time.Hour), nil)
+		set("login:recent", now, nil)
+		set("login:locked", now.Add(-2*time.Hour), &lockedUntil)
+
+		n, err := r.DeleteStale(ctx, now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		_, err = r.Find(ctx, "login:stale")
+		assert.ErrorIs(t, err, application.ErrNotFound)
+		_, err = r.Find(ctx, "login:locked")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,18 @@
+package converter
+
+import (
+	"gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+)
+
+//go:generate goverter gen .
+
+// goverter:converter
+// goverter:output:file login_failures_gen.go
+// goverter:output:package converter
+// goverter:extend gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/converter/convext:CopyTime
+// goverter:extend gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/converter/convext:CopyTimePtr
+type LoginFailuresConverter interface {
+	ToEntity(source model.LoginFailures) entity.LoginFailures
+	ToModel(source entity.LoginFailures) model.LoginFailures
+}
@@ -0,0 +1,29 @@
+// Code generated by github.com/jmattheis/goverter, DO NOT EDIT.
+//go:build !goverter
+
+package converter
+
+import (
+	convext "gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/converter/convext"
+	model "gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/model"
+	entity "gophermart/internal/gophermart/modules/identity/domain/entity"
+)
+
+type LoginFailuresConverterImpl struct{}
+
+func (c *LoginFailuresConverterImpl) ToEntity(source model.LoginFailures) entity.LoginFailures {
+	var entityLoginFailures entity.LoginFailures
+	entityLoginFailures.Key = source.Key
+	entityLoginFailures.Count = source.Count
+	entityLoginFailures.LastFailedAt = convext.CopyTime(source.LastFailedAt)
+	entityLoginFailures.LockedUntil = convext.CopyTimePtr(source.LockedUntil)
+	return entityLoginFailures
+}
+func (c *LoginFailuresConverterImpl) ToModel(source entity.LoginFailures) model.LoginFailures {
+	var modelLoginFailures model.LoginFailures
+	modelLoginFailures.Key = source.Key
+	modelLoginFailures.Count = source.Count
+	modelLoginFailures.LastFailedAt = convext.CopyTime(source.LastFailedAt)
+	modelLoginFailures.LockedUntil = convext.CopyTimePtr(source.LockedUntil)
+	return modelLoginFailures
+}
@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+)
+
+//go:generate goverter gen .
+
+// goverter:converter
+// goverter:output:file session_gen.go
+// goverter:output:package converter
+// goverter:extend gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/converter/convext:CopyTime
+// goverter:extend gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/converter/convext:CopyTimePtr
+type SessionConverter interface {
+	ToEntity(source model.Session) entity.Session
+	ToModel(source entity.Session) model.Session
+	RefreshTokenToEntity(source model.RefreshToken) entity.RefreshToken
+	RefreshTokenToModel(source entity.RefreshToken) model.RefreshToken
+}
@@ -0,0 +1,50 @@
+// Code generated by github.com/jmattheis/goverter, DO NOT EDIT.
+//go:build !goverter
+
+package converter
+
+import (
+	convext "gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/converter/convext"
+	model "gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/model"
+	entity "gophermart/internal/gophermart/modules/identity/domain/entity"
+	vo "gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+type SessionConverterImpl struct{}
+
+func (c *SessionConverterImpl) RefreshTokenToEntity(source model.RefreshToken) entity.RefreshToken {
+	var entityRefreshToken entity.RefreshToken
+	entityRefreshToken.Hash = source.Hash
+	entityRefreshToken.SessionID = vo.SessionID(source.SessionID)
+	entityRefreshToken.IssuedAt = convext.CopyTime(source.IssuedAt)
+	entityRefreshToken.UsedAt = convext.CopyTimePtr(source.UsedAt)
+	return entityRefreshToken
+}
+func (c *SessionConverterImpl) RefreshTokenToModel(source entity.RefreshToken) model.RefreshToken {
+	var modelRefreshToken model.RefreshToken
+	modelRefreshToken.Hash = source.Hash
+	modelRefreshToken.SessionID = int64(source.SessionID)
+	modelRefreshToken.IssuedAt = convext.CopyTime(source.IssuedAt)
+	modelRefreshToken.UsedAt = convext.CopyTimePtr(source.UsedAt)
+	return modelRefreshToken
+}
+func (c *SessionConverterImpl) ToEntity(source model.Session) entity.Session {
+	var entitySession entity.Session
+	entitySession.ID = vo.SessionID(source.ID)
+	entitySession.UserID = vo.UserID(source.UserID)
+	entitySession.CreatedAt = convext.CopyTime(source.CreatedAt)
+	entitySession.ExpiresAt = convext.CopyTime(source.ExpiresAt)
+	entitySession.MaxExpiresAt = convext.CopyTime(source.MaxExpiresAt)
+	entitySession.RevokedAt = convext.CopyTimePtr(source.RevokedAt)
+	return entitySession
+}
+func (c *SessionConverterImpl) ToModel(source entity.Session) model.Session {
+	var modelSession model.Session
+	modelSession.ID = int64(source.ID)
+	modelSession.UserID = int64(source.UserID)
+	modelSession.CreatedAt = convext.CopyTime(source.CreatedAt)
+	modelSession.ExpiresAt = convext.CopyTime(source.ExpiresAt)
+	modelSession.MaxExpiresAt = convext.CopyTime(source.MaxExpiresAt)
+	modelSession.RevokedAt = convext.CopyTimePtr(source.RevokedAt)
+	return modelSession
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/converter"
+	"gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+)
+
+// LoginFailureRepository is a PostgreSQL implementation of port.LoginFailureRepository.
+type LoginFailureRepository struct {
+	transactor *postgreskit.Transactor
+	conv       converter.LoginFailuresConverter
+}
+
+// NewLoginFailureRepository creates a new LoginFailureRepository.
+func NewLoginFailureRepository(transactor *postgreskit.Transactor) *LoginFailureRepository {
+	return &LoginFailureRepository{
+		transactor: transactor,
+		conv:       &converter.LoginFailuresConverterImpl{},
+	}
+}
+
+// Find returns the failures recorded for the key or application.ErrNotFound.
+func (r *LoginFailureRepository) Find(ctx context.Context, key string) (*entity.LoginFailures, error) {
+	var f entity.LoginFailures
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, `
+			SELECT key, count, last_failed_at, locked_until
+			FROM login_failures
+			WHERE key = $1
+		`, key)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRow, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[model.LoginFailures])
+		if err != nil {
+			return err
+		}
+
+		f = r.conv.ToEntity(dbRow)
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, application.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+// Update applies fn to the row of the key under a row lock. A missing row is inserted empty
+// first, so concurrent first failures of the same key are serialized as well.
+func (r *LoginFailureRepository) Update(ctx context.Context, key string, fn func(f *entity.LoginFailures)) error {
+	return r.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		q := r.transactor.GetQuerier(ctx)
+
+		_, err := q.Exec(ctx, `
+			INSERT INTO login_failures (key)
+			VALUES ($1)
+			ON CONFLICT (key) DO NOTHING
+		`, key)
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.Query(ctx, `
+			SELECT key, count, last_failed_at, locked_until
+			FROM login_failures
+			WHERE key = $1
+			FOR UPDATE
+		`, key)
+		if err != nil {
+			return err
+		}
+		dbRow, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[model.LoginFailures])
+		if err != nil {
+			return err
+		}
+
+		f := r.conv.ToEntity(dbRow)
+		if f.Count == 0 {
+			// Just inserted: start from an empty record, not from the column defaults.
+			f = entity.LoginFailures{Key: key}
+		}
+		fn(&f)
+		dbRow = r.conv.ToModel(f)
+
+		_, err = q.Exec(ctx, `
+			UPDATE login_failures
+			SET count = $1, last_failed_at = $2, locked_until = $3
+			WHERE key = $4
+		`, dbRow.Count, dbRow.LastFailedAt, dbRow.LockedUntil, key)
+		return err
+	})
+}
+
+// Delete forgets the failures of the key.
+func (r *LoginFailureRepository) Delete(ctx context.Context, key string) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		_, err := q.Exec(ctx, `DELETE FROM login_failures WHERE key = $1`, key)
+		return err
+	})
+}
+
+// DeleteStale removes rows whose last failure and lockout end are both before t.
+func (r *LoginFailureRepository) DeleteStale(ctx context.Context, t time.Time) (int, error) {
+	var deleted int
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		tag, err := q.Exec(ctx, `
+			DELETE FROM login_failures
+			WHERE last_failed_at < $1 AND (locked_until IS NULL OR locked_until < $1)
+		`, t)
+		if err != nil {
+			return err
+		}
+
+		deleted = int(tag.RowsAffected())
+		return nil
+	})
+
+	return deleted, err
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// LoginFailures is the DB projection of the login_failures table row.
+type LoginFailures struct {
+	Key          string
+	Count        int
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
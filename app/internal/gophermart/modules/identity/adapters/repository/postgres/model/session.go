@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Session is the DB projection of the sessions table row.
+type Session struct {
+	ID           int64
+	UserID       int64
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	MaxExpiresAt time.Time
+	RevokedAt    *time.Time
+}
+
+// RefreshToken is the DB projection of the refresh_tokens table row.
+type RefreshToken struct {
+	Hash      string
+	SessionID int64
+	IssuedAt  time.Time
+	UsedAt    *time.Time
+}
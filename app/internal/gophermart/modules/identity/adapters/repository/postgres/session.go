@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/converter"
+	"gophermart/internal/gophermart/modules/identity/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// SessionRepository is a PostgreSQL implementation of port.SessionRepository.
+type SessionRepository struct {
+	transactor *postgreskit.Transactor
+	conv       converter.SessionConverter
+}
+
+// NewSessionRepository creates a new SessionRepository.
+func NewSessionRepository(transactor *postgreskit.Transactor) *SessionRepository {
+	return &SessionRepository{
+		transactor: transactor,
+		conv:       &converter.SessionConverterImpl{},
+	}
+}
+
+// Create inserts the session and sets s.ID.
+func (r *SessionRepository) Create(ctx context.Context, s *entity.Session) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbSession := r.conv.ToModel(*s)
+
+		var dbID int64
+		err := q.QueryRow(ctx, `
+			INSERT INTO sessions (user_id, created_at, expires_at, max_expires_at, revoked_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, dbSession.UserID, dbSession.CreatedAt, dbSession.ExpiresAt, dbSession.MaxExpiresAt, dbSession.RevokedAt).Scan(&dbID)
+		if err != nil {
+			return err
+		}
+		s.ID = vo.SessionID(dbID)
+
+		return nil
+	})
+}
+
+// Update persists the session expiry and revocation. Revocation is monotonic: a stored revocation
+// is never cleared, and an update that does not revoke the session applies only while it is not revoked,
+// so a refresh racing a logout cannot bring the session back.
+//
+// Errors:
+//   - application.ErrConflict — the session does not exist, or the update does not revoke it
+//     and it was revoked meanwhile
+func (r *SessionRepository) Update(ctx context.Context, s *entity.Session) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbSession := r.conv.ToModel(*s)
+
+		tag, err := q.Exec(ctx, `
+			UPDATE sessions
+			SET expires_at = $1, revoked_at = COALESCE(revoked_at, $2)
+			WHERE id = $3 AND ($2::TIMESTAMPTZ IS NOT NULL OR revoked_at IS NULL)
+		`, dbSession.ExpiresAt, dbSession.RevokedAt, dbSession.ID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrConflict
+		}
+
+		return nil
+	})
+}
+
+// FindByID returns the session or application.ErrNotFound.
+func (r *SessionRepository) FindByID(ctx context.Context, id vo.SessionID) (*entity.Session, error) {
+	var s entity.Session
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, `
+			SELECT id, user_id, created_at, expires_at, max_expires_at, revoked_at
+			FROM sessions
+			WHERE id = $1
+		`, id)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRow, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[model.Session])
+		if err != nil {
+			return err
+		}
+
+		s = r.conv.ToEntity(dbRow)
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, application.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// AddRefreshToken stores a refresh token issued for a session.
+func (r *SessionRepository) AddRefreshToken(ctx context.Context, t entity.RefreshToken) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbToken := r.conv.RefreshTokenToModel(t)
+
+		_, err := q.Exec(ctx, `
+			INSERT INTO refresh_tokens (token_hash, session_id, issued_at, used_at)
+			VALUES ($1, $2, $3, $4)
+		`, dbToken.Hash, dbToken.SessionID, dbToken.IssuedAt, dbToken.UsedAt)
+		return err
+	})
+}
+
+// FindRefreshToken returns the refresh token with the hash or application.ErrNotFound.
+func (r *SessionRepository) FindRefreshToken(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	var t entity.RefreshToken
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, `
+			SELECT token_hash, session_id, issued_at, used_at
+			FROM refresh_tokens
+			WHERE token_hash = $1
+		`, hash)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRow, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[model.RefreshToken])
+		if err != nil {
+			return err
+		}
+
+		t = r.conv.RefreshTokenToEntity(dbRow)
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, application.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// UseRefreshToken marks the token used; the conditional update lets only one of
+// concurrent refreshes with the same token win, the others get application.ErrConflict.
+func (r *SessionRepository) UseRefreshToken(ctx context.Context, hash string, at time.Time) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		tag, err := q.Exec(ctx, `
+			UPDATE refresh_tokens
+			SET used_at = $1
+			WHERE token_hash = $2 AND used_at IS NULL
+		`, at, hash)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrConflict
+		}
+
+		return nil
+	})
+}
+
+// DeleteStale removes sessions that expired or were revoked before the given time, with their
+// refresh tokens, and refresh tokens of other sessions used before it; returns the number of removed rows.
+func (r *SessionRepository) DeleteStale(ctx context.Context, before time.Time) (int, error) {
+	var deleted int
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		return q.QueryRow(ctx, `
+			WITH ended AS (
+				DELETE FROM sessions
+				WHERE expires_at < $1 OR revoked_at < $1
+				RETURNING id
+			), used AS (
+				DELETE FROM refresh_tokens
+				WHERE used_at < $1 AND session_id NOT IN (SELECT id FROM ended)
+				RETURNING 1
+			)
+			SELECT (SELECT count(*) FROM ended) + (SELECT count(*) FROM used)
+		`, before).Scan(&deleted)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// RevokeByUserID revokes the user's active sessions except keep.
+func (r *SessionRepository) RevokeByUserID(ctx context.Context, userID vo.UserID, keep vo.SessionID, at time.Time) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		_, err := q.Exec(ctx, `
+			UPDATE sessions
+			SET revoked_at = $1
+			WHERE user_id = $2 AND id <> $3 AND revoked_at IS NULL
+		`, at, userID, keep)
+		return err
+	})
+}
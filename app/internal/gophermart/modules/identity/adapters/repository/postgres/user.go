@@ -53,6 +53,28 @@ func (r *UserRepository) Create(ctx context.Context, u *entity.User) error {
 	})
 }
 
+// UpdatePasswordHash replaces the hash only if it is still oldHash; updated_at is maintained by a trigger.
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id vo.UserID, oldHash, newHash string) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		tag, err := q.Exec(ctx, `
+			UPDATE users
+			SET password_hash = $1
+			WHERE id = $2 AND password_hash = $3
+		`, newHash, id, oldHash)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrConflict
+		}
+
+		return nil
+	})
+}
+
 func (r *UserRepository) FindByID(ctx context.Context, id vo.UserID) (*entity.User, error) {
 	var u entity.User
 
@@ -0,0 +1,15 @@
+package api
+
+import "context"
+
+// UserRef is a module API view of a user.
+type UserRef struct {
+	UserID int64
+	Login  string
+}
+
+// UserDirectoryAPI defines the user lookup contract exposed to other modules.
+type UserDirectoryAPI interface {
+	// FindByLogin returns application.ErrNotFound if no user has the login.
+	FindByLogin(ctx context.Context, login string) (UserRef, error)
+}
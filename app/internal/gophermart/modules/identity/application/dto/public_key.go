@@ -0,0 +1,10 @@
+package dto
+
+import "crypto"
+
+// PublicKey is a published token verification key.
+type PublicKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// SessionTokens is the token pair of an auth session.
+type SessionTokens struct {
+	AccessToken  string
+	RefreshToken string
+	// ExpiresAt is when the session ends unless the refresh token is used before.
+	ExpiresAt time.Time
+}
+
+// Principal identifies the user and session behind a valid access token.
+type Principal struct {
+	UserID    vo.UserID
+	SessionID vo.SessionID
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "gophermart/internal/gophermart/modules/identity/domain/vo"
+
 // RegisterInput is the input for user registration.
 type RegisterInput struct {
 	Login    string
@@ -10,4 +12,14 @@ type RegisterInput struct {
 type LoginInput struct {
 	Login    string
 	Password string
+	// ClientIP throttles failed logins per client; empty skips the per-IP throttle.
+	ClientIP string
+}
+
+// ChangePasswordInput is the input for a password change within an auth session.
+type ChangePasswordInput struct {
+	UserID          vo.UserID
+	SessionID       vo.SessionID
+	CurrentPassword string
+	NewPassword     string
 }
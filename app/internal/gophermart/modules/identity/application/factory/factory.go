@@ -1,7 +1,10 @@
 package factory
 
 import (
+	"time"
+
 	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/api"
 	"gophermart/internal/gophermart/modules/identity/application/dto"
 	"gophermart/internal/gophermart/modules/identity/application/port"
 	"gophermart/internal/gophermart/modules/identity/application/usecase"
@@ -11,16 +14,37 @@ import (
 // Params contains dependencies required to build identity use cases.
 type Params struct {
 	UserRepo       port.UserRepository
+	SessionRepo    port.SessionRepository
 	BalanceGateway port.BalanceGateway
 	Transactor     appport.Transactor
 	Hasher         appport.PasswordHasher
+	Tokens         port.TokenProvider
+	RefreshTokens  port.RefreshTokenGenerator
+	LoginFailures  port.LoginFailureRepository
+	Notifier       port.LockoutNotifier
 	Clock          appport.Clock
+	Log            appport.Logger
+	// SessionTTL is how long a session lasts without a refresh.
+	SessionTTL time.Duration
+	// SessionMaxLifetime caps a session from login, however often it is refreshed.
+	SessionMaxLifetime time.Duration
+	// LoginPolicy throttles failed logins per login, IPPolicy per client IP.
+	LoginPolicy vo.LoginThrottlePolicy
+	IPPolicy    vo.LoginThrottlePolicy
 }
 
 // UseCases holds identity module use cases exposed to composition root.
 type UseCases struct {
-	Register appport.UseCase[dto.RegisterInput, vo.UserID]
-	Login    appport.UseCase[dto.LoginInput, vo.UserID]
+	Register           appport.UseCase[dto.RegisterInput, vo.UserID]
+	Login              appport.UseCase[dto.LoginInput, vo.UserID]
+	ChangePassword     appport.UseCase[dto.ChangePasswordInput, struct{}]
+	CreateSession      appport.UseCase[vo.UserID, dto.SessionTokens]
+	RefreshSession     appport.UseCase[string, dto.SessionTokens]
+	RevokeSession      appport.UseCase[vo.SessionID, struct{}]
+	Authenticate       appport.UseCase[string, dto.Principal]
+	ListPublicKeys     appport.UseCase[struct{}, []dto.PublicKey]
+	PurgeLoginFailures appport.BackgroundRunner
+	PurgeSessions      appport.BackgroundRunner
 }
 
 // NewUseCases builds identity module use cases.
@@ -29,6 +53,29 @@ func NewUseCases(p Params) UseCases {
 		Register: usecase.NewRegisterUser(
 			p.UserRepo, p.UserRepo, p.BalanceGateway, p.Transactor, p.Hasher, p.Clock,
 		),
-		Login: usecase.NewLoginUser(p.UserRepo, p.Hasher),
+		Login: usecase.NewLoginUser(
+			p.UserRepo, p.UserRepo, p.LoginFailures, p.Notifier, p.Hasher, p.Clock, p.Log, p.LoginPolicy, p.IPPolicy,
+		),
+		ChangePassword: usecase.NewChangePassword(
+			p.UserRepo, p.UserRepo, p.SessionRepo, p.Transactor, p.Hasher,
+			p.LoginFailures, p.Notifier, p.Clock, p.Log, p.LoginPolicy,
+		),
+		CreateSession: usecase.NewCreateSession(
+			p.SessionRepo, p.Tokens, p.RefreshTokens, p.Transactor, p.Clock, p.SessionTTL, p.SessionMaxLifetime,
+		),
+		RefreshSession: usecase.NewRefreshSession(
+			p.SessionRepo, p.SessionRepo, p.Tokens, p.RefreshTokens, p.Transactor, p.Clock, p.SessionTTL,
+		),
+		RevokeSession:      usecase.NewRevokeSession(p.SessionRepo, p.SessionRepo, p.Clock),
+		Authenticate:       usecase.NewAuthenticate(p.SessionRepo, p.Tokens, p.Clock),
+		ListPublicKeys:     usecase.NewListPublicKeys(p.Tokens),
+		PurgeLoginFailures: usecase.NewPurgeLoginFailures(p.LoginFailures, p.Clock, p.LoginPolicy, p.IPPolicy),
+		PurgeSessions:      usecase.NewPurgeSessions(p.SessionRepo, p.Clock, p.SessionTTL),
 	}
 }
+
+// NewUserDirectory builds the identity user directory API. It depends only on the user repository,
+// so modules that identity itself depends on can be built with it.
+func NewUserDirectory(userRepo port.UserReader) api.UserDirectoryAPI {
+	return usecase.NewUserDirectory(userRepo)
+}
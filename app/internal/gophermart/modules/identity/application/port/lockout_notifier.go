@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// LockoutNotifier is told when repeated failed logins lock an account out.
+type LockoutNotifier interface {
+	AccountLocked(ctx context.Context, userID vo.UserID, login string, until time.Time) error
+}
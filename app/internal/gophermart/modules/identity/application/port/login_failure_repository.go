@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+)
+
+// LoginFailureRepository stores failed login attempts per throttle key.
+type LoginFailureRepository interface {
+	// Find returns the failures recorded for the key or application.ErrNotFound.
+	Find(ctx context.Context, key string) (*entity.LoginFailures, error)
+	// Update applies fn to the failures of the key, starting from an empty record if there are none,
+	// and stores the result. Concurrent updates of the same key are serialized.
+	Update(ctx context.Context, key string, fn func(f *entity.LoginFailures)) error
+	// Delete forgets the failures of the key; a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// DeleteStale removes records whose last failure and lockout are both before t
+	// and returns the number of removed records.
+	DeleteStale(ctx context.Context, t time.Time) (int, error)
+}
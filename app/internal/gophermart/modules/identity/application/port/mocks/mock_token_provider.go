@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	port "gophermart/internal/gophermart/modules/identity/application/port"
 	vo "gophermart/internal/gophermart/modules/identity/domain/vo"
 	reflect "reflect"
 
@@ -41,27 +42,42 @@ func (m *MockTokenProvider) EXPECT() *MockTokenProviderMockRecorder {
 }
 
 // Issue mocks base method.
-func (m *MockTokenProvider) Issue(userID vo.UserID) (string, error) {
+func (m *MockTokenProvider) Issue(userID vo.UserID, sessionID vo.SessionID) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Issue", userID)
+	ret := m.ctrl.Call(m, "Issue", userID, sessionID)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Issue indicates an expected call of Issue.
-func (mr *MockTokenProviderMockRecorder) Issue(userID any) *gomock.Call {
+func (mr *MockTokenProviderMockRecorder) Issue(userID, sessionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockTokenProvider)(nil).Issue), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockTokenProvider)(nil).Issue), userID, sessionID)
+}
+
+// PublicKeys mocks base method.
+func (m *MockTokenProvider) PublicKeys() []port.PublicKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicKeys")
+	ret0, _ := ret[0].([]port.PublicKey)
+	return ret0
+}
+
+// PublicKeys indicates an expected call of PublicKeys.
+func (mr *MockTokenProviderMockRecorder) PublicKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicKeys", reflect.TypeOf((*MockTokenProvider)(nil).PublicKeys))
 }
 
 // Validate mocks base method.
-func (m *MockTokenProvider) Validate(token string) (vo.UserID, error) {
+func (m *MockTokenProvider) Validate(token string) (vo.UserID, vo.SessionID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Validate", token)
 	ret0, _ := ret[0].(vo.UserID)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(vo.SessionID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Validate indicates an expected call of Validate.
@@ -69,3 +85,56 @@ func (mr *MockTokenProviderMockRecorder) Validate(token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTokenProvider)(nil).Validate), token)
 }
+
+// MockRefreshTokenGenerator is a mock of RefreshTokenGenerator interface.
+type MockRefreshTokenGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenGeneratorMockRecorder is the mock recorder for MockRefreshTokenGenerator.
+type MockRefreshTokenGeneratorMockRecorder struct {
+	mock *MockRefreshTokenGenerator
+}
+
+// NewMockRefreshTokenGenerator creates a new mock instance.
+func NewMockRefreshTokenGenerator(ctrl *gomock.Controller) *MockRefreshTokenGenerator {
+	mock := &MockRefreshTokenGenerator{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenGenerator) EXPECT() *MockRefreshTokenGeneratorMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockRefreshTokenGenerator) Generate() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockRefreshTokenGeneratorMockRecorder) Generate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockRefreshTokenGenerator)(nil).Generate))
+}
+
+// Hash mocks base method.
+func (m *MockRefreshTokenGenerator) Hash(token string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", token)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockRefreshTokenGeneratorMockRecorder) Hash(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockRefreshTokenGenerator)(nil).Hash), token)
+}
@@ -0,0 +1,42 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// SessionReader provides read-only access to auth sessions.
+type SessionReader interface {
+	// FindByID returns the session or application.ErrNotFound.
+	FindByID(ctx context.Context, id vo.SessionID) (*entity.Session, error)
+	// FindRefreshToken returns the refresh token with the hash or application.ErrNotFound.
+	FindRefreshToken(ctx context.Context, hash string) (*entity.RefreshToken, error)
+}
+
+// SessionWriter provides write access to auth sessions.
+type SessionWriter interface {
+	// Create inserts the session and sets its ID.
+	Create(ctx context.Context, s *entity.Session) error
+	// Update persists the session expiry and revocation. A stored revocation is kept; an update that
+	// does not revoke the session returns application.ErrConflict if it was revoked meanwhile.
+	Update(ctx context.Context, s *entity.Session) error
+	// AddRefreshToken stores a refresh token issued for a session.
+	AddRefreshToken(ctx context.Context, t entity.RefreshToken) error
+	// UseRefreshToken marks the token used at the given time;
+	// returns application.ErrConflict if it was already used.
+	UseRefreshToken(ctx context.Context, hash string, at time.Time) error
+	// RevokeByUserID revokes all active sessions of the user except keep.
+	RevokeByUserID(ctx context.Context, userID vo.UserID, keep vo.SessionID, at time.Time) error
+	// DeleteStale removes sessions that ended before the given time with their refresh tokens,
+	// and refresh tokens used before it; returns the number of removed sessions and tokens.
+	DeleteStale(ctx context.Context, before time.Time) (int, error)
+}
+
+// SessionRepository combines reader and writer for identity DI wiring.
+type SessionRepository interface {
+	SessionReader
+	SessionWriter
+}
@@ -1,9 +1,29 @@
 package port
 
-import "gophermart/internal/gophermart/modules/identity/domain/vo"
+import (
+	"crypto"
+
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
 
 // TokenProvider issues and validates auth tokens.
 type TokenProvider interface {
-	Issue(userID vo.UserID) (token string, err error)
-	Validate(token string) (userID vo.UserID, err error)
+	Issue(userID vo.UserID, sessionID vo.SessionID) (token string, err error)
+	Validate(token string) (userID vo.UserID, sessionID vo.SessionID, err error)
+	// PublicKeys returns the asymmetric keys that verify issued tokens; shared secrets are never listed.
+	PublicKeys() []PublicKey
+}
+
+// PublicKey is a token verification key other services may use.
+type PublicKey struct {
+	// ID is the kid header of tokens signed with the matching private key.
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// RefreshTokenGenerator creates opaque refresh tokens; only their hashes are stored.
+type RefreshTokenGenerator interface {
+	Generate() (token string, err error)
+	Hash(token string) string
 }
@@ -16,6 +16,9 @@ type UserReader interface {
 // UserWriter provides write access to users for identity module.
 type UserWriter interface {
 	Create(ctx context.Context, u *entity.User) error
+	// UpdatePasswordHash replaces oldHash with newHash; returns application.ErrConflict
+	// if the stored hash is no longer oldHash, e.g. the password was changed concurrently.
+	UpdatePasswordHash(ctx context.Context, id vo.UserID, oldHash, newHash string) error
 }
 
 // UserRepository combines reader and writer for identity DI wiring.
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+)
+
+// Authenticate resolves an access token to the user and session it was issued for.
+type Authenticate struct {
+	sessionReader port.SessionReader
+	tokens        port.TokenProvider
+	clock         appport.Clock
+}
+
+// NewAuthenticate returns the authenticate use case.
+func NewAuthenticate(
+	sessionReader port.SessionReader,
+	tokens port.TokenProvider,
+	clock appport.Clock,
+) appport.UseCase[string, dto.Principal] {
+	return &Authenticate{sessionReader: sessionReader, tokens: tokens, clock: clock}
+}
+
+// Execute validates the token and checks that its session is still active,
+// so tokens of logged out or revoked sessions are rejected before they expire.
+//
+// Errors:
+//   - application.ErrInvalidCredentials — token is invalid or expired, or its session is not active
+func (uc *Authenticate) Execute(ctx context.Context, token string) (dto.Principal, error) {
+	userID, sessionID, err := uc.tokens.Validate(token)
+	if err != nil {
+		return dto.Principal{}, application.ErrInvalidCredentials
+	}
+
+	s, err := uc.sessionReader.FindByID(ctx, sessionID)
+	if errors.Is(err, application.ErrNotFound) {
+		return dto.Principal{}, application.ErrInvalidCredentials
+	}
+	if err != nil {
+		return dto.Principal{}, err
+	}
+	if s.UserID != userID || !s.Active(uc.clock.Now()) {
+		return dto.Principal{}, application.ErrInvalidCredentials
+	}
+
+	return dto.Principal{UserID: userID, SessionID: sessionID}, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// ChangePassword replaces the user's password and ends the user's other sessions.
+type ChangePassword struct {
+	userReader    port.UserReader
+	userWriter    port.UserWriter
+	sessionWriter port.SessionWriter
+	transactor    appport.Transactor
+	hasher        appport.PasswordHasher
+	attempts      loginAttempts
+	loginPolicy   vo.LoginThrottlePolicy
+	clock         appport.Clock
+}
+
+// NewChangePassword returns the change password use case.
+// Wrong current passwords count as failed logins of the account under loginPolicy.
+func NewChangePassword(
+	userReader port.UserReader,
+	userWriter port.UserWriter,
+	sessionWriter port.SessionWriter,
+	transactor appport.Transactor,
+	hasher appport.PasswordHasher,
+	failures port.LoginFailureRepository,
+	notifier port.LockoutNotifier,
+	clock appport.Clock,
+	log appport.Logger,
+	loginPolicy vo.LoginThrottlePolicy,
+) appport.UseCase[dto.ChangePasswordInput, struct{}] {
+	return &ChangePassword{
+		userReader:    userReader,
+		userWriter:    userWriter,
+		sessionWriter: sessionWriter,
+		transactor:    transactor,
+		hasher:        hasher,
+		attempts:      loginAttempts{failures: failures, notifier: notifier, clock: clock, log: log},
+		loginPolicy:   loginPolicy,
+		clock:         clock,
+	}
+}
+
+// Execute checks the current password, stores the new hash and revokes all sessions
+// of the user except the one the change was made in. The current password check shares
+// the login throttle of the account, so a stolen access token does not allow guessing it.
+//
+// Errors:
+//   - application.ErrInvalidCredentials — current password is wrong
+//   - *application.ErrLoginThrottled — too many failed password checks, retry later
+//   - application.ErrConflict — password was changed concurrently
+func (uc *ChangePassword) Execute(ctx context.Context, in dto.ChangePasswordInput) (struct{}, error) {
+	u, err := uc.userReader.FindByID(ctx, in.UserID)
+	if err != nil {
+		return struct{}{}, err
+	}
+
+	reserved, err := uc.attempts.reserve(ctx, []throttle{{key: entity.LoginThrottleKey(u.Login), policy: uc.loginPolicy}})
+	if err != nil {
+		return struct{}{}, err
+	}
+	if !uc.hasher.Compare(in.CurrentPassword, u.PasswordHash) {
+		return struct{}{}, uc.attempts.fail(ctx, reserved, u)
+	}
+	uc.attempts.succeed(ctx, reserved, u.ID)
+
+	hash, err := uc.hasher.Hash(in.NewPassword)
+	if err != nil {
+		return struct{}{}, err
+	}
+
+	err = uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.userWriter.UpdatePasswordHash(ctx, u.ID, u.PasswordHash, hash); err != nil {
+			return err
+		}
+		return uc.sessionWriter.RevokeByUserID(ctx, u.ID, in.SessionID, uc.clock.Now())
+	})
+	return struct{}{}, err
+}
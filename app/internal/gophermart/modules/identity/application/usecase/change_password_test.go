@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	identityportmocks "gophermart/internal/gophermart/modules/identity/application/port/mocks"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestChangePassword_Execute(t *testing.T) {
+	ctx := context.Background()
+	input := dto.ChangePasswordInput{UserID: 1, SessionID: 5, CurrentPassword: "old", NewPassword: "new"}
+	user := &entity.User{ID: 1, Login: "alice", PasswordHash: "old-hash"}
+	policy := vo.LoginThrottlePolicy{
+		FreeAttempts:     1,
+		BaseDelay:        time.Second,
+		LockoutThreshold: 3,
+		LockoutDuration:  15 * time.Minute,
+	}
+
+	t.Run("success revokes other sessions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepo := identityportmocks.NewMockUserRepository(ctrl)
+		sessionWriter := identityportmocks.NewMockSessionWriter(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		userRepo.EXPECT().FindByID(ctx, vo.UserID(1)).Return(user, nil)
+		clk.EXPECT().Now().Return(sessionTime).Times(2)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{}))
+		hasher.EXPECT().Compare("old", "old-hash").Return(true)
+		failures.EXPECT().Delete(ctx, "login:alice").Return(nil)
+		hasher.EXPECT().Hash("new").Return("new-hash", nil)
+		runInTransaction(transactor, ctx)
+		userRepo.EXPECT().UpdatePasswordHash(ctx, vo.UserID(1), "old-hash", "new-hash").Return(nil)
+		sessionWriter.EXPECT().RevokeByUserID(ctx, vo.UserID(1), vo.SessionID(5), sessionTime).Return(nil)
+
+		uc := NewChangePassword(userRepo, userRepo, sessionWriter, transactor, hasher, failures, nil, clk, nil, policy)
+		_, err := uc.Execute(ctx, input)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong current password counts a failed login", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepo := identityportmocks.NewMockUserRepository(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		recorded := entity.LoginFailures{Key: "login:alice"}
+		userRepo.EXPECT().FindByID(ctx, vo.UserID(1)).Return(user, nil)
+		clk.EXPECT().Now().Return(sessionTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&recorded))
+		hasher.EXPECT().Compare("old", "old-hash").Return(false)
+
+		uc := NewChangePassword(userRepo, userRepo, nil, nil, hasher, failures, nil, clk, nil, policy)
+		_, err := uc.Execute(ctx, input)
+
+		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+		assert.Equal(t, 1, recorded.Count)
+	})
+
+	t.Run("lockout notifies the user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepo := identityportmocks.NewMockUserRepository(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		notifier := identityportmocks.NewMockLockoutNotifier(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		stored := entity.LoginFailures{Key: "login:alice", Count: 2, LastFailedAt: sessionTime.Add(-time.Minute)}
+		userRepo.EXPECT().FindByID(ctx, vo.UserID(1)).Return(user, nil)
+		clk.EXPECT().Now().Return(sessionTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&stored))
+		hasher.EXPECT().Compare("old", "old-hash").Return(false)
+		notifier.EXPECT().AccountLocked(ctx, vo.UserID(1), "alice", sessionTime.Add(15*time.Minute)).Return(nil)
+
+		uc := NewChangePassword(userRepo, userRepo, nil, nil, hasher, failures, notifier, clk, nil, policy)
+		_, err := uc.Execute(ctx, input)
+
+		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+	})
+
+	t.Run("throttled check is rejected without comparing the password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepo := identityportmocks.NewMockUserRepository(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		lockedUntil := sessionTime.Add(10 * time.Minute)
+		userRepo.EXPECT().FindByID(ctx, vo.UserID(1)).Return(user, nil)
+		clk.EXPECT().Now().Return(sessionTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{
+			Key: "login:alice", Count: 3, LastFailedAt: sessionTime, LockedUntil: &lockedUntil,
+		}))
+
+		uc := NewChangePassword(userRepo, userRepo, nil, nil, nil, failures, nil, clk, nil, policy)
+		_, err := uc.Execute(ctx, input)
+
+		var throttled *application.ErrLoginThrottled
+		require.ErrorAs(t, err, &throttled)
+		assert.Equal(t, 10*time.Minute, throttled.RetryAfter)
+	})
+
+	t.Run("concurrent change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepo := identityportmocks.NewMockUserRepository(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		userRepo.EXPECT().FindByID(ctx, vo.UserID(1)).Return(user, nil)
+		clk.EXPECT().Now().Return(sessionTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{}))
+		hasher.EXPECT().Compare("old", "old-hash").Return(true)
+		failures.EXPECT().Delete(ctx, "login:alice").Return(nil)
+		hasher.EXPECT().Hash("new").Return("new-hash", nil)
+		runInTransaction(transactor, ctx)
+		userRepo.EXPECT().UpdatePasswordHash(ctx, vo.UserID(1), "old-hash", "new-hash").Return(application.ErrConflict)
+
+		uc := NewChangePassword(userRepo, userRepo, nil, transactor, hasher, failures, nil, clk, nil, policy)
+		_, err := uc.Execute(ctx, input)
+
+		assert.ErrorIs(t, err, application.ErrConflict)
+	})
+}
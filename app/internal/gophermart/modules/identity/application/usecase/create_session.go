@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// CreateSession starts an auth session for an authenticated user.
+type CreateSession struct {
+	sessionWriter port.SessionWriter
+	tokens        port.TokenProvider
+	refreshTokens port.RefreshTokenGenerator
+	transactor    appport.Transactor
+	clock         appport.Clock
+	ttl           time.Duration
+	maxLifetime   time.Duration
+}
+
+// NewCreateSession returns the create session use case; sessions last ttl unless refreshed
+// and end maxLifetime after login however often they are refreshed.
+func NewCreateSession(
+	sessionWriter port.SessionWriter,
+	tokens port.TokenProvider,
+	refreshTokens port.RefreshTokenGenerator,
+	transactor appport.Transactor,
+	clock appport.Clock,
+	ttl time.Duration,
+	maxLifetime time.Duration,
+) appport.UseCase[vo.UserID, dto.SessionTokens] {
+	return &CreateSession{
+		sessionWriter: sessionWriter,
+		tokens:        tokens,
+		refreshTokens: refreshTokens,
+		transactor:    transactor,
+		clock:         clock,
+		ttl:           ttl,
+		maxLifetime:   maxLifetime,
+	}
+}
+
+// Execute stores a new session and issues its access and refresh tokens.
+func (uc *CreateSession) Execute(ctx context.Context, userID vo.UserID) (dto.SessionTokens, error) {
+	now := uc.clock.Now()
+	s := entity.NewSession(userID, now, uc.ttl, uc.maxLifetime)
+
+	var out dto.SessionTokens
+	err := uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.sessionWriter.Create(ctx, s); err != nil {
+			return err
+		}
+
+		var err error
+		out, err = issueSessionTokens(ctx, uc.sessionWriter, uc.tokens, uc.refreshTokens, s, now)
+		return err
+	})
+	if err != nil {
+		return dto.SessionTokens{}, err
+	}
+
+	return out, nil
+}
+
+// issueSessionTokens stores a new refresh token of the session and issues an access token for it.
+func issueSessionTokens(
+	ctx context.Context,
+	sessionWriter port.SessionWriter,
+	tokens port.TokenProvider,
+	refreshTokens port.RefreshTokenGenerator,
+	s *entity.Session,
+	now time.Time,
+) (dto.SessionTokens, error) {
+	refreshToken, err := refreshTokens.Generate()
+	if err != nil {
+		return dto.SessionTokens{}, err
+	}
+
+	err = sessionWriter.AddRefreshToken(ctx, entity.RefreshToken{
+		Hash:      refreshTokens.Hash(refreshToken),
+		SessionID: s.ID,
+		IssuedAt:  now,
+	})
+	if err != nil {
+		return dto.SessionTokens{}, err
+	}
+
+	accessToken, err := tokens.Issue(s.UserID, s.ID)
+	if err != nil {
+		return dto.SessionTokens{}, err
+	}
+
+	return dto.SessionTokens{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresAt: s.ExpiresAt}, nil
+}
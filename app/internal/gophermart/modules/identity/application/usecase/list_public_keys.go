@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+)
+
+// ListPublicKeys returns the keys other services use to verify access tokens.
+type ListPublicKeys struct {
+	tokens port.TokenProvider
+}
+
+// NewListPublicKeys returns the list public keys use case.
+func NewListPublicKeys(tokens port.TokenProvider) appport.UseCase[struct{}, []dto.PublicKey] {
+	return &ListPublicKeys{tokens: tokens}
+}
+
+// Execute returns the asymmetric verification keys, retired ones included while they are configured.
+func (uc *ListPublicKeys) Execute(_ context.Context, _ struct{}) ([]dto.PublicKey, error) {
+	keys := uc.tokens.PublicKeys()
+
+	result := make([]dto.PublicKey, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, dto.PublicKey{ID: k.ID, Algorithm: k.Algorithm, Key: k.Key})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+	identityportmocks "gophermart/internal/gophermart/modules/identity/application/port/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListPublicKeys_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tokens := identityportmocks.NewMockTokenProvider(ctrl)
+	key := ed25519.PublicKey(make([]byte, ed25519.PublicKeySize))
+	tokens.EXPECT().PublicKeys().Return([]port.PublicKey{{ID: "2026-01", Algorithm: "EdDSA", Key: key}})
+
+	out, err := NewListPublicKeys(tokens).Execute(context.Background(), struct{}{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []dto.PublicKey{{ID: "2026-01", Algorithm: "EdDSA", Key: key}}, out)
+}
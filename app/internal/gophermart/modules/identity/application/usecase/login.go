@@ -2,36 +2,109 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
 	"gophermart/internal/gophermart/application"
 	appport "gophermart/internal/gophermart/application/port"
 	"gophermart/internal/gophermart/modules/identity/application/dto"
 	"gophermart/internal/gophermart/modules/identity/application/port"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
 	"gophermart/internal/gophermart/modules/identity/domain/vo"
 )
 
 // LoginUser authenticates by login and password.
 type LoginUser struct {
-	userReader port.UserReader
-	hasher     appport.PasswordHasher
+	userReader  port.UserReader
+	userWriter  port.UserWriter
+	attempts    loginAttempts
+	hasher      appport.PasswordHasher
+	log         appport.Logger
+	loginPolicy vo.LoginThrottlePolicy
+	ipPolicy    vo.LoginThrottlePolicy
 }
 
 // NewLoginUser returns the login use case (interactor) as port abstraction.
-func NewLoginUser(userReader port.UserReader, hasher appport.PasswordHasher) appport.UseCase[dto.LoginInput, vo.UserID] {
-	return &LoginUser{userReader: userReader, hasher: hasher}
+// Failed logins are throttled per login with loginPolicy and per client IP with ipPolicy.
+func NewLoginUser(
+	userReader port.UserReader,
+	userWriter port.UserWriter,
+	failures port.LoginFailureRepository,
+	notifier port.LockoutNotifier,
+	hasher appport.PasswordHasher,
+	clock appport.Clock,
+	log appport.Logger,
+	loginPolicy vo.LoginThrottlePolicy,
+	ipPolicy vo.LoginThrottlePolicy,
+) appport.UseCase[dto.LoginInput, vo.UserID] {
+	return &LoginUser{
+		userReader:  userReader,
+		userWriter:  userWriter,
+		attempts:    loginAttempts{failures: failures, notifier: notifier, clock: clock, log: log},
+		hasher:      hasher,
+		log:         log,
+		loginPolicy: loginPolicy,
+		ipPolicy:    ipPolicy,
+	}
 }
 
-// Execute checks credentials and returns the user ID.
+// Execute checks credentials and returns the user ID. The attempt is counted as failed
+// before the password is checked and taken back if it succeeds, so concurrent attempts
+// cannot slip past the throttle together. Attempts are rejected without checking the password
+// while the login or the client IP is throttled. A password hash made with outdated hasher
+// settings is replaced while the plain password is at hand.
 //
 // Errors:
 //   - application.ErrInvalidCredentials — wrong login or password
+//   - *application.ErrLoginThrottled — too many failed logins, retry later
 func (uc *LoginUser) Execute(ctx context.Context, in dto.LoginInput) (vo.UserID, error) {
+	reserved, err := uc.attempts.reserve(ctx, uc.throttles(in))
+	if err != nil {
+		return 0, err
+	}
+
 	u, err := uc.userReader.FindByLogin(ctx, in.Login)
+	if errors.Is(err, application.ErrNotFound) {
+		return 0, uc.attempts.fail(ctx, reserved, nil)
+	}
 	if err != nil {
+		uc.attempts.release(ctx, reserved)
 		return 0, err
 	}
 	if u == nil || !uc.hasher.Compare(in.Password, u.PasswordHash) {
-		return 0, application.ErrInvalidCredentials
+		return 0, uc.attempts.fail(ctx, reserved, u)
+	}
+	uc.attempts.succeed(ctx, reserved, u.ID)
+
+	if uc.hasher.NeedsRehash(u.PasswordHash) {
+		uc.rehash(ctx, u, in.Password)
 	}
 	return u.ID, nil
 }
+
+// throttles returns the login throttle first, then the client IP throttle if the IP is known.
+func (uc *LoginUser) throttles(in dto.LoginInput) []throttle {
+	throttles := []throttle{{key: entity.LoginThrottleKey(in.Login), policy: uc.loginPolicy}}
+	if in.ClientIP != "" {
+		throttles = append(throttles, throttle{key: entity.ClientIPThrottleKey(in.ClientIP), policy: uc.ipPolicy})
+	}
+	return throttles
+}
+
+// rehash is best effort: the login succeeds even if the new hash is not stored,
+// the next login tries again.
+func (uc *LoginUser) rehash(ctx context.Context, u *entity.User, password string) {
+	hash, err := uc.hasher.Hash(password)
+	if err != nil {
+		uc.log.Warn("failed to rehash password", "user_id", u.ID, "error", err)
+		return
+	}
+
+	err = uc.userWriter.UpdatePasswordHash(ctx, u.ID, u.PasswordHash, hash)
+	if errors.Is(err, application.ErrConflict) {
+		// The password was changed meanwhile; the new hash already uses current settings.
+		return
+	}
+	if err != nil {
+		uc.log.Warn("failed to store rehashed password", "user_id", u.ID, "error", err)
+	}
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// throttle is a throttle key with the policy applied to it.
+type throttle struct {
+	key    string
+	policy vo.LoginThrottlePolicy
+}
+
+// reservation is an attempt counted as failed in advance for a throttle key.
+type reservation struct {
+	throttle
+	// locked reports whether counting the attempt locked the key out.
+	locked   bool
+	failures entity.LoginFailures
+}
+
+// loginAttempts throttles password checks. An attempt is counted as failed before the password
+// is compared and taken back if it succeeds, so concurrent attempts cannot slip past the throttle
+// together. The first throttle key of an attempt is the login key of the account.
+type loginAttempts struct {
+	failures port.LoginFailureRepository
+	notifier port.LockoutNotifier
+	clock    appport.Clock
+	log      appport.Logger
+}
+
+// reserve counts the attempt as failed for every throttle key, checking the throttle
+// in the same update. If any key is throttled, the counted attempts are taken back
+// and ErrLoginThrottled is returned with the longest wait among throttled keys.
+func (a loginAttempts) reserve(ctx context.Context, throttles []throttle) ([]reservation, error) {
+	now := a.clock.Now()
+
+	retryAt := now
+	reserved := make([]reservation, 0, len(throttles))
+	for _, t := range throttles {
+		var (
+			r         reservation
+			throttled bool
+		)
+		err := a.failures.Update(ctx, t.key, func(f *entity.LoginFailures) {
+			r = reservation{throttle: t}
+			throttled = false
+			if at := f.RetryAt(t.policy); at.After(now) {
+				throttled = true
+				if at.After(retryAt) {
+					retryAt = at
+				}
+				return
+			}
+			r.locked = f.RecordFailure(t.policy, now)
+			r.failures = *f
+		})
+		if err != nil {
+			a.release(ctx, reserved)
+			return nil, err
+		}
+		if !throttled {
+			reserved = append(reserved, r)
+		}
+	}
+
+	if retryAt.After(now) {
+		a.release(ctx, reserved)
+		return nil, &application.ErrLoginThrottled{RetryAfter: retryAt.Sub(now)}
+	}
+	return reserved, nil
+}
+
+// fail keeps the reserved failures and returns ErrInvalidCredentials. Locking out an existing
+// user's login is reported to the notifier, other lockouts are logged.
+func (a loginAttempts) fail(ctx context.Context, reserved []reservation, u *entity.User) error {
+	for i, r := range reserved {
+		if !r.locked {
+			continue
+		}
+		if i == 0 && u != nil {
+			a.notifyLocked(ctx, u, *r.failures.LockedUntil)
+			continue
+		}
+		a.log.Warn("login locked out", "key", r.key, "failures", r.failures.Count, "until", *r.failures.LockedUntil)
+	}
+
+	return application.ErrInvalidCredentials
+}
+
+// succeed resets the login failures of the account. Only this attempt is taken back from other
+// keys: one valid account must not unlock guessing others from the same client IP.
+func (a loginAttempts) succeed(ctx context.Context, reserved []reservation, userID vo.UserID) {
+	if err := a.failures.Delete(ctx, reserved[0].key); err != nil {
+		a.log.Warn("failed to reset login failures", "user_id", userID, "error", err)
+	}
+	a.release(ctx, reserved[1:])
+}
+
+// release takes the reserved failures back. It is best effort: a failure left counted
+// only delays later attempts.
+func (a loginAttempts) release(ctx context.Context, reserved []reservation) {
+	for _, r := range reserved {
+		err := a.failures.Update(ctx, r.key, func(f *entity.LoginFailures) {
+			f.ReleaseFailure(r.policy)
+		})
+		if err != nil {
+			a.log.Warn("failed to release login attempt", "key", r.key, "error", err)
+		}
+	}
+}
+
+// notifyLocked is best effort: the lockout holds even if the user is not told about it.
+func (a loginAttempts) notifyLocked(ctx context.Context, u *entity.User, until time.Time) {
+	if err := a.notifier.AccountLocked(ctx, u.ID, u.Login, until); err != nil {
+		a.log.Warn("failed to notify about account lockout", "user_id", u.ID, "error", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"gophermart/internal/gophermart/application"
 	appmocks "gophermart/internal/gophermart/application/port/mocks"
@@ -13,69 +14,352 @@ import (
 	"gophermart/internal/gophermart/modules/identity/domain/vo"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
 func TestLoginUser_Execute(t *testing.T) {
 	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
 	input := dto.LoginInput{Login: "alice", Password: "secret"}
+	policy := vo.LoginThrottlePolicy{
+		FreeAttempts:     1,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute,
+		LockoutThreshold: 3,
+		LockoutDuration:  15 * time.Minute,
+		Window:           time.Hour,
+	}
 
 	t.Run("success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		userReader := identityportmocks.NewMockUserReader(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
 		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
 
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{}))
 		userReader.EXPECT().FindByLogin(ctx, "alice").Return(&entity.User{
 			ID: vo.UserID(1), Login: "alice", PasswordHash: "hashed",
 		}, nil)
 		hasher.EXPECT().Compare("secret", "hashed").Return(true)
+		failures.EXPECT().Delete(ctx, "login:alice").Return(nil)
+		hasher.EXPECT().NeedsRehash("hashed").Return(false)
 
-		uc := NewLoginUser(userReader, hasher)
+		uc := NewLoginUser(userReader, nil, failures, nil, hasher, clk, nil, policy, policy)
 		id, err := uc.Execute(ctx, input)
 
 		assert.NoError(t, err)
 		assert.Equal(t, vo.UserID(1), id)
 	})
 
-	t.Run("user not found", func(t *testing.T) {
+	t.Run("success keeps client IP failures", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		userReader := identityportmocks.NewMockUserReader(ctrl)
-		userReader.EXPECT().FindByLogin(ctx, "alice").Return(nil, nil)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		ipRecord := entity.LoginFailures{Key: "ip:203.0.113.7", Count: 1, LastFailedAt: fixedTime.Add(-time.Minute)}
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{}))
+		failures.EXPECT().Update(ctx, "ip:203.0.113.7", gomock.Any()).DoAndReturn(updateRecord(&ipRecord)).Times(2)
+		userReader.EXPECT().FindByLogin(ctx, "alice").Return(&entity.User{ID: 1, PasswordHash: "hashed"}, nil)
+		hasher.EXPECT().Compare("secret", "hashed").Return(true)
+		failures.EXPECT().Delete(ctx, "login:alice").Return(nil)
+		hasher.EXPECT().NeedsRehash("hashed").Return(false)
+
+		uc := NewLoginUser(userReader, nil, failures, nil, hasher, clk, nil, policy, policy)
+		_, err := uc.Execute(ctx, dto.LoginInput{Login: "alice", Password: "secret", ClientIP: "203.0.113.7"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, ipRecord.Count, "only this attempt is taken back")
+	})
+
+	t.Run("outdated hash is replaced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		userReader := identityportmocks.NewMockUserReader(ctrl)
+		userWriter := identityportmocks.NewMockUserWriter(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{}))
+		userReader.EXPECT().FindByLogin(ctx, "alice").Return(&entity.User{
+			ID: vo.UserID(1), Login: "alice", PasswordHash: "old-hash",
+		}, nil)
+		hasher.EXPECT().Compare("secret", "old-hash").Return(true)
+		failures.EXPECT().Delete(ctx, "login:alice").Return(nil)
+		hasher.EXPECT().NeedsRehash("old-hash").Return(true)
+		hasher.EXPECT().Hash("secret").Return("new-hash", nil)
+		userWriter.EXPECT().UpdatePasswordHash(ctx, vo.UserID(1), "old-hash", "new-hash").Return(nil)
+
+		uc := NewLoginUser(userReader, userWriter, failures, nil, hasher, clk, nil, policy, policy)
+		id, err := uc.Execute(ctx, input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, vo.UserID(1), id)
+	})
+
+	t.Run("password changed during rehash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		userReader := identityportmocks.NewMockUserReader(ctrl)
+		userWriter := identityportmocks.NewMockUserWriter(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
 
-		uc := NewLoginUser(userReader, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{}))
+		userReader.EXPECT().FindByLogin(ctx, "alice").Return(&entity.User{ID: 1, PasswordHash: "old-hash"}, nil)
+		hasher.EXPECT().Compare("secret", "old-hash").Return(true)
+		failures.EXPECT().Delete(ctx, "login:alice").Return(nil)
+		hasher.EXPECT().NeedsRehash("old-hash").Return(true)
+		hasher.EXPECT().Hash("secret").Return("new-hash", nil)
+		userWriter.EXPECT().UpdatePasswordHash(ctx, vo.UserID(1), "old-hash", "new-hash").Return(application.ErrConflict)
+
+		uc := NewLoginUser(userReader, userWriter, failures, nil, hasher, clk, nil, policy, policy)
+		_, err := uc.Execute(ctx, input)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed rehash does not fail login", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		userReader := identityportmocks.NewMockUserReader(ctrl)
+		userWriter := identityportmocks.NewMockUserWriter(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+		log := appmocks.NewMockLogger(ctrl)
+
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{}))
+		userReader.EXPECT().FindByLogin(ctx, "alice").Return(&entity.User{ID: 1, PasswordHash: "old-hash"}, nil)
+		hasher.EXPECT().Compare("secret", "old-hash").Return(true)
+		failures.EXPECT().Delete(ctx, "login:alice").Return(nil)
+		hasher.EXPECT().NeedsRehash("old-hash").Return(true)
+		hasher.EXPECT().Hash("secret").Return("new-hash", nil)
+		userWriter.EXPECT().UpdatePasswordHash(ctx, vo.UserID(1), "old-hash", "new-hash").Return(errors.New("db error"))
+		log.EXPECT().Warn("failed to store rehashed password", gomock.Any())
+
+		uc := NewLoginUser(userReader, userWriter, failures, nil, hasher, clk, log, policy, policy)
+		id, err := uc.Execute(ctx, input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, vo.UserID(1), id)
+	})
+
+	t.Run("user not found counts a failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		userReader := identityportmocks.NewMockUserReader(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		recorded := entity.LoginFailures{Key: "login:alice"}
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&recorded))
+		userReader.EXPECT().FindByLogin(ctx, "alice").Return(nil, application.ErrNotFound)
+
+		uc := NewLoginUser(userReader, nil, failures, nil, nil, clk, nil, policy, policy)
 		_, err := uc.Execute(ctx, input)
 
 		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+		assert.Equal(t, 1, recorded.Count)
+		assert.Equal(t, fixedTime, recorded.LastFailedAt)
 	})
 
-	t.Run("wrong password", func(t *testing.T) {
+	t.Run("wrong password counts a failure per login and client IP", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		userReader := identityportmocks.NewMockUserReader(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
 		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
 
+		loginRecord := entity.LoginFailures{Key: "login:alice"}
+		ipRecord := entity.LoginFailures{Key: "ip:203.0.113.7"}
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&loginRecord))
+		failures.EXPECT().Update(ctx, "ip:203.0.113.7", gomock.Any()).DoAndReturn(updateRecord(&ipRecord))
 		userReader.EXPECT().FindByLogin(ctx, "alice").Return(&entity.User{
 			ID: vo.UserID(1), PasswordHash: "hashed",
 		}, nil)
+		hasher.EXPECT().Compare("wrong", "hashed").Return(false)
+
+		uc := NewLoginUser(userReader, nil, failures, nil, hasher, clk, nil, policy, policy)
+		_, err := uc.Execute(ctx, dto.LoginInput{Login: "alice", Password: "wrong", ClientIP: "203.0.113.7"})
+
+		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+		assert.Equal(t, 1, loginRecord.Count)
+		assert.Equal(t, 1, ipRecord.Count)
+	})
+
+	t.Run("throttled login is rejected without checking the password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		stored := entity.LoginFailures{Key: "login:alice", Count: 2, LastFailedAt: fixedTime.Add(-200 * time.Millisecond)}
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&stored))
+
+		uc := NewLoginUser(nil, nil, failures, nil, nil, clk, nil, policy, policy)
+		_, err := uc.Execute(ctx, input)
+
+		var throttled *application.ErrLoginThrottled
+		require.ErrorAs(t, err, &throttled)
+		assert.Equal(t, 800*time.Millisecond, throttled.RetryAfter)
+		assert.Equal(t, 2, stored.Count, "a throttled attempt is not counted")
+	})
+
+	t.Run("longest wait of login and client IP wins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		lockedUntil := fixedTime.Add(10 * time.Minute)
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{
+			Key: "login:alice", Count: 2, LastFailedAt: fixedTime,
+		}))
+		failures.EXPECT().Update(ctx, "ip:203.0.113.7", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{
+			Key: "ip:203.0.113.7", Count: 3, LastFailedAt: fixedTime.Add(-5 * time.Minute), LockedUntil: &lockedUntil,
+		}))
+
+		uc := NewLoginUser(nil, nil, failures, nil, nil, clk, nil, policy, policy)
+		_, err := uc.Execute(ctx, dto.LoginInput{Login: "alice", Password: "secret", ClientIP: "203.0.113.7"})
+
+		var throttled *application.ErrLoginThrottled
+		require.ErrorAs(t, err, &throttled)
+		assert.Equal(t, 10*time.Minute, throttled.RetryAfter)
+	})
+
+	t.Run("throttled client IP takes back the login attempt", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		lockedUntil := fixedTime.Add(10 * time.Minute)
+		loginRecord := entity.LoginFailures{Key: "login:alice"}
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&loginRecord)).Times(2)
+		failures.EXPECT().Update(ctx, "ip:203.0.113.7", gomock.Any()).DoAndReturn(updateRecord(&entity.LoginFailures{
+			Key: "ip:203.0.113.7", Count: 3, LastFailedAt: fixedTime, LockedUntil: &lockedUntil,
+		}))
+
+		uc := NewLoginUser(nil, nil, failures, nil, nil, clk, nil, policy, policy)
+		_, err := uc.Execute(ctx, dto.LoginInput{Login: "alice", Password: "secret", ClientIP: "203.0.113.7"})
+
+		var throttled *application.ErrLoginThrottled
+		require.ErrorAs(t, err, &throttled)
+		assert.Equal(t, 10*time.Minute, throttled.RetryAfter)
+		assert.Zero(t, loginRecord.Count)
+	})
+
+	t.Run("concurrent attempts are counted before the password check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		userReader := identityportmocks.NewMockUserReader(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		// The first attempt is still comparing the password when the second one arrives.
+		stored := entity.LoginFailures{Key: "login:alice", Count: 1, LastFailedAt: fixedTime.Add(-time.Minute)}
+		uc := NewLoginUser(userReader, nil, failures, nil, hasher, clk, nil, policy, policy)
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&stored)).Times(2)
+		userReader.EXPECT().FindByLogin(ctx, "alice").Return(&entity.User{ID: 1, PasswordHash: "hashed"}, nil)
+		hasher.EXPECT().Compare("wrong", "hashed").DoAndReturn(func(string, string) bool {
+			_, err := uc.Execute(ctx, dto.LoginInput{Login: "alice", Password: "wrong"})
+
+			var throttled *application.ErrLoginThrottled
+			require.ErrorAs(t, err, &throttled)
+			assert.Equal(t, time.Second, throttled.RetryAfter)
+			return false
+		})
+
+		_, err := uc.Execute(ctx, dto.LoginInput{Login: "alice", Password: "wrong"})
+
+		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+		assert.Equal(t, 2, stored.Count)
+	})
+
+	t.Run("lockout notifies the user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		userReader := identityportmocks.NewMockUserReader(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		notifier := identityportmocks.NewMockLockoutNotifier(ctrl)
+		hasher := appmocks.NewMockPasswordHasher(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		stored := entity.LoginFailures{Key: "login:alice", Count: 2, LastFailedAt: fixedTime.Add(-time.Minute)}
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&stored))
+		userReader.EXPECT().FindByLogin(ctx, "alice").Return(&entity.User{ID: 1, Login: "alice", PasswordHash: "hashed"}, nil)
 		hasher.EXPECT().Compare("secret", "hashed").Return(false)
+		notifier.EXPECT().AccountLocked(ctx, vo.UserID(1), "alice", fixedTime.Add(15*time.Minute)).Return(nil)
 
-		uc := NewLoginUser(userReader, hasher)
+		uc := NewLoginUser(userReader, nil, failures, notifier, hasher, clk, nil, policy, policy)
 		_, err := uc.Execute(ctx, input)
 
 		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+		assert.Equal(t, 3, stored.Count)
+	})
+
+	t.Run("failure store error fails the login", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).Return(errors.New("db error"))
+
+		uc := NewLoginUser(nil, nil, failures, nil, nil, clk, nil, policy, policy)
+		_, err := uc.Execute(ctx, input)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, application.ErrInvalidCredentials)
 	})
 
 	t.Run("repo error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		userReader := identityportmocks.NewMockUserReader(ctrl)
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		recorded := entity.LoginFailures{Key: "login:alice"}
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().Update(ctx, "login:alice", gomock.Any()).DoAndReturn(updateRecord(&recorded)).Times(2)
 		userReader.EXPECT().FindByLogin(ctx, "alice").Return(nil, errors.New("db error"))
 
-		uc := NewLoginUser(userReader, nil)
+		uc := NewLoginUser(userReader, nil, failures, nil, nil, clk, nil, policy, policy)
 		_, err := uc.Execute(ctx, input)
 
 		assert.Error(t, err)
+		assert.Zero(t, recorded.Count, "the attempt is taken back")
 	})
 }
+
+// updateRecord makes a mocked LoginFailureRepository.Update apply its function to f.
+func updateRecord(f *entity.LoginFailures) func(context.Context, string, func(*entity.LoginFailures)) error {
+	return func(_ context.Context, _ string, fn func(*entity.LoginFailures)) error {
+		fn(f)
+		return nil
+	}
+}
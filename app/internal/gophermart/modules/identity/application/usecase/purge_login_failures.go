@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// PurgeLoginFailures removes failed login records that no longer throttle anything.
+type PurgeLoginFailures struct {
+	failures port.LoginFailureRepository
+	clock    appport.Clock
+	// retention is how long records are kept after the last failure and lockout; 0 keeps them.
+	retention time.Duration
+}
+
+// NewPurgeLoginFailures returns the purge use case. Records are kept for the longest window
+// of the policies; a policy without a window remembers failures until a successful login.
+func NewPurgeLoginFailures(
+	failures port.LoginFailureRepository,
+	clock appport.Clock,
+	policies ...vo.LoginThrottlePolicy,
+) *PurgeLoginFailures {
+	uc := &PurgeLoginFailures{failures: failures, clock: clock}
+	for _, p := range policies {
+		if p.Window <= 0 {
+			uc.retention = 0
+			break
+		}
+		if w := max(p.Window, p.MaxDelay); w > uc.retention {
+			uc.retention = w
+		}
+	}
+	return uc
+}
+
+// Run removes stale records and returns the number of removed records.
+func (uc *PurgeLoginFailures) Run(ctx context.Context) (int, error) {
+	if uc.retention == 0 {
+		return 0, nil
+	}
+	return uc.failures.DeleteStale(ctx, uc.clock.Now().Add(-uc.retention))
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	identityportmocks "gophermart/internal/gophermart/modules/identity/application/port/mocks"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeLoginFailures_Run(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("keeps records for the longest window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		failures := identityportmocks.NewMockLoginFailureRepository(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		clk.EXPECT().Now().Return(fixedTime)
+		failures.EXPECT().DeleteStale(ctx, fixedTime.Add(-2*time.Hour)).Return(4, nil)
+
+		uc := NewPurgeLoginFailures(failures, clk,
+			vo.LoginThrottlePolicy{Window: time.Hour},
+			vo.LoginThrottlePolicy{Window: 2 * time.Hour},
+		)
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, n)
+	})
+
+	t.Run("policy without window keeps everything", func(t *testing.T) {
+		uc := NewPurgeLoginFailures(nil, nil, vo.LoginThrottlePolicy{Window: time.Hour}, vo.LoginThrottlePolicy{})
+		n, err := uc.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
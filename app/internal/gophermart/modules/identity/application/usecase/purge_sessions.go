@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+)
+
+// PurgeSessions removes ended sessions and used refresh tokens.
+type PurgeSessions struct {
+	sessions port.SessionWriter
+	clock    appport.Clock
+	// retention is how long ended sessions and used refresh tokens are kept.
+	retention time.Duration
+}
+
+// NewPurgeSessions returns the purge use case. Records are kept for the session TTL: reusing
+// a refresh token used within it still revokes the session, a later one is rejected as unknown.
+func NewPurgeSessions(sessions port.SessionWriter, clock appport.Clock, sessionTTL time.Duration) *PurgeSessions {
+	return &PurgeSessions{sessions: sessions, clock: clock, retention: sessionTTL}
+}
+
+// Run removes stale sessions and refresh tokens and returns the number of removed records.
+func (uc *PurgeSessions) Run(ctx context.Context) (int, error) {
+	return uc.sessions.DeleteStale(ctx, uc.clock.Now().Add(-uc.retention))
+}
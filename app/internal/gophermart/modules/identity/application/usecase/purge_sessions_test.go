@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	identityportmocks "gophermart/internal/gophermart/modules/identity/application/port/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeSessions_Run(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	sessions := identityportmocks.NewMockSessionWriter(ctrl)
+	clk := appmocks.NewMockClock(ctrl)
+
+	clk.EXPECT().Now().Return(fixedTime)
+	sessions.EXPECT().DeleteStale(ctx, fixedTime.Add(-720*time.Hour)).Return(3, nil)
+
+	n, err := NewPurgeSessions(sessions, clk, 720*time.Hour).Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
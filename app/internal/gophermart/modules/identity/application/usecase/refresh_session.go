@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+)
+
+// RefreshSession exchanges a refresh token for a new token pair (rotation).
+type RefreshSession struct {
+	sessionReader port.SessionReader
+	sessionWriter port.SessionWriter
+	tokens        port.TokenProvider
+	refreshTokens port.RefreshTokenGenerator
+	transactor    appport.Transactor
+	clock         appport.Clock
+	ttl           time.Duration
+}
+
+// NewRefreshSession returns the refresh session use case; each refresh extends the session by ttl,
+// up to its absolute expiry set at login.
+func NewRefreshSession(
+	sessionReader port.SessionReader,
+	sessionWriter port.SessionWriter,
+	tokens port.TokenProvider,
+	refreshTokens port.RefreshTokenGenerator,
+	transactor appport.Transactor,
+	clock appport.Clock,
+	ttl time.Duration,
+) appport.UseCase[string, dto.SessionTokens] {
+	return &RefreshSession{
+		sessionReader: sessionReader,
+		sessionWriter: sessionWriter,
+		tokens:        tokens,
+		refreshTokens: refreshTokens,
+		transactor:    transactor,
+		clock:         clock,
+		ttl:           ttl,
+	}
+}
+
+// Execute uses up the refresh token, extends its session and issues a new token pair.
+// A session past its absolute expiry is not rotated: the user has to log in again.
+// A refresh token is single-use: presenting a used one means it leaked (or a client raced itself),
+// so the whole session is revoked and every token issued from it stops working.
+//
+// Errors:
+//   - application.ErrInvalidCredentials — token is unknown or its session is revoked or expired
+//   - application.ErrRefreshTokenReused — token was already used; the session is now revoked
+func (uc *RefreshSession) Execute(ctx context.Context, refreshToken string) (dto.SessionTokens, error) {
+	hash := uc.refreshTokens.Hash(refreshToken)
+	now := uc.clock.Now()
+
+	var (
+		out    dto.SessionTokens
+		reused bool
+	)
+	err := uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		token, err := uc.sessionReader.FindRefreshToken(ctx, hash)
+		if errors.Is(err, application.ErrNotFound) {
+			return application.ErrInvalidCredentials
+		}
+		if err != nil {
+			return err
+		}
+
+		s, err := uc.sessionReader.FindByID(ctx, token.SessionID)
+		if err != nil {
+			return err
+		}
+		if !s.Active(now) {
+			return application.ErrInvalidCredentials
+		}
+
+		err = uc.sessionWriter.UseRefreshToken(ctx, hash, now)
+		if errors.Is(err, application.ErrConflict) {
+			// The revocation must commit, so the reuse is reported after the transaction.
+			reused = true
+			s.Revoke(now)
+			return uc.sessionWriter.Update(ctx, s)
+		}
+		if err != nil {
+			return err
+		}
+
+		// A logout committed since the session was read makes the extension fail.
+		s.Extend(now, uc.ttl)
+		err = uc.sessionWriter.Update(ctx, s)
+		if errors.Is(err, application.ErrConflict) {
+			return application.ErrInvalidCredentials
+		}
+		if err != nil {
+			return err
+		}
+
+		out, err = issueSessionTokens(ctx, uc.sessionWriter, uc.tokens, uc.refreshTokens, s, now)
+		return err
+	})
+	if err != nil {
+		return dto.SessionTokens{}, err
+	}
+	if reused {
+		return dto.SessionTokens{}, application.ErrRefreshTokenReused
+	}
+
+	return out, nil
+}
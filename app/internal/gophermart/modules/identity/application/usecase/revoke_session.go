@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// RevokeSession ends an auth session (logout).
+type RevokeSession struct {
+	sessionReader port.SessionReader
+	sessionWriter port.SessionWriter
+	clock         appport.Clock
+}
+
+// NewRevokeSession returns the revoke session use case.
+func NewRevokeSession(
+	sessionReader port.SessionReader,
+	sessionWriter port.SessionWriter,
+	clock appport.Clock,
+) appport.UseCase[vo.SessionID, struct{}] {
+	return &RevokeSession{sessionReader: sessionReader, sessionWriter: sessionWriter, clock: clock}
+}
+
+// Execute revokes the session: its access and refresh tokens are rejected from now on.
+// Revoking a revoked session is a no-op.
+//
+// Errors:
+//   - application.ErrNotFound — session does not exist
+func (uc *RevokeSession) Execute(ctx context.Context, id vo.SessionID) (struct{}, error) {
+	s, err := uc.sessionReader.FindByID(ctx, id)
+	if err != nil {
+		return struct{}{}, err
+	}
+	if s.RevokedAt != nil {
+		return struct{}{}, nil
+	}
+
+	s.Revoke(uc.clock.Now())
+	return struct{}{}, uc.sessionWriter.Update(ctx, s)
+}
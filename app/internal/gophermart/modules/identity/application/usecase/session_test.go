@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	identityportmocks "gophermart/internal/gophermart/modules/identity/application/port/mocks"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testSessionTTL         = 24 * time.Hour
+	testSessionMaxLifetime = 72 * time.Hour
+)
+
+var sessionTime = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// stubRefreshTokens generates a fixed token and hashes by prefixing.
+type stubRefreshTokens struct {
+	token string
+}
+
+func (s stubRefreshTokens) Generate() (string, error) {
+	return s.token, nil
+}
+
+func (s stubRefreshTokens) Hash(token string) string {
+	return "hash:" + token
+}
+
+func runInTransaction(transactor *appmocks.MockTransactor, ctx context.Context) {
+	transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+}
+
+func TestCreateSession_Execute(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	sessionWriter := identityportmocks.NewMockSessionWriter(ctrl)
+	tokens := identityportmocks.NewMockTokenProvider(ctrl)
+	transactor := appmocks.NewMockTransactor(ctrl)
+	clk := appmocks.NewMockClock(ctrl)
+
+	clk.EXPECT().Now().Return(sessionTime)
+	runInTransaction(transactor, ctx)
+	sessionWriter.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.Session) error {
+		assert.Equal(t, vo.UserID(1), s.UserID)
+		assert.Equal(t, sessionTime.Add(testSessionMaxLifetime), s.MaxExpiresAt)
+		s.ID = 5
+		return nil
+	})
+	sessionWriter.EXPECT().AddRefreshToken(ctx, entity.RefreshToken{
+		Hash: "hash:refresh-1", SessionID: 5, IssuedAt: sessionTime,
+	}).Return(nil)
+	tokens.EXPECT().Issue(vo.UserID(1), vo.SessionID(5)).Return("access-1", nil)
+
+	uc := NewCreateSession(sessionWriter, tokens, stubRefreshTokens{token: "refresh-1"}, transactor, clk, testSessionTTL, testSessionMaxLifetime)
+	out, err := uc.Execute(ctx, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, dto.SessionTokens{
+		AccessToken: "access-1", RefreshToken: "refresh-1", ExpiresAt: sessionTime.Add(testSessionTTL),
+	}, out)
+}
+
+func TestRefreshSession_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := sessionTime.Add(time.Hour)
+	activeSession := func() *entity.Session {
+		return &entity.Session{
+			ID: 5, UserID: 1, CreatedAt: sessionTime,
+			ExpiresAt: sessionTime.Add(testSessionTTL), MaxExpiresAt: sessionTime.Add(testSessionMaxLifetime),
+		}
+	}
+
+	setup := func(t *testing.T) (
+		*identityportmocks.MockSessionReader,
+		*identityportmocks.MockSessionWriter,
+		*identityportmocks.MockTokenProvider,
+		*appmocks.MockTransactor,
+		*appmocks.MockClock,
+	) {
+		ctrl := gomock.NewController(t)
+		clk := appmocks.NewMockClock(ctrl)
+		clk.EXPECT().Now().Return(now)
+		return identityportmocks.NewMockSessionReader(ctrl),
+			identityportmocks.NewMockSessionWriter(ctrl),
+			identityportmocks.NewMockTokenProvider(ctrl),
+			appmocks.NewMockTransactor(ctrl),
+			clk
+	}
+
+	t.Run("rotates token and extends session", func(t *testing.T) {
+		sessionReader, sessionWriter, tokens, transactor, clk := setup(t)
+
+		runInTransaction(transactor, ctx)
+		sessionReader.EXPECT().FindRefreshToken(ctx, "hash:refresh-1").Return(&entity.RefreshToken{
+			Hash: "hash:refresh-1", SessionID: 5, IssuedAt: sessionTime,
+		}, nil)
+		sessionReader.EXPECT().FindByID(ctx, vo.SessionID(5)).Return(activeSession(), nil)
+		sessionWriter.EXPECT().UseRefreshToken(ctx, "hash:refresh-1", now).Return(nil)
+		sessionWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.Session) error {
+			assert.Equal(t, now.Add(testSessionTTL), s.ExpiresAt)
+			assert.Nil(t, s.RevokedAt)
+			return nil
+		})
+		sessionWriter.EXPECT().AddRefreshToken(ctx, entity.RefreshToken{
+			Hash: "hash:refresh-2", SessionID: 5, IssuedAt: now,
+		}).Return(nil)
+		tokens.EXPECT().Issue(vo.UserID(1), vo.SessionID(5)).Return("access-2", nil)
+
+		uc := NewRefreshSession(sessionReader, sessionWriter, tokens, stubRefreshTokens{token: "refresh-2"}, transactor, clk, testSessionTTL)
+		out, err := uc.Execute(ctx, "refresh-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, dto.SessionTokens{
+			AccessToken: "access-2", RefreshToken: "refresh-2", ExpiresAt: now.Add(testSessionTTL),
+		}, out)
+	})
+
+	t.Run("extension stops at max lifetime", func(t *testing.T) {
+		sessionReader, sessionWriter, tokens, transactor, clk := setup(t)
+
+		runInTransaction(transactor, ctx)
+		sessionReader.EXPECT().FindRefreshToken(ctx, "hash:refresh-1").Return(&entity.RefreshToken{
+			Hash: "hash:refresh-1", SessionID: 5, IssuedAt: sessionTime,
+		}, nil)
+		capped := activeSession()
+		capped.MaxExpiresAt = now.Add(time.Hour)
+		sessionReader.EXPECT().FindByID(ctx, vo.SessionID(5)).Return(capped, nil)
+		sessionWriter.EXPECT().UseRefreshToken(ctx, "hash:refresh-1", now).Return(nil)
+		sessionWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.Session) error {
+			assert.Equal(t, now.Add(time.Hour), s.ExpiresAt)
+			return nil
+		})
+		sessionWriter.EXPECT().AddRefreshToken(ctx, gomock.Any()).Return(nil)
+		tokens.EXPECT().Issue(vo.UserID(1), vo.SessionID(5)).Return("access-2", nil)
+
+		uc := NewRefreshSession(sessionReader, sessionWriter, tokens, stubRefreshTokens{token: "refresh-2"}, transactor, clk, testSessionTTL)
+		out, err := uc.Execute(ctx, "refresh-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Hour), out.ExpiresAt)
+	})
+
+	t.Run("session past max lifetime", func(t *testing.T) {
+		sessionReader, sessionWriter, tokens, transactor, clk := setup(t)
+
+		runInTransaction(transactor, ctx)
+		sessionReader.EXPECT().FindRefreshToken(ctx, "hash:refresh-1").Return(&entity.RefreshToken{
+			Hash: "hash:refresh-1", SessionID: 5, IssuedAt: sessionTime,
+		}, nil)
+		ended := activeSession()
+		ended.MaxExpiresAt = now
+		ended.ExpiresAt = now
+		sessionReader.EXPECT().FindByID(ctx, vo.SessionID(5)).Return(ended, nil)
+
+		uc := NewRefreshSession(sessionReader, sessionWriter, tokens, stubRefreshTokens{}, transactor, clk, testSessionTTL)
+		_, err := uc.Execute(ctx, "refresh-1")
+
+		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+	})
+
+	t.Run("reused token revokes session", func(t *testing.T) {
+		sessionReader, sessionWriter, tokens, transactor, clk := setup(t)
+
+		runInTransaction(transactor, ctx)
+		usedAt := sessionTime.Add(time.Minute)
+		sessionReader.EXPECT().FindRefreshToken(ctx, "hash:refresh-1").Return(&entity.RefreshToken{
+			Hash: "hash:refresh-1", SessionID: 5, IssuedAt: sessionTime, UsedAt: &usedAt,
+		}, nil)
+		sessionReader.EXPECT().FindByID(ctx, vo.SessionID(5)).Return(activeSession(), nil)
+		sessionWriter.EXPECT().UseRefreshToken(ctx, "hash:refresh-1", now).Return(application.ErrConflict)
+		sessionWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.Session) error {
+			require.NotNil(t, s.RevokedAt)
+			assert.Equal(t, now, *s.RevokedAt)
+			return nil
+		})
+
+		uc := NewRefreshSession(sessionReader, sessionWriter, tokens, stubRefreshTokens{}, transactor, clk, testSessionTTL)
+		_, err := uc.Execute(ctx, "refresh-1")
+
+		assert.ErrorIs(t, err, application.ErrRefreshTokenReused)
+	})
+
+	t.Run("session revoked during refresh", func(t *testing.T) {
+		sessionReader, sessionWriter, tokens, transactor, clk := setup(t)
+
+		runInTransaction(transactor, ctx)
+		sessionReader.EXPECT().FindRefreshToken(ctx, "hash:refresh-1").Return(&entity.RefreshToken{
+			Hash: "hash:refresh-1", SessionID: 5, IssuedAt: sessionTime,
+		}, nil)
+		sessionReader.EXPECT().FindByID(ctx, vo.SessionID(5)).Return(activeSession(), nil)
+		sessionWriter.EXPECT().UseRefreshToken(ctx, "hash:refresh-1", now).Return(nil)
+		sessionWriter.EXPECT().Update(ctx, gomock.Any()).Return(application.ErrConflict)
+
+		uc := NewRefreshSession(sessionReader, sessionWriter, tokens, stubRefreshTokens{}, transactor, clk, testSessionTTL)
+		_, err := uc.Execute(ctx, "refresh-1")
+
+		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		sessionReader, sessionWriter, tokens, transactor, clk := setup(t)
+
+		runInTransaction(transactor, ctx)
+		sessionReader.EXPECT().FindRefreshToken(ctx, "hash:forged").Return(nil, application.ErrNotFound)
+
+		uc := NewRefreshSession(sessionReader, sessionWriter, tokens, stubRefreshTokens{}, transactor, clk, testSessionTTL)
+		_, err := uc.Execute(ctx, "forged")
+
+		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		sessionReader, sessionWriter, tokens, transactor, clk := setup(t)
+
+		runInTransaction(transactor, ctx)
+		sessionReader.EXPECT().FindRefreshToken(ctx, "hash:refresh-1").Return(&entity.RefreshToken{
+			Hash: "hash:refresh-1", SessionID: 5, IssuedAt: sessionTime,
+		}, nil)
+		revoked := activeSession()
+		revoked.Revoke(sessionTime)
+		sessionReader.EXPECT().FindByID(ctx, vo.SessionID(5)).Return(revoked, nil)
+
+		uc := NewRefreshSession(sessionReader, sessionWriter, tokens, stubRefreshTokens{}, transactor, clk, testSessionTTL)
+		_, err := uc.Execute(ctx, "refresh-1")
+
+		assert.ErrorIs(t, err, application.ErrInvalidCredentials)
+	})
+}
+
+func TestRevokeSession_Execute(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	sessionReader := identityportmocks.NewMockSessionReader(ctrl)
+	sessionWriter := identityportmocks.NewMockSessionWriter(ctrl)
+	clk := appmocks.NewMockClock(ctrl)
+
+	sessionReader.EXPECT().FindByID(ctx, vo.SessionID(5)).Return(&entity.Session{
+		ID: 5, UserID: 1, ExpiresAt: sessionTime.Add(testSessionTTL),
+	}, nil)
+	clk.EXPECT().Now().Return(sessionTime)
+	sessionWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.Session) error {
+		assert.False(t, s.Active(sessionTime))
+		return nil
+	})
+
+	uc := NewRevokeSession(sessionReader, sessionWriter, clk)
+	_, err := uc.Execute(ctx, 5)
+
+	assert.NoError(t, err)
+}
+
+func TestAuthenticate_Execute(t *testing.T) {
+	ctx := context.Background()
+	active := &entity.Session{ID: 5, UserID: 1, ExpiresAt: sessionTime.Add(testSessionTTL)}
+	revokedAt := sessionTime.Add(-time.Minute)
+	revoked := &entity.Session{ID: 5, UserID: 1, ExpiresAt: sessionTime.Add(testSessionTTL), RevokedAt: &revokedAt}
+
+	tests := []struct {
+		name      string
+		tokenErr  error
+		session   *entity.Session
+		lookupErr error
+		wantErr   error
+	}{
+		{name: "active session", session: active},
+		{name: "invalid token", tokenErr: errors.New("bad signature"), wantErr: application.ErrInvalidCredentials},
+		{name: "revoked session", session: revoked, wantErr: application.ErrInvalidCredentials},
+		{name: "unknown session", lookupErr: application.ErrNotFound, wantErr: application.ErrInvalidCredentials},
+		{name: "lookup failure", lookupErr: errors.New("db down"), wantErr: errors.New("db down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			sessionReader := identityportmocks.NewMockSessionReader(ctrl)
+			tokens := identityportmocks.NewMockTokenProvider(ctrl)
+			clk := appmocks.NewMockClock(ctrl)
+
+			tokens.EXPECT().Validate("access").Return(vo.UserID(1), vo.SessionID(5), tt.tokenErr)
+			if tt.tokenErr == nil {
+				sessionReader.EXPECT().FindByID(ctx, vo.SessionID(5)).Return(tt.session, tt.lookupErr)
+			}
+			if tt.session != nil {
+				clk.EXPECT().Now().Return(sessionTime)
+			}
+
+			uc := NewAuthenticate(sessionReader, tokens, clk)
+			out, err := uc.Execute(ctx, "access")
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, dto.Principal{UserID: 1, SessionID: 5}, out)
+		})
+	}
+}
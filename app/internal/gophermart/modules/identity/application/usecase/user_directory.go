@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"gophermart/internal/gophermart/modules/identity/application/api"
+	"gophermart/internal/gophermart/modules/identity/application/port"
+)
+
+// UserDirectory resolves users for other modules.
+type UserDirectory struct {
+	userReader port.UserReader
+}
+
+// NewUserDirectory returns the user directory as module API.
+func NewUserDirectory(userReader port.UserReader) api.UserDirectoryAPI {
+	return &UserDirectory{userReader: userReader}
+}
+
+// FindByLogin looks the user up by login.
+//
+// Errors:
+//   - application.ErrNotFound — no user has the login
+func (d *UserDirectory) FindByLogin(ctx context.Context, login string) (api.UserRef, error) {
+	u, err := d.userReader.FindByLogin(ctx, login)
+	if err != nil {
+		return api.UserRef{}, err
+	}
+	return api.UserRef{UserID: int64(u.ID), Login: u.Login}, nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/identity/application/api"
+	identityportmocks "gophermart/internal/gophermart/modules/identity/application/port/mocks"
+	"gophermart/internal/gophermart/modules/identity/domain/entity"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserDirectory_FindByLogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userReader := identityportmocks.NewMockUserReader(ctrl)
+
+		userReader.EXPECT().FindByLogin(ctx, "bob").Return(&entity.User{ID: vo.UserID(2), Login: "bob"}, nil)
+
+		ref, err := NewUserDirectory(userReader).FindByLogin(ctx, "bob")
+
+		assert.NoError(t, err)
+		assert.Equal(t, api.UserRef{UserID: 2, Login: "bob"}, ref)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userReader := identityportmocks.NewMockUserReader(ctrl)
+
+		userReader.EXPECT().FindByLogin(ctx, "bob").Return(nil, application.ErrNotFound)
+
+		_, err := NewUserDirectory(userReader).FindByLogin(ctx, "bob")
+
+		assert.ErrorIs(t, err, application.ErrNotFound)
+	})
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// LoginFailures counts consecutive failed logins for a throttle key: a login or a client IP.
+type LoginFailures struct {
+	Key          string
+	Count        int
+	LastFailedAt time.Time
+	// LockedUntil is set when Count reaches the lockout threshold.
+	LockedUntil *time.Time
+}
+
+// LoginThrottleKey returns the throttle key of failed logins for the login.
+func LoginThrottleKey(login string) string {
+	return "login:" + login
+}
+
+// ClientIPThrottleKey returns the throttle key of failed logins from the client IP.
+func ClientIPThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+// RetryAt returns the earliest time the next login attempt is allowed.
+func (f *LoginFailures) RetryAt(p vo.LoginThrottlePolicy) time.Time {
+	if f.LockedUntil != nil {
+		return *f.LockedUntil
+	}
+	return f.LastFailedAt.Add(p.Delay(f.Count))
+}
+
+// RecordFailure counts a failed login at now and reports whether it locked logins out.
+// Failures older than the policy window and an expired lockout are forgotten first.
+func (f *LoginFailures) RecordFailure(p vo.LoginThrottlePolicy, now time.Time) bool {
+	lockExpired := f.LockedUntil != nil && !now.Before(*f.LockedUntil)
+	windowPassed := p.Window > 0 && now.Sub(f.LastFailedAt) >= p.Window
+	if lockExpired || (f.LockedUntil == nil && windowPassed) {
+		f.Count = 0
+		f.LockedUntil = nil
+	}
+
+	f.Count++
+	f.LastFailedAt = now
+
+	if f.LockedUntil == nil && p.Locks(f.Count) {
+		until := now.Add(p.LockoutDuration)
+		f.LockedUntil = &until
+		return true
+	}
+	return false
+}
+
+// ReleaseFailure takes back a failure counted in advance for an attempt that succeeded,
+// together with the lockout it caused.
+func (f *LoginFailures) ReleaseFailure(p vo.LoginThrottlePolicy) {
+	if f.Count > 0 {
+		f.Count--
+	}
+	if f.LockedUntil != nil && !p.Locks(f.Count) {
+		f.LockedUntil = nil
+	}
+}
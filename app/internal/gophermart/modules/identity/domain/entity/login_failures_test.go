@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+func TestLoginFailures_RecordFailure(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := vo.LoginThrottlePolicy{
+		FreeAttempts:     1,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute,
+		LockoutThreshold: 3,
+		LockoutDuration:  15 * time.Minute,
+		Window:           time.Hour,
+	}
+
+	t.Run("delay grows until lockout", func(t *testing.T) {
+		f := &LoginFailures{Key: LoginThrottleKey("alice")}
+
+		assert.False(t, f.RecordFailure(p, now))
+		assert.Equal(t, now, f.RetryAt(p))
+
+		assert.False(t, f.RecordFailure(p, now))
+		assert.Equal(t, now.Add(time.Second), f.RetryAt(p))
+
+		assert.True(t, f.RecordFailure(p, now))
+		assert.Equal(t, now.Add(15*time.Minute), f.RetryAt(p))
+
+		assert.False(t, f.RecordFailure(p, now.Add(time.Minute)), "already locked")
+		assert.Equal(t, now.Add(15*time.Minute), f.RetryAt(p), "failures while locked do not extend the lockout")
+	})
+
+	t.Run("expired lockout starts over", func(t *testing.T) {
+		until := now
+		f := &LoginFailures{Count: 3, LastFailedAt: now.Add(-15 * time.Minute), LockedUntil: &until}
+
+		assert.False(t, f.RecordFailure(p, now))
+		assert.Equal(t, 1, f.Count)
+		assert.Nil(t, f.LockedUntil)
+	})
+
+	t.Run("failures outside the window are forgotten", func(t *testing.T) {
+		f := &LoginFailures{Count: 2, LastFailedAt: now.Add(-time.Hour)}
+
+		assert.False(t, f.RecordFailure(p, now))
+		assert.Equal(t, 1, f.Count)
+	})
+}
+
+func TestLoginFailures_ReleaseFailure(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := vo.LoginThrottlePolicy{LockoutThreshold: 3, LockoutDuration: 15 * time.Minute}
+
+	t.Run("takes back the failure", func(t *testing.T) {
+		f := &LoginFailures{Count: 1, LastFailedAt: now.Add(-time.Minute)}
+
+		f.RecordFailure(p, now)
+		f.ReleaseFailure(p)
+		assert.Equal(t, 1, f.Count)
+		assert.Nil(t, f.LockedUntil)
+	})
+
+	t.Run("takes back the lockout it caused", func(t *testing.T) {
+		f := &LoginFailures{Count: 2, LastFailedAt: now.Add(-time.Minute)}
+
+		require.True(t, f.RecordFailure(p, now))
+		f.ReleaseFailure(p)
+		assert.Equal(t, 2, f.Count)
+		assert.Nil(t, f.LockedUntil)
+	})
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+)
+
+// Session is a login session: the family of refresh tokens rotated from one login.
+// Access tokens name the session, so revoking it invalidates them before they expire.
+type Session struct {
+	ID        vo.SessionID
+	UserID    vo.UserID
+	CreatedAt time.Time
+	// ExpiresAt ends the session unless it is refreshed before; every refresh extends it up to MaxExpiresAt.
+	ExpiresAt time.Time
+	// MaxExpiresAt is the absolute end of the session set at login; refreshes never move it.
+	MaxExpiresAt time.Time
+	RevokedAt    *time.Time
+}
+
+// NewSession starts a session for the user that lasts ttl unless refreshed
+// and no longer than maxLifetime however often it is refreshed.
+func NewSession(userID vo.UserID, now time.Time, ttl, maxLifetime time.Duration) *Session {
+	s := &Session{
+		UserID:       userID,
+		CreatedAt:    now,
+		MaxExpiresAt: now.Add(maxLifetime),
+	}
+	s.Extend(now, ttl)
+	return s
+}
+
+// Active reports whether the session is neither revoked nor expired at now.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Extend moves the session expiry to now plus ttl, but not past MaxExpiresAt.
+func (s *Session) Extend(now time.Time, ttl time.Duration) {
+	s.ExpiresAt = now.Add(ttl)
+	if s.ExpiresAt.After(s.MaxExpiresAt) {
+		s.ExpiresAt = s.MaxExpiresAt
+	}
+}
+
+// Revoke ends the session at now; revoking again keeps the first revocation time.
+func (s *Session) Revoke(now time.Time) {
+	if s.RevokedAt == nil {
+		s.RevokedAt = &now
+	}
+}
+
+// RefreshToken is a single-use refresh token of a session; only its hash is stored.
+type RefreshToken struct {
+	Hash      string
+	SessionID vo.SessionID
+	IssuedAt  time.Time
+	// UsedAt is set once the token is exchanged for a new one.
+	UsedAt *time.Time
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_Lifecycle(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSession(1, now, time.Hour, 24*time.Hour)
+
+	assert.True(t, s.Active(now))
+	assert.False(t, s.Active(now.Add(time.Hour)), "expiry is exclusive")
+
+	s.Extend(now.Add(30*time.Minute), time.Hour)
+	assert.True(t, s.Active(now.Add(time.Hour)))
+
+	s.Revoke(now.Add(time.Minute))
+	s.Revoke(now.Add(2 * time.Minute))
+	assert.False(t, s.Active(now.Add(time.Minute)))
+	assert.Equal(t, now.Add(time.Minute), *s.RevokedAt)
+}
+
+func TestSession_ExtendStopsAtMaxLifetime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSession(1, now, time.Hour, 90*time.Minute)
+	assert.Equal(t, now.Add(90*time.Minute), s.MaxExpiresAt)
+
+	s.Extend(now.Add(time.Hour), time.Hour)
+	assert.Equal(t, now.Add(90*time.Minute), s.ExpiresAt, "extension is capped")
+	assert.False(t, s.Active(now.Add(90*time.Minute)))
+
+	short := NewSession(1, now, time.Hour, 30*time.Minute)
+	assert.Equal(t, now.Add(30*time.Minute), short.ExpiresAt)
+}
@@ -0,0 +1,49 @@
+package vo
+
+import "time"
+
+// LoginThrottlePolicy slows down repeated failed logins and locks them out for a while.
+type LoginThrottlePolicy struct {
+	// FreeAttempts is the number of failures allowed without any delay.
+	FreeAttempts int
+	// BaseDelay is the delay after the first failure beyond FreeAttempts; it doubles on every next failure.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay (0 — uncapped).
+	MaxDelay time.Duration
+	// LockoutThreshold is the number of failures that locks logins out (0 — never).
+	LockoutThreshold int
+	// LockoutDuration is how long a lockout lasts.
+	LockoutDuration time.Duration
+	// Window is how long failures are remembered after the last one (0 — until a successful login).
+	Window time.Duration
+}
+
+// Delay returns the wait required after the given number of consecutive failures.
+func (p LoginThrottlePolicy) Delay(failures int) time.Duration {
+	excess := failures - p.FreeAttempts
+	if excess <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < excess; i++ {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+		// Stop doubling before time.Duration overflows.
+		if delay > time.Duration(1<<62) {
+			break
+		}
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// Locks reports whether the given number of consecutive failures locks logins out.
+func (p LoginThrottlePolicy) Locks(failures int) bool {
+	return p.LockoutThreshold > 0 && failures >= p.LockoutThreshold
+}
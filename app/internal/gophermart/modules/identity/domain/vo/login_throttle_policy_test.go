@@ -0,0 +1,19 @@
+package vo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottlePolicy_Delay(t *testing.T) {
+	p := LoginThrottlePolicy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Zero(t, p.Delay(3))
+	assert.Equal(t, time.Second, p.Delay(4))
+	assert.Equal(t, 2*time.Second, p.Delay(5))
+	assert.Equal(t, 4*time.Second, p.Delay(6))
+	assert.Equal(t, 5*time.Second, p.Delay(7))
+	assert.Equal(t, 5*time.Second, p.Delay(1000))
+}
@@ -0,0 +1,4 @@
+package vo
+
+// SessionID is identity module auth session identifier.
+type SessionID int64
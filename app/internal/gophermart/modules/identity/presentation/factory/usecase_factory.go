@@ -10,4 +10,12 @@ import (
 type UseCaseFactory interface {
 	RegisterUseCase() port.UseCase[dto.RegisterInput, vo.UserID]
 	LoginUseCase() port.UseCase[dto.LoginInput, vo.UserID]
+	ChangePasswordUseCase() port.UseCase[dto.ChangePasswordInput, struct{}]
+	CreateSessionUseCase() port.UseCase[vo.UserID, dto.SessionTokens]
+	RefreshSessionUseCase() port.UseCase[string, dto.SessionTokens]
+	RevokeSessionUseCase() port.UseCase[vo.SessionID, struct{}]
+	AuthenticateUseCase() port.UseCase[string, dto.Principal]
+	ListPublicKeysUseCase() port.UseCase[struct{}, []dto.PublicKey]
+	PurgeLoginFailuresUseCase() port.BackgroundRunner
+	PurgeSessionsUseCase() port.BackgroundRunner
 }
@@ -11,3 +11,43 @@ type LoginRequest struct {
 	Login    string `json:"login" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+// MaxPasswordBytes is the longest accepted new password: bcrypt refuses longer input.
+const MaxPasswordBytes = 72
+
+// ChangePasswordRequest is the HTTP request body for a password change.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// RefreshRequest is the HTTP request body for token refresh when the refresh cookie is absent.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse is the HTTP response body with the tokens of an auth session.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    string `json:"expires_at"`
+}
+
+// JWKSResponse is the JSON Web Key Set with the public keys that verify access tokens.
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a public key in JSON Web Key form (RFC 7517, RFC 8037).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// N and E are the RSA modulus and exponent.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv and X are the OKP curve and public key.
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	httpdto "gophermart/internal/gophermart/modules/identity/presentation/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jwksCacheControl lets verifiers cache the key set; a new key must be published
+// at least this long before it starts signing.
+const jwksCacheControl = "public, max-age=300"
+
+// JWKS returns the public keys that verify access tokens.
+func (h *UserHandler) JWKS(c *gin.Context) {
+	keys, err := h.useCases.ListPublicKeysUseCase().Execute(c.Request.Context(), struct{}{})
+	if err != nil {
+		h.log.Error("list public keys use case failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	resp := httpdto.JWKSResponse{Keys: make([]httpdto.JWK, 0, len(keys))}
+	for _, k := range keys {
+		jwk, ok := newJWK(k)
+		if !ok {
+			h.log.Warn("skipping public key of unsupported type", "kid", k.ID)
+			continue
+		}
+		resp.Keys = append(resp.Keys, jwk)
+	}
+
+	c.Header("Cache-Control", jwksCacheControl)
+	c.JSON(http.StatusOK, resp)
+}
+
+func newJWK(k dto.PublicKey) (httpdto.JWK, bool) {
+	jwk := httpdto.JWK{Kid: k.ID, Alg: k.Algorithm, Use: "sig"}
+	switch key := k.Key.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	default:
+		return httpdto.JWK{}, false
+	}
+	return jwk, true
+}
@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	httpdto "gophermart/internal/gophermart/modules/identity/presentation/http/dto"
+)
+
+func TestUserHandler_JWKS(t *testing.T) {
+	_, factory, router := setupUserRouter(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	factory.listPublicKeysUC = &stubUseCase[struct{}, []dto.PublicKey]{out: []dto.PublicKey{
+		{ID: "rsa-1", Algorithm: "RS256", Key: &rsaKey.PublicKey},
+		{ID: "ed-1", Algorithm: "EdDSA", Key: edPub},
+	}}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+	var resp httpdto.JWKSResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Keys, 2)
+
+	assert.Equal(t, httpdto.JWK{
+		Kty: "RSA",
+		Kid: "rsa-1",
+		Alg: "RS256",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+		E:   "AQAB",
+	}, resp.Keys[0])
+	assert.Equal(t, httpdto.JWK{
+		Kty: "OKP",
+		Kid: "ed-1",
+		Alg: "EdDSA",
+		Use: "sig",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(edPub),
+	}, resp.Keys[1])
+}
+
+func TestUserHandler_JWKS_NoPublicKeys(t *testing.T) {
+	_, factory, router := setupUserRouter(t)
+	factory.listPublicKeysUC = &stubUseCase[struct{}, []dto.PublicKey]{}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"keys":[]}`, w.Body.String())
+}
+
+func TestUserHandler_JWKS_Error(t *testing.T) {
+	_, factory, router := setupUserRouter(t)
+	factory.listPublicKeysUC = &stubUseCase[struct{}, []dto.PublicKey]{err: errors.New("boom")}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+	httpdto "gophermart/internal/gophermart/modules/identity/presentation/http/dto"
+	"gophermart/internal/gophermart/presentation/http/httpcontext"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangePassword replaces the password of the authenticated user; the user's other sessions are revoked.
+// Wrong current passwords are throttled like failed logins: throttled attempts get 429
+// with Retry-After in seconds.
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, ok := httpcontext.UserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	sessionID, ok := httpcontext.SessionID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var req httpdto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if len(req.NewPassword) > httpdto.MaxPasswordBytes {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "new password is too long"})
+		return
+	}
+
+	_, err := h.useCases.ChangePasswordUseCase().Execute(c.Request.Context(), dto.ChangePasswordInput{
+		UserID:          vo.UserID(userID),
+		SessionID:       vo.SessionID(sessionID),
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		var throttled *application.ErrLoginThrottled
+		switch {
+		case errors.As(err, &throttled):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many failed password checks"})
+		case errors.Is(err, application.ErrInvalidCredentials):
+			// Not 401: the session is valid, only the password proof failed.
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "current password is wrong"})
+		case errors.Is(err, application.ErrConflict):
+			c.AbortWithStatus(http.StatusConflict)
+		default:
+			h.log.Error("change password use case failed", "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
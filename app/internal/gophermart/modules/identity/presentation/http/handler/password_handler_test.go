@@ -0,0 +1,60 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+)
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+	body := `{"current_password": "old", "new_password": "new"}`
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCalled bool
+	}{
+		{"success", body, nil, http.StatusNoContent, true},
+		{"wrong current password", body, application.ErrInvalidCredentials, http.StatusForbidden, true},
+		{"throttled", body, &application.ErrLoginThrottled{RetryAfter: time.Minute}, http.StatusTooManyRequests, true},
+		{"concurrent change", body, application.ErrConflict, http.StatusConflict, true},
+		{"internal error", body, errors.New("db error"), http.StatusInternalServerError, true},
+		{"missing new password", `{"current_password": "old"}`, nil, http.StatusBadRequest, false},
+		{
+			"new password too long for bcrypt",
+			`{"current_password": "old", "new_password": "` + strings.Repeat("ж", 37) + `"}`,
+			nil, http.StatusBadRequest, false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupUserRouter(t)
+			spy := &spyUseCase[dto.ChangePasswordInput, struct{}]{err: tt.err}
+			factory.changePasswordUC = spy
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/user/password", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantCalled, spy.called)
+			if tt.wantCalled {
+				assert.Equal(t, dto.ChangePasswordInput{
+					UserID: 1, SessionID: 9, CurrentPassword: "old", NewPassword: "new",
+				}, spy.in)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/identity/application/dto"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
+	httpdto "gophermart/internal/gophermart/modules/identity/presentation/http/dto"
+	"gophermart/internal/gophermart/presentation/http/httpcontext"
+
+	"github.com/gin-gonic/gin"
+)
+
+// refreshCookiePath limits the refresh token cookie to the identity endpoints.
+const refreshCookiePath = "/api/user"
+
+// Refresh exchanges a refresh token from the cookie or the request body for a new token pair.
+func (h *UserHandler) Refresh(c *gin.Context) {
+	refreshToken, _ := c.Cookie(httpcontext.RefreshCookieName)
+	if refreshToken == "" {
+		var req httpdto.RefreshRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		refreshToken = req.RefreshToken
+	}
+
+	tokens, err := h.useCases.RefreshSessionUseCase().Execute(c.Request.Context(), refreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrRefreshTokenReused):
+			h.log.Warn("refresh token reuse detected, session revoked")
+			clearAuthCookies(c)
+			c.AbortWithStatus(http.StatusUnauthorized)
+		case errors.Is(err, application.ErrInvalidCredentials):
+			clearAuthCookies(c)
+			c.AbortWithStatus(http.StatusUnauthorized)
+		default:
+			h.log.Error("refresh session use case failed", "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+	respondWithTokens(c, tokens)
+}
+
+// Logout revokes the session of the request and clears the auth cookies.
+func (h *UserHandler) Logout(c *gin.Context) {
+	sessionID, ok := httpcontext.SessionID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	_, err := h.useCases.RevokeSessionUseCase().Execute(c.Request.Context(), vo.SessionID(sessionID))
+	if err != nil && !errors.Is(err, application.ErrNotFound) {
+		h.log.Error("revoke session use case failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	clearAuthCookies(c)
+	c.Status(http.StatusNoContent)
+}
+
+// respondWithTokens writes the access token to cookie and Authorization header,
+// the refresh token to its cookie, and both to the response body.
+func respondWithTokens(c *gin.Context, tokens dto.SessionTokens) {
+	setCookie(c, httpcontext.CookieName, tokens.AccessToken, "/", 0)
+	setCookie(c, httpcontext.RefreshCookieName, tokens.RefreshToken, refreshCookiePath, time.Until(tokens.ExpiresAt))
+	c.Header("Authorization", "Bearer "+tokens.AccessToken)
+	c.JSON(http.StatusOK, httpdto.TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt.Format(time.RFC3339),
+	})
+}
+
+// clearAuthCookies expires the access and refresh token cookies.
+func clearAuthCookies(c *gin.Context) {
+	setCookie(c, httpcontext.CookieName, "", "/", -1)
+	setCookie(c, httpcontext.RefreshCookieName, "", refreshCookiePath, -1)
+}
+
+// setCookie writes an HttpOnly cookie; maxAge 0 makes it a session cookie, negative deletes it.
+func setCookie(c *gin.Context, name, value, path string, maxAge time.Duration) {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		HttpOnly: true,
+		Secure:   false, // true in prod (HTTPS)
+		SameSite: http.SameSiteStrictMode,
+	}
+	switch {
+	case maxAge < 0:
+		cookie.MaxAge = -1
+	case maxAge > 0:
+		cookie.MaxAge = int(maxAge.Seconds())
+	}
+	http.SetCookie(c.Writer, cookie)
+}
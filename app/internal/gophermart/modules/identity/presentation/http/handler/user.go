@@ -1,15 +1,17 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"gophermart/internal/gophermart/application"
 	appport "gophermart/internal/gophermart/application/port"
 	"gophermart/internal/gophermart/modules/identity/application/dto"
-	"gophermart/internal/gophermart/modules/identity/application/port"
+	"gophermart/internal/gophermart/modules/identity/domain/vo"
 	"gophermart/internal/gophermart/modules/identity/presentation/factory"
 	httpdto "gophermart/internal/gophermart/modules/identity/presentation/http/dto"
-	"gophermart/internal/gophermart/presentation/http/httpcontext"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,20 +19,18 @@ import (
 // UserHandler manages registration and authentication requests.
 type UserHandler struct {
 	useCases factory.UseCaseFactory
-	tokens   port.TokenProvider
 	log      appport.Logger
 }
 
 // NewUserHandler creates a UserHandler with identity use cases provider.
-func NewUserHandler(useCases factory.UseCaseFactory, tokens port.TokenProvider, log appport.Logger) *UserHandler {
+func NewUserHandler(useCases factory.UseCaseFactory, log appport.Logger) *UserHandler {
 	return &UserHandler{
 		useCases: useCases,
-		tokens:   tokens,
 		log:      log,
 	}
 }
 
-// Register creates a new user and issues an auth token.
+// Register creates a new user and starts an auth session.
 func (h *UserHandler) Register(c *gin.Context) {
 	var req httpdto.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -50,17 +50,11 @@ func (h *UserHandler) Register(c *gin.Context) {
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
-	token, err := h.tokens.Issue(userID)
-	if err != nil {
-		h.log.Error("failed to issue token", "error", err)
-		c.AbortWithStatus(http.StatusInternalServerError)
-		return
-	}
-	setAuthToken(c, token)
-	c.Status(http.StatusOK)
+	h.startSession(c, userID)
 }
 
-// Login authenticates a user and issues an auth token.
+// Login authenticates a user and starts an auth session. Throttled attempts get 429
+// with Retry-After in seconds.
 func (h *UserHandler) Login(c *gin.Context) {
 	var req httpdto.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -69,37 +63,33 @@ func (h *UserHandler) Login(c *gin.Context) {
 	}
 	userID, err := h.useCases.LoginUseCase().Execute(
 		c.Request.Context(),
-		dto.LoginInput{Login: req.Login, Password: req.Password},
+		dto.LoginInput{Login: req.Login, Password: req.Password, ClientIP: c.ClientIP()},
 	)
 	if err != nil {
-		if err == application.ErrInvalidCredentials {
+		var throttled *application.ErrLoginThrottled
+		switch {
+		case errors.Is(err, application.ErrInvalidCredentials):
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
+		case errors.As(err, &throttled):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many failed logins"})
+			return
 		}
 		h.log.Error("login use case failed", "error", err)
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
-	token, err := h.tokens.Issue(userID)
+	h.startSession(c, userID)
+}
+
+// startSession creates a session for the user and responds with its tokens.
+func (h *UserHandler) startSession(c *gin.Context, userID vo.UserID) {
+	tokens, err := h.useCases.CreateSessionUseCase().Execute(c.Request.Context(), userID)
 	if err != nil {
-		h.log.Error("failed to issue token", "error", err)
+		h.log.Error("create session use case failed", "error", err)
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
-	setAuthToken(c, token)
-	c.Status(http.StatusOK)
-}
-
-// setAuthToken writes the token to cookie and Authorization header.
-func setAuthToken(c *gin.Context, token string) {
-	cookie := &http.Cookie{
-		Name:     httpcontext.CookieName,
-		Value:    token,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false, // true in prod (HTTPS)
-		SameSite: http.SameSiteStrictMode,
-	}
-	http.SetCookie(c.Writer, cookie)
-	c.Header("Authorization", "Bearer "+token)
+	respondWithTokens(c, tokens)
 }
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -17,7 +19,6 @@ import (
 	"gophermart/internal/gophermart/application/port"
 	portmocks "gophermart/internal/gophermart/application/port/mocks"
 	"gophermart/internal/gophermart/modules/identity/application/dto"
-	identityportmocks "gophermart/internal/gophermart/modules/identity/application/port/mocks"
 	"gophermart/internal/gophermart/modules/identity/domain/vo"
 	"gophermart/internal/gophermart/modules/identity/presentation/http/handler"
 	"gophermart/internal/gophermart/presentation/http/httpcontext"
@@ -33,9 +34,31 @@ func (s *stubUseCase[In, Out]) Execute(_ context.Context, _ In) (Out, error) {
 	return s.out, s.err
 }
 
+// spyUseCase records the input it was called with.
+type spyUseCase[In, Out any] struct {
+	in     In
+	called bool
+	out    Out
+	err    error
+}
+
+func (s *spyUseCase[In, Out]) Execute(_ context.Context, in In) (Out, error) {
+	s.in = in
+	s.called = true
+	return s.out, s.err
+}
+
 type testIdentityFactory struct {
-	registerUC port.UseCase[dto.RegisterInput, vo.UserID]
-	loginUC    port.UseCase[dto.LoginInput, vo.UserID]
+	registerUC       port.UseCase[dto.RegisterInput, vo.UserID]
+	loginUC          port.UseCase[dto.LoginInput, vo.UserID]
+	changePasswordUC port.UseCase[dto.ChangePasswordInput, struct{}]
+	createSessionUC  port.UseCase[vo.UserID, dto.SessionTokens]
+	refreshSessionUC port.UseCase[string, dto.SessionTokens]
+	revokeSessionUC  port.UseCase[vo.SessionID, struct{}]
+	authenticateUC   port.UseCase[string, dto.Principal]
+	listPublicKeysUC port.UseCase[struct{}, []dto.PublicKey]
+	purgeFailuresUC  port.BackgroundRunner
+	purgeSessionsUC  port.BackgroundRunner
 }
 
 func (f *testIdentityFactory) RegisterUseCase() port.UseCase[dto.RegisterInput, vo.UserID] {
@@ -46,30 +69,93 @@ func (f *testIdentityFactory) LoginUseCase() port.UseCase[dto.LoginInput, vo.Use
 	return f.loginUC
 }
 
-func setupUserRouter(t *testing.T) (*gomock.Controller, *testIdentityFactory, *identityportmocks.MockTokenProvider, *gin.Engine) {
+func (f *testIdentityFactory) ChangePasswordUseCase() port.UseCase[dto.ChangePasswordInput, struct{}] {
+	return f.changePasswordUC
+}
+
+func (f *testIdentityFactory) CreateSessionUseCase() port.UseCase[vo.UserID, dto.SessionTokens] {
+	return f.createSessionUC
+}
+
+func (f *testIdentityFactory) RefreshSessionUseCase() port.UseCase[string, dto.SessionTokens] {
+	return f.refreshSessionUC
+}
+
+func (f *testIdentityFactory) RevokeSessionUseCase() port.UseCase[vo.SessionID, struct{}] {
+	return f.revokeSessionUC
+}
+
+func (f *testIdentityFactory) AuthenticateUseCase() port.UseCase[string, dto.Principal] {
+	return f.authenticateUC
+}
+
+func (f *testIdentityFactory) ListPublicKeysUseCase() port.UseCase[struct{}, []dto.PublicKey] {
+	return f.listPublicKeysUC
+}
+
+func (f *testIdentityFactory) PurgeLoginFailuresUseCase() port.BackgroundRunner {
+	return f.purgeFailuresUC
+}
+
+func (f *testIdentityFactory) PurgeSessionsUseCase() port.BackgroundRunner {
+	return f.purgeSessionsUC
+}
+
+// testSessionTokens is the token pair returned by session use case stubs.
+var testSessionTokens = dto.SessionTokens{
+	AccessToken:  "test-jwt-token",
+	RefreshToken: "test-refresh-token",
+	ExpiresAt:    time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+}
+
+func setupUserRouter(t *testing.T) (*gomock.Controller, *testIdentityFactory, *gin.Engine) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
-	factory := &testIdentityFactory{}
-	tokens := identityportmocks.NewMockTokenProvider(ctrl)
+	factory := &testIdentityFactory{
+		createSessionUC: &stubUseCase[vo.UserID, dto.SessionTokens]{out: testSessionTokens},
+	}
 	log := portmocks.NewMockLogger(ctrl)
 	log.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	log.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
 
-	h := handler.NewUserHandler(factory, tokens, log)
+	h := handler.NewUserHandler(factory, log)
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
 	r.POST("/api/user/register", h.Register)
 	r.POST("/api/user/login", h.Login)
+	r.POST("/api/user/token/refresh", h.Refresh)
+	r.GET("/.well-known/jwks.json", h.JWKS)
+	authenticated := func(c *gin.Context) {
+		c.Set(httpcontext.UserIDKey, int64(1))
+		c.Set(httpcontext.SessionIDKey, int64(9))
+		c.Next()
+	}
+	r.POST("/api/user/logout", authenticated, h.Logout)
+	r.POST("/api/user/password", authenticated, h.ChangePassword)
 
-	return ctrl, factory, tokens, r
+	return ctrl, factory, r
+}
+
+// findCookie returns the response cookie with the name or nil.
+func findCookie(w *httptest.ResponseRecorder, name string) *http.Cookie {
+	result := w.Result()
+	defer result.Body.Close()
+	for _, c := range result.Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
 }
 
 func TestUserHandler_Register_Success(t *testing.T) {
-	_, factory, tokens, router := setupUserRouter(t)
+	_, factory, router := setupUserRouter(t)
 
 	var userID vo.UserID = 42
 	factory.registerUC = &stubUseCase[dto.RegisterInput, vo.UserID]{out: userID}
-	tokens.EXPECT().Issue(userID).Return("test-jwt-token", nil)
+	session := &spyUseCase[vo.UserID, dto.SessionTokens]{out: testSessionTokens}
+	factory.createSessionUC = session
 
 	body, err := json.Marshal(map[string]string{"login": "alice", "password": "secret123"})
 	require.NoError(t, err)
@@ -79,24 +165,26 @@ func TestUserHandler_Register_Success(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, userID, session.in)
 	assert.Contains(t, w.Header().Get("Authorization"), "Bearer test-jwt-token")
 
-	result := w.Result()
-	defer result.Body.Close()
-	cookies := result.Cookies()
-	require.NotEmpty(t, cookies)
-	var found bool
-	for _, c := range cookies {
-		if c.Name == httpcontext.CookieName {
-			assert.Equal(t, "test-jwt-token", c.Value)
-			found = true
-		}
-	}
-	assert.True(t, found, "auth cookie not set")
+	authCookie := findCookie(w, httpcontext.CookieName)
+	require.NotNil(t, authCookie, "auth cookie not set")
+	assert.Equal(t, "test-jwt-token", authCookie.Value)
+	refreshCookie := findCookie(w, httpcontext.RefreshCookieName)
+	require.NotNil(t, refreshCookie, "refresh cookie not set")
+	assert.Equal(t, "test-refresh-token", refreshCookie.Value)
+	assert.Equal(t, "/api/user", refreshCookie.Path)
+	assert.True(t, refreshCookie.HttpOnly)
+
+	var resp map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "test-refresh-token", resp["refresh_token"])
+	assert.Equal(t, testSessionTokens.ExpiresAt.Format(time.RFC3339), resp["expires_at"])
 }
 
 func TestUserHandler_Register_AlreadyExists(t *testing.T) {
-	_, factory, _, router := setupUserRouter(t)
+	_, factory, router := setupUserRouter(t)
 
 	factory.registerUC = &stubUseCase[dto.RegisterInput, vo.UserID]{err: application.ErrAlreadyExists}
 
@@ -111,7 +199,7 @@ func TestUserHandler_Register_AlreadyExists(t *testing.T) {
 }
 
 func TestUserHandler_Register_BadJSON(t *testing.T) {
-	_, _, _, router := setupUserRouter(t)
+	_, _, router := setupUserRouter(t)
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPost, "/api/user/register", bytes.NewReader([]byte("not json")))
@@ -122,11 +210,13 @@ func TestUserHandler_Register_BadJSON(t *testing.T) {
 }
 
 func TestUserHandler_Login_Success(t *testing.T) {
-	_, factory, tokens, router := setupUserRouter(t)
+	_, factory, router := setupUserRouter(t)
 
 	var userID vo.UserID = 7
 	factory.loginUC = &stubUseCase[dto.LoginInput, vo.UserID]{out: userID}
-	tokens.EXPECT().Issue(userID).Return("login-token", nil)
+	tokens := testSessionTokens
+	tokens.AccessToken = "login-token"
+	factory.createSessionUC = &stubUseCase[vo.UserID, dto.SessionTokens]{out: tokens}
 
 	body, err := json.Marshal(map[string]string{"login": "alice", "password": "secret123"})
 	require.NoError(t, err)
@@ -140,7 +230,7 @@ func TestUserHandler_Login_Success(t *testing.T) {
 }
 
 func TestUserHandler_Login_InvalidCredentials(t *testing.T) {
-	_, factory, _, router := setupUserRouter(t)
+	_, factory, router := setupUserRouter(t)
 
 	factory.loginUC = &stubUseCase[dto.LoginInput, vo.UserID]{err: application.ErrInvalidCredentials}
 
@@ -154,8 +244,44 @@ func TestUserHandler_Login_InvalidCredentials(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
+func TestUserHandler_Login_PassesClientIP(t *testing.T) {
+	_, factory, router := setupUserRouter(t)
+
+	spy := &spyUseCase[dto.LoginInput, vo.UserID]{out: 7}
+	factory.loginUC = spy
+
+	body, err := json.Marshal(map[string]string{"login": "alice", "password": "secret123"})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "203.0.113.7:51234"
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "203.0.113.7", spy.in.ClientIP)
+}
+
+func TestUserHandler_Login_Throttled(t *testing.T) {
+	_, factory, router := setupUserRouter(t)
+
+	factory.loginUC = &stubUseCase[dto.LoginInput, vo.UserID]{
+		err: &application.ErrLoginThrottled{RetryAfter: 1500 * time.Millisecond},
+	}
+
+	body, err := json.Marshal(map[string]string{"login": "alice", "password": "wrong"})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+}
+
 func TestUserHandler_Login_BadJSON(t *testing.T) {
-	_, _, _, router := setupUserRouter(t)
+	_, _, router := setupUserRouter(t)
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPost, "/api/user/login", bytes.NewReader([]byte("{invalid")))
@@ -164,3 +290,110 @@ func TestUserHandler_Login_BadJSON(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+func TestUserHandler_Login_SessionFailure(t *testing.T) {
+	_, factory, router := setupUserRouter(t)
+
+	factory.loginUC = &stubUseCase[dto.LoginInput, vo.UserID]{out: 7}
+	factory.createSessionUC = &stubUseCase[vo.UserID, dto.SessionTokens]{err: errors.New("db down")}
+
+	body, err := json.Marshal(map[string]string{"login": "alice", "password": "secret123"})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/user/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Nil(t, findCookie(w, httpcontext.CookieName))
+}
+
+func TestUserHandler_Refresh(t *testing.T) {
+	t.Run("token from cookie", func(t *testing.T) {
+		_, factory, router := setupUserRouter(t)
+		spy := &spyUseCase[string, dto.SessionTokens]{out: testSessionTokens}
+		factory.refreshSessionUC = spy
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/user/token/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: httpcontext.RefreshCookieName, Value: "old-refresh-token"})
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "old-refresh-token", spy.in)
+		assert.Contains(t, w.Header().Get("Authorization"), "Bearer test-jwt-token")
+		refreshCookie := findCookie(w, httpcontext.RefreshCookieName)
+		require.NotNil(t, refreshCookie)
+		assert.Equal(t, "test-refresh-token", refreshCookie.Value)
+	})
+
+	t.Run("token from body", func(t *testing.T) {
+		_, factory, router := setupUserRouter(t)
+		spy := &spyUseCase[string, dto.SessionTokens]{out: testSessionTokens}
+		factory.refreshSessionUC = spy
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/user/token/refresh",
+			bytes.NewReader([]byte(`{"refresh_token":"body-refresh-token"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "body-refresh-token", spy.in)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		_, factory, router := setupUserRouter(t)
+		spy := &spyUseCase[string, dto.SessionTokens]{}
+		factory.refreshSessionUC = spy
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/user/token/refresh", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.False(t, spy.called)
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "invalid token", err: application.ErrInvalidCredentials, want: http.StatusUnauthorized},
+		{name: "reused token", err: application.ErrRefreshTokenReused, want: http.StatusUnauthorized},
+		{name: "internal error", err: errors.New("db down"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupUserRouter(t)
+			factory.refreshSessionUC = &stubUseCase[string, dto.SessionTokens]{err: tt.err}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/user/token/refresh", nil)
+			req.AddCookie(&http.Cookie{Name: httpcontext.RefreshCookieName, Value: "old-refresh-token"})
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestUserHandler_Logout(t *testing.T) {
+	_, factory, router := setupUserRouter(t)
+	spy := &spyUseCase[vo.SessionID, struct{}]{}
+	factory.revokeSessionUC = spy
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/user/logout", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, vo.SessionID(9), spy.in)
+	authCookie := findCookie(w, httpcontext.CookieName)
+	require.NotNil(t, authCookie)
+	assert.Equal(t, -1, authCookie.MaxAge)
+	refreshCookie := findCookie(w, httpcontext.RefreshCookieName)
+	require.NotNil(t, refreshCookie)
+	assert.Equal(t, -1, refreshCookie.MaxAge)
+}
@@ -4,7 +4,6 @@ import (
 	"github.com/gin-gonic/gin"
 
 	appport "gophermart/internal/gophermart/application/port"
-	"gophermart/internal/gophermart/modules/identity/application/port"
 	"gophermart/internal/gophermart/modules/identity/presentation/factory"
 	"gophermart/internal/gophermart/modules/identity/presentation/http/handler"
 )
@@ -13,10 +12,31 @@ import (
 func RegisterPublicRoutes(
 	api *gin.RouterGroup,
 	useCases factory.UseCaseFactory,
-	tokens port.TokenProvider,
 	log appport.Logger,
 ) {
-	userHandler := handler.NewUserHandler(useCases, tokens, log)
+	userHandler := handler.NewUserHandler(useCases, log)
 	api.POST("/register", userHandler.Register)
 	api.POST("/login", userHandler.Login)
+	api.POST("/token/refresh", userHandler.Refresh)
+}
+
+// RegisterProtectedRoutes registers identity endpoints that require authentication.
+func RegisterProtectedRoutes(
+	api *gin.RouterGroup,
+	useCases factory.UseCaseFactory,
+	log appport.Logger,
+) {
+	userHandler := handler.NewUserHandler(useCases, log)
+	api.POST("/logout", userHandler.Logout)
+	api.POST("/password", userHandler.ChangePassword)
+}
+
+// RegisterWellKnownRoutes registers public discovery endpoints under /.well-known.
+func RegisterWellKnownRoutes(
+	api *gin.RouterGroup,
+	useCases factory.UseCaseFactory,
+	log appport.Logger,
+) {
+	userHandler := handler.NewUserHandler(useCases, log)
+	api.GET("/jwks.json", userHandler.JWKS)
 }
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/presentation/factory"
+)
+
+// LoginFailuresWorker periodically removes failed login records that no longer throttle anything.
+type LoginFailuresWorker struct {
+	purge    port.BackgroundRunner
+	log      port.Logger
+	interval time.Duration
+}
+
+// NewLoginFailuresWorker creates a new failed login purge background worker.
+func NewLoginFailuresWorker(useCases factory.UseCaseFactory, log port.Logger, interval time.Duration) *LoginFailuresWorker {
+	return &LoginFailuresWorker{
+		purge:    useCases.PurgeLoginFailuresUseCase(),
+		log:      log,
+		interval: interval,
+	}
+}
+
+// Start runs the worker loop in a goroutine. Cancel ctx to stop.
+func (w *LoginFailuresWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+func (w *LoginFailuresWorker) run(ctx context.Context) {
+	w.log.Info("login failures purge worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("login failures purge worker stopped")
+			return
+		case <-ticker.C:
+			w.purgeStale(ctx)
+		}
+	}
+}
+
+func (w *LoginFailuresWorker) purgeStale(ctx context.Context) {
+	purged, err := w.purge.Run(ctx)
+	if err != nil {
+		w.log.Error("login failures purge failed", "error", err)
+		return
+	}
+
+	if purged > 0 {
+		w.log.Info("stale login failures purged", "count", purged)
+	}
+}
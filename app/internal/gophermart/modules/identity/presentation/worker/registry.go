@@ -1,6 +1,12 @@
 package worker
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/presentation/factory"
+)
 
 // Starter describes a background worker that can be started with context.
 type Starter interface {
@@ -8,9 +14,23 @@ type Starter interface {
 }
 
 // RegistryParams contains dependencies required to build identity workers.
-type RegistryParams struct{}
+type RegistryParams struct {
+	UseCases factory.UseCaseFactory
+	Log      port.Logger
+	// LoginFailuresPurgeInterval is the stale failed login purge period; 0 disables the purge worker.
+	LoginFailuresPurgeInterval time.Duration
+	// SessionPurgeInterval is the ended session purge period; 0 disables the purge worker.
+	SessionPurgeInterval time.Duration
+}
 
 // BuildWorkers builds all identity module background workers.
-func BuildWorkers(_ RegistryParams) []Starter {
-	return []Starter{}
+func BuildWorkers(p RegistryParams) []Starter {
+	workers := []Starter{}
+	if p.LoginFailuresPurgeInterval > 0 {
+		workers = append(workers, NewLoginFailuresWorker(p.UseCases, p.Log, p.LoginFailuresPurgeInterval))
+	}
+	if p.SessionPurgeInterval > 0 {
+		workers = append(workers, NewSessionsWorker(p.UseCases, p.Log, p.SessionPurgeInterval))
+	}
+	return workers
 }
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/identity/presentation/factory"
+)
+
+// SessionsWorker periodically removes ended sessions and used refresh tokens.
+type SessionsWorker struct {
+	purge    port.BackgroundRunner
+	log      port.Logger
+	interval time.Duration
+}
+
+// NewSessionsWorker creates a new session purge background worker.
+func NewSessionsWorker(useCases factory.UseCaseFactory, log port.Logger, interval time.Duration) *SessionsWorker {
+	return &SessionsWorker{
+		purge:    useCases.PurgeSessionsUseCase(),
+		log:      log,
+		interval: interval,
+	}
+}
+
+// Start runs the worker loop in a goroutine. Cancel ctx to stop.
+func (w *SessionsWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+func (w *SessionsWorker) run(ctx context.Context) {
+	w.log.Info("session purge worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("session purge worker stopped")
+			return
+		case <-ticker.C:
+			w.purgeStale(ctx)
+		}
+	}
+}
+
+func (w *SessionsWorker) purgeStale(ctx context.Context) {
+	purged, err := w.purge.Run(ctx)
+	if err != nil {
+		w.log.Error("session purge failed", "error", err)
+		return
+	}
+
+	if purged > 0 {
+		w.log.Info("stale sessions and refresh tokens purged", "count", purged)
+	}
+}
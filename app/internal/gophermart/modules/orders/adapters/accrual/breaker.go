@@ -0,0 +1,87 @@
+package accrual
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker stops calls to the accrual system after repeated failures (5xx, network errors).
+// After openTimeout a single probe is let through: success closes the breaker, failure reopens it.
+type CircuitBreaker struct {
+	mu          sync.Mutex
+	threshold   int
+	openTimeout time.Duration
+	failures    int
+	state       breakerState
+	openedAt    time.Time
+	now         func() time.Time
+}
+
+// NewCircuitBreaker creates a breaker opening after threshold consecutive failures.
+// A non-positive threshold disables the breaker.
+func NewCircuitBreaker(threshold int, openTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		now:         time.Now,
+	}
+}
+
+// Allow reports whether a call may proceed; otherwise it returns how long the breaker stays open.
+func (b *CircuitBreaker) Allow() (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		remaining := b.openedAt.Add(b.openTimeout).Sub(b.now())
+		if remaining > 0 {
+			return remaining, false
+		}
+		b.state = breakerHalfOpen
+		b.openedAt = b.now()
+		return 0, true
+	case breakerHalfOpen:
+		// A probe is in flight; let another one through only if it never reported back.
+		remaining := b.openedAt.Add(b.openTimeout).Sub(b.now())
+		if remaining > 0 {
+			return remaining, false
+		}
+		b.openedAt = b.now()
+		return 0, true
+	default:
+		return 0, true
+	}
+}
+
+// Success records a call the accrual system answered.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.state = breakerClosed
+}
+
+// Failure records a failed call and opens the breaker when the threshold is reached.
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 {
+		return
+	}
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
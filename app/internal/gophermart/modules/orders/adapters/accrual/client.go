@@ -6,44 +6,102 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"gophermart/internal/gophermart/application"
 	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+	"gophermart/internal/pkg/money"
+	"gophermart/internal/pkg/option"
 )
 
 // defaultRetryAfter is a fallback when 429 response has no Retry-After header.
 const defaultRetryAfter = 60 * time.Second
 
 // Client is an HTTP implementation of port.AccrualClient.
+// Requests pass through a shared rate limiter and circuit breaker, so all workers
+// slow down together on 429 and stop calling a failing accrual system.
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	limiter    *RateLimiter
+	breaker    *CircuitBreaker
+	now        func() time.Time
+}
+
+// WithRateLimiter sets the limiter shared by all requests of the client.
+func WithRateLimiter(l *RateLimiter) option.Option[Client] {
+	return func(c *Client) { c.limiter = l }
+}
+
+// WithCircuitBreaker sets the breaker guarding requests of the client.
+func WithCircuitBreaker(b *CircuitBreaker) option.Option[Client] {
+	return func(c *Client) { c.breaker = b }
+}
+
+// WithNow overrides the time source used to resolve HTTP-date Retry-After values.
+func WithNow(now func() time.Time) option.Option[Client] {
+	return func(c *Client) { c.now = now }
 }
 
 // NewClient creates a new accrual HTTP client.
-func NewClient(baseURL string, httpClient *http.Client) *Client {
-	return &Client{
+// Without options the client has no rate limit and never opens the circuit.
+func NewClient(baseURL string, httpClient *http.Client, opts ...option.Option[Client]) *Client {
+	c := &Client{
 		baseURL:    baseURL,
 		httpClient: httpClient,
+		limiter:    NewRateLimiter(0, 1),
+		breaker:    NewCircuitBreaker(0, 0),
+		now:        time.Now,
 	}
+	option.Apply(c, opts...)
+	return c
 }
 
 // NewClientFromConfig creates a new accrual client from adapter config.
 func NewClientFromConfig(cfg Config) *Client {
-	return NewClient(cfg.Address, &http.Client{Timeout: cfg.HTTPTimeout})
+	return NewClient(cfg.Address, &http.Client{Timeout: cfg.HTTPTimeout},
+		WithRateLimiter(NewRateLimiter(cfg.RateLimit, cfg.RateBurst)),
+		WithCircuitBreaker(NewCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerTimeout)),
+	)
 }
 
 type accrualResponse struct {
-	Order   string   `json:"order"`
-	Status  string   `json:"status"`
-	Accrual *float64 `json:"accrual,omitempty"`
+	Order  string `json:"order"`
+	Status string `json:"status"`
+	// Accrual is decoded as a raw number: the accrual system may send more than two decimals.
+	Accrual *json.Number `json:"accrual,omitempty"`
+}
+
+// points converts the reported accrual to points, truncating digits below a hundredth
+// so an order is never credited more than the accrual system granted.
+func (r accrualResponse) points() (*vo.Points, error) {
+	if r.Accrual == nil {
+		return nil, nil
+	}
+	v, err := money.ParseTruncated(r.Accrual.String())
+	if err != nil {
+		return nil, err
+	}
+	p := vo.Points(v)
+	return &p, nil
 }
 
 // GetOrderAccrual calls the accrual system.
+//
+// Errors:
+//   - application.ErrRateLimit — accrual system answered 429; the shared limiter is paused
+//   - application.ErrUnavailable — circuit is open after repeated failures
 func (c *Client) GetOrderAccrual(ctx context.Context, orderNumber string) (*dto.AccrualOrderInfo, error) {
-	url := fmt.Sprintf("%s/api/orders/%s", c.baseURL, orderNumber)
+	if wait, ok := c.breaker.Allow(); !ok {
+		return nil, &application.ErrUnavailable{RetryAfter: wait}
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
 
+	url := fmt.Sprintf("%s/api/orders/%s", c.baseURL, orderNumber)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("accrual: create request: %w", err)
@@ -51,35 +109,65 @@ func (c *Client) GetOrderAccrual(ctx context.Context, orderNumber string) (*dto.
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		// Cancellation on our side says nothing about the accrual system health.
+		if ctx.Err() == nil {
+			c.breaker.Failure()
+		}
 		return nil, fmt.Errorf("accrual: do request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		c.breaker.Failure()
+		return nil, fmt.Errorf("accrual: unexpected status %d", resp.StatusCode)
+	}
+	c.breaker.Success()
+
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var body accrualResponse
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		dec := json.NewDecoder(resp.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
 			return nil, fmt.Errorf("accrual: decode response: %w", err)
 		}
+		accrual, err := body.points()
+		if err != nil {
+			return nil, fmt.Errorf("accrual: decode accrual %q: %w", body.Accrual.String(), err)
+		}
+		c.limiter.Recover()
 		return &dto.AccrualOrderInfo{
 			Status:  body.Status,
-			Accrual: body.Accrual,
+			Accrual: accrual,
 		}, nil
 
 	case http.StatusNoContent:
 		// Order not registered in accrual system
+		c.limiter.Recover()
 		return nil, nil
 
 	case http.StatusTooManyRequests:
-		retryAfter := defaultRetryAfter
-		if ra := resp.Header.Get("Retry-After"); ra != "" {
-			if seconds, err := strconv.Atoi(ra); err == nil {
-				retryAfter = time.Duration(seconds) * time.Second
-			}
-		}
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), c.now())
+		c.limiter.Throttle(retryAfter)
 		return nil, &application.ErrRateLimit{RetryAfter: retryAfter}
 
 	default:
 		return nil, fmt.Errorf("accrual: unexpected status %d", resp.StatusCode)
 	}
 }
+
+// parseRetryAfter reads Retry-After as delay-seconds or HTTP-date (RFC 9110, section 10.2.3).
+// Missing or malformed values fall back to defaultRetryAfter; dates in the past mean no delay.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultRetryAfter
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return defaultRetryAfter
+}
@@ -0,0 +1,153 @@
+package accrual
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "seconds", value: "120", want: 2 * time.Minute},
+		{name: "http date", value: "Thu, 01 Jan 2026 12:00:30 GMT", want: 30 * time.Second},
+		{name: "http date in the past", value: "Thu, 01 Jan 2026 11:00:00 GMT", want: 0},
+		{name: "empty", value: "", want: defaultRetryAfter},
+		{name: "malformed", value: "soon", want: defaultRetryAfter},
+		{name: "negative", value: "-5", want: defaultRetryAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.value, now))
+		})
+	}
+}
+
+func TestClient_AccrualTruncatedToHundredths(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"order":"12345678903","status":"PROCESSED","accrual":729.989}`))
+	}))
+	defer srv.Close()
+
+	info, err := NewClient(srv.URL, srv.Client()).GetOrderAccrual(context.Background(), "12345678903")
+
+	require.NoError(t, err)
+	require.NotNil(t, info.Accrual)
+	assert.Equal(t, vo.Points(72998), *info.Accrual)
+}
+
+func TestClient_RateLimitThrottlesSharedLimiter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "Thu, 01 Jan 2026 12:00:10 GMT")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	limiter := NewRateLimiter(100, 10)
+	c := NewClient(srv.URL, srv.Client(), WithRateLimiter(limiter), WithNow(func() time.Time { return now }))
+
+	_, err := c.GetOrderAccrual(context.Background(), "12345678903")
+
+	var rl *application.ErrRateLimit
+	require.ErrorAs(t, err, &rl)
+	assert.Equal(t, 10*time.Second, rl.RetryAfter)
+	assert.Equal(t, 50.0, limiter.Rate())
+
+	// Every other caller now waits for the pause instead of hitting the accrual system.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestClient_CircuitBreakerOpensOnServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if healthy.Load() {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(2, 30*time.Second)
+	breaker.now = func() time.Time { return now }
+	c := NewClient(srv.URL, srv.Client(), WithCircuitBreaker(breaker))
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := c.GetOrderAccrual(ctx, "12345678903")
+		require.Error(t, err)
+	}
+
+	_, err := c.GetOrderAccrual(ctx, "12345678903")
+	var unavailable *application.ErrUnavailable
+	require.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, 30*time.Second, unavailable.RetryAfter)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// After the open timeout a probe goes through and closes the circuit.
+	now = now.Add(30 * time.Second)
+	healthy.Store(true)
+
+	info, err := c.GetOrderAccrual(ctx, "12345678903")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	_, err = c.GetOrderAccrual(ctx, "12345678903")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestCircuitBreaker_HalfOpenProbeFailureReopens(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewCircuitBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.Failure()
+	_, ok := b.Allow()
+	assert.False(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = b.Allow()
+	assert.True(t, ok, "probe allowed")
+	_, ok = b.Allow()
+	assert.False(t, ok, "single probe at a time")
+
+	b.Failure()
+	wait, ok := b.Allow()
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, wait)
+}
+
+func TestRateLimiter_RecoverRestoresRate(t *testing.T) {
+	l := NewRateLimiter(100, 1)
+
+	l.Throttle(0)
+	l.Throttle(0)
+	assert.Equal(t, 25.0, l.Rate())
+
+	for range 20 {
+		l.Recover()
+	}
+	assert.Equal(t, 100.0, l.Rate())
+}
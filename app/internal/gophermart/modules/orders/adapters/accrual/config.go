@@ -6,4 +6,11 @@ import "time"
 type Config struct {
 	Address     string
 	HTTPTimeout time.Duration
+	// RateLimit is the maximum requests per second shared by all workers (0 — unlimited).
+	RateLimit float64
+	RateBurst int
+	// BreakerThreshold is the number of consecutive failures that opens the circuit (0 — disabled).
+	BreakerThreshold int
+	// BreakerTimeout is how long the circuit stays open before a probe request.
+	BreakerTimeout time.Duration
 }
@@ -0,0 +1,118 @@
+package accrual
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by all accrual workers of the instance.
+// It adapts to the accrual system: a 429 pauses every caller for Retry-After and halves the rate,
+// successful responses raise the rate back towards the configured maximum.
+type RateLimiter struct {
+	mu          sync.Mutex
+	maxRate     float64 // tokens per second; 0 — unlimited
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// NewRateLimiter creates a limiter allowing up to maxRate requests per second with the given burst.
+// A non-positive maxRate disables rate limiting, Retry-After pauses still apply.
+func NewRateLimiter(maxRate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		maxRate: maxRate,
+		rate:    maxRate,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		now:     time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Throttle pauses all callers for retryAfter and halves the request rate.
+func (l *RateLimiter) Throttle(retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if until := now.Add(retryAfter); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	if l.maxRate > 0 {
+		l.rate = max(l.rate/2, l.minRate())
+	}
+	l.tokens = 0
+	l.last = now
+}
+
+// Recover raises the request rate by a tenth of the maximum after a successful request.
+func (l *RateLimiter) Recover() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxRate > 0 {
+		l.rate = min(l.rate+l.maxRate/10, l.maxRate)
+	}
+}
+
+// Rate returns the current request rate per second (0 — unlimited).
+func (l *RateLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+// reserve takes a token and returns zero, or returns how long to wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.maxRate <= 0 {
+		return 0
+	}
+
+	if !l.last.IsZero() {
+		elapsed := now.Sub(l.last).Seconds()
+		l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// minRate is the floor the rate is never throttled below, so polling always makes progress.
+func (l *RateLimiter) minRate() float64 {
+	return l.maxRate / 64
+}
@@ -20,12 +20,14 @@ func NewBalanceGatewayAdapter(api balanceapi.AccrualAPI) *BalanceGatewayAdapter
 func (a *BalanceGatewayAdapter) ApplyAccrual(
 	ctx context.Context,
 	userID vo.UserID,
+	orderNumber vo.OrderNumber,
 	amount vo.Points,
 	processedAt time.Time,
 ) error {
 	return a.api.ApplyAccrual(ctx, balanceapi.ApplyAccrualInput{
 		UserID:      int64(userID),
-		Amount:      float64(amount),
+		OrderNumber: orderNumber.String(),
+		Amount:      int64(amount),
 		ProcessedAt: processedAt,
 	})
 }
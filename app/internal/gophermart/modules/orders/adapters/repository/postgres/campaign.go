@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter"
+	"gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+)
+
+// CampaignRepository is a PostgreSQL implementation of port.CampaignRepository.
+type CampaignRepository struct {
+	transactor *postgreskit.Transactor
+	conv       converter.CampaignConverter
+}
+
+// NewCampaignRepository creates a new CampaignRepository.
+func NewCampaignRepository(transactor *postgreskit.Transactor) *CampaignRepository {
+	return &CampaignRepository{
+		transactor: transactor,
+		conv:       &converter.CampaignConverterImpl{},
+	}
+}
+
+// Create inserts the campaign and sets c.ID.
+func (r *CampaignRepository) Create(ctx context.Context, c *entity.Campaign) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbCampaign := r.conv.ToModel(*c)
+
+		return q.QueryRow(ctx, `
+			INSERT INTO campaigns (name, kind, multiplier, bonus, first_order_only, starts_at, ends_at, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id
+		`, dbCampaign.Name, dbCampaign.Kind, dbCampaign.Multiplier, dbCampaign.Bonus, dbCampaign.FirstOrderOnly,
+			dbCampaign.StartsAt, dbCampaign.EndsAt, dbCampaign.CreatedAt, dbCampaign.UpdatedAt).Scan(&c.ID)
+	})
+}
+
+// Update overwrites the campaign settings; updated_at is maintained by a trigger.
+func (r *CampaignRepository) Update(ctx context.Context, c *entity.Campaign) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+		dbCampaign := r.conv.ToModel(*c)
+
+		tag, err := q.Exec(ctx, `
+			UPDATE campaigns
+			SET name = $1, kind = $2, multiplier = $3, bonus = $4, first_order_only = $5,
+			    starts_at = $6, ends_at = $7
+			WHERE id = $8
+		`, dbCampaign.Name, dbCampaign.Kind, dbCampaign.Multiplier, dbCampaign.Bonus, dbCampaign.FirstOrderOnly,
+			dbCampaign.StartsAt, dbCampaign.EndsAt, dbCampaign.ID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// Delete removes the campaign. Bonuses it credited keep their copy of the campaign name.
+func (r *CampaignRepository) Delete(ctx context.Context, id int64) error {
+	return r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		tag, err := q.Exec(ctx, `DELETE FROM campaigns WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return application.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// FindByID returns the campaign or application.ErrNotFound.
+func (r *CampaignRepository) FindByID(ctx context.Context, id int64) (*entity.Campaign, error) {
+	campaigns, err := r.list(ctx, `
+		SELECT id, name, kind, multiplier, bonus, first_order_only, starts_at, ends_at, created_at, updated_at
+		FROM campaigns
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(campaigns) == 0 {
+		return nil, application.ErrNotFound
+	}
+	return &campaigns[0], nil
+}
+
+// List returns all campaigns, latest start first.
+func (r *CampaignRepository) List(ctx context.Context) ([]entity.Campaign, error) {
+	return r.list(ctx, `
+		SELECT id, name, kind, multiplier, bonus, first_order_only, starts_at, ends_at, created_at, updated_at
+		FROM campaigns
+		ORDER BY starts_at DESC, id DESC
+	`)
+}
+
+// ListActive returns the campaigns whose period contains t, in creation order.
+func (r *CampaignRepository) ListActive(ctx context.Context, t time.Time) ([]entity.Campaign, error) {
+	return r.list(ctx, `
+		SELECT id, name, kind, multiplier, bonus, first_order_only, starts_at, ends_at, created_at, updated_at
+		FROM campaigns
+		WHERE starts_at <= $1 AND ends_at > $1
+		ORDER BY id
+	`, t)
+}
+
+func (r *CampaignRepository) list(ctx context.Context, query string, args ...any) ([]entity.Campaign, error) {
+	var result []entity.Campaign
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRows, err := pgx.CollectRows(rows, pgx.RowToStructByPos[model.Campaign])
+		if err != nil {
+			return err
+		}
+
+		result = result[:0]
+		for _, dbRow := range dbRows {
+			result = append(result, r.conv.ToEntity(dbRow))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+)
+
+//go:generate goverter gen .
+
+// goverter:converter
+// goverter:output:file campaign_gen.go
+// goverter:output:package converter
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:CopyTime
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:CampaignKindFromDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:CampaignKindToDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:MultiplierFromDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:MultiplierToDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:OptionalPointsFromDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:OptionalPointsToDB
+type CampaignConverter interface {
+	ToEntity(source model.Campaign) entity.Campaign
+	ToModel(source entity.Campaign) model.Campaign
+}
@@ -0,0 +1,41 @@
+// Code generated by github.com/jmattheis/goverter, DO NOT EDIT.
+//go:build !goverter
+
+package converter
+
+import (
+	convext "gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext"
+	model "gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/model"
+	entity "gophermart/internal/gophermart/modules/orders/domain/entity"
+)
+
+type CampaignConverterImpl struct{}
+
+func (c *CampaignConverterImpl) ToEntity(source model.Campaign) entity.Campaign {
+	var entityCampaign entity.Campaign
+	entityCampaign.ID = source.ID
+	entityCampaign.Name = source.Name
+	entityCampaign.Kind = convext.CampaignKindFromDB(source.Kind)
+	entityCampaign.Multiplier = convext.MultiplierFromDB(source.Multiplier)
+	entityCampaign.Bonus = convext.OptionalPointsFromDB(source.Bonus)
+	entityCampaign.FirstOrderOnly = source.FirstOrderOnly
+	entityCampaign.StartsAt = convext.CopyTime(source.StartsAt)
+	entityCampaign.EndsAt = convext.CopyTime(source.EndsAt)
+	entityCampaign.CreatedAt = convext.CopyTime(source.CreatedAt)
+	entityCampaign.UpdatedAt = convext.CopyTime(source.UpdatedAt)
+	return entityCampaign
+}
+func (c *CampaignConverterImpl) ToModel(source entity.Campaign) model.Campaign {
+	var modelCampaign model.Campaign
+	modelCampaign.ID = source.ID
+	modelCampaign.Name = source.Name
+	modelCampaign.Kind = convext.CampaignKindToDB(source.Kind)
+	modelCampaign.Multiplier = convext.MultiplierToDB(source.Multiplier)
+	modelCampaign.Bonus = convext.OptionalPointsToDB(source.Bonus)
+	modelCampaign.FirstOrderOnly = source.FirstOrderOnly
+	modelCampaign.StartsAt = convext.CopyTime(source.StartsAt)
+	modelCampaign.EndsAt = convext.CopyTime(source.EndsAt)
+	modelCampaign.CreatedAt = convext.CopyTime(source.CreatedAt)
+	modelCampaign.UpdatedAt = convext.CopyTime(source.UpdatedAt)
+	return modelCampaign
+}
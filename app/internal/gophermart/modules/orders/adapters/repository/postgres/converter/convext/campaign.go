@@ -0,0 +1,48 @@
+package convext
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+func CampaignKindFromDB(v string) entity.CampaignKind {
+	return entity.CampaignKind(v)
+}
+
+func CampaignKindToDB(v entity.CampaignKind) string {
+	return string(v)
+}
+
+// MultiplierFromDB maps NULL to zero, the multiplier of a non-MULTIPLIER campaign.
+func MultiplierFromDB(v pgtype.Numeric) vo.Multiplier {
+	if !v.Valid {
+		return 0
+	}
+	return vo.Multiplier(postgreskit.MinorFromNumeric(v))
+}
+
+func MultiplierToDB(v vo.Multiplier) pgtype.Numeric {
+	if v == 0 {
+		return pgtype.Numeric{}
+	}
+	return postgreskit.NumericFromMinor(int64(v))
+}
+
+// OptionalPointsFromDB maps NULL to zero points.
+func OptionalPointsFromDB(v pgtype.Numeric) vo.Points {
+	if !v.Valid {
+		return 0
+	}
+	return vo.Points(postgreskit.MinorFromNumeric(v))
+}
+
+// OptionalPointsToDB maps zero points to NULL.
+func OptionalPointsToDB(v vo.Points) pgtype.Numeric {
+	if v == 0 {
+		return pgtype.Numeric{}
+	}
+	return postgreskit.NumericFromMinor(int64(v))
+}
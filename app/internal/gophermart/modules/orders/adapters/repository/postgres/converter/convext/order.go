@@ -3,6 +3,9 @@ package convext
 import (
 	"fmt"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
+	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
 	"gophermart/internal/gophermart/modules/orders/domain/entity"
 	"gophermart/internal/gophermart/modules/orders/domain/vo"
 )
@@ -37,18 +40,64 @@ func StatusToDB(v entity.OrderStatus) (int16, error) {
 	}
 }
 
-func PointsFromDB(v *float64) *vo.Points {
+func NullableStatusFromDB(v *int16) (entity.OrderStatus, error) {
+	if v == nil {
+		return "", nil
+	}
+	return StatusFromDB(*v)
+}
+
+func NullableStatusToDB(v entity.OrderStatus) (*int16, error) {
+	if v == "" {
+		return nil, nil
+	}
+	code, err := StatusToDB(v)
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func NullableStringFromDB(v *string) string {
 	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func NullableStringToDB(v string) *string {
+	if v == "" {
 		return nil
 	}
-	p := vo.Points(*v)
+	return &v
+}
+
+func PointsFromDB(v pgtype.Numeric) *vo.Points {
+	if !v.Valid {
+		return nil
+	}
+	p := vo.Points(postgreskit.MinorFromNumeric(v))
 	return &p
 }
 
-func PointsToDB(v *vo.Points) *float64 {
+func PointsToDB(v *vo.Points) pgtype.Numeric {
 	if v == nil {
+		return pgtype.Numeric{}
+	}
+	return postgreskit.NumericFromMinor(int64(*v))
+}
+
+func InvalidReasonFromDB(v *string) entity.InvalidReason {
+	if v == nil {
+		return ""
+	}
+	return entity.InvalidReason(*v)
+}
+
+func InvalidReasonToDB(v entity.InvalidReason) *string {
+	if v == "" {
 		return nil
 	}
-	f := float64(*v)
-	return &f
+	s := string(v)
+	return &s
 }
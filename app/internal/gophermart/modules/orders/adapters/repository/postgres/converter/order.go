@@ -15,9 +15,25 @@ import (
 // goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:StatusToDB
 // goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:PointsFromDB
 // goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:PointsToDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:OptionalPointsFromDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:OptionalPointsToDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:InvalidReasonFromDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:InvalidReasonToDB
 // goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:CopyTime
 // goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:CopyTimePtr
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:NullableStatusFromDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:NullableStatusToDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:NullableStringFromDB
+// goverter:extend gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter/convext:NullableStringToDB
 type OrderConverter interface {
+	// goverter:ignore Changes Bonuses Lease
 	ToEntity(source model.Order) (entity.Order, error)
 	ToModel(source entity.Order) (model.Order, error)
+	// goverter:map ChangedAt At
+	StatusChangeToEntity(source model.StatusChange) (entity.StatusChange, error)
+	// goverter:map At ChangedAt
+	StatusChangeToModel(source entity.StatusChange) (model.StatusChange, error)
+	BonusToEntity(source model.OrderBonus) entity.OrderBonus
+	// goverter:ignore OrderNumber
+	BonusToModel(source entity.OrderBonus) model.OrderBonus
 }
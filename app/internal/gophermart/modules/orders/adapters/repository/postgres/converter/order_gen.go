@@ -12,6 +12,54 @@ import (
 
 type OrderConverterImpl struct{}
 
+func (c *OrderConverterImpl) BonusToEntity(source model.OrderBonus) entity.OrderBonus {
+	var entityOrderBonus entity.OrderBonus
+	entityOrderBonus.CampaignID = source.CampaignID
+	entityOrderBonus.CampaignName = source.CampaignName
+	entityOrderBonus.Amount = convext.OptionalPointsFromDB(source.Amount)
+	return entityOrderBonus
+}
+func (c *OrderConverterImpl) BonusToModel(source entity.OrderBonus) model.OrderBonus {
+	var modelOrderBonus model.OrderBonus
+	modelOrderBonus.CampaignID = source.CampaignID
+	modelOrderBonus.CampaignName = source.CampaignName
+	modelOrderBonus.Amount = convext.OptionalPointsToDB(source.Amount)
+	return modelOrderBonus
+}
+func (c *OrderConverterImpl) StatusChangeToEntity(source model.StatusChange) (entity.StatusChange, error) {
+	var entityStatusChange entity.StatusChange
+	entityOrderStatus, err := convext.NullableStatusFromDB(source.From)
+	if err != nil {
+		return entityStatusChange, err
+	}
+	entityStatusChange.From = entityOrderStatus
+	entityOrderStatus2, err := convext.StatusFromDB(source.To)
+	if err != nil {
+		return entityStatusChange, err
+	}
+	entityStatusChange.To = entityOrderStatus2
+	entityStatusChange.Source = entity.StatusSource(source.Source)
+	entityStatusChange.RawStatus = convext.NullableStringFromDB(source.RawStatus)
+	entityStatusChange.At = convext.CopyTime(source.ChangedAt)
+	return entityStatusChange, nil
+}
+func (c *OrderConverterImpl) StatusChangeToModel(source entity.StatusChange) (model.StatusChange, error) {
+	var modelStatusChange model.StatusChange
+	pInt16, err := convext.NullableStatusToDB(source.From)
+	if err != nil {
+		return modelStatusChange, err
+	}
+	modelStatusChange.From = pInt16
+	xint16, err := convext.StatusToDB(source.To)
+	if err != nil {
+		return modelStatusChange, err
+	}
+	modelStatusChange.To = xint16
+	modelStatusChange.Source = string(source.Source)
+	modelStatusChange.RawStatus = convext.NullableStringToDB(source.RawStatus)
+	modelStatusChange.ChangedAt = convext.CopyTime(source.At)
+	return modelStatusChange, nil
+}
 func (c *OrderConverterImpl) ToEntity(source model.Order) (entity.Order, error) {
 	var entityOrder entity.Order
 	entityOrder.Number = vo.OrderNumber(source.Number)
@@ -24,6 +72,9 @@ func (c *OrderConverterImpl) ToEntity(source model.Order) (entity.Order, error)
 	entityOrder.Accrual = convext.PointsFromDB(source.Accrual)
 	entityOrder.UploadedAt = convext.CopyTime(source.UploadedAt)
 	entityOrder.ProcessedAt = convext.CopyTimePtr(source.ProcessedAt)
+	entityOrder.InvalidReason = convext.InvalidReasonFromDB(source.InvalidReason)
+	entityOrder.PollAttempts = source.PollAttempts
+	entityOrder.NextPollAt = convext.CopyTime(source.NextPollAt)
 	return entityOrder, nil
 }
 func (c *OrderConverterImpl) ToModel(source entity.Order) (model.Order, error) {
@@ -38,5 +89,8 @@ func (c *OrderConverterImpl) ToModel(source entity.Order) (model.Order, error) {
 	modelOrder.Accrual = convext.PointsToDB(source.Accrual)
 	modelOrder.UploadedAt = convext.CopyTime(source.UploadedAt)
 	modelOrder.ProcessedAt = convext.CopyTimePtr(source.ProcessedAt)
+	modelOrder.InvalidReason = convext.InvalidReasonToDB(source.InvalidReason)
+	modelOrder.PollAttempts = source.PollAttempts
+	modelOrder.NextPollAt = convext.CopyTime(source.NextPollAt)
 	return modelOrder, nil
 }
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Campaign is the DB projection of the campaigns table row.
+type Campaign struct {
+	ID             int64
+	Name           string
+	Kind           string
+	Multiplier     pgtype.Numeric
+	Bonus          pgtype.Numeric
+	FirstOrderOnly bool
+	StartsAt       time.Time
+	EndsAt         time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
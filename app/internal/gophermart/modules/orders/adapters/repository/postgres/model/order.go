@@ -3,15 +3,27 @@ package model
 import (
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
 	"gophermart/internal/gophermart/modules/orders/domain/vo"
 )
 
 // Order is the DB projection of the orders table row.
 type Order struct {
-	Number      vo.OrderNumber
-	UserID      vo.UserID
-	Status      int16
-	Accrual     *float64
-	UploadedAt  time.Time
-	ProcessedAt *time.Time
+	Number        vo.OrderNumber
+	UserID        vo.UserID
+	Status        int16
+	Accrual       pgtype.Numeric
+	UploadedAt    time.Time
+	ProcessedAt   *time.Time
+	InvalidReason *string
+	PollAttempts  int
+	NextPollAt    time.Time
+}
+
+// ClaimedOrder is an orders table row returned by a poll claim, with its new lease.
+type ClaimedOrder struct {
+	Order
+	LeaseOwner     string
+	LeaseExpiresAt time.Time
 }
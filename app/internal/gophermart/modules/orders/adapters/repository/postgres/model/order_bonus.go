@@ -0,0 +1,15 @@
+package model
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+// OrderBonus is the DB projection of the order_bonuses table row joined with its order number.
+type OrderBonus struct {
+	OrderNumber  vo.OrderNumber
+	CampaignID   int64
+	CampaignName string
+	Amount       pgtype.Numeric
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// StatusChange is the DB projection of the order_status_history table row.
+type StatusChange struct {
+	From      *int16
+	To        int16
+	Source    string
+	RawStatus *string
+	ChangedAt time.Time
+}
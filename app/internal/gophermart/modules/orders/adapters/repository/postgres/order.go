@@ -5,14 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	postgreskit "gophermart/internal/gophermart/adapters/repository/postgres"
 	"gophermart/internal/gophermart/application"
 	"gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/converter"
 	"gophermart/internal/gophermart/modules/orders/adapters/repository/postgres/model"
+	"gophermart/internal/gophermart/modules/orders/application/port"
 	"gophermart/internal/gophermart/modules/orders/domain/entity"
 	"gophermart/internal/gophermart/modules/orders/domain/vo"
 )
@@ -25,6 +28,19 @@ var statusToInt = map[entity.OrderStatus]int16{
 	entity.OrderStatusProcessed:  3,
 }
 
+// statusCodes maps domain statuses to their DB SMALLINT codes.
+func statusCodes(statuses []entity.OrderStatus) ([]int16, error) {
+	ints := make([]int16, len(statuses))
+	for i, s := range statuses {
+		v, ok := statusToInt[s]
+		if !ok {
+			return nil, fmt.Errorf("unknown order status: %s", s)
+		}
+		ints[i] = v
+	}
+	return ints, nil
+}
+
 // OrderRepository is a PostgreSQL implementation of port.OrderRepository.
 type OrderRepository struct {
 	transactor *postgreskit.Transactor
@@ -39,19 +55,33 @@ func NewOrderRepository(transactor *postgreskit.Transactor) *OrderRepository {
 	}
 }
 
-// Create inserts a new order.
+// Create inserts a new order together with its recorded status changes.
 func (r *OrderRepository) Create(ctx context.Context, o *entity.Order) error {
-	return r.transactor.DoWithRetry(ctx, func() error {
+	dbOrder, err := r.conv.ToModel(*o)
+	if err != nil {
+		return err
+	}
+	h, err := r.historyColumns(o.Changes)
+	if err != nil {
+		return err
+	}
+
+	err = r.transactor.DoWithRetry(ctx, func() error {
 		q := r.transactor.GetQuerier(ctx)
-		dbOrder, err := r.conv.ToModel(*o)
-		if err != nil {
-			return err
-		}
 
-		_, err = q.Exec(ctx, `
-			INSERT INTO orders (number, user_id, status, accrual, uploaded_at)
-			VALUES ($1, $2, $3, $4, $5)
-		`, dbOrder.Number, dbOrder.UserID, dbOrder.Status, dbOrder.Accrual, dbOrder.UploadedAt)
+		// A single statement keeps the order and its history atomic without an explicit transaction.
+		_, err := q.Exec(ctx, `
+			WITH ins AS (
+				INSERT INTO orders (number, user_id, status, accrual, uploaded_at, next_poll_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				RETURNING id
+			)
+			INSERT INTO order_status_history (order_id, from_status, to_status, source, raw_status, changed_at)
+			SELECT ins.id, h.from_status, h.to_status, h.source, h.raw_status, h.changed_at
+			FROM ins, unnest($7::SMALLINT[], $8::SMALLINT[], $9::TEXT[], $10::TEXT[], $11::TIMESTAMPTZ[])
+			     AS h(from_status, to_status, source, raw_status, changed_at)
+		`, dbOrder.Number, dbOrder.UserID, dbOrder.Status, dbOrder.Accrual, dbOrder.UploadedAt, dbOrder.NextPollAt,
+			h.from, h.to, h.source, h.rawStatus, h.changedAt)
 		if err != nil {
 			var pgErr *pgconn.PgError
 			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -62,9 +92,15 @@ func (r *OrderRepository) Create(ctx context.Context, o *entity.Order) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	o.Changes = nil
+	return nil
 }
 
-// FindByNumber returns the order by its number or application.ErrNotFound.
+// FindByNumber returns the order with its campaign bonuses by its number or application.ErrNotFound.
 func (r *OrderRepository) FindByNumber(ctx context.Context, number vo.OrderNumber) (*entity.Order, error) {
 	var o entity.Order
 
@@ -72,7 +108,8 @@ func (r *OrderRepository) FindByNumber(ctx context.Context, number vo.OrderNumbe
 		q := r.transactor.GetQuerier(ctx)
 
 		rows, err := q.Query(ctx, `
-			SELECT number, user_id, status, accrual, uploaded_at, processed_at
+			SELECT number, user_id, status, accrual, uploaded_at, processed_at,
+			       invalid_reason, poll_attempts, next_poll_at
 			FROM orders
 			WHERE number = $1
 		`, number.String())
@@ -87,7 +124,16 @@ func (r *OrderRepository) FindByNumber(ctx context.Context, number vo.OrderNumbe
 		}
 
 		o, err = r.conv.ToEntity(dbRow)
-		return err
+		if err != nil {
+			return err
+		}
+
+		orders := []entity.Order{o}
+		if err := r.attachBonuses(ctx, orders); err != nil {
+			return err
+		}
+		o = orders[0]
+		return nil
 	})
 
 	if err != nil {
@@ -100,19 +146,42 @@ func (r *OrderRepository) FindByNumber(ctx context.Context, number vo.OrderNumbe
 	return &o, nil
 }
 
-// ListByUserID returns all orders for the given user, sorted by uploaded_at DESC.
-func (r *OrderRepository) ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Order, error) {
+// ListByUserID returns the user's orders matching the filter with their campaign bonuses,
+// sorted by uploaded_at DESC, number DESC.
+func (r *OrderRepository) ListByUserID(ctx context.Context, userID vo.UserID, filter port.OrderFilter) ([]entity.Order, error) {
+	ints, err := statusCodes(filter.Statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	var afterAt *time.Time
+	var afterNumber *string
+	if filter.After != nil {
+		afterAt, afterNumber = &filter.After.At, &filter.After.Key
+	}
+	var limit *int
+	if filter.Limit > 0 {
+		limit = &filter.Limit
+	}
+
 	var result []entity.Order
 
-	err := r.transactor.DoWithRetry(ctx, func() error {
+	err = r.transactor.DoWithRetry(ctx, func() error {
 		q := r.transactor.GetQuerier(ctx)
 
+		// NULL parameters disable their condition; LIMIT NULL returns all rows.
 		rows, err := q.Query(ctx, `
-			SELECT number, user_id, status, accrual, uploaded_at, processed_at
+			SELECT number, user_id, status, accrual, uploaded_at, processed_at,
+			       invalid_reason, poll_attempts, next_poll_at
 			FROM orders
 			WHERE user_id = $1
-			ORDER BY uploaded_at DESC
-		`, userID)
+			  AND (cardinality($2::SMALLINT[]) = 0 OR status = ANY($2))
+			  AND ($3::TIMESTAMPTZ IS NULL OR uploaded_at >= $3)
+			  AND ($4::TIMESTAMPTZ IS NULL OR uploaded_at < $4)
+			  AND ($5::TIMESTAMPTZ IS NULL OR (uploaded_at, number) < ($5, $6::TEXT))
+			ORDER BY uploaded_at DESC, number DESC
+			LIMIT $7
+		`, userID, ints, filter.From, filter.To, afterAt, afterNumber, limit)
 		if err != nil {
 			return err
 		}
@@ -132,7 +201,7 @@ func (r *OrderRepository) ListByUserID(ctx context.Context, userID vo.UserID) ([
 			result = append(result, o)
 		}
 
-		return nil
+		return r.attachBonuses(ctx, result)
 	})
 
 	if err != nil {
@@ -142,33 +211,22 @@ func (r *OrderRepository) ListByUserID(ctx context.Context, userID vo.UserID) ([
 	return result, nil
 }
 
-// ListByStatuses returns orders matching any of the given statuses, limited by limit.
-func (r *OrderRepository) ListByStatuses(ctx context.Context, statuses []entity.OrderStatus, limit int) ([]entity.Order, error) {
-	if len(statuses) == 0 {
-		return nil, nil
-	}
-
-	ints := make([]int16, len(statuses))
-	for i, s := range statuses {
-		v, ok := statusToInt[s]
-		if !ok {
-			return nil, fmt.Errorf("unknown order status: %s", s)
-		}
-		ints[i] = v
-	}
-
+// ListAccruedByUserID returns the user's processed orders with a positive accrual
+// processed in [from, to), sorted by processed_at ASC.
+func (r *OrderRepository) ListAccruedByUserID(ctx context.Context, userID vo.UserID, from, to time.Time) ([]entity.Order, error) {
 	var result []entity.Order
 
 	err := r.transactor.DoWithRetry(ctx, func() error {
 		q := r.transactor.GetQuerier(ctx)
 
 		rows, err := q.Query(ctx, `
-			SELECT number, user_id, status, accrual, uploaded_at, processed_at
+			SELECT number, user_id, status, accrual, uploaded_at, processed_at,
+			       invalid_reason, poll_attempts, next_poll_at
 			FROM orders
-			WHERE status = ANY($1)
-			ORDER BY uploaded_at ASC
-			LIMIT $2
-		`, ints, limit)
+			WHERE user_id = $1 AND status = $2 AND accrual > 0
+			  AND processed_at >= $3 AND processed_at < $4
+			ORDER BY processed_at, number
+		`, userID, statusToInt[entity.OrderStatusProcessed], from, to)
 		if err != nil {
 			return err
 		}
@@ -179,7 +237,7 @@ func (r *OrderRepository) ListByStatuses(ctx context.Context, statuses []entity.
 			return err
 		}
 
-		result = result[:0]
+		result = result[:0] // reset on retry
 		for _, dbRow := range dbRows {
 			o, err := r.conv.ToEntity(dbRow)
 			if err != nil {
@@ -198,33 +256,92 @@ func (r *OrderRepository) ListByStatuses(ctx context.Context, statuses []entity.
 	return result, nil
 }
 
-// StreamByStatuses returns a lazy iterator over orders matching the given statuses.
-// The DB cursor remains open while the caller iterates; rows are closed automatically
-// when the iterator is exhausted or the caller breaks out of the range loop.
-func (r *OrderRepository) StreamByStatuses(ctx context.Context, statuses []entity.OrderStatus, limit int) iter.Seq2[entity.Order, error] {
+// HasProcessed reports whether the user has any processed order.
+func (r *OrderRepository) HasProcessed(ctx context.Context, userID vo.UserID) (bool, error) {
+	var exists bool
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		return q.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM orders WHERE user_id = $1 AND status = $2)
+		`, userID, statusToInt[entity.OrderStatusProcessed]).Scan(&exists)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// ClaimFirstOrder inserts the user's first-order claim for the order; false if the user already has one.
+// A conflicting insert waits for the transaction holding the claim, so the loser sees it committed.
+func (r *OrderRepository) ClaimFirstOrder(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) (bool, error) {
+	var claimed bool
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		tag, err := q.Exec(ctx, `
+			INSERT INTO first_order_claims (user_id, order_number)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO NOTHING
+		`, userID, orderNumber)
+		if err != nil {
+			return err
+		}
+
+		claimed = tag.RowsAffected() == 1
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return claimed, nil
+}
+
+// ClaimByStatuses leases due orders matching the given statuses to owner and yields them.
+// Rows locked by a concurrent claim are skipped (FOR UPDATE SKIP LOCKED), rows leased by
+// another instance are skipped until lease_expires_at passes, so a crashed instance's
+// batch is reclaimed automatically once its leases expire. Orders are yielded with their Lease.
+func (r *OrderRepository) ClaimByStatuses(
+	ctx context.Context,
+	statuses []entity.OrderStatus,
+	owner string,
+	now time.Time,
+	leaseTTL time.Duration,
+	limit int,
+) iter.Seq2[entity.Order, error] {
 	return func(yield func(entity.Order, error) bool) {
 		if len(statuses) == 0 {
 			return
 		}
 
-		ints := make([]int16, len(statuses))
-		for i, s := range statuses {
-			v, ok := statusToInt[s]
-			if !ok {
-				yield(entity.Order{}, fmt.Errorf("unknown order status: %s", s))
-				return
-			}
-			ints[i] = v
+		ints, err := statusCodes(statuses)
+		if err != nil {
+			yield(entity.Order{}, err)
+			return
 		}
 
 		q := r.transactor.GetQuerier(ctx)
 		rows, err := q.Query(ctx, `
-			SELECT number, user_id, status, accrual, uploaded_at, processed_at
-			FROM orders
-			WHERE status = ANY($1)
-			ORDER BY uploaded_at ASC
-			LIMIT $2
-		`, ints, limit)
+			UPDATE orders o
+			SET lease_owner = $1, lease_expires_at = $2
+			FROM (
+				SELECT id
+				FROM orders
+				WHERE status = ANY($3) AND next_poll_at <= $4
+				  AND (lease_expires_at IS NULL OR lease_expires_at <= $4)
+				ORDER BY next_poll_at ASC
+				LIMIT $5
+				FOR UPDATE SKIP LOCKED
+			) due
+			WHERE o.id = due.id
+			RETURNING o.number, o.user_id, o.status, o.accrual, o.uploaded_at, o.processed_at,
+			          o.invalid_reason, o.poll_attempts, o.next_poll_at,
+			          o.lease_owner, o.lease_expires_at
+		`, owner, now.Add(leaseTTL), ints, now, limit)
 		if err != nil {
 			yield(entity.Order{}, err)
 			return
@@ -232,17 +349,19 @@ func (r *OrderRepository) StreamByStatuses(ctx context.Context, statuses []entit
 		defer rows.Close()
 
 		for rows.Next() {
-			dbRow, err := pgx.RowToStructByPos[model.Order](rows)
+			dbRow, err := pgx.RowToStructByPos[model.ClaimedOrder](rows)
 			if err != nil {
 				yield(entity.Order{}, err)
 				return
 			}
 
-			o, err := r.conv.ToEntity(dbRow)
+			o, err := r.conv.ToEntity(dbRow.Order)
 			if err != nil {
 				yield(entity.Order{}, err)
 				return
 			}
+			// The stored expiry, not now+leaseTTL: Update compares it at the database precision.
+			o.Lease = &entity.PollLease{Owner: dbRow.LeaseOwner, ExpiresAt: dbRow.LeaseExpiresAt}
 
 			if !yield(o, nil) {
 				return
@@ -255,21 +374,212 @@ func (r *OrderRepository) StreamByStatuses(ctx context.Context, statuses []entit
 	}
 }
 
-// Update updates the order status, accrual, and processed_at.
+// Update updates the order status, accrual, processed_at and poll schedule,
+// appends its recorded status changes to the history, stores its campaign bonuses
+// and releases the poll lease.
+// The update is a compare-and-set on the status the order was loaded with, so a stale copy
+// can neither regress nor re-finalize an order moved on by a concurrent processor.
+// A claimed order must also still hold its lease, so a processor whose lease expired
+// cannot overwrite the result of the claim that took the order over.
+//
+// Errors:
+//   - application.ErrConflict — the order does not exist, its stored status has changed
+//     or its lease has passed to another claim
 func (r *OrderRepository) Update(ctx context.Context, o *entity.Order) error {
-	return r.transactor.DoWithRetry(ctx, func() error {
+	dbOrder, err := r.conv.ToModel(*o)
+	if err != nil {
+		return err
+	}
+	prevStatus, ok := statusToInt[o.PersistedStatus()]
+	if !ok {
+		return fmt.Errorf("unknown order status %q", o.PersistedStatus())
+	}
+	h, err := r.historyColumns(o.Changes)
+	if err != nil {
+		return err
+	}
+	b := r.bonusColumns(o.Bonuses)
+	var leaseOwner *string
+	var leaseExpiresAt *time.Time
+	if o.Lease != nil {
+		leaseOwner, leaseExpiresAt = &o.Lease.Owner, &o.Lease.ExpiresAt
+	}
+
+	err = r.transactor.DoWithRetry(ctx, func() error {
 		q := r.transactor.GetQuerier(ctx)
-		dbOrder, err := r.conv.ToModel(*o)
+
+		var updated int
+		err := q.QueryRow(ctx, `
+			WITH upd AS (
+				UPDATE orders
+				SET status = $1, accrual = $2, processed_at = $3,
+				    invalid_reason = $4, poll_attempts = $5, next_poll_at = $6,
+				    lease_owner = NULL, lease_expires_at = NULL
+				WHERE number = $7 AND status = $8
+				  AND ($17::TEXT IS NULL OR (lease_owner = $17 AND lease_expires_at = $18))
+				RETURNING id
+			), hist AS (
+				INSERT INTO order_status_history (order_id, from_status, to_status, source, raw_status, changed_at)
+				SELECT upd.id, h.from_status, h.to_status, h.source, h.raw_status, h.changed_at
+				FROM upd, unnest($9::SMALLINT[], $10::SMALLINT[], $11::TEXT[], $12::TEXT[], $13::TIMESTAMPTZ[])
+				     AS h(from_status, to_status, source, raw_status, changed_at)
+			), bonus AS (
+				INSERT INTO order_bonuses (order_id, campaign_id, campaign_name, amount)
+				SELECT upd.id, b.campaign_id, b.campaign_name, b.amount
+				FROM upd, unnest($14::BIGINT[], $15::TEXT[], $16::NUMERIC[]) AS b(campaign_id, campaign_name, amount)
+				ON CONFLICT (order_id, campaign_id) DO NOTHING
+			)
+			SELECT count(*) FROM upd
+		`, dbOrder.Status, dbOrder.Accrual, dbOrder.ProcessedAt,
+			dbOrder.InvalidReason, dbOrder.PollAttempts, dbOrder.NextPollAt, dbOrder.Number, prevStatus,
+			h.from, h.to, h.source, h.rawStatus, h.changedAt,
+			b.campaignID, b.campaignName, b.amount, leaseOwner, leaseExpiresAt).Scan(&updated)
 		if err != nil {
 			return err
 		}
+		if updated == 0 {
+			return application.ErrConflict
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-		_, err = q.Exec(ctx, `
-			UPDATE orders
-			SET status = $1, accrual = $2, processed_at = $3
-			WHERE number = $4
-		`, dbOrder.Status, dbOrder.Accrual, dbOrder.ProcessedAt, dbOrder.Number)
+	o.Changes = nil
+	return nil
+}
 
-		return err
+// StatusHistory returns the status timeline of the order, oldest first.
+func (r *OrderRepository) StatusHistory(ctx context.Context, number vo.OrderNumber) ([]entity.StatusChange, error) {
+	var result []entity.StatusChange
+
+	err := r.transactor.DoWithRetry(ctx, func() error {
+		q := r.transactor.GetQuerier(ctx)
+
+		rows, err := q.Query(ctx, `
+			SELECT h.from_status, h.to_status, h.source, h.raw_status, h.changed_at
+			FROM order_status_history h
+			JOIN orders o ON o.id = h.order_id
+			WHERE o.number = $1
+			ORDER BY h.changed_at ASC, h.id ASC
+		`, number.String())
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dbRows, err := pgx.CollectRows(rows, pgx.RowToStructByPos[model.StatusChange])
+		if err != nil {
+			return err
+		}
+
+		result = result[:0]
+		for _, dbRow := range dbRows {
+			c, err := r.conv.StatusChangeToEntity(dbRow)
+			if err != nil {
+				return err
+			}
+			result = append(result, c)
+		}
+
+		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// historyColumns holds status changes as column arrays for a single unnest-based insert.
+type historyColumns struct {
+	from      []*int16
+	to        []int16
+	source    []string
+	rawStatus []*string
+	changedAt []time.Time
+}
+
+func (r *OrderRepository) historyColumns(changes []entity.StatusChange) (historyColumns, error) {
+	h := historyColumns{
+		from:      make([]*int16, 0, len(changes)),
+		to:        make([]int16, 0, len(changes)),
+		source:    make([]string, 0, len(changes)),
+		rawStatus: make([]*string, 0, len(changes)),
+		changedAt: make([]time.Time, 0, len(changes)),
+	}
+	for _, c := range changes {
+		dbChange, err := r.conv.StatusChangeToModel(c)
+		if err != nil {
+			return historyColumns{}, err
+		}
+		h.from = append(h.from, dbChange.From)
+		h.to = append(h.to, dbChange.To)
+		h.source = append(h.source, dbChange.Source)
+		h.rawStatus = append(h.rawStatus, dbChange.RawStatus)
+		h.changedAt = append(h.changedAt, dbChange.ChangedAt)
+	}
+	return h, nil
+}
+
+// bonusColumns holds campaign bonuses as column arrays for a single unnest-based insert.
+type bonusColumns struct {
+	campaignID   []int64
+	campaignName []string
+	amount       []pgtype.Numeric
+}
+
+func (r *OrderRepository) bonusColumns(bonuses []entity.OrderBonus) bonusColumns {
+	b := bonusColumns{
+		campaignID:   make([]int64, 0, len(bonuses)),
+		campaignName: make([]string, 0, len(bonuses)),
+		amount:       make([]pgtype.Numeric, 0, len(bonuses)),
+	}
+	for _, bonus := range bonuses {
+		dbBonus := r.conv.BonusToModel(bonus)
+		b.campaignID = append(b.campaignID, dbBonus.CampaignID)
+		b.campaignName = append(b.campaignName, dbBonus.CampaignName)
+		b.amount = append(b.amount, dbBonus.Amount)
+	}
+	return b
+}
+
+// attachBonuses loads the campaign bonuses of the orders in one query.
+func (r *OrderRepository) attachBonuses(ctx context.Context, orders []entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	index := make(map[vo.OrderNumber]int, len(orders))
+	numbers := make([]string, 0, len(orders))
+	for i, o := range orders {
+		index[o.Number] = i
+		numbers = append(numbers, o.Number.String())
+	}
+
+	q := r.transactor.GetQuerier(ctx)
+	rows, err := q.Query(ctx, `
+		SELECT o.number, b.campaign_id, b.campaign_name, b.amount
+		FROM order_bonuses b
+		JOIN orders o ON o.id = b.order_id
+		WHERE o.number = ANY($1)
+		ORDER BY b.campaign_id
+	`, numbers)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	dbRows, err := pgx.CollectRows(rows, pgx.RowToStructByPos[model.OrderBonus])
+	if err != nil {
+		return err
+	}
+
+	for _, dbRow := range dbRows {
+		i := index[dbRow.OrderNumber]
+		orders[i].Bonuses = append(orders[i].Bonuses, r.conv.BonusToEntity(dbRow))
+	}
+	return nil
 }
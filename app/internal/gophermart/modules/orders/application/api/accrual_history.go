@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// ListAccrualsInput is a module API input for reading the accrual history of a user.
+// From is inclusive, To is exclusive.
+type ListAccrualsInput struct {
+	UserID int64
+	From   time.Time
+	To     time.Time
+}
+
+// AccrualRecord is a module API view of points accrued for a processed order.
+type AccrualRecord struct {
+	OrderNumber string
+	// Amount is in minor units (hundredths of a point).
+	Amount      int64
+	ProcessedAt time.Time
+}
+
+// AccrualHistoryAPI defines the accrual history contract exposed to other modules.
+type AccrualHistoryAPI interface {
+	// ListAccruals returns accruals of the user's orders processed in the range, oldest first.
+	ListAccruals(ctx context.Context, in ListAccrualsInput) ([]AccrualRecord, error)
+}
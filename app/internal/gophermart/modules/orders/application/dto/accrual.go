@@ -1,7 +1,16 @@
 package dto
 
+import "gophermart/internal/gophermart/modules/orders/domain/vo"
+
 // AccrualOrderInfo holds the response from the accrual system for a single order.
 type AccrualOrderInfo struct {
 	Status  string
-	Accrual *float64
+	Accrual *vo.Points
+}
+
+// AccrualPushInput is an order status pushed by the accrual system.
+type AccrualPushInput struct {
+	OrderNumber string
+	Status      string
+	Accrual     *vo.Points
 }
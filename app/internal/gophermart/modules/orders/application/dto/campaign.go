@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+// CampaignInput is the input for creating a bonus campaign.
+type CampaignInput struct {
+	Name string
+	// Kind is MULTIPLIER or BONUS; only the matching Multiplier or Bonus is set.
+	Kind           string
+	Multiplier     vo.Multiplier
+	Bonus          vo.Points
+	FirstOrderOnly bool
+	// StartsAt (inclusive) and EndsAt (exclusive) bound the processing time of credited orders.
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// UpdateCampaignInput is the input for replacing the settings of a bonus campaign.
+type UpdateCampaignInput struct {
+	ID int64
+	CampaignInput
+}
+
+// CampaignOutput is the output for a single bonus campaign.
+type CampaignOutput struct {
+	ID             int64
+	Name           string
+	Kind           string
+	Multiplier     vo.Multiplier
+	Bonus          vo.Points
+	FirstOrderOnly bool
+	StartsAt       time.Time
+	EndsAt         time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -12,10 +12,63 @@ type UploadOrderInput struct {
 	OrderNumber string
 }
 
+// ListOrdersInput is the input for listing the user's orders.
+type ListOrdersInput struct {
+	UserID vo.UserID
+	// Statuses keeps orders in any of the statuses; empty keeps all.
+	Statuses []string
+	// From (inclusive) and To (exclusive) bound the upload time.
+	From *time.Time
+	To   *time.Time
+	// Cursor continues a previous page; Limit is the page size.
+	// Without both the whole history is listed.
+	Cursor string
+	Limit  int
+}
+
+// OrderPageOutput is a page of the user's orders.
+type OrderPageOutput struct {
+	Orders []OrderOutput
+	// NextCursor continues the listing; empty on the last page.
+	NextCursor string
+}
+
 // OrderOutput is the output for a single order in the list.
 type OrderOutput struct {
-	Number     string
-	Status     string
-	Accrual    *float64
+	Number  string
+	Status  string
+	Accrual *vo.Points
+	// Bonuses are the campaign parts of Accrual.
+	Bonuses    []BonusOutput
 	UploadedAt time.Time
 }
+
+// BonusOutput is the part of an order accrual credited by a campaign.
+type BonusOutput struct {
+	CampaignID   int64
+	CampaignName string
+	Amount       vo.Points
+}
+
+// GetOrderInput is the input for fetching a single order of the user.
+type GetOrderInput struct {
+	UserID      vo.UserID
+	OrderNumber string
+}
+
+// StatusChangeOutput is a single entry of the order status timeline.
+type StatusChangeOutput struct {
+	From      string
+	To        string
+	Source    string
+	RawStatus string
+	At        time.Time
+}
+
+// OrderDetailsOutput is the output for a single order with its status timeline.
+type OrderDetailsOutput struct {
+	OrderOutput
+	InvalidReason string
+	ProcessedAt   *time.Time
+	History       []StatusChangeOutput
+}
@@ -1,7 +1,10 @@
 package factory
 
 import (
+	"time"
+
 	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/api"
 	"gophermart/internal/gophermart/modules/orders/application/dto"
 	"gophermart/internal/gophermart/modules/orders/application/port"
 	"gophermart/internal/gophermart/modules/orders/application/usecase"
@@ -11,6 +14,7 @@ import (
 // Params contains dependencies required to build orders use cases.
 type Params struct {
 	OrderRepo         port.OrderRepository
+	CampaignRepo      port.CampaignRepository
 	BalanceGateway    port.BalanceGateway
 	Validator         vo.OrderNumberValidator
 	AccrualClient     port.AccrualClient
@@ -20,13 +24,23 @@ type Params struct {
 	BatchSize         int
 	MaxWorkers        int
 	OptimisticRetries int
+	PollPolicy        vo.PollPolicy
+	LeaseOwner        string
+	LeaseTTL          time.Duration
 }
 
 // UseCases holds orders module use cases exposed to composition root.
 type UseCases struct {
 	UploadOrder    appport.UseCase[dto.UploadOrderInput, struct{}]
-	ListOrders     appport.UseCase[vo.UserID, []dto.OrderOutput]
+	ListOrders     appport.UseCase[dto.ListOrdersInput, dto.OrderPageOutput]
+	GetOrder       appport.UseCase[dto.GetOrderInput, dto.OrderDetailsOutput]
+	IngestAccrual  appport.UseCase[dto.AccrualPushInput, struct{}]
 	ProcessAccrual appport.BackgroundRunner
+	CreateCampaign appport.UseCase[dto.CampaignInput, dto.CampaignOutput]
+	UpdateCampaign appport.UseCase[dto.UpdateCampaignInput, dto.CampaignOutput]
+	DeleteCampaign appport.UseCase[int64, struct{}]
+	GetCampaign    appport.UseCase[int64, dto.CampaignOutput]
+	ListCampaigns  appport.UseCase[struct{}, []dto.CampaignOutput]
 }
 
 // NewUseCases builds orders module use cases.
@@ -34,9 +48,26 @@ func NewUseCases(p Params) UseCases {
 	return UseCases{
 		UploadOrder: usecase.NewUploadOrder(p.OrderRepo, p.OrderRepo, p.Validator, p.Clock),
 		ListOrders:  usecase.NewListOrders(p.OrderRepo),
+		GetOrder:    usecase.NewGetOrder(p.OrderRepo, p.Validator),
+		IngestAccrual: usecase.NewIngestAccrual(
+			p.OrderRepo, p.OrderRepo, p.CampaignRepo, p.BalanceGateway, p.Transactor,
+			p.Validator, p.Clock, p.OptimisticRetries, p.PollPolicy,
+		),
 		ProcessAccrual: usecase.NewProcessAccrual(
-			p.OrderRepo, p.OrderRepo, p.BalanceGateway, p.AccrualClient,
+			p.OrderRepo, p.OrderRepo, p.CampaignRepo, p.BalanceGateway, p.AccrualClient,
 			p.Transactor, p.Clock, p.Log, p.BatchSize, p.MaxWorkers, p.OptimisticRetries,
+			p.PollPolicy, p.LeaseOwner, p.LeaseTTL,
 		),
+		CreateCampaign: usecase.NewCreateCampaign(p.CampaignRepo, p.Clock),
+		UpdateCampaign: usecase.NewUpdateCampaign(p.CampaignRepo, p.CampaignRepo, p.Clock),
+		DeleteCampaign: usecase.NewDeleteCampaign(p.CampaignRepo),
+		GetCampaign:    usecase.NewGetCampaign(p.CampaignRepo),
+		ListCampaigns:  usecase.NewListCampaigns(p.CampaignRepo),
 	}
 }
+
+// NewAccrualHistory builds the orders accrual history API used by balance reconciliation.
+// The balance use cases are wired before the orders ones, so it is built from the order repository alone.
+func NewAccrualHistory(orderRepo port.OrderReader) api.AccrualHistoryAPI {
+	return usecase.NewAccrualHistory(orderRepo)
+}
@@ -9,5 +9,12 @@ import (
 
 // BalanceGateway is an orders-module port for cross-module balance accrual updates.
 type BalanceGateway interface {
-	ApplyAccrual(ctx context.Context, userID vo.UserID, amount vo.Points, processedAt time.Time) error
+	// ApplyAccrual credits the order accrual to the user; repeated calls for the same order are no-ops.
+	ApplyAccrual(
+		ctx context.Context,
+		userID vo.UserID,
+		orderNumber vo.OrderNumber,
+		amount vo.Points,
+		processedAt time.Time,
+	) error
 }
@@ -0,0 +1,35 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+)
+
+// CampaignReader provides read-only access to bonus campaigns.
+type CampaignReader interface {
+	// FindByID returns the campaign or application.ErrNotFound.
+	FindByID(ctx context.Context, id int64) (*entity.Campaign, error)
+	// List returns all campaigns, latest start first.
+	List(ctx context.Context) ([]entity.Campaign, error)
+	// ListActive returns the campaigns active at t.
+	ListActive(ctx context.Context, t time.Time) ([]entity.Campaign, error)
+}
+
+// CampaignWriter provides write access to bonus campaigns.
+type CampaignWriter interface {
+	// Create inserts the campaign and sets its ID.
+	Create(ctx context.Context, c *entity.Campaign) error
+	// Update overwrites the campaign or returns application.ErrNotFound.
+	Update(ctx context.Context, c *entity.Campaign) error
+	// Delete removes the campaign or returns application.ErrNotFound.
+	// Bonuses already credited by it are kept.
+	Delete(ctx context.Context, id int64) error
+}
+
+// CampaignRepository combines reader and writer for campaigns DI wiring.
+type CampaignRepository interface {
+	CampaignReader
+	CampaignWriter
+}
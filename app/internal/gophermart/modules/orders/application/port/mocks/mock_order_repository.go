@@ -11,10 +11,12 @@ package mocks
 
 import (
 	context "context"
+	port "gophermart/internal/gophermart/modules/orders/application/port"
 	entity "gophermart/internal/gophermart/modules/orders/domain/entity"
 	vo "gophermart/internal/gophermart/modules/orders/domain/vo"
 	iter "iter"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -58,48 +60,64 @@ func (mr *MockOrderReaderMockRecorder) FindByNumber(ctx, number any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNumber", reflect.TypeOf((*MockOrderReader)(nil).FindByNumber), ctx, number)
 }
 
-// ListByStatuses mocks base method.
-func (m *MockOrderReader) ListByStatuses(ctx context.Context, statuses []entity.OrderStatus, limit int) ([]entity.Order, error) {
+// HasProcessed mocks base method.
+func (m *MockOrderReader) HasProcessed(ctx context.Context, userID vo.UserID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByStatuses", ctx, statuses, limit)
+	ret := m.ctrl.Call(m, "HasProcessed", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasProcessed indicates an expected call of HasProcessed.
+func (mr *MockOrderReaderMockRecorder) HasProcessed(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasProcessed", reflect.TypeOf((*MockOrderReader)(nil).HasProcessed), ctx, userID)
+}
+
+// ListAccruedByUserID mocks base method.
+func (m *MockOrderReader) ListAccruedByUserID(ctx context.Context, userID vo.UserID, from, to time.Time) ([]entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccruedByUserID", ctx, userID, from, to)
 	ret0, _ := ret[0].([]entity.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListByStatuses indicates an expected call of ListByStatuses.
-func (mr *MockOrderReaderMockRecorder) ListByStatuses(ctx, statuses, limit any) *gomock.Call {
+// ListAccruedByUserID indicates an expected call of ListAccruedByUserID.
+func (mr *MockOrderReaderMockRecorder) ListAccruedByUserID(ctx, userID, from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatuses", reflect.TypeOf((*MockOrderReader)(nil).ListByStatuses), ctx, statuses, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccruedByUserID", reflect.TypeOf((*MockOrderReader)(nil).ListAccruedByUserID), ctx, userID, from, to)
 }
 
 // ListByUserID mocks base method.
-func (m *MockOrderReader) ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Order, error) {
+func (m *MockOrderReader) ListByUserID(ctx context.Context, userID vo.UserID, filter port.OrderFilter) ([]entity.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID, filter)
 	ret0, _ := ret[0].([]entity.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByUserID indicates an expected call of ListByUserID.
-func (mr *MockOrderReaderMockRecorder) ListByUserID(ctx, userID any) *gomock.Call {
+func (mr *MockOrderReaderMockRecorder) ListByUserID(ctx, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockOrderReader)(nil).ListByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockOrderReader)(nil).ListByUserID), ctx, userID, filter)
 }
 
-// StreamByStatuses mocks base method.
-func (m *MockOrderReader) StreamByStatuses(ctx context.Context, statuses []entity.OrderStatus, limit int) iter.Seq2[entity.Order, error] {
+// StatusHistory mocks base method.
+func (m *MockOrderReader) StatusHistory(ctx context.Context, number vo.OrderNumber) ([]entity.StatusChange, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StreamByStatuses", ctx, statuses, limit)
-	ret0, _ := ret[0].(iter.Seq2[entity.Order, error])
-	return ret0
+	ret := m.ctrl.Call(m, "StatusHistory", ctx, number)
+	ret0, _ := ret[0].([]entity.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// StreamByStatuses indicates an expected call of StreamByStatuses.
-func (mr *MockOrderReaderMockRecorder) StreamByStatuses(ctx, statuses, limit any) *gomock.Call {
+// StatusHistory indicates an expected call of StatusHistory.
+func (mr *MockOrderReaderMockRecorder) StatusHistory(ctx, number any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByStatuses", reflect.TypeOf((*MockOrderReader)(nil).StreamByStatuses), ctx, statuses, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusHistory", reflect.TypeOf((*MockOrderReader)(nil).StatusHistory), ctx, number)
 }
 
 // MockOrderWriter is a mock of OrderWriter interface.
@@ -126,6 +144,35 @@ func (m *MockOrderWriter) EXPECT() *MockOrderWriterMockRecorder {
 	return m.recorder
 }
 
+// ClaimByStatuses mocks base method.
+func (m *MockOrderWriter) ClaimByStatuses(ctx context.Context, statuses []entity.OrderStatus, owner string, now time.Time, leaseTTL time.Duration, limit int) iter.Seq2[entity.Order, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimByStatuses", ctx, statuses, owner, now, leaseTTL, limit)
+	ret0, _ := ret[0].(iter.Seq2[entity.Order, error])
+	return ret0
+}
+
+// ClaimByStatuses indicates an expected call of ClaimByStatuses.
+func (mr *MockOrderWriterMockRecorder) ClaimByStatuses(ctx, statuses, owner, now, leaseTTL, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimByStatuses", reflect.TypeOf((*MockOrderWriter)(nil).ClaimByStatuses), ctx, statuses, owner, now, leaseTTL, limit)
+}
+
+// ClaimFirstOrder mocks base method.
+func (m *MockOrderWriter) ClaimFirstOrder(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimFirstOrder", ctx, userID, orderNumber)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimFirstOrder indicates an expected call of ClaimFirstOrder.
+func (mr *MockOrderWriterMockRecorder) ClaimFirstOrder(ctx, userID, orderNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimFirstOrder", reflect.TypeOf((*MockOrderWriter)(nil).ClaimFirstOrder), ctx, userID, orderNumber)
+}
+
 // Create mocks base method.
 func (m *MockOrderWriter) Create(ctx context.Context, o *entity.Order) error {
 	m.ctrl.T.Helper()
@@ -178,6 +225,35 @@ func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ClaimByStatuses mocks base method.
+func (m *MockOrderRepository) ClaimByStatuses(ctx context.Context, statuses []entity.OrderStatus, owner string, now time.Time, leaseTTL time.Duration, limit int) iter.Seq2[entity.Order, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimByStatuses", ctx, statuses, owner, now, leaseTTL, limit)
+	ret0, _ := ret[0].(iter.Seq2[entity.Order, error])
+	return ret0
+}
+
+// ClaimByStatuses indicates an expected call of ClaimByStatuses.
+func (mr *MockOrderRepositoryMockRecorder) ClaimByStatuses(ctx, statuses, owner, now, leaseTTL, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimByStatuses", reflect.TypeOf((*MockOrderRepository)(nil).ClaimByStatuses), ctx, statuses, owner, now, leaseTTL, limit)
+}
+
+// ClaimFirstOrder mocks base method.
+func (m *MockOrderRepository) ClaimFirstOrder(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimFirstOrder", ctx, userID, orderNumber)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimFirstOrder indicates an expected call of ClaimFirstOrder.
+func (mr *MockOrderRepositoryMockRecorder) ClaimFirstOrder(ctx, userID, orderNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimFirstOrder", reflect.TypeOf((*MockOrderRepository)(nil).ClaimFirstOrder), ctx, userID, orderNumber)
+}
+
 // Create mocks base method.
 func (m *MockOrderRepository) Create(ctx context.Context, o *entity.Order) error {
 	m.ctrl.T.Helper()
@@ -207,48 +283,64 @@ func (mr *MockOrderRepositoryMockRecorder) FindByNumber(ctx, number any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNumber", reflect.TypeOf((*MockOrderRepository)(nil).FindByNumber), ctx, number)
 }
 
-// ListByStatuses mocks base method.
-func (m *MockOrderRepository) ListByStatuses(ctx context.Context, statuses []entity.OrderStatus, limit int) ([]entity.Order, error) {
+// HasProcessed mocks base method.
+func (m *MockOrderRepository) HasProcessed(ctx context.Context, userID vo.UserID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasProcessed", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasProcessed indicates an expected call of HasProcessed.
+func (mr *MockOrderRepositoryMockRecorder) HasProcessed(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasProcessed", reflect.TypeOf((*MockOrderRepository)(nil).HasProcessed), ctx, userID)
+}
+
+// ListAccruedByUserID mocks base method.
+func (m *MockOrderRepository) ListAccruedByUserID(ctx context.Context, userID vo.UserID, from, to time.Time) ([]entity.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByStatuses", ctx, statuses, limit)
+	ret := m.ctrl.Call(m, "ListAccruedByUserID", ctx, userID, from, to)
 	ret0, _ := ret[0].([]entity.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListByStatuses indicates an expected call of ListByStatuses.
-func (mr *MockOrderRepositoryMockRecorder) ListByStatuses(ctx, statuses, limit any) *gomock.Call {
+// ListAccruedByUserID indicates an expected call of ListAccruedByUserID.
+func (mr *MockOrderRepositoryMockRecorder) ListAccruedByUserID(ctx, userID, from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatuses", reflect.TypeOf((*MockOrderRepository)(nil).ListByStatuses), ctx, statuses, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccruedByUserID", reflect.TypeOf((*MockOrderRepository)(nil).ListAccruedByUserID), ctx, userID, from, to)
 }
 
 // ListByUserID mocks base method.
-func (m *MockOrderRepository) ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Order, error) {
+func (m *MockOrderRepository) ListByUserID(ctx context.Context, userID vo.UserID, filter port.OrderFilter) ([]entity.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID, filter)
 	ret0, _ := ret[0].([]entity.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByUserID indicates an expected call of ListByUserID.
-func (mr *MockOrderRepositoryMockRecorder) ListByUserID(ctx, userID any) *gomock.Call {
+func (mr *MockOrderRepositoryMockRecorder) ListByUserID(ctx, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockOrderRepository)(nil).ListByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockOrderRepository)(nil).ListByUserID), ctx, userID, filter)
 }
 
-// StreamByStatuses mocks base method.
-func (m *MockOrderRepository) StreamByStatuses(ctx context.Context, statuses []entity.OrderStatus, limit int) iter.Seq2[entity.Order, error] {
+// StatusHistory mocks base method.
+func (m *MockOrderRepository) StatusHistory(ctx context.Context, number vo.OrderNumber) ([]entity.StatusChange, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StreamByStatuses", ctx, statuses, limit)
-	ret0, _ := ret[0].(iter.Seq2[entity.Order, error])
-	return ret0
+	ret := m.ctrl.Call(m, "StatusHistory", ctx, number)
+	ret0, _ := ret[0].([]entity.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// StreamByStatuses indicates an expected call of StreamByStatuses.
-func (mr *MockOrderRepositoryMockRecorder) StreamByStatuses(ctx, statuses, limit any) *gomock.Call {
+// StatusHistory indicates an expected call of StatusHistory.
+func (mr *MockOrderRepositoryMockRecorder) StatusHistory(ctx, number any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByStatuses", reflect.TypeOf((*MockOrderRepository)(nil).StreamByStatuses), ctx, statuses, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusHistory", reflect.TypeOf((*MockOrderRepository)(nil).StatusHistory), ctx, number)
 }
 
 // Update mocks base method.
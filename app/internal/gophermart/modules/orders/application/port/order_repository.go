@@ -3,23 +3,61 @@ package port
 import (
 	"context"
 	"iter"
+	"time"
 
 	"gophermart/internal/gophermart/modules/orders/domain/entity"
 	"gophermart/internal/gophermart/modules/orders/domain/vo"
+	"gophermart/internal/pkg/cursor"
 )
 
+// OrderFilter narrows a user's order listing; the zero value selects the whole history.
+type OrderFilter struct {
+	// Statuses keeps orders in any of the statuses; empty keeps all.
+	Statuses []entity.OrderStatus
+	// From (inclusive) and To (exclusive) bound the upload time.
+	From *time.Time
+	To   *time.Time
+	// After continues the listing after the order at this position (upload time, order number).
+	After *cursor.Cursor
+	// Limit caps the number of orders; 0 means no limit.
+	Limit int
+}
+
 // OrderReader provides read-only access to orders for orders module.
 type OrderReader interface {
 	FindByNumber(ctx context.Context, number vo.OrderNumber) (*entity.Order, error)
-	ListByUserID(ctx context.Context, userID vo.UserID) ([]entity.Order, error)
-	ListByStatuses(ctx context.Context, statuses []entity.OrderStatus, limit int) ([]entity.Order, error)
-	StreamByStatuses(ctx context.Context, statuses []entity.OrderStatus, limit int) iter.Seq2[entity.Order, error]
+	// ListByUserID returns the user's orders matching the filter, newest first.
+	ListByUserID(ctx context.Context, userID vo.UserID, filter OrderFilter) ([]entity.Order, error)
+	// ListAccruedByUserID returns the user's processed orders with a positive accrual
+	// processed in [from, to), oldest first.
+	ListAccruedByUserID(ctx context.Context, userID vo.UserID, from, to time.Time) ([]entity.Order, error)
+	// HasProcessed reports whether the user has any processed order.
+	HasProcessed(ctx context.Context, userID vo.UserID) (bool, error)
+	// StatusHistory returns the order status timeline, oldest first.
+	StatusHistory(ctx context.Context, number vo.OrderNumber) ([]entity.StatusChange, error)
 }
 
 // OrderWriter provides write access to orders for orders module.
 type OrderWriter interface {
 	Create(ctx context.Context, o *entity.Order) error
+	// Update persists order changes and releases its poll lease.
+	// A claimed order (Lease set) is updated only while its lease is still held.
 	Update(ctx context.Context, o *entity.Order) error
+	// ClaimByStatuses leases up to limit orders due for polling at now to owner for leaseTTL
+	// and yields them with Lease set.
+	// Orders leased by another instance are skipped until their lease expires.
+	ClaimByStatuses(
+		ctx context.Context,
+		statuses []entity.OrderStatus,
+		owner string,
+		now time.Time,
+		leaseTTL time.Duration,
+		limit int,
+	) iter.Seq2[entity.Order, error]
+	// ClaimFirstOrder records the order as the one taking the user's first-order campaign bonuses
+	// and reports whether it did; false means another order holds the claim. A claim of a concurrent
+	// transaction blocks until that transaction ends, so only one of two racing orders gets true.
+	ClaimFirstOrder(ctx context.Context, userID vo.UserID, orderNumber vo.OrderNumber) (bool, error)
 }
 
 // OrderRepository combines reader and writer for orders DI wiring.
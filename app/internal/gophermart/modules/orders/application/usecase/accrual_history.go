@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"gophermart/internal/gophermart/modules/orders/application/api"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+// AccrualHistory exposes accruals of processed orders to other modules.
+type AccrualHistory struct {
+	orderReader port.OrderReader
+}
+
+// NewAccrualHistory returns the accrual history as module API.
+func NewAccrualHistory(orderReader port.OrderReader) api.AccrualHistoryAPI {
+	return &AccrualHistory{orderReader: orderReader}
+}
+
+// ListAccruals returns accruals of the user's orders processed in [From, To), oldest first.
+func (h *AccrualHistory) ListAccruals(ctx context.Context, in api.ListAccrualsInput) ([]api.AccrualRecord, error) {
+	orders, err := h.orderReader.ListAccruedByUserID(ctx, vo.UserID(in.UserID), in.From, in.To)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.AccrualRecord, 0, len(orders))
+	for _, o := range orders {
+		if o.Accrual == nil || o.ProcessedAt == nil {
+			continue
+		}
+		result = append(result, api.AccrualRecord{
+			OrderNumber: o.Number.String(),
+			Amount:      int64(*o.Accrual),
+			ProcessedAt: *o.ProcessedAt,
+		})
+	}
+
+	return result, nil
+}
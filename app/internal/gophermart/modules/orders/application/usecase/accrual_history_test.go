@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/modules/orders/application/api"
+	ordersportmocks "gophermart/internal/gophermart/modules/orders/application/port/mocks"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccrualHistory_ListAccruals(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	in := api.ListAccrualsInput{UserID: 1, From: from, To: to}
+
+	t.Run("maps processed orders", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+
+		accrual := vo.Points(50050)
+		processedAt := from.Add(time.Hour)
+		orderReader.EXPECT().ListAccruedByUserID(ctx, vo.UserID(1), from, to).Return([]entity.Order{
+			{Number: "12345678903", Status: entity.OrderStatusProcessed, Accrual: &accrual, ProcessedAt: &processedAt},
+		}, nil)
+
+		records, err := NewAccrualHistory(orderReader).ListAccruals(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []api.AccrualRecord{
+			{OrderNumber: "12345678903", Amount: 50050, ProcessedAt: processedAt},
+		}, records)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+
+		orderReader.EXPECT().ListAccruedByUserID(ctx, vo.UserID(1), from, to).Return(nil, errors.New("db error"))
+
+		_, err := NewAccrualHistory(orderReader).ListAccruals(ctx, in)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+// accrualTransition applies an accrual system status report to an order: it moves the order
+// to the reported status and credits the accrual with campaign bonuses on PROCESSED.
+// Shared by polling and push ingestion so both paths follow the same state transitions and crediting rules.
+type accrualTransition struct {
+	orderReader       port.OrderReader
+	orderWriter       port.OrderWriter
+	campaigns         port.CampaignReader
+	balanceGateway    port.BalanceGateway
+	transactor        appport.Transactor
+	optimisticRetries int
+}
+
+// apply persists the order in the reported status. Unknown statuses are ignored.
+// On PROCESSED the campaigns active at now add their bonuses to the accrual,
+// and the order update and balance credit commit in one transaction.
+//
+// Errors:
+//   - *entity.TransitionError — the reported status would regress or re-finalize the order
+//   - application.ErrConflict — the order status changed since it was loaded
+func (t accrualTransition) apply(
+	ctx context.Context,
+	order entity.Order,
+	info dto.AccrualOrderInfo,
+	trigger entity.Trigger,
+	now time.Time,
+) error {
+	switch info.Status {
+	case "PROCESSING", "REGISTERED":
+		if err := order.MarkProcessing(trigger, now); err != nil {
+			return err
+		}
+		return t.orderWriter.Update(ctx, &order)
+
+	case "INVALID":
+		if err := order.MarkInvalid(entity.InvalidReasonRejected, trigger, now); err != nil {
+			return err
+		}
+		return t.orderWriter.Update(ctx, &order)
+
+	case "PROCESSED":
+		var accrual vo.Points
+		if info.Accrual != nil {
+			accrual = *info.Accrual
+		}
+
+		return application.WithOptimisticRetry(t.optimisticRetries, func() error {
+			return t.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+				// Every attempt starts from the loaded order, the previous one was rolled back.
+				attempt := order
+				attempt.Changes = slices.Clone(order.Changes)
+				attempt.Bonuses = slices.Clone(order.Bonuses)
+				if err := attempt.MarkProcessed(accrual, trigger, now); err != nil {
+					return err
+				}
+				bonuses, err := t.campaignBonuses(ctx, order, accrual, now)
+				if err != nil {
+					return err
+				}
+				attempt.AddBonuses(bonuses)
+				if err := t.orderWriter.Update(ctx, &attempt); err != nil {
+					return err
+				}
+
+				if info.Accrual == nil && len(bonuses) == 0 {
+					return nil
+				}
+
+				return t.balanceGateway.ApplyAccrual(ctx, order.UserID, order.Number, *attempt.Accrual, now)
+			})
+		})
+	}
+
+	return nil
+}
+
+// campaignBonuses evaluates the campaigns active at now against the base accrual of the order.
+// Whether it is the user's first processed order is looked up only if an active campaign asks for it.
+// The lookup cannot see a concurrent first order that has not committed yet, so the order also claims
+// the user's first-order marker: of two racing first orders only the one holding the claim gets the bonuses.
+func (t accrualTransition) campaignBonuses(
+	ctx context.Context,
+	order entity.Order,
+	base vo.Points,
+	now time.Time,
+) ([]entity.OrderBonus, error) {
+	campaigns, err := t.campaigns.ListActive(ctx, now)
+	if err != nil || len(campaigns) == 0 {
+		return nil, err
+	}
+
+	firstOrder := false
+	if slices.ContainsFunc(campaigns, func(c entity.Campaign) bool { return c.FirstOrderOnly }) {
+		processed, err := t.orderReader.HasProcessed(ctx, order.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !processed {
+			firstOrder, err = t.orderWriter.ClaimFirstOrder(ctx, order.UserID, order.Number)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return entity.EvaluateCampaigns(campaigns, base, firstOrder, now), nil
+}
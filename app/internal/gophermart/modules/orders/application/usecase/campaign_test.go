@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	ordersportmocks "gophermart/internal/gophermart/modules/orders/application/port/mocks"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testCampaignInput = dto.CampaignInput{
+	Name:       "Double weekend",
+	Kind:       "MULTIPLIER",
+	Multiplier: 200,
+	StartsAt:   time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+	EndsAt:     time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+}
+
+func TestCreateCampaign_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores valid campaign", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		campaignWriter := ordersportmocks.NewMockCampaignWriter(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		clk.EXPECT().Now().Return(fixedTime)
+		campaignWriter.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, c *entity.Campaign) error {
+			assert.Equal(t, entity.CampaignKindMultiplier, c.Kind)
+			c.ID = 1
+			return nil
+		})
+
+		uc := NewCreateCampaign(campaignWriter, clk)
+		out, err := uc.Execute(ctx, testCampaignInput)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.CampaignOutput{
+			ID: 1, Name: "Double weekend", Kind: "MULTIPLIER", Multiplier: 200,
+			StartsAt: testCampaignInput.StartsAt, EndsAt: testCampaignInput.EndsAt,
+			CreatedAt: fixedTime, UpdatedAt: fixedTime,
+		}, out)
+	})
+
+	t.Run("invalid campaign is not stored", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		campaignWriter := ordersportmocks.NewMockCampaignWriter(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		clk.EXPECT().Now().Return(fixedTime)
+
+		in := testCampaignInput
+		in.EndsAt = in.StartsAt
+		uc := NewCreateCampaign(campaignWriter, clk)
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrInvalidCampaign)
+		assert.ErrorIs(t, err, entity.ErrCampaignPeriod)
+	})
+}
+
+func TestUpdateCampaign_Execute(t *testing.T) {
+	ctx := context.Background()
+	createdAt := fixedTime.Add(-24 * time.Hour)
+
+	t.Run("overwrites settings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		campaignReader := ordersportmocks.NewMockCampaignReader(ctrl)
+		campaignWriter := ordersportmocks.NewMockCampaignWriter(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		campaignReader.EXPECT().FindByID(ctx, int64(4)).Return(&entity.Campaign{
+			ID: 4, Name: "Old", Kind: entity.CampaignKindBonus, Bonus: 5000,
+			StartsAt: createdAt, EndsAt: fixedTime, CreatedAt: createdAt, UpdatedAt: createdAt,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		campaignWriter.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		uc := NewUpdateCampaign(campaignReader, campaignWriter, clk)
+		out, err := uc.Execute(ctx, dto.UpdateCampaignInput{ID: 4, CampaignInput: testCampaignInput})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.CampaignOutput{
+			ID: 4, Name: "Double weekend", Kind: "MULTIPLIER", Multiplier: 200,
+			StartsAt: testCampaignInput.StartsAt, EndsAt: testCampaignInput.EndsAt,
+			CreatedAt: createdAt, UpdatedAt: fixedTime,
+		}, out)
+	})
+
+	t.Run("campaign not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		campaignReader := ordersportmocks.NewMockCampaignReader(ctrl)
+		campaignWriter := ordersportmocks.NewMockCampaignWriter(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		campaignReader.EXPECT().FindByID(ctx, int64(4)).Return(nil, application.ErrNotFound)
+
+		uc := NewUpdateCampaign(campaignReader, campaignWriter, clk)
+		_, err := uc.Execute(ctx, dto.UpdateCampaignInput{ID: 4, CampaignInput: testCampaignInput})
+
+		assert.True(t, errors.Is(err, application.ErrNotFound))
+	})
+}
+
+func TestDeleteCampaign_Execute(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	campaignWriter := ordersportmocks.NewMockCampaignWriter(ctrl)
+
+	campaignWriter.EXPECT().Delete(ctx, int64(5)).Return(application.ErrNotFound)
+
+	uc := NewDeleteCampaign(campaignWriter)
+	_, err := uc.Execute(ctx, 5)
+
+	assert.ErrorIs(t, err, application.ErrNotFound)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+)
+
+// CreateCampaign registers a new bonus campaign.
+type CreateCampaign struct {
+	campaignWriter port.CampaignWriter
+	clock          appport.Clock
+}
+
+// NewCreateCampaign returns the create campaign use case.
+func NewCreateCampaign(campaignWriter port.CampaignWriter, clock appport.Clock) appport.UseCase[dto.CampaignInput, dto.CampaignOutput] {
+	return &CreateCampaign{campaignWriter: campaignWriter, clock: clock}
+}
+
+// Execute validates the campaign and stores it. The campaign applies to orders processed
+// within its period, including orders already uploaded.
+//
+// Errors:
+//   - application.ErrInvalidCampaign — name, period or rule is invalid; wraps the domain reason
+func (uc *CreateCampaign) Execute(ctx context.Context, in dto.CampaignInput) (dto.CampaignOutput, error) {
+	now := uc.clock.Now()
+	c := entity.Campaign{CreatedAt: now}
+	if err := applyCampaignInput(&c, in, now); err != nil {
+		return dto.CampaignOutput{}, err
+	}
+
+	if err := uc.campaignWriter.Create(ctx, &c); err != nil {
+		return dto.CampaignOutput{}, err
+	}
+
+	return newCampaignOutput(c), nil
+}
+
+// applyCampaignInput overwrites the campaign settings with the input and validates them.
+func applyCampaignInput(c *entity.Campaign, in dto.CampaignInput, now time.Time) error {
+	c.Name = in.Name
+	c.Kind = entity.CampaignKind(in.Kind)
+	c.Multiplier = in.Multiplier
+	c.Bonus = in.Bonus
+	c.FirstOrderOnly = in.FirstOrderOnly
+	c.StartsAt = in.StartsAt
+	c.EndsAt = in.EndsAt
+	c.UpdatedAt = now
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", application.ErrInvalidCampaign, err)
+	}
+	return nil
+}
+
+func newCampaignOutput(c entity.Campaign) dto.CampaignOutput {
+	return dto.CampaignOutput{
+		ID:             c.ID,
+		Name:           c.Name,
+		Kind:           string(c.Kind),
+		Multiplier:     c.Multiplier,
+		Bonus:          c.Bonus,
+		FirstOrderOnly: c.FirstOrderOnly,
+		StartsAt:       c.StartsAt,
+		EndsAt:         c.EndsAt,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+	}
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+)
+
+// DeleteCampaign removes a bonus campaign.
+type DeleteCampaign struct {
+	campaignWriter port.CampaignWriter
+}
+
+// NewDeleteCampaign returns the delete campaign use case.
+func NewDeleteCampaign(campaignWriter port.CampaignWriter) appport.UseCase[int64, struct{}] {
+	return &DeleteCampaign{campaignWriter: campaignWriter}
+}
+
+// Execute deletes the campaign; orders processed later no longer get its bonus.
+// Bonuses already credited stay in the order breakdown.
+//
+// Errors:
+//   - application.ErrNotFound — campaign does not exist
+func (uc *DeleteCampaign) Execute(ctx context.Context, id int64) (struct{}, error) {
+	return struct{}{}, uc.campaignWriter.Delete(ctx, id)
+}
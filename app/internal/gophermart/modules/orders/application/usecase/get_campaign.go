@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+)
+
+// GetCampaign returns a single bonus campaign.
+type GetCampaign struct {
+	campaignReader port.CampaignReader
+}
+
+// NewGetCampaign returns the get campaign use case.
+func NewGetCampaign(campaignReader port.CampaignReader) appport.UseCase[int64, dto.CampaignOutput] {
+	return &GetCampaign{campaignReader: campaignReader}
+}
+
+// Execute loads the campaign.
+//
+// Errors:
+//   - application.ErrNotFound — campaign does not exist
+func (uc *GetCampaign) Execute(ctx context.Context, id int64) (dto.CampaignOutput, error) {
+	c, err := uc.campaignReader.FindByID(ctx, id)
+	if err != nil {
+		return dto.CampaignOutput{}, err
+	}
+	return newCampaignOutput(*c), nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+// GetOrder returns a single order of the given user with its status timeline.
+type GetOrder struct {
+	orderReader port.OrderReader
+	validator   vo.OrderNumberValidator
+}
+
+// NewGetOrder returns the get order use case.
+func NewGetOrder(orderReader port.OrderReader, validator vo.OrderNumberValidator) appport.UseCase[dto.GetOrderInput, dto.OrderDetailsOutput] {
+	return &GetOrder{orderReader: orderReader, validator: validator}
+}
+
+// Execute loads the order and its status history.
+// Orders of other users are reported as not found so their existence is not disclosed.
+//
+// Errors:
+//   - application.ErrInvalidOrderNumber — order number failed Luhn check
+//   - application.ErrNotFound — order does not exist or belongs to another user
+func (uc *GetOrder) Execute(ctx context.Context, in dto.GetOrderInput) (dto.OrderDetailsOutput, error) {
+	orderNumber, err := vo.NewOrderNumber(uc.validator, in.OrderNumber)
+	if err != nil {
+		return dto.OrderDetailsOutput{}, application.ErrInvalidOrderNumber
+	}
+
+	order, err := uc.orderReader.FindByNumber(ctx, orderNumber)
+	if err != nil {
+		return dto.OrderDetailsOutput{}, err
+	}
+	if order.UserID != in.UserID {
+		return dto.OrderDetailsOutput{}, application.ErrNotFound
+	}
+
+	history, err := uc.orderReader.StatusHistory(ctx, orderNumber)
+	if err != nil {
+		return dto.OrderDetailsOutput{}, err
+	}
+
+	out := dto.OrderDetailsOutput{
+		OrderOutput: dto.OrderOutput{
+			Number:     order.Number.String(),
+			Status:     string(order.Status),
+			Accrual:    order.Accrual,
+			Bonuses:    bonusOutputs(order.Bonuses),
+			UploadedAt: order.UploadedAt,
+		},
+		InvalidReason: string(order.InvalidReason),
+		ProcessedAt:   order.ProcessedAt,
+		History:       make([]dto.StatusChangeOutput, 0, len(history)),
+	}
+	for _, c := range history {
+		out.History = append(out.History, dto.StatusChangeOutput{
+			From:      string(c.From),
+			To:        string(c.To),
+			Source:    string(c.Source),
+			RawStatus: c.RawStatus,
+			At:        c.At,
+		})
+	}
+
+	return out, nil
+}
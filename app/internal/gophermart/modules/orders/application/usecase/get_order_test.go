@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	ordersportmocks "gophermart/internal/gophermart/modules/orders/application/port/mocks"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetOrder_Execute(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	number := vo.OrderNumber("12345678903")
+	in := dto.GetOrderInput{UserID: 1, OrderNumber: number.String()}
+
+	t.Run("returns order with history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+
+		processedAt := fixedTime.Add(time.Hour)
+		accrual := vo.Points(70000)
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusProcessed,
+			Accrual: &accrual, UploadedAt: fixedTime, ProcessedAt: &processedAt,
+		}, nil)
+		orderReader.EXPECT().StatusHistory(ctx, number).Return([]entity.StatusChange{
+			{To: entity.OrderStatusNew, Source: entity.StatusSourceUpload, At: fixedTime},
+			{
+				From: entity.OrderStatusNew, To: entity.OrderStatusProcessed,
+				Source: entity.StatusSourceAccrualPoll, RawStatus: "PROCESSED", At: processedAt,
+			},
+		}, nil)
+
+		uc := NewGetOrder(orderReader, stubOrderNumberValidator{valid: true})
+		out, err := uc.Execute(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "PROCESSED", out.Status)
+		assert.Equal(t, &accrual, out.Accrual)
+		assert.Equal(t, &processedAt, out.ProcessedAt)
+		assert.Equal(t, []dto.StatusChangeOutput{
+			{To: "NEW", Source: "UPLOAD", At: fixedTime},
+			{From: "NEW", To: "PROCESSED", Source: "ACCRUAL_POLL", RawStatus: "PROCESSED", At: processedAt},
+		}, out.History)
+	})
+
+	t.Run("order of another user is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{Number: number, UserID: 2}, nil)
+
+		uc := NewGetOrder(orderReader, stubOrderNumberValidator{valid: true})
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrNotFound)
+	})
+
+	t.Run("invalid order number", func(t *testing.T) {
+		uc := NewGetOrder(nil, stubOrderNumberValidator{valid: false})
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, application.ErrInvalidOrderNumber)
+	})
+
+	t.Run("history error propagated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+
+		dbErr := errors.New("db error")
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{Number: number, UserID: 1}, nil)
+		orderReader.EXPECT().StatusHistory(ctx, number).Return(nil, dbErr)
+
+		uc := NewGetOrder(orderReader, stubOrderNumberValidator{valid: true})
+		_, err := uc.Execute(ctx, in)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
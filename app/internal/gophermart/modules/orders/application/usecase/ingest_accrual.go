@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+
+	"gophermart/internal/gophermart/application"
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+// IngestAccrual applies order statuses pushed by the accrual system.
+// It shares the transition and crediting logic with ProcessAccrual; polling remains the fallback.
+type IngestAccrual struct {
+	orderReader port.OrderReader
+	transition  accrualTransition
+	validator   vo.OrderNumberValidator
+	clock       appport.Clock
+	pollPolicy  vo.PollPolicy
+}
+
+// NewIngestAccrual returns the ingest accrual use case.
+func NewIngestAccrual(
+	orderReader port.OrderReader,
+	orderWriter port.OrderWriter,
+	campaigns port.CampaignReader,
+	balanceGateway port.BalanceGateway,
+	transactor appport.Transactor,
+	validator vo.OrderNumberValidator,
+	clock appport.Clock,
+	optimisticRetries int,
+	pollPolicy vo.PollPolicy,
+) appport.UseCase[dto.AccrualPushInput, struct{}] {
+	return &IngestAccrual{
+		orderReader: orderReader,
+		transition: accrualTransition{
+			orderReader:       orderReader,
+			orderWriter:       orderWriter,
+			campaigns:         campaigns,
+			balanceGateway:    balanceGateway,
+			transactor:        transactor,
+			optimisticRetries: optimisticRetries,
+		},
+		validator:  validator,
+		clock:      clock,
+		pollPolicy: pollPolicy,
+	}
+}
+
+// Execute applies the pushed status to the order. Pushes for orders already in a final status
+// are acknowledged without changes, so redelivered notifications are harmless.
+// A non-final push defers the next poll by the poll policy's maximum delay.
+//
+// Errors:
+//   - application.ErrInvalidOrderNumber — order number failed Luhn check
+//   - application.ErrNotFound — order is not registered in the loyalty system
+//   - application.ErrConflict — order status changed concurrently (e.g. by the poller)
+func (uc *IngestAccrual) Execute(ctx context.Context, in dto.AccrualPushInput) (struct{}, error) {
+	orderNumber, err := vo.NewOrderNumber(uc.validator, in.OrderNumber)
+	if err != nil {
+		return struct{}{}, application.ErrInvalidOrderNumber
+	}
+
+	order, err := uc.orderReader.FindByNumber(ctx, orderNumber)
+	if err != nil {
+		return struct{}{}, err
+	}
+	if order.Final() {
+		return struct{}{}, nil
+	}
+
+	now := uc.clock.Now()
+	order.DeferPoll(now.Add(uc.pollPolicy.MaxDelay))
+
+	info := dto.AccrualOrderInfo{Status: in.Status, Accrual: in.Accrual}
+	trigger := entity.Trigger{Source: entity.StatusSourceWebhook, RawStatus: in.Status}
+	return struct{}{}, uc.transition.apply(ctx, *order, info, trigger, now)
+}
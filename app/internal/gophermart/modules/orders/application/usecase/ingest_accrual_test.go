@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermart/internal/gophermart/application"
+	appmocks "gophermart/internal/gophermart/application/port/mocks"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	ordersportmocks "gophermart/internal/gophermart/modules/orders/application/port/mocks"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIngestAccrual_Execute(t *testing.T) {
+	ctx := context.Background()
+	number := vo.OrderNumber("12345678903")
+
+	t.Run("processed push credits balance", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+		orderWriter := ordersportmocks.NewMockOrderWriter(ctrl)
+		campaigns := ordersportmocks.NewMockCampaignReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		accrual := vo.Points(50000)
+		var credited vo.Points
+		balanceGateway := &stubBalanceGateway{
+			apply: func(_ context.Context, _ vo.UserID, _ vo.OrderNumber, amount vo.Points, _ time.Time) error {
+				credited = amount
+				return nil
+			},
+		}
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusProcessing,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		campaigns.EXPECT().ListActive(ctx, fixedTime).Return(nil, nil)
+		orderWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, entity.OrderStatusProcessed, o.Status)
+				assert.Equal(t, []entity.StatusChange{{
+					From: entity.OrderStatusProcessing, To: entity.OrderStatusProcessed,
+					Source: entity.StatusSourceWebhook, RawStatus: "PROCESSED", At: fixedTime,
+				}}, o.Changes)
+				return nil
+			},
+		)
+
+		uc := NewIngestAccrual(orderReader, orderWriter, campaigns, balanceGateway, transactor, stubOrderNumberValidator{valid: true}, clk, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "PROCESSED", Accrual: &accrual})
+
+		assert.NoError(t, err)
+		assert.Equal(t, accrual, credited)
+	})
+
+	t.Run("processed push credits campaign bonuses", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+		orderWriter := ordersportmocks.NewMockOrderWriter(ctrl)
+		campaigns := ordersportmocks.NewMockCampaignReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		accrual := vo.Points(50000)
+		var credited vo.Points
+		balanceGateway := &stubBalanceGateway{
+			apply: func(_ context.Context, _ vo.UserID, _ vo.OrderNumber, amount vo.Points, _ time.Time) error {
+				credited = amount
+				return nil
+			},
+		}
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusProcessing,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		campaigns.EXPECT().ListActive(ctx, fixedTime).Return([]entity.Campaign{
+			{
+				ID: 1, Name: "Double weekend", Kind: entity.CampaignKindMultiplier, Multiplier: 200,
+				StartsAt: fixedTime.Add(-time.Hour), EndsAt: fixedTime.Add(time.Hour),
+			},
+			{
+				ID: 2, Name: "First order", Kind: entity.CampaignKindBonus, Bonus: 5000, FirstOrderOnly: true,
+				StartsAt: fixedTime.Add(-time.Hour), EndsAt: fixedTime.Add(time.Hour),
+			},
+		}, nil)
+		orderReader.EXPECT().HasProcessed(ctx, vo.UserID(1)).Return(false, nil)
+		orderWriter.EXPECT().ClaimFirstOrder(ctx, vo.UserID(1), number).Return(true, nil)
+		orderWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, vo.Points(105000), *o.Accrual)
+				assert.Equal(t, []entity.OrderBonus{
+					{CampaignID: 1, CampaignName: "Double weekend", Amount: 50000},
+					{CampaignID: 2, CampaignName: "First order", Amount: 5000},
+				}, o.Bonuses)
+				return nil
+			},
+		)
+
+		uc := NewIngestAccrual(orderReader, orderWriter, campaigns, balanceGateway, transactor, stubOrderNumberValidator{valid: true}, clk, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "PROCESSED", Accrual: &accrual})
+
+		assert.NoError(t, err)
+		assert.Equal(t, vo.Points(105000), credited)
+	})
+
+	t.Run("first order campaign skipped for returning user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+		orderWriter := ordersportmocks.NewMockOrderWriter(ctrl)
+		campaigns := ordersportmocks.NewMockCampaignReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		var credited bool
+		balanceGateway := &stubBalanceGateway{
+			apply: func(context.Context, vo.UserID, vo.OrderNumber, vo.Points, time.Time) error {
+				credited = true
+				return nil
+			},
+		}
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusProcessing,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		campaigns.EXPECT().ListActive(ctx, fixedTime).Return([]entity.Campaign{{
+			ID: 2, Name: "First order", Kind: entity.CampaignKindBonus, Bonus: 5000, FirstOrderOnly: true,
+			StartsAt: fixedTime.Add(-time.Hour), EndsAt: fixedTime.Add(time.Hour),
+		}}, nil)
+		orderReader.EXPECT().HasProcessed(ctx, vo.UserID(1)).Return(true, nil)
+		orderWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Empty(t, o.Bonuses)
+				return nil
+			},
+		)
+
+		// No accrual reported and no bonus: nothing to credit.
+		uc := NewIngestAccrual(orderReader, orderWriter, campaigns, balanceGateway, transactor, stubOrderNumberValidator{valid: true}, clk, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "PROCESSED"})
+
+		assert.NoError(t, err)
+		assert.False(t, credited)
+	})
+
+	t.Run("first order campaign skipped when a concurrent first order holds the claim", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+		orderWriter := ordersportmocks.NewMockOrderWriter(ctrl)
+		campaigns := ordersportmocks.NewMockCampaignReader(ctrl)
+		transactor := appmocks.NewMockTransactor(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		var credited bool
+		balanceGateway := &stubBalanceGateway{
+			apply: func(context.Context, vo.UserID, vo.OrderNumber, vo.Points, time.Time) error {
+				credited = true
+				return nil
+			},
+		}
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusProcessing,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		transactor.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		)
+		campaigns.EXPECT().ListActive(ctx, fixedTime).Return([]entity.Campaign{{
+			ID: 2, Name: "First order", Kind: entity.CampaignKindBonus, Bonus: 5000, FirstOrderOnly: true,
+			StartsAt: fixedTime.Add(-time.Hour), EndsAt: fixedTime.Add(time.Hour),
+		}}, nil)
+		orderReader.EXPECT().HasProcessed(ctx, vo.UserID(1)).Return(false, nil)
+		orderWriter.EXPECT().ClaimFirstOrder(ctx, vo.UserID(1), number).Return(false, nil)
+		orderWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Empty(t, o.Bonuses)
+				return nil
+			},
+		)
+
+		// No accrual reported and no bonus: nothing to credit.
+		uc := NewIngestAccrual(orderReader, orderWriter, campaigns, balanceGateway, transactor, stubOrderNumberValidator{valid: true}, clk, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "PROCESSED"})
+
+		assert.NoError(t, err)
+		assert.False(t, credited)
+	})
+
+	t.Run("non-final push defers polling", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+		orderWriter := ordersportmocks.NewMockOrderWriter(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusNew, NextPollAt: fixedTime,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		orderWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, entity.OrderStatusProcessing, o.Status)
+				assert.Equal(t, fixedTime.Add(testPollPolicy.MaxDelay), o.NextPollAt)
+				return nil
+			},
+		)
+
+		uc := NewIngestAccrual(orderReader, orderWriter, nil, &stubBalanceGateway{}, nil, stubOrderNumberValidator{valid: true}, clk, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "REGISTERED"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("final order is left untouched", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusProcessed,
+		}, nil)
+
+		uc := NewIngestAccrual(orderReader, nil, nil, nil, nil, stubOrderNumberValidator{valid: true}, nil, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "INVALID"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid order number", func(t *testing.T) {
+		uc := NewIngestAccrual(nil, nil, nil, nil, nil, stubOrderNumberValidator{valid: false}, nil, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: "123", Status: "PROCESSED"})
+
+		assert.ErrorIs(t, err, application.ErrInvalidOrderNumber)
+	})
+
+	t.Run("unknown order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(nil, application.ErrNotFound)
+
+		uc := NewIngestAccrual(orderReader, nil, nil, nil, nil, stubOrderNumberValidator{valid: true}, nil, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "PROCESSED"})
+
+		assert.ErrorIs(t, err, application.ErrNotFound)
+	})
+
+	t.Run("concurrent change reported as conflict", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+		orderWriter := ordersportmocks.NewMockOrderWriter(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusNew,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		orderWriter.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, entity.OrderStatusNew, o.PersistedStatus())
+				return application.ErrConflict
+			},
+		)
+
+		uc := NewIngestAccrual(orderReader, orderWriter, nil, &stubBalanceGateway{}, nil, stubOrderNumberValidator{valid: true}, clk, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "INVALID"})
+
+		assert.ErrorIs(t, err, application.ErrConflict)
+	})
+
+	t.Run("update error propagated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+		orderWriter := ordersportmocks.NewMockOrderWriter(ctrl)
+		clk := appmocks.NewMockClock(ctrl)
+
+		dbErr := errors.New("db down")
+		orderReader.EXPECT().FindByNumber(ctx, number).Return(&entity.Order{
+			Number: number, UserID: 1, Status: entity.OrderStatusNew,
+		}, nil)
+		clk.EXPECT().Now().Return(fixedTime)
+		orderWriter.EXPECT().Update(ctx, gomock.Any()).Return(dbErr)
+
+		uc := NewIngestAccrual(orderReader, orderWriter, nil, &stubBalanceGateway{}, nil, stubOrderNumberValidator{valid: true}, clk, 3, testPollPolicy)
+		_, err := uc.Execute(ctx, dto.AccrualPushInput{OrderNumber: number.String(), Status: "INVALID"})
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
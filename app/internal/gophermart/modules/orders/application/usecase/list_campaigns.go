@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+)
+
+// ListCampaigns returns all bonus campaigns.
+type ListCampaigns struct {
+	campaignReader port.CampaignReader
+}
+
+// NewListCampaigns returns the list campaigns use case.
+func NewListCampaigns(campaignReader port.CampaignReader) appport.UseCase[struct{}, []dto.CampaignOutput] {
+	return &ListCampaigns{campaignReader: campaignReader}
+}
+
+// Execute returns all campaigns, past and future included, latest start first.
+func (uc *ListCampaigns) Execute(ctx context.Context, _ struct{}) ([]dto.CampaignOutput, error) {
+	campaigns, err := uc.campaignReader.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.CampaignOutput, 0, len(campaigns))
+	for _, c := range campaigns {
+		result = append(result, newCampaignOutput(c))
+	}
+
+	return result, nil
+}
@@ -3,43 +3,78 @@ package usecase
 import (
 	"context"
 
+	"gophermart/internal/gophermart/application"
 	appport "gophermart/internal/gophermart/application/port"
 	"gophermart/internal/gophermart/modules/orders/application/dto"
 	"gophermart/internal/gophermart/modules/orders/application/port"
-	"gophermart/internal/gophermart/modules/orders/domain/vo"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+	"gophermart/internal/pkg/cursor"
 )
 
-// ListOrders returns all orders uploaded by the given user.
+// ListOrders returns the orders uploaded by the given user.
 type ListOrders struct {
 	orderReader port.OrderReader
 }
 
 // NewListOrders returns the list orders use case.
-func NewListOrders(orderReader port.OrderReader) appport.UseCase[vo.UserID, []dto.OrderOutput] {
+func NewListOrders(orderReader port.OrderReader) appport.UseCase[dto.ListOrdersInput, dto.OrderPageOutput] {
 	return &ListOrders{orderReader: orderReader}
 }
 
-// Execute fetches orders and maps them to output DTOs.
-// Returns an empty slice if the user has no orders.
-func (uc *ListOrders) Execute(ctx context.Context, userID vo.UserID) ([]dto.OrderOutput, error) {
-	orders, err := uc.orderReader.ListByUserID(ctx, userID)
+// Execute fetches orders matching the filters, newest first, and maps them to output DTOs.
+// With a cursor or limit it returns one page and the cursor of the next one; otherwise the whole history.
+// Returns an empty slice if the user has no matching orders.
+//
+// Errors:
+//   - application.ErrInvalidCursor — cursor is malformed
+func (uc *ListOrders) Execute(ctx context.Context, in dto.ListOrdersInput) (dto.OrderPageOutput, error) {
+	page := application.PageRequest{Cursor: in.Cursor, Limit: in.Limit}
+	after, err := page.After()
 	if err != nil {
-		return nil, err
+		return dto.OrderPageOutput{}, err
 	}
 
+	filter := port.OrderFilter{From: in.From, To: in.To, After: after}
+	for _, s := range in.Statuses {
+		filter.Statuses = append(filter.Statuses, entity.OrderStatus(s))
+	}
+	limit := page.PageLimit()
+	if limit > 0 {
+		// One extra row tells whether there is a next page.
+		filter.Limit = limit + 1
+	}
+
+	orders, err := uc.orderReader.ListByUserID(ctx, in.UserID, filter)
+	if err != nil {
+		return dto.OrderPageOutput{}, err
+	}
+
+	orders, next := application.TrimPage(orders, limit, func(o entity.Order) cursor.Cursor {
+		return cursor.Cursor{At: o.UploadedAt, Key: o.Number.String()}
+	})
+
 	result := make([]dto.OrderOutput, 0, len(orders))
 	for _, o := range orders {
-		out := dto.OrderOutput{
+		result = append(result, dto.OrderOutput{
 			Number:     o.Number.String(),
 			Status:     string(o.Status),
+			Accrual:    o.Accrual,
+			Bonuses:    bonusOutputs(o.Bonuses),
 			UploadedAt: o.UploadedAt,
-		}
-		if o.Accrual != nil {
-			v := float64(*o.Accrual)
-			out.Accrual = &v
-		}
-		result = append(result, out)
+		})
 	}
 
-	return result, nil
+	return dto.OrderPageOutput{Orders: result, NextCursor: next}, nil
+}
+
+// bonusOutputs maps campaign bonuses of an order to output DTOs.
+func bonusOutputs(bonuses []entity.OrderBonus) []dto.BonusOutput {
+	if len(bonuses) == 0 {
+		return nil
+	}
+	out := make([]dto.BonusOutput, 0, len(bonuses))
+	for _, b := range bonuses {
+		out = append(out, dto.BonusOutput{CampaignID: b.CampaignID, CampaignName: b.CampaignName, Amount: b.Amount})
+	}
+	return out
 }
@@ -6,17 +6,23 @@ import (
 	"testing"
 	"time"
 
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/application/port"
 	ordersportmocks "gophermart/internal/gophermart/modules/orders/application/port/mocks"
 	"gophermart/internal/gophermart/modules/orders/domain/entity"
 	"gophermart/internal/gophermart/modules/orders/domain/vo"
+	"gophermart/internal/pkg/cursor"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
 func TestListOrders_Execute(t *testing.T) {
 	ctx := context.Background()
 	userID := vo.UserID(1)
+	in := dto.ListOrdersInput{UserID: userID}
 
 	t.Run("returns mapped orders", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -24,44 +30,95 @@ func TestListOrders_Execute(t *testing.T) {
 
 		accrual := vo.Points(500)
 		now := time.Now()
-		orderReader.EXPECT().ListByUserID(ctx, userID).Return([]entity.Order{
-			{Number: "111", Status: entity.OrderStatusProcessed, Accrual: &accrual, UploadedAt: now},
+		orderReader.EXPECT().ListByUserID(ctx, userID, port.OrderFilter{}).Return([]entity.Order{
+			{
+				Number: "111", Status: entity.OrderStatusProcessed, Accrual: &accrual, UploadedAt: now,
+				Bonuses: []entity.OrderBonus{{CampaignID: 7, CampaignName: "Double weekend", Amount: 250}},
+			},
 			{Number: "222", Status: entity.OrderStatusNew, UploadedAt: now},
 		}, nil)
 
 		uc := NewListOrders(orderReader)
-		result, err := uc.Execute(ctx, userID)
+		result, err := uc.Execute(ctx, in)
 
 		assert.NoError(t, err)
-		assert.Len(t, result, 2)
-		assert.Equal(t, "111", result[0].Number)
-		assert.Equal(t, "PROCESSED", result[0].Status)
-		assert.NotNil(t, result[0].Accrual)
-		assert.Equal(t, float64(500), *result[0].Accrual)
-		assert.Nil(t, result[1].Accrual)
+		assert.Len(t, result.Orders, 2)
+		assert.Equal(t, "111", result.Orders[0].Number)
+		assert.Equal(t, "PROCESSED", result.Orders[0].Status)
+		assert.NotNil(t, result.Orders[0].Accrual)
+		assert.Equal(t, vo.Points(500), *result.Orders[0].Accrual)
+		assert.Equal(t, []dto.BonusOutput{{CampaignID: 7, CampaignName: "Double weekend", Amount: 250}}, result.Orders[0].Bonuses)
+		assert.Nil(t, result.Orders[1].Accrual)
+		assert.Nil(t, result.Orders[1].Bonuses)
+		assert.Empty(t, result.NextCursor)
+	})
+
+	t.Run("pages with filters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
+
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		from := now.Add(-24 * time.Hour)
+		after := cursor.Cursor{At: now, Key: "999"}
+
+		orderReader.EXPECT().ListByUserID(ctx, userID, port.OrderFilter{
+			Statuses: []entity.OrderStatus{entity.OrderStatusNew, entity.OrderStatusProcessing},
+			From:     &from,
+			After:    &after,
+			Limit:    3,
+		}).Return([]entity.Order{
+			{Number: "333", Status: entity.OrderStatusNew, UploadedAt: now.Add(-time.Minute)},
+			{Number: "222", Status: entity.OrderStatusNew, UploadedAt: now.Add(-2 * time.Minute)},
+			{Number: "111", Status: entity.OrderStatusProcessing, UploadedAt: now.Add(-3 * time.Minute)},
+		}, nil)
+
+		uc := NewListOrders(orderReader)
+		result, err := uc.Execute(ctx, dto.ListOrdersInput{
+			UserID:   userID,
+			Statuses: []string{"NEW", "PROCESSING"},
+			From:     &from,
+			Cursor:   after.Encode(),
+			Limit:    2,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Orders, 2)
+		assert.Equal(t, "222", result.Orders[1].Number)
+
+		next, err := cursor.Decode(result.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "222", next.Key)
+		assert.True(t, now.Add(-2*time.Minute).Equal(next.At))
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		uc := NewListOrders(nil)
+		_, err := uc.Execute(ctx, dto.ListOrdersInput{UserID: userID, Cursor: "garbage"})
+
+		assert.ErrorIs(t, err, application.ErrInvalidCursor)
 	})
 
 	t.Run("empty list", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
 
-		orderReader.EXPECT().ListByUserID(ctx, userID).Return(nil, nil)
+		orderReader.EXPECT().ListByUserID(ctx, userID, port.OrderFilter{}).Return(nil, nil)
 
 		uc := NewListOrders(orderReader)
-		result, err := uc.Execute(ctx, userID)
+		result, err := uc.Execute(ctx, in)
 
 		assert.NoError(t, err)
-		assert.Empty(t, result)
+		assert.Empty(t, result.Orders)
 	})
 
 	t.Run("repo error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		orderReader := ordersportmocks.NewMockOrderReader(ctrl)
 
-		orderReader.EXPECT().ListByUserID(ctx, userID).Return(nil, errors.New("db error"))
+		orderReader.EXPECT().ListByUserID(ctx, userID, port.OrderFilter{}).Return(nil, errors.New("db error"))
 
 		uc := NewListOrders(orderReader)
-		_, err := uc.Execute(ctx, userID)
+		_, err := uc.Execute(ctx, in)
 
 		assert.Error(t, err)
 	})
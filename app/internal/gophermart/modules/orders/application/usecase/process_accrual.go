@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -14,24 +15,30 @@ import (
 	"gophermart/internal/gophermart/modules/orders/domain/vo"
 )
 
-// ProcessAccrual fetches pending orders and synchronizes their status with the accrual system.
+// minBackOffPause is the shortest pause before retrying an order the accrual system asked
+// to back off from; a Retry-After in the past must not turn the retry loop into a busy loop.
+const minBackOffPause = time.Second
+
+// ProcessAccrual claims pending orders and synchronizes their status with the accrual system.
+// Orders are leased to this instance for leaseTTL, so replicas never poll the same order concurrently.
 type ProcessAccrual struct {
-	orderReader       port.OrderReader
-	orderWriter       port.OrderWriter
-	balanceGateway    port.BalanceGateway
-	accrualClient     port.AccrualClient
-	transactor        appport.Transactor
-	clock             appport.Clock
-	log               appport.Logger
-	batchSize         int
-	maxWorkers        int
-	optimisticRetries int
+	orderWriter   port.OrderWriter
+	accrualClient port.AccrualClient
+	transition    accrualTransition
+	clock         appport.Clock
+	log           appport.Logger
+	batchSize     int
+	maxWorkers    int
+	pollPolicy    vo.PollPolicy
+	leaseOwner    string
+	leaseTTL      time.Duration
 }
 
 // NewProcessAccrual returns the process accrual use case.
 func NewProcessAccrual(
 	orderReader port.OrderReader,
 	orderWriter port.OrderWriter,
+	campaigns port.CampaignReader,
 	balanceGateway port.BalanceGateway,
 	accrualClient port.AccrualClient,
 	transactor appport.Transactor,
@@ -40,28 +47,43 @@ func NewProcessAccrual(
 	batchSize int,
 	maxWorkers int,
 	optimisticRetries int,
+	pollPolicy vo.PollPolicy,
+	leaseOwner string,
+	leaseTTL time.Duration,
 ) *ProcessAccrual {
 	return &ProcessAccrual{
-		orderReader:       orderReader,
-		orderWriter:       orderWriter,
-		balanceGateway:    balanceGateway,
-		accrualClient:     accrualClient,
-		transactor:        transactor,
-		clock:             clock,
-		log:               log,
-		batchSize:         batchSize,
-		maxWorkers:        maxWorkers,
-		optimisticRetries: optimisticRetries,
+		orderWriter:   orderWriter,
+		accrualClient: accrualClient,
+		transition: accrualTransition{
+			orderReader:       orderReader,
+			orderWriter:       orderWriter,
+			campaigns:         campaigns,
+			balanceGateway:    balanceGateway,
+			transactor:        transactor,
+			optimisticRetries: optimisticRetries,
+		},
+		clock:      clock,
+		log:        log,
+		batchSize:  batchSize,
+		maxWorkers: maxWorkers,
+		pollPolicy: pollPolicy,
+		leaseOwner: leaseOwner,
+		leaseTTL:   leaseTTL,
 	}
 }
 
-// Run streams a batch of pending orders from the DB and processes them
+// Run claims a batch of pending orders due for polling and processes them
 // concurrently via errgroup. Returns the number of successfully processed orders.
+// When the accrual system asks to back off (rate limit, open circuit) workers pause and retry
+// the orders they already claimed; the batch is aborted only if the pause outlasts the lease.
+// Orders left unprocessed (errors, aborted batch) keep their lease until it expires.
 func (uc *ProcessAccrual) Run(ctx context.Context) (int, error) {
-	orders := uc.orderReader.StreamByStatuses(ctx, []entity.OrderStatus{
+	claimedAt := uc.clock.Now()
+	leaseDeadline := claimedAt.Add(uc.leaseTTL)
+	orders := uc.orderWriter.ClaimByStatuses(ctx, []entity.OrderStatus{
 		entity.OrderStatusNew,
 		entity.OrderStatusProcessing,
-	}, uc.batchSize)
+	}, uc.leaseOwner, claimedAt, uc.leaseTTL, uc.batchSize)
 
 	g, gCtx := errgroup.WithContext(ctx)
 	g.SetLimit(uc.maxWorkers)
@@ -88,11 +110,17 @@ func (uc *ProcessAccrual) Run(ctx context.Context) (int, error) {
 
 	for order := range ch {
 		g.Go(func() error {
-			if err := uc.processOrder(gCtx, order); err != nil {
-				var rl *application.ErrRateLimit
-				if errors.As(err, &rl) {
+			if err := uc.processOrderWithPause(gCtx, order, leaseDeadline); err != nil {
+				if _, ok := application.RetryAfter(err); ok {
 					return err
 				}
+				if errors.Is(err, application.ErrConflict) {
+					// Another processor (webhook, replica) moved the order first.
+					uc.log.Debug("order changed concurrently, skipped",
+						"order", order.Number.String(),
+					)
+					return nil
+				}
 				uc.log.Warn("failed to process order accrual",
 					"order", order.Number.String(),
 					"error", err,
@@ -115,50 +143,52 @@ func (uc *ProcessAccrual) Run(ctx context.Context) (int, error) {
 	return int(processed.Load()), nil
 }
 
+// processOrderWithPause processes the order, pausing and retrying while the accrual system
+// asks to back off, for at least minBackOffPause. It gives up with the back-off error once
+// the pause would outlast the lease.
+func (uc *ProcessAccrual) processOrderWithPause(ctx context.Context, order entity.Order, leaseDeadline time.Time) error {
+	for {
+		err := uc.processOrder(ctx, order)
+		pause, ok := application.RetryAfter(err)
+		if !ok {
+			return err
+		}
+		pause = max(pause, minBackOffPause)
+		if !uc.clock.Now().Add(pause).Before(leaseDeadline) {
+			return err
+		}
+
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
 func (uc *ProcessAccrual) processOrder(ctx context.Context, order entity.Order) error {
 	info, err := uc.accrualClient.GetOrderAccrual(ctx, order.Number.String())
 	if err != nil {
 		return err
 	}
 
-	if info == nil {
-		// Order not registered in accrual system yet — skip.
-		// TODO: add max attempts or TTL to avoid polling stale orders forever.
-		return nil
-	}
-
 	now := uc.clock.Now()
 
-	switch info.Status {
-	case "PROCESSING", "REGISTERED":
-		order.MarkProcessing()
-		return uc.orderWriter.Update(ctx, &order)
-
-	case "INVALID":
-		order.MarkInvalid(now)
-		return uc.orderWriter.Update(ctx, &order)
-
-	case "PROCESSED":
-		accrual := vo.Points(0)
-		if info.Accrual != nil {
-			accrual = vo.Points(*info.Accrual)
+	if info == nil {
+		// Order not registered in accrual system yet: back off exponentially
+		// and give up once the poll policy is exhausted.
+		order.RecordUnansweredPoll(uc.pollPolicy, now)
+		if order.PollExpired(uc.pollPolicy, now) {
+			trigger := entity.Trigger{Source: entity.StatusSourcePollPolicy}
+			if err := order.MarkInvalid(entity.InvalidReasonPollExpired, trigger, now); err != nil {
+				return err
+			}
 		}
-
-		return application.WithOptimisticRetry(uc.optimisticRetries, func() error {
-			return uc.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
-				order.MarkProcessed(accrual, now)
-				if err := uc.orderWriter.Update(ctx, &order); err != nil {
-					return err
-				}
-
-				if info.Accrual == nil {
-					return nil
-				}
-
-				return uc.balanceGateway.ApplyAccrual(ctx, order.UserID, accrual, now)
-			})
-		})
+		return uc.orderWriter.Update(ctx, &order)
 	}
 
-	return nil
+	trigger := entity.Trigger{Source: entity.StatusSourceAccrualPoll, RawStatus: info.Status}
+	return uc.transition.apply(ctx, order, *info, trigger, now)
 }
@@ -21,23 +21,59 @@ import (
 
 var fixedTime = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
 
+const (
+	testLeaseOwner = "instance-1"
+	testLeaseTTL   = time.Minute
+)
+
+var testPollPolicy = vo.PollPolicy{
+	MaxAttempts: 3,
+	TTL:         24 * time.Hour,
+	BaseDelay:   time.Second,
+	MaxDelay:    time.Minute,
+}
+
 type stubBalanceGateway struct {
-	apply func(ctx context.Context, userID vo.UserID, amount vo.Points, processedAt time.Time) error
+	apply func(
+		ctx context.Context,
+		userID vo.UserID,
+		orderNumber vo.OrderNumber,
+		amount vo.Points,
+		processedAt time.Time,
+	) error
 }
 
 func (s *stubBalanceGateway) ApplyAccrual(
 	ctx context.Context,
 	userID vo.UserID,
+	orderNumber vo.OrderNumber,
 	amount vo.Points,
 	processedAt time.Time,
 ) error {
 	if s.apply != nil {
-		return s.apply(ctx, userID, amount, processedAt)
+		return s.apply(ctx, userID, orderNumber, amount, processedAt)
 	}
 	return nil
 }
 
-// ordersIter builds an iter.Seq2 from a slice — handy for mocking StreamByStatuses.
+// stubCampaignReader reports no active campaigns unless active is set.
+type stubCampaignReader struct {
+	active []entity.Campaign
+}
+
+func (s *stubCampaignReader) FindByID(context.Context, int64) (*entity.Campaign, error) {
+	return nil, application.ErrNotFound
+}
+
+func (s *stubCampaignReader) List(context.Context) ([]entity.Campaign, error) {
+	return s.active, nil
+}
+
+func (s *stubCampaignReader) ListActive(context.Context, time.Time) ([]entity.Campaign, error) {
+	return s.active, nil
+}
+
+// ordersIter builds an iter.Seq2 from a slice — handy for mocking ClaimByStatuses.
 func ordersIter(orders ...entity.Order) iter.Seq2[entity.Order, error] {
 	return func(yield func(entity.Order, error) bool) {
 		for _, o := range orders {
@@ -51,7 +87,6 @@ func ordersIter(orders ...entity.Order) iter.Seq2[entity.Order, error] {
 func newTestProcessAccrual(
 	ctrl *gomock.Controller,
 ) (
-	*ordersportmocks.MockOrderReader,
 	*ordersportmocks.MockOrderWriter,
 	*stubBalanceGateway,
 	*ordersportmocks.MockAccrualClient,
@@ -60,7 +95,6 @@ func newTestProcessAccrual(
 	*appmocks.MockLogger,
 	port.BackgroundRunner,
 ) {
-	orderReader := ordersportmocks.NewMockOrderReader(ctrl)
 	orderWriter := ordersportmocks.NewMockOrderWriter(ctrl)
 	balanceGateway := &stubBalanceGateway{}
 	accrualClient := ordersportmocks.NewMockAccrualClient(ctrl)
@@ -68,16 +102,20 @@ func newTestProcessAccrual(
 	clk := appmocks.NewMockClock(ctrl)
 	logger := appmocks.NewMockLogger(ctrl)
 
-	uc := NewProcessAccrual(orderReader, orderWriter, balanceGateway, accrualClient, transactor, clk, logger, 50, 5, 3)
-	return orderReader, orderWriter, balanceGateway, accrualClient, transactor, clk, logger, uc
+	uc := NewProcessAccrual(
+		ordersportmocks.NewMockOrderReader(ctrl), orderWriter, &stubCampaignReader{}, balanceGateway, accrualClient, transactor, clk, logger,
+		50, 5, 3, testPollPolicy, testLeaseOwner, testLeaseTTL,
+	)
+	return orderWriter, balanceGateway, accrualClient, transactor, clk, logger, uc
 }
 
 func TestProcessAccrual_Run(t *testing.T) {
 	t.Run("empty batch", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
-		orderReader, _, _, _, _, _, _, uc := newTestProcessAccrual(ctrl)
+		orderWriter, _, _, _, clk, _, uc := newTestProcessAccrual(ctrl)
 
-		orderReader.EXPECT().StreamByStatuses(gomock.Any(), gomock.Any(), 50).Return(ordersIter())
+		clk.EXPECT().Now().Return(fixedTime)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter())
 
 		processed, err := uc.Run(context.Background())
 
@@ -87,25 +125,32 @@ func TestProcessAccrual_Run(t *testing.T) {
 
 	t.Run("order processed with accrual", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
-		orderReader, orderWriter, balanceGateway, accrualClient, transactor, clk, _, uc := newTestProcessAccrual(ctrl)
+		orderWriter, balanceGateway, accrualClient, transactor, clk, _, uc := newTestProcessAccrual(ctrl)
 
-		accrual := float64(700)
+		accrual := vo.Points(70000)
 		order := entity.Order{Number: "12345678903", UserID: 1, Status: entity.OrderStatusNew}
 
-		orderReader.EXPECT().StreamByStatuses(gomock.Any(), gomock.Any(), 50).Return(ordersIter(order))
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
 		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(&dto.AccrualOrderInfo{
 			Status: "PROCESSED", Accrual: &accrual,
 		}, nil)
-		clk.EXPECT().Now().Return(fixedTime)
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
 		transactor.EXPECT().RunInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(ctx context.Context, fn func(context.Context) error) error {
 				return fn(ctx)
 			},
 		)
 		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
-		balanceGateway.apply = func(_ context.Context, userID vo.UserID, amount vo.Points, processedAt time.Time) error {
+		balanceGateway.apply = func(
+			_ context.Context,
+			userID vo.UserID,
+			orderNumber vo.OrderNumber,
+			amount vo.Points,
+			processedAt time.Time,
+		) error {
 			assert.Equal(t, vo.UserID(1), userID)
-			assert.Equal(t, vo.Points(accrual), amount)
+			assert.Equal(t, vo.OrderNumber("12345678903"), orderNumber)
+			assert.Equal(t, accrual, amount)
 			assert.Equal(t, fixedTime, processedAt)
 			return nil
 		}
@@ -118,16 +163,27 @@ func TestProcessAccrual_Run(t *testing.T) {
 
 	t.Run("order invalid", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
-		orderReader, orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
 
 		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
 
-		orderReader.EXPECT().StreamByStatuses(gomock.Any(), gomock.Any(), 50).Return(ordersIter(order))
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
 		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(&dto.AccrualOrderInfo{
 			Status: "INVALID",
 		}, nil)
-		clk.EXPECT().Now().Return(fixedTime)
-		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, []entity.StatusChange{{
+					From:      entity.OrderStatusNew,
+					To:        entity.OrderStatusInvalid,
+					Source:    entity.StatusSourceAccrualPoll,
+					RawStatus: "INVALID",
+					At:        fixedTime,
+				}}, o.Changes)
+				return nil
+			},
+		)
 
 		processed, err := uc.Run(context.Background())
 
@@ -137,15 +193,15 @@ func TestProcessAccrual_Run(t *testing.T) {
 
 	t.Run("order still processing", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
-		orderReader, orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
 
 		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
 
-		orderReader.EXPECT().StreamByStatuses(gomock.Any(), gomock.Any(), 50).Return(ordersIter(order))
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
 		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(&dto.AccrualOrderInfo{
 			Status: "PROCESSING",
 		}, nil)
-		clk.EXPECT().Now().Return(fixedTime)
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
 		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 
 		processed, err := uc.Run(context.Background())
@@ -154,14 +210,26 @@ func TestProcessAccrual_Run(t *testing.T) {
 		assert.Equal(t, 1, processed)
 	})
 
-	t.Run("order not registered in accrual", func(t *testing.T) {
+	t.Run("order not registered in accrual backs off", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
-		orderReader, _, _, accrualClient, _, _, _, uc := newTestProcessAccrual(ctrl)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
 
-		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
+		order := entity.Order{
+			Number: "12345678903", Status: entity.OrderStatusNew,
+			UploadedAt: fixedTime.Add(-time.Hour), PollAttempts: 1,
+		}
 
-		orderReader.EXPECT().StreamByStatuses(gomock.Any(), gomock.Any(), 50).Return(ordersIter(order))
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
 		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(nil, nil)
+		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, entity.OrderStatusNew, o.Status)
+				assert.Equal(t, 2, o.PollAttempts)
+				assert.Equal(t, fixedTime.Add(2*time.Second), o.NextPollAt)
+				return nil
+			},
+		)
 
 		processed, err := uc.Run(context.Background())
 
@@ -169,14 +237,67 @@ func TestProcessAccrual_Run(t *testing.T) {
 		assert.Equal(t, 1, processed)
 	})
 
-	t.Run("rate limit propagated", func(t *testing.T) {
+	t.Run("order not registered in accrual expires after max attempts", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
-		orderReader, _, _, accrualClient, _, _, _, uc := newTestProcessAccrual(ctrl)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
+
+		order := entity.Order{
+			Number: "12345678903", Status: entity.OrderStatusNew,
+			UploadedAt: fixedTime.Add(-time.Hour), PollAttempts: 2,
+		}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
+		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(nil, nil)
+		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, entity.OrderStatusInvalid, o.Status)
+				assert.Equal(t, entity.InvalidReasonPollExpired, o.InvalidReason)
+				return nil
+			},
+		)
+
+		processed, err := uc.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+	})
+
+	t.Run("order not registered in accrual expires after ttl", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
+
+		order := entity.Order{
+			Number: "12345678903", Status: entity.OrderStatusNew,
+			UploadedAt: fixedTime.Add(-25 * time.Hour),
+		}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
+		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(nil, nil)
+		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, entity.OrderStatusInvalid, o.Status)
+				assert.Equal(t, entity.InvalidReasonPollExpired, o.InvalidReason)
+				return nil
+			},
+		)
+
+		processed, err := uc.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+	})
+
+	t.Run("rate limit outlasting lease propagated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
 
 		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
 		rlErr := &application.ErrRateLimit{RetryAfter: 60 * time.Second}
 
-		orderReader.EXPECT().StreamByStatuses(gomock.Any(), gomock.Any(), 50).Return(ordersIter(order))
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
 		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(nil, rlErr)
 
 		processed, err := uc.Run(context.Background())
@@ -185,13 +306,125 @@ func TestProcessAccrual_Run(t *testing.T) {
 		assert.Equal(t, 0, processed)
 	})
 
+	t.Run("rate limited order retried after pause", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
+
+		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(3)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
+		gomock.InOrder(
+			accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").
+				Return(nil, &application.ErrRateLimit{RetryAfter: time.Millisecond}),
+			accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").
+				Return(&dto.AccrualOrderInfo{Status: "PROCESSING"}, nil),
+		)
+		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, o *entity.Order) error {
+				assert.Equal(t, entity.OrderStatusProcessing, o.Status)
+				return nil
+			},
+		)
+
+		processed, err := uc.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+	})
+
+	t.Run("retry-after in the past still pauses", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
+
+		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(3)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
+		gomock.InOrder(
+			accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").
+				Return(nil, &application.ErrRateLimit{RetryAfter: 0}),
+			accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").
+				Return(&dto.AccrualOrderInfo{Status: "PROCESSING"}, nil),
+		)
+		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		start := time.Now()
+		processed, err := uc.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		assert.GreaterOrEqual(t, time.Since(start), minBackOffPause)
+	})
+
+	t.Run("open circuit pauses and retries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderWriter, _, accrualClient, _, clk, _, uc := newTestProcessAccrual(ctrl)
+
+		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(3)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
+		gomock.InOrder(
+			accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").
+				Return(nil, &application.ErrUnavailable{RetryAfter: time.Millisecond}),
+			accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").
+				Return(&dto.AccrualOrderInfo{Status: "INVALID"}, nil),
+		)
+		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		processed, err := uc.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+	})
+
+	t.Run("final order is not downgraded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderWriter, _, accrualClient, _, clk, logger, uc := newTestProcessAccrual(ctrl)
+
+		accrual := vo.Points(100)
+		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusProcessed, Accrual: &accrual}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
+		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(&dto.AccrualOrderInfo{Status: "PROCESSING"}, nil)
+		logger.EXPECT().Warn("failed to process order accrual", gomock.Any()).Do(func(_ string, args ...any) {
+			assert.ErrorIs(t, args[3].(error), entity.ErrIllegalTransition)
+		})
+
+		processed, err := uc.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, processed)
+	})
+
+	t.Run("order changed concurrently skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderWriter, _, accrualClient, _, clk, logger, uc := newTestProcessAccrual(ctrl)
+
+		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
+
+		clk.EXPECT().Now().Return(fixedTime).Times(2)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
+		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(&dto.AccrualOrderInfo{Status: "INVALID"}, nil)
+		orderWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Return(application.ErrConflict)
+		logger.EXPECT().Debug("order changed concurrently, skipped", gomock.Any())
+
+		processed, err := uc.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, processed)
+	})
+
 	t.Run("accrual client error logged and skipped", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
-		orderReader, _, _, accrualClient, _, _, logger, uc := newTestProcessAccrual(ctrl)
+		orderWriter, _, accrualClient, _, clk, logger, uc := newTestProcessAccrual(ctrl)
 
 		order := entity.Order{Number: "12345678903", Status: entity.OrderStatusNew}
 
-		orderReader.EXPECT().StreamByStatuses(gomock.Any(), gomock.Any(), 50).Return(ordersIter(order))
+		clk.EXPECT().Now().Return(fixedTime)
+		orderWriter.EXPECT().ClaimByStatuses(gomock.Any(), gomock.Any(), testLeaseOwner, fixedTime, testLeaseTTL, 50).Return(ordersIter(order))
 		accrualClient.EXPECT().GetOrderAccrual(gomock.Any(), "12345678903").Return(nil, errors.New("timeout"))
 		logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
 
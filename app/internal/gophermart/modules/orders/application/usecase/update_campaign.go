@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	appport "gophermart/internal/gophermart/application/port"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/application/port"
+)
+
+// UpdateCampaign replaces the settings of a bonus campaign.
+type UpdateCampaign struct {
+	campaignReader port.CampaignReader
+	campaignWriter port.CampaignWriter
+	clock          appport.Clock
+}
+
+// NewUpdateCampaign returns the update campaign use case.
+func NewUpdateCampaign(
+	campaignReader port.CampaignReader,
+	campaignWriter port.CampaignWriter,
+	clock appport.Clock,
+) appport.UseCase[dto.UpdateCampaignInput, dto.CampaignOutput] {
+	return &UpdateCampaign{campaignReader: campaignReader, campaignWriter: campaignWriter, clock: clock}
+}
+
+// Execute overwrites the campaign with the input. Bonuses already credited are not recalculated.
+//
+// Errors:
+//   - application.ErrNotFound — campaign does not exist
+//   - application.ErrInvalidCampaign — name, period or rule is invalid; wraps the domain reason
+func (uc *UpdateCampaign) Execute(ctx context.Context, in dto.UpdateCampaignInput) (dto.CampaignOutput, error) {
+	c, err := uc.campaignReader.FindByID(ctx, in.ID)
+	if err != nil {
+		return dto.CampaignOutput{}, err
+	}
+
+	if err := applyCampaignInput(c, in.CampaignInput, uc.clock.Now()); err != nil {
+		return dto.CampaignOutput{}, err
+	}
+
+	if err := uc.campaignWriter.Update(ctx, c); err != nil {
+		return dto.CampaignOutput{}, err
+	}
+
+	return newCampaignOutput(*c), nil
+}
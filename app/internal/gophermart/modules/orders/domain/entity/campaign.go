@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+// CampaignKind selects how a campaign raises the order accrual.
+type CampaignKind string
+
+const (
+	// CampaignKindMultiplier scales the accrual reported by the accrual system.
+	CampaignKindMultiplier CampaignKind = "MULTIPLIER"
+	// CampaignKindBonus adds a fixed amount of points.
+	CampaignKindBonus CampaignKind = "BONUS"
+)
+
+var (
+	// ErrCampaignNameRequired — campaign name is empty.
+	ErrCampaignNameRequired = errors.New("campaign name is required")
+	// ErrCampaignPeriod — campaign ends at or before its start.
+	ErrCampaignPeriod = errors.New("campaign must end after it starts")
+	// ErrCampaignRule — unknown kind, or the multiplier or bonus does not fit the kind.
+	ErrCampaignRule = errors.New("campaign rule does not match its kind")
+)
+
+// Campaign is a time-boxed marketing rule that raises the points credited for processed orders.
+type Campaign struct {
+	ID   int64
+	Name string
+	Kind CampaignKind
+	// Multiplier scales the base accrual of a MULTIPLIER campaign; it is above 1 and at most vo.MaxMultiplier.
+	Multiplier vo.Multiplier
+	// Bonus is added to the accrual by a BONUS campaign.
+	Bonus vo.Points
+	// FirstOrderOnly limits the campaign to the first processed order of a user.
+	FirstOrderOnly bool
+	// StartsAt (inclusive) and EndsAt (exclusive) bound the processing time the campaign applies to.
+	StartsAt  time.Time
+	EndsAt    time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks that the campaign is named, has a non-empty period and a rule of its kind.
+func (c *Campaign) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrCampaignNameRequired
+	}
+	if !c.EndsAt.After(c.StartsAt) {
+		return ErrCampaignPeriod
+	}
+	switch c.Kind {
+	case CampaignKindMultiplier:
+		if c.Multiplier <= 100 || c.Multiplier > vo.MaxMultiplier || c.Bonus != 0 {
+			return ErrCampaignRule
+		}
+	case CampaignKindBonus:
+		if c.Bonus <= 0 || c.Multiplier != 0 {
+			return ErrCampaignRule
+		}
+	default:
+		return ErrCampaignRule
+	}
+	return nil
+}
+
+// ActiveAt reports whether the campaign applies to orders processed at t.
+func (c *Campaign) ActiveAt(t time.Time) bool {
+	return !t.Before(c.StartsAt) && t.Before(c.EndsAt)
+}
+
+// Extra returns the points the campaign adds on top of the base accrual.
+func (c *Campaign) Extra(base vo.Points) vo.Points {
+	switch c.Kind {
+	case CampaignKindMultiplier:
+		return c.Multiplier.Apply(base) - base
+	case CampaignKindBonus:
+		return c.Bonus
+	default:
+		return 0
+	}
+}
+
+// OrderBonus is the part of an order accrual credited by a campaign.
+type OrderBonus struct {
+	CampaignID   int64
+	CampaignName string
+	Amount       vo.Points
+}
+
+// EvaluateCampaigns returns the bonuses campaigns add to the base accrual of an order processed at t.
+// Every campaign is computed from the base accrual, so multipliers do not compound;
+// campaigns that add nothing are left out.
+func EvaluateCampaigns(campaigns []Campaign, base vo.Points, firstOrder bool, t time.Time) []OrderBonus {
+	var bonuses []OrderBonus
+	for _, c := range campaigns {
+		if !c.ActiveAt(t) || (c.FirstOrderOnly && !firstOrder) {
+			continue
+		}
+		if extra := c.Extra(base); extra > 0 {
+			bonuses = append(bonuses, OrderBonus{CampaignID: c.ID, CampaignName: c.Name, Amount: extra})
+		}
+	}
+	return bonuses
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+func TestCampaign_Validate(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := Campaign{Name: "Weekend", Kind: CampaignKindMultiplier, Multiplier: 200, StartsAt: start, EndsAt: start.Add(48 * time.Hour)}
+
+	tests := []struct {
+		name   string
+		modify func(c *Campaign)
+		want   error
+	}{
+		{"valid multiplier", func(*Campaign) {}, nil},
+		{"valid bonus", func(c *Campaign) { c.Kind, c.Multiplier, c.Bonus = CampaignKindBonus, 0, 5000 }, nil},
+		{"blank name", func(c *Campaign) { c.Name = " " }, ErrCampaignNameRequired},
+		{"empty period", func(c *Campaign) { c.EndsAt = c.StartsAt }, ErrCampaignPeriod},
+		{"multiplier not above one", func(c *Campaign) { c.Multiplier = 100 }, ErrCampaignRule},
+		{"multiplier at maximum", func(c *Campaign) { c.Multiplier = vo.MaxMultiplier }, nil},
+		{"multiplier above maximum", func(c *Campaign) { c.Multiplier = vo.MaxMultiplier + 1 }, ErrCampaignRule},
+		{"multiplier with bonus", func(c *Campaign) { c.Bonus = 100 }, ErrCampaignRule},
+		{"bonus without amount", func(c *Campaign) { c.Kind, c.Multiplier = CampaignKindBonus, 0 }, ErrCampaignRule},
+		{"unknown kind", func(c *Campaign) { c.Kind = "CASHBACK" }, ErrCampaignRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			assert.Equal(t, tt.want, c.Validate())
+		})
+	}
+}
+
+func TestEvaluateCampaigns(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+	campaigns := []Campaign{
+		{ID: 1, Name: "Double weekend", Kind: CampaignKindMultiplier, Multiplier: 200, StartsAt: start, EndsAt: end},
+		{ID: 2, Name: "First order", Kind: CampaignKindBonus, Bonus: 5000, FirstOrderOnly: true, StartsAt: start, EndsAt: end},
+		{ID: 3, Name: "Expired", Kind: CampaignKindBonus, Bonus: 100, StartsAt: start.Add(-time.Hour), EndsAt: start},
+	}
+
+	t.Run("first order gets every active campaign", func(t *testing.T) {
+		got := EvaluateCampaigns(campaigns, 12345, true, start)
+
+		assert.Equal(t, []OrderBonus{
+			{CampaignID: 1, CampaignName: "Double weekend", Amount: 12345},
+			{CampaignID: 2, CampaignName: "First order", Amount: 5000},
+		}, got)
+	})
+
+	t.Run("later order skips first order campaigns", func(t *testing.T) {
+		got := EvaluateCampaigns(campaigns, 100, false, end.Add(-time.Second))
+
+		assert.Equal(t, []OrderBonus{{CampaignID: 1, CampaignName: "Double weekend", Amount: 100}}, got)
+	})
+
+	t.Run("multiplier of zero accrual adds nothing", func(t *testing.T) {
+		assert.Empty(t, EvaluateCampaigns(campaigns[:1], 0, true, start))
+	})
+
+	t.Run("fractional multiplier rounds down", func(t *testing.T) {
+		c := []Campaign{{ID: 4, Name: "x1.5", Kind: CampaignKindMultiplier, Multiplier: 150, StartsAt: start, EndsAt: end}}
+
+		got := EvaluateCampaigns(c, 3, false, start)
+
+		assert.Equal(t, []OrderBonus{{CampaignID: 4, CampaignName: "x1.5", Amount: 1}}, got)
+	})
+
+	t.Run("campaign ended", func(t *testing.T) {
+		assert.Empty(t, EvaluateCampaigns(campaigns, 100, true, end))
+	})
+}
+
+func TestOrder_AddBonuses(t *testing.T) {
+	accrual := vo.Points(10000)
+	o := Order{Status: OrderStatusProcessed, Accrual: &accrual}
+
+	o.AddBonuses([]OrderBonus{{CampaignID: 1, Amount: 10000}, {CampaignID: 2, Amount: 5000}})
+
+	assert.Equal(t, vo.Points(25000), *o.Accrual)
+	assert.Len(t, o.Bonuses, 2)
+}
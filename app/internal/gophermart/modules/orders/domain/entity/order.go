@@ -16,41 +16,147 @@ const (
 	OrderStatusProcessed  OrderStatus = "PROCESSED"
 )
 
+// InvalidReason explains why an order ended up INVALID.
+type InvalidReason string
+
+const (
+	// InvalidReasonRejected — the accrual system reported the order as INVALID.
+	InvalidReasonRejected InvalidReason = "REJECTED"
+	// InvalidReasonPollExpired — the accrual system never registered the order within the poll policy.
+	InvalidReasonPollExpired InvalidReason = "POLL_EXPIRED"
+)
+
 // Order is orders module aggregate root.
 type Order struct {
-	Number      vo.OrderNumber
-	UserID      vo.UserID
-	Status      OrderStatus
-	Accrual     *vo.Points
-	UploadedAt  time.Time
-	ProcessedAt *time.Time
+	Number        vo.OrderNumber
+	UserID        vo.UserID
+	Status        OrderStatus
+	Accrual       *vo.Points
+	UploadedAt    time.Time
+	ProcessedAt   *time.Time
+	InvalidReason InvalidReason
+	PollAttempts  int
+	NextPollAt    time.Time
+	// Bonuses are the campaign parts of Accrual; Accrual includes them.
+	Bonuses []OrderBonus
+	// Changes are status changes recorded since the order was loaded, not yet persisted.
+	Changes []StatusChange
+	// Lease is the poll lease the order was claimed with; nil if it was loaded without a claim.
+	Lease *PollLease
 }
 
-// NewOrder creates a new order with NEW status.
+// PollLease identifies one claim of an order for polling. Updates of a claimed order are
+// rejected once the lease has passed to another claim.
+type PollLease struct {
+	Owner     string
+	ExpiresAt time.Time
+}
+
+// NewOrder creates a new order with NEW status, due for polling immediately.
 func NewOrder(number vo.OrderNumber, userID vo.UserID, now time.Time) *Order {
-	return &Order{
+	o := &Order{
 		Number:     number,
 		UserID:     userID,
-		Status:     OrderStatusNew,
 		UploadedAt: now,
+		NextPollAt: now,
 	}
+	// The empty status always moves to NEW.
+	_ = o.transition(OrderStatusNew, Trigger{Source: StatusSourceUpload}, now)
+	return o
 }
 
 // MarkProcessed transitions order to PROCESSED with accrual.
-func (o *Order) MarkProcessed(accrual vo.Points, now time.Time) {
-	o.Status = OrderStatusProcessed
+// Returns *TransitionError if the order is already final.
+func (o *Order) MarkProcessed(accrual vo.Points, trigger Trigger, now time.Time) error {
+	if err := o.transition(OrderStatusProcessed, trigger, now); err != nil {
+		return err
+	}
 	o.Accrual = &accrual
 	o.ProcessedAt = &now
+	return nil
 }
 
-// MarkInvalid transitions order to INVALID.
-func (o *Order) MarkInvalid(now time.Time) {
-	o.Status = OrderStatusInvalid
+// AddBonuses adds campaign bonuses to the accrual of a processed order.
+func (o *Order) AddBonuses(bonuses []OrderBonus) {
+	var total vo.Points
+	if o.Accrual != nil {
+		total = *o.Accrual
+	}
+	for _, b := range bonuses {
+		total += b.Amount
+	}
+	o.Accrual = &total
+	o.Bonuses = append(o.Bonuses, bonuses...)
+}
+
+// MarkInvalid transitions order to INVALID for the given reason.
+// Returns *TransitionError if the order is already final.
+func (o *Order) MarkInvalid(reason InvalidReason, trigger Trigger, now time.Time) error {
+	if err := o.transition(OrderStatusInvalid, trigger, now); err != nil {
+		return err
+	}
 	o.Accrual = nil
 	o.ProcessedAt = &now
+	o.InvalidReason = reason
+	return nil
 }
 
 // MarkProcessing transitions order to PROCESSING.
-func (o *Order) MarkProcessing() {
-	o.Status = OrderStatusProcessing
+// Returns *TransitionError if the order is already final.
+func (o *Order) MarkProcessing(trigger Trigger, now time.Time) error {
+	return o.transition(OrderStatusProcessing, trigger, now)
+}
+
+// Final reports whether the order reached a terminal status and needs no further accrual updates.
+func (o *Order) Final() bool {
+	return o.Status.Final()
+}
+
+// PersistedStatus returns the status the order had when it was loaded, before unsaved changes.
+// Repositories compare it with the stored status to reject updates based on a stale copy.
+func (o *Order) PersistedStatus() OrderStatus {
+	if len(o.Changes) > 0 {
+		return o.Changes[0].From
+	}
+	return o.Status
+}
+
+// transition checks the state machine, sets the status and records the change;
+// staying in the current non-final status is not a change.
+func (o *Order) transition(to OrderStatus, trigger Trigger, now time.Time) error {
+	if !CanTransition(o.Status, to) {
+		return &TransitionError{From: o.Status, To: to}
+	}
+	if o.Status == to {
+		return nil
+	}
+	o.Changes = append(o.Changes, StatusChange{
+		From:      o.Status,
+		To:        to,
+		Source:    trigger.Source,
+		RawStatus: trigger.RawStatus,
+		At:        now,
+	})
+	o.Status = to
+	return nil
+}
+
+// RecordUnansweredPoll counts a poll the accrual system did not answer (order not registered)
+// and defers the next poll by the policy backoff.
+func (o *Order) RecordUnansweredPoll(policy vo.PollPolicy, now time.Time) {
+	o.PollAttempts++
+	o.NextPollAt = now.Add(policy.Backoff(o.PollAttempts))
+}
+
+// PollExpired reports whether the order exhausted its poll budget.
+func (o *Order) PollExpired(policy vo.PollPolicy, now time.Time) bool {
+	return policy.Exhausted(o.PollAttempts, o.UploadedAt, now)
+}
+
+// DeferPoll moves the next poll to until if it is later, keeping polling only as a fallback
+// for orders whose status is pushed by the accrual system.
+func (o *Order) DeferPoll(until time.Time) {
+	if until.After(o.NextPollAt) {
+		o.NextPollAt = until
+	}
 }
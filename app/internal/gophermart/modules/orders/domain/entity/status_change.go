@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// StatusSource identifies what caused an order status change.
+type StatusSource string
+
+const (
+	// StatusSourceUpload — the user uploaded the order.
+	StatusSourceUpload StatusSource = "UPLOAD"
+	// StatusSourceAccrualPoll — the accrual system reported a status when polled.
+	StatusSourceAccrualPoll StatusSource = "ACCRUAL_POLL"
+	// StatusSourceWebhook — the accrual system pushed a status to the webhook.
+	StatusSourceWebhook StatusSource = "WEBHOOK"
+	// StatusSourcePollPolicy — the poll policy gave up on an order the accrual system never registered.
+	StatusSourcePollPolicy StatusSource = "POLL_POLICY"
+	// StatusSourceBackfill — reconstructed for orders uploaded before the timeline was recorded.
+	StatusSourceBackfill StatusSource = "BACKFILL"
+)
+
+// Trigger describes the cause of a status change.
+type Trigger struct {
+	Source StatusSource
+	// RawStatus is the status reported by the accrual system, if any.
+	RawStatus string
+}
+
+// StatusChange is a single entry of the order status timeline.
+type StatusChange struct {
+	// From is empty for the initial status.
+	From      OrderStatus
+	To        OrderStatus
+	Source    StatusSource
+	RawStatus string
+	At        time.Time
+}
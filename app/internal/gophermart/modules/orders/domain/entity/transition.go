@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIllegalTransition is matched by every TransitionError.
+var ErrIllegalTransition = errors.New("illegal order status transition")
+
+// TransitionError reports an order status change the state machine does not allow.
+type TransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("illegal order status transition %s -> %s", e.From, e.To)
+}
+
+// Unwrap lets errors.Is match ErrIllegalTransition.
+func (e *TransitionError) Unwrap() error {
+	return ErrIllegalTransition
+}
+
+// allowedTransitions is the order state machine; INVALID and PROCESSED are final.
+// The empty status is the state before the order is created.
+var allowedTransitions = map[OrderStatus][]OrderStatus{
+	"":                    {OrderStatusNew},
+	OrderStatusNew:        {OrderStatusProcessing, OrderStatusInvalid, OrderStatusProcessed},
+	OrderStatusProcessing: {OrderStatusInvalid, OrderStatusProcessed},
+}
+
+// CanTransition reports whether the state machine allows moving from one status to another.
+// Staying in a non-final status is allowed and is not a change; a final status can never be re-entered.
+func CanTransition(from, to OrderStatus) bool {
+	if from == to {
+		return from == OrderStatusNew || from == OrderStatusProcessing
+	}
+	for _, allowed := range allowedTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Final reports whether the status is terminal.
+func (s OrderStatus) Final() bool {
+	return s == OrderStatusInvalid || s == OrderStatusProcessed
+}
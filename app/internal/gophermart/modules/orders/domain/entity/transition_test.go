@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		want     bool
+	}{
+		{"", OrderStatusNew, true},
+		{"", OrderStatusProcessed, false},
+		{OrderStatusNew, OrderStatusNew, true},
+		{OrderStatusNew, OrderStatusProcessing, true},
+		{OrderStatusNew, OrderStatusInvalid, true},
+		{OrderStatusNew, OrderStatusProcessed, true},
+		{OrderStatusProcessing, OrderStatusProcessing, true},
+		{OrderStatusProcessing, OrderStatusNew, false},
+		{OrderStatusProcessing, OrderStatusInvalid, true},
+		{OrderStatusProcessing, OrderStatusProcessed, true},
+		{OrderStatusProcessed, OrderStatusProcessing, false},
+		{OrderStatusProcessed, OrderStatusProcessed, false},
+		{OrderStatusProcessed, OrderStatusInvalid, false},
+		{OrderStatusInvalid, OrderStatusProcessed, false},
+		{OrderStatusInvalid, OrderStatusInvalid, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestOrder_IllegalTransition(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	trigger := Trigger{Source: StatusSourceAccrualPoll}
+
+	o := Order{Number: "12345678903", Status: OrderStatusProcessed}
+	err := o.MarkProcessing(trigger, now)
+
+	var terr *TransitionError
+	require.ErrorAs(t, err, &terr)
+	assert.Equal(t, &TransitionError{From: OrderStatusProcessed, To: OrderStatusProcessing}, terr)
+	assert.ErrorIs(t, err, ErrIllegalTransition)
+	assert.Equal(t, OrderStatusProcessed, o.Status)
+	assert.Empty(t, o.Changes)
+
+	err = o.MarkProcessed(100, trigger, now)
+	assert.ErrorIs(t, err, ErrIllegalTransition, "an order is finalized only once")
+}
+
+func TestOrder_PersistedStatus(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	trigger := Trigger{Source: StatusSourceAccrualPoll}
+
+	o := Order{Number: "12345678903", Status: OrderStatusNew}
+	assert.Equal(t, OrderStatusNew, o.PersistedStatus())
+
+	require.NoError(t, o.MarkProcessing(trigger, now))
+	require.NoError(t, o.MarkProcessed(100, trigger, now))
+	assert.Equal(t, OrderStatusProcessed, o.Status)
+	assert.Equal(t, OrderStatusNew, o.PersistedStatus())
+}
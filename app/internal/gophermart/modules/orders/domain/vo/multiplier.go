@@ -0,0 +1,44 @@
+package vo
+
+import "gophermart/internal/pkg/money"
+
+// Multiplier scales an accrual, kept exactly as hundredths: 200 doubles the accrual.
+type Multiplier int64
+
+// MaxMultiplier is the largest accepted multiplier, ten times the accrual.
+// With it Apply stays within int64 for accruals up to math.MaxInt64/MaxMultiplier hundredths.
+const MaxMultiplier Multiplier = 1000
+
+// ParseMultiplier parses a decimal factor with at most two fractional digits, e.g. "1.5".
+func ParseMultiplier(s string) (Multiplier, error) {
+	v, err := money.Parse(s)
+	return Multiplier(v), err
+}
+
+// Apply returns p scaled by the multiplier, rounded down to hundredths of a point.
+func (m Multiplier) Apply(p Points) Points {
+	return Points(int64(p) * int64(m) / 100)
+}
+
+// String formats the multiplier as a decimal number, e.g. "1.5".
+func (m Multiplier) String() string {
+	return money.Format(int64(m))
+}
+
+// MarshalJSON encodes the multiplier as a JSON number, e.g. 1.5.
+func (m Multiplier) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number with at most two fractional digits.
+func (m *Multiplier) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	v, err := ParseMultiplier(string(b))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
@@ -1,4 +1,35 @@
 package vo
 
-// Points is loyalty amount used by orders context.
-type Points float64
+import "gophermart/internal/pkg/money"
+
+// Points is loyalty amount used by orders context, kept exactly as hundredths of a point.
+type Points int64
+
+// ParsePoints parses a decimal amount with at most two fractional digits, e.g. "729.98".
+func ParsePoints(s string) (Points, error) {
+	v, err := money.Parse(s)
+	return Points(v), err
+}
+
+// String formats points as a decimal number, e.g. "729.98".
+func (p Points) String() string {
+	return money.Format(int64(p))
+}
+
+// MarshalJSON encodes points as a JSON number, e.g. 729.98.
+func (p Points) MarshalJSON() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number with at most two fractional digits.
+func (p *Points) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	v, err := ParsePoints(string(b))
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
@@ -0,0 +1,47 @@
+package vo
+
+import "time"
+
+// PollPolicy bounds how long an order unknown to the accrual system keeps being polled.
+type PollPolicy struct {
+	// MaxAttempts is the number of unanswered polls after which polling gives up (0 — unlimited).
+	MaxAttempts int
+	// TTL is the maximum order age while polling continues (0 — unlimited).
+	TTL time.Duration
+	// BaseDelay is the delay after the first unanswered poll; it doubles on every next attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay (0 — uncapped).
+	MaxDelay time.Duration
+}
+
+// Backoff returns the delay before the next poll after the given number of unanswered attempts.
+func (p PollPolicy) Backoff(attempts int) time.Duration {
+	if attempts <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempts; i++ {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+		// Stop doubling before time.Duration overflows.
+		if delay > time.Duration(1<<62) {
+			break
+		}
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// Exhausted reports whether polling should stop for an order uploaded at since.
+func (p PollPolicy) Exhausted(attempts int, since, now time.Time) bool {
+	if p.MaxAttempts > 0 && attempts >= p.MaxAttempts {
+		return true
+	}
+	return p.TTL > 0 && now.Sub(since) >= p.TTL
+}
@@ -3,12 +3,18 @@ package factory
 import (
 	"gophermart/internal/gophermart/application/port"
 	"gophermart/internal/gophermart/modules/orders/application/dto"
-	"gophermart/internal/gophermart/modules/orders/domain/vo"
 )
 
 // UseCaseFactory provides orders use cases to the presentation layer.
 type UseCaseFactory interface {
 	UploadOrderUseCase() port.UseCase[dto.UploadOrderInput, struct{}]
-	ListOrdersUseCase() port.UseCase[vo.UserID, []dto.OrderOutput]
+	ListOrdersUseCase() port.UseCase[dto.ListOrdersInput, dto.OrderPageOutput]
+	GetOrderUseCase() port.UseCase[dto.GetOrderInput, dto.OrderDetailsOutput]
+	IngestAccrualUseCase() port.UseCase[dto.AccrualPushInput, struct{}]
 	ProcessAccrualUseCase() port.BackgroundRunner
+	CreateCampaignUseCase() port.UseCase[dto.CampaignInput, dto.CampaignOutput]
+	UpdateCampaignUseCase() port.UseCase[dto.UpdateCampaignInput, dto.CampaignOutput]
+	DeleteCampaignUseCase() port.UseCase[int64, struct{}]
+	GetCampaignUseCase() port.UseCase[int64, dto.CampaignOutput]
+	ListCampaignsUseCase() port.UseCase[struct{}, []dto.CampaignOutput]
 }
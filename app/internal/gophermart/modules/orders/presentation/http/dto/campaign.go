@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+)
+
+// CampaignRequest is the HTTP request body for creating or replacing a bonus campaign.
+// A MULTIPLIER campaign sets multiplier, a BONUS campaign sets bonus.
+type CampaignRequest struct {
+	Name           string        `json:"name" binding:"required"`
+	Kind           string        `json:"kind" binding:"required,oneof=MULTIPLIER BONUS"`
+	Multiplier     vo.Multiplier `json:"multiplier" binding:"gte=0"`
+	Bonus          vo.Points     `json:"bonus" binding:"gte=0"`
+	FirstOrderOnly bool          `json:"first_order_only"`
+	StartsAt       time.Time     `json:"starts_at" binding:"required"`
+	EndsAt         time.Time     `json:"ends_at" binding:"required"`
+}
+
+// CampaignResponse is the HTTP response body for a single bonus campaign.
+type CampaignResponse struct {
+	ID             int64         `json:"id"`
+	Name           string        `json:"name"`
+	Kind           string        `json:"kind"`
+	Multiplier     vo.Multiplier `json:"multiplier,omitempty"`
+	Bonus          vo.Points     `json:"bonus,omitempty"`
+	FirstOrderOnly bool          `json:"first_order_only"`
+	StartsAt       string        `json:"starts_at"`
+	EndsAt         string        `json:"ends_at"`
+	CreatedAt      string        `json:"created_at"`
+	UpdatedAt      string        `json:"updated_at"`
+}
@@ -1,9 +1,73 @@
 package dto
 
+import (
+	"encoding/json"
+
+	"gophermart/internal/gophermart/modules/orders/domain/vo"
+	"gophermart/internal/gophermart/presentation/http/pagination"
+	"gophermart/internal/pkg/money"
+)
+
+// ListOrdersQuery is the query string of the order listing.
+type ListOrdersQuery struct {
+	pagination.Query
+	Status []string `form:"status" binding:"omitempty,dive,oneof=NEW PROCESSING INVALID PROCESSED"`
+}
+
 // OrderResponse is the HTTP response body for a single order.
 type OrderResponse struct {
-	Number     string   `json:"number"`
-	Status     string   `json:"status"`
-	Accrual    *float64 `json:"accrual,omitempty"`
-	UploadedAt string   `json:"uploaded_at"`
+	Number     string          `json:"number"`
+	Status     string          `json:"status"`
+	Accrual    *vo.Points      `json:"accrual,omitempty"`
+	Bonuses    []BonusResponse `json:"bonuses,omitempty"`
+	UploadedAt string          `json:"uploaded_at"`
+}
+
+// BonusResponse is the HTTP response body for the part of an order accrual credited by a campaign.
+type BonusResponse struct {
+	CampaignID int64     `json:"campaign_id"`
+	Campaign   string    `json:"campaign"`
+	Amount     vo.Points `json:"amount"`
+}
+
+// StatusChangeResponse is the HTTP response body for a single status timeline entry.
+type StatusChangeResponse struct {
+	From      string `json:"from,omitempty"`
+	To        string `json:"to"`
+	Source    string `json:"source"`
+	RawStatus string `json:"raw_status,omitempty"`
+	At        string `json:"at"`
+}
+
+// OrderDetailsResponse is the HTTP response body for a single order with its status timeline.
+type OrderDetailsResponse struct {
+	OrderResponse
+	InvalidReason string                 `json:"invalid_reason,omitempty"`
+	ProcessedAt   string                 `json:"processed_at,omitempty"`
+	History       []StatusChangeResponse `json:"history"`
+}
+
+// AccrualPushRequest is the HTTP request body of an order status pushed by the accrual system.
+type AccrualPushRequest struct {
+	Order  string `json:"order" binding:"required"`
+	Status string `json:"status" binding:"required,oneof=REGISTERED PROCESSING INVALID PROCESSED"`
+	// Accrual is decoded as a raw number: the accrual system may send more than two decimals.
+	Accrual *json.Number `json:"accrual"`
+}
+
+// Points converts the pushed accrual to points the same way the polling client does,
+// truncating digits below a hundredth; a negative accrual is invalid.
+func (r AccrualPushRequest) Points() (*vo.Points, error) {
+	if r.Accrual == nil {
+		return nil, nil
+	}
+	v, err := money.ParseTruncated(r.Accrual.String())
+	if err != nil {
+		return nil, err
+	}
+	if v < 0 {
+		return nil, money.ErrInvalidAmount
+	}
+	p := vo.Points(v)
+	return &p, nil
 }
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	httpdto "gophermart/internal/gophermart/modules/orders/presentation/http/dto"
+)
+
+// CreateCampaign registers a bonus campaign.
+func (h *OrderHandler) CreateCampaign(c *gin.Context) {
+	var req httpdto.CampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	campaign, err := h.useCases.CreateCampaignUseCase().Execute(c.Request.Context(), newCampaignInput(req))
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidCampaign) {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Error("create campaign failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newCampaignResponse(campaign))
+}
+
+// ListCampaigns returns all bonus campaigns, latest start first.
+func (h *OrderHandler) ListCampaigns(c *gin.Context) {
+	campaigns, err := h.useCases.ListCampaignsUseCase().Execute(c.Request.Context(), struct{}{})
+	if err != nil {
+		h.log.Error("list campaigns failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]httpdto.CampaignResponse, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		resp = append(resp, newCampaignResponse(campaign))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCampaign returns a single bonus campaign.
+func (h *OrderHandler) GetCampaign(c *gin.Context) {
+	id, ok := campaignID(c)
+	if !ok {
+		return
+	}
+
+	campaign, err := h.useCases.GetCampaignUseCase().Execute(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, application.ErrNotFound) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		h.log.Error("get campaign failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, newCampaignResponse(campaign))
+}
+
+// UpdateCampaign replaces the settings of a bonus campaign.
+func (h *OrderHandler) UpdateCampaign(c *gin.Context) {
+	id, ok := campaignID(c)
+	if !ok {
+		return
+	}
+
+	var req httpdto.CampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	campaign, err := h.useCases.UpdateCampaignUseCase().Execute(
+		c.Request.Context(),
+		dto.UpdateCampaignInput{ID: id, CampaignInput: newCampaignInput(req)},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
+		case errors.Is(err, application.ErrInvalidCampaign):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			h.log.Error("update campaign failed", "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, newCampaignResponse(campaign))
+}
+
+// DeleteCampaign removes a bonus campaign; bonuses it already credited are kept.
+func (h *OrderHandler) DeleteCampaign(c *gin.Context) {
+	id, ok := campaignID(c)
+	if !ok {
+		return
+	}
+
+	if _, err := h.useCases.DeleteCampaignUseCase().Execute(c.Request.Context(), id); err != nil {
+		if errors.Is(err, application.ErrNotFound) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		h.log.Error("delete campaign failed", "error", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// campaignID parses the campaign ID path parameter and answers 400 if it is malformed.
+func campaignID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid campaign id"})
+		return 0, false
+	}
+	return id, true
+}
+
+func newCampaignInput(req httpdto.CampaignRequest) dto.CampaignInput {
+	return dto.CampaignInput{
+		Name:           req.Name,
+		Kind:           req.Kind,
+		Multiplier:     req.Multiplier,
+		Bonus:          req.Bonus,
+		FirstOrderOnly: req.FirstOrderOnly,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+	}
+}
+
+func newCampaignResponse(c dto.CampaignOutput) httpdto.CampaignResponse {
+	return httpdto.CampaignResponse{
+		ID:             c.ID,
+		Name:           c.Name,
+		Kind:           c.Kind,
+		Multiplier:     c.Multiplier,
+		Bonus:          c.Bonus,
+		FirstOrderOnly: c.FirstOrderOnly,
+		StartsAt:       c.StartsAt.Format(time.RFC3339),
+		EndsAt:         c.EndsAt.Format(time.RFC3339),
+		CreatedAt:      c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      c.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,199 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gophermart/internal/gophermart/application"
+	"gophermart/internal/gophermart/modules/orders/application/dto"
+	"gophermart/internal/gophermart/modules/orders/domain/entity"
+	"gophermart/internal/gophermart/presentation/http/middleware"
+)
+
+const testCampaignBody = `{"name":"Double weekend","kind":"MULTIPLIER","multiplier":2,
+	"starts_at":"2026-01-03T00:00:00Z","ends_at":"2026-01-05T00:00:00Z"}`
+
+var (
+	testCampaignStart = time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)
+	testCampaignEnd   = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+)
+
+func newAdminRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.AdminTokenHeader, testAdminToken)
+	return req
+}
+
+func TestOrderHandler_CreateCampaign_Success(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	spy := &spyUseCase[dto.CampaignInput, dto.CampaignOutput]{out: dto.CampaignOutput{
+		ID: 1, Name: "Double weekend", Kind: "MULTIPLIER", Multiplier: 200,
+		StartsAt: testCampaignStart, EndsAt: testCampaignEnd, CreatedAt: createdAt, UpdatedAt: createdAt,
+	}}
+	factory.createCampaignUC = spy
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newAdminRequest(http.MethodPost, "/api/admin/campaigns", testCampaignBody))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, dto.CampaignInput{
+		Name: "Double weekend", Kind: "MULTIPLIER", Multiplier: 200,
+		StartsAt: testCampaignStart, EndsAt: testCampaignEnd,
+	}, spy.in)
+	assert.JSONEq(t, `{"id":1,"name":"Double weekend","kind":"MULTIPLIER","multiplier":2,"first_order_only":false,
+		"starts_at":"2026-01-03T00:00:00Z","ends_at":"2026-01-05T00:00:00Z",
+		"created_at":"2026-01-01T12:00:00Z","updated_at":"2026-01-01T12:00:00Z"}`, w.Body.String())
+}
+
+func TestOrderHandler_CreateCampaign_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+		want int
+	}{
+		{name: "invalid campaign", err: fmt.Errorf("%w: %w", application.ErrInvalidCampaign, entity.ErrCampaignPeriod), want: http.StatusUnprocessableEntity},
+		{name: "unknown kind", body: `{"name":"x","kind":"CASHBACK","starts_at":"2026-01-03T00:00:00Z","ends_at":"2026-01-05T00:00:00Z"}`, want: http.StatusBadRequest},
+		{name: "missing period", body: `{"name":"x","kind":"BONUS","bonus":50}`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupOrderRouter(t)
+			factory.createCampaignUC = &stubUseCase[dto.CampaignInput, dto.CampaignOutput]{err: tt.err}
+
+			body := tt.body
+			if body == "" {
+				body = testCampaignBody
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newAdminRequest(http.MethodPost, "/api/admin/campaigns", body))
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestOrderHandler_Campaigns_AdminToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "missing token"},
+		{name: "wrong token", token: "guess"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupOrderRouter(t)
+			factory.listCampaignsUC = &stubUseCase[struct{}, []dto.CampaignOutput]{}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/campaigns", nil)
+			if tt.token != "" {
+				req.Header.Set(middleware.AdminTokenHeader, tt.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+func TestOrderHandler_ListCampaigns(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.listCampaignsUC = &stubUseCase[struct{}, []dto.CampaignOutput]{out: []dto.CampaignOutput{{
+		ID: 2, Name: "First order", Kind: "BONUS", Bonus: 5000, FirstOrderOnly: true,
+		StartsAt: testCampaignStart, EndsAt: testCampaignEnd, CreatedAt: testCampaignStart, UpdatedAt: testCampaignStart,
+	}}}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newAdminRequest(http.MethodGet, "/api/admin/campaigns", ""))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":2,"name":"First order","kind":"BONUS","bonus":50,"first_order_only":true,
+		"starts_at":"2026-01-03T00:00:00Z","ends_at":"2026-01-05T00:00:00Z",
+		"created_at":"2026-01-03T00:00:00Z","updated_at":"2026-01-03T00:00:00Z"}]`, w.Body.String())
+}
+
+func TestOrderHandler_GetCampaign(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		err    error
+		want   int
+	}{
+		{name: "found", target: "/api/admin/campaigns/1", want: http.StatusOK},
+		{name: "not found", target: "/api/admin/campaigns/1", err: application.ErrNotFound, want: http.StatusNotFound},
+		{name: "malformed id", target: "/api/admin/campaigns/abc", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, factory, router := setupOrderRouter(t)
+			factory.getCampaignUC = &stubUseCase[int64, dto.CampaignOutput]{out: dto.CampaignOutput{ID: 1}, err: tt.err}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newAdminRequest(http.MethodGet, tt.target, ""))
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestOrderHandler_UpdateCampaign(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		_, factory, router := setupOrderRouter(t)
+		spy := &spyUseCase[dto.UpdateCampaignInput, dto.CampaignOutput]{out: dto.CampaignOutput{ID: 4}}
+		factory.updateCampaignUC = spy
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newAdminRequest(http.MethodPut, "/api/admin/campaigns/4", testCampaignBody))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int64(4), spy.in.ID)
+		assert.Equal(t, "Double weekend", spy.in.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, factory, router := setupOrderRouter(t)
+		factory.updateCampaignUC = &stubUseCase[dto.UpdateCampaignInput, dto.CampaignOutput]{err: application.ErrNotFound}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newAdminRequest(http.MethodPut, "/api/admin/campaigns/4", testCampaignBody))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestOrderHandler_DeleteCampaign(t *testing.T) {
+	t.Run("deleted", func(t *testing.T) {
+		_, factory, router := setupOrderRouter(t)
+		spy := &spyUseCase[int64, struct{}]{}
+		factory.deleteCampaignUC = spy
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newAdminRequest(http.MethodDelete, "/api/admin/campaigns/5", ""))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, int64(5), spy.in)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, factory, router := setupOrderRouter(t)
+		factory.deleteCampaignUC = &stubUseCase[int64, struct{}]{err: application.ErrNotFound}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newAdminRequest(http.MethodDelete, "/api/admin/campaigns/5", ""))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
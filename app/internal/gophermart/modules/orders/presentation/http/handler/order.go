@@ -16,6 +16,7 @@ import (
 	"gophermart/internal/gophermart/modules/orders/presentation/factory"
 	httpdto "gophermart/internal/gophermart/modules/orders/presentation/http/dto"
 	"gophermart/internal/gophermart/presentation/http/httpcontext"
+	"gophermart/internal/gophermart/presentation/http/pagination"
 )
 
 // maxOrderNumberBytes is a safety limit for order number body size.
@@ -77,7 +78,8 @@ func (h *OrderHandler) Upload(c *gin.Context) {
 	c.Status(http.StatusAccepted)
 }
 
-// List returns all orders uploaded by the authenticated user.
+// List returns the orders uploaded by the authenticated user.
+// Optional query: status (repeatable), from/to (RFC 3339), cursor and limit; a next page is advertised in Link.
 func (h *OrderHandler) List(c *gin.Context) {
 	userID, ok := httpcontext.UserID(c)
 	if !ok {
@@ -85,27 +87,141 @@ func (h *OrderHandler) List(c *gin.Context) {
 		return
 	}
 
-	orders, err := h.useCases.ListOrdersUseCase().Execute(c.Request.Context(), vo.UserID(userID))
+	var query httpdto.ListOrdersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid query"})
+		return
+	}
+
+	page, err := h.useCases.ListOrdersUseCase().Execute(c.Request.Context(), dto.ListOrdersInput{
+		UserID:   vo.UserID(userID),
+		Statuses: query.Status,
+		From:     query.From,
+		To:       query.To,
+		Cursor:   query.Cursor,
+		Limit:    query.Limit,
+	})
 	if err != nil {
+		if errors.Is(err, application.ErrInvalidCursor) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+			return
+		}
 		h.log.Error("list orders failed", "error", err)
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
 
-	if len(orders) == 0 {
+	if len(page.Orders) == 0 {
 		c.Status(http.StatusNoContent)
 		return
 	}
 
-	resp := make([]httpdto.OrderResponse, 0, len(orders))
-	for _, o := range orders {
-		resp = append(resp, httpdto.OrderResponse{
-			Number:     o.Number,
-			Status:     o.Status,
-			Accrual:    o.Accrual,
-			UploadedAt: o.UploadedAt.Format(time.RFC3339),
+	pagination.SetNext(c, page.NextCursor)
+
+	resp := make([]httpdto.OrderResponse, 0, len(page.Orders))
+	for _, o := range page.Orders {
+		resp = append(resp, newOrderResponse(o))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Get returns a single order of the authenticated user with its status timeline.
+func (h *OrderHandler) Get(c *gin.Context) {
+	userID, ok := httpcontext.UserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	order, err := h.useCases.GetOrderUseCase().Execute(
+		c.Request.Context(),
+		dto.GetOrderInput{UserID: vo.UserID(userID), OrderNumber: c.Param("number")},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
+		case errors.Is(err, application.ErrInvalidOrderNumber):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid order number"})
+		default:
+			h.log.Error("get order failed", "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := httpdto.OrderDetailsResponse{
+		OrderResponse: newOrderResponse(order.OrderOutput),
+		InvalidReason: order.InvalidReason,
+		History:       make([]httpdto.StatusChangeResponse, 0, len(order.History)),
+	}
+	if order.ProcessedAt != nil {
+		resp.ProcessedAt = order.ProcessedAt.Format(time.RFC3339)
+	}
+	for _, ch := range order.History {
+		resp.History = append(resp.History, httpdto.StatusChangeResponse{
+			From:      ch.From,
+			To:        ch.To,
+			Source:    ch.Source,
+			RawStatus: ch.RawStatus,
+			At:        ch.At.Format(time.RFC3339),
 		})
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
+
+func newOrderResponse(o dto.OrderOutput) httpdto.OrderResponse {
+	resp := httpdto.OrderResponse{
+		Number:     o.Number,
+		Status:     o.Status,
+		Accrual:    o.Accrual,
+		UploadedAt: o.UploadedAt.Format(time.RFC3339),
+	}
+	for _, b := range o.Bonuses {
+		resp.Bonuses = append(resp.Bonuses, httpdto.BonusResponse{
+			CampaignID: b.CampaignID,
+			Campaign:   b.CampaignName,
+			Amount:     b.Amount,
+		})
+	}
+	return resp
+}
+
+// AccrualWebhook applies an order status pushed by the accrual system.
+// The request signature is verified by middleware before the handler runs.
+func (h *OrderHandler) AccrualWebhook(c *gin.Context) {
+	var req httpdto.AccrualPushRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	accrual, err := req.Points()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	_, err = h.useCases.IngestAccrualUseCase().Execute(
+		c.Request.Context(),
+		dto.AccrualPushInput{OrderNumber: req.Order, Status: req.Status, Accrual: accrual},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
+		case errors.Is(err, application.ErrConflict):
+			// Changed concurrently; a redelivery sees the new status.
+			c.AbortWithStatus(http.StatusConflict)
+		case errors.Is(err, application.ErrInvalidOrderNumber):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid order number"})
+		default:
+			h.log.Error("ingest accrual failed", "error", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -3,14 +3,20 @@ package handler_test
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"gophermart/internal/gophermart/application"
@@ -20,6 +26,12 @@ import (
 	"gophermart/internal/gophermart/modules/orders/domain/vo"
 	"gophermart/internal/gophermart/modules/orders/presentation/http/handler"
 	"gophermart/internal/gophermart/presentation/http/httpcontext"
+	"gophermart/internal/gophermart/presentation/http/middleware"
+)
+
+const (
+	testWebhookSecret = "webhook-secret"
+	testAdminToken    = "admin-token"
 )
 
 type stubUseCase[In, Out any] struct {
@@ -31,24 +43,70 @@ func (s *stubUseCase[In, Out]) Execute(_ context.Context, _ In) (Out, error) {
 	return s.out, s.err
 }
 
+type spyUseCase[In, Out any] struct {
+	in  In
+	out Out
+	err error
+}
+
+func (s *spyUseCase[In, Out]) Execute(_ context.Context, in In) (Out, error) {
+	s.in = in
+	return s.out, s.err
+}
+
 type testOrdersFactory struct {
 	uploadOrderUC    port.UseCase[dto.UploadOrderInput, struct{}]
-	listOrdersUC     port.UseCase[vo.UserID, []dto.OrderOutput]
+	listOrdersUC     port.UseCase[dto.ListOrdersInput, dto.OrderPageOutput]
+	getOrderUC       port.UseCase[dto.GetOrderInput, dto.OrderDetailsOutput]
+	ingestAccrualUC  port.UseCase[dto.AccrualPushInput, struct{}]
 	processAccrualUC port.BackgroundRunner
+	createCampaignUC port.UseCase[dto.CampaignInput, dto.CampaignOutput]
+	updateCampaignUC port.UseCase[dto.UpdateCampaignInput, dto.CampaignOutput]
+	deleteCampaignUC port.UseCase[int64, struct{}]
+	getCampaignUC    port.UseCase[int64, dto.CampaignOutput]
+	listCampaignsUC  port.UseCase[struct{}, []dto.CampaignOutput]
 }
 
 func (f *testOrdersFactory) UploadOrderUseCase() port.UseCase[dto.UploadOrderInput, struct{}] {
 	return f.uploadOrderUC
 }
 
-func (f *testOrdersFactory) ListOrdersUseCase() port.UseCase[vo.UserID, []dto.OrderOutput] {
+func (f *testOrdersFactory) ListOrdersUseCase() port.UseCase[dto.ListOrdersInput, dto.OrderPageOutput] {
 	return f.listOrdersUC
 }
 
+func (f *testOrdersFactory) GetOrderUseCase() port.UseCase[dto.GetOrderInput, dto.OrderDetailsOutput] {
+	return f.getOrderUC
+}
+
+func (f *testOrdersFactory) IngestAccrualUseCase() port.UseCase[dto.AccrualPushInput, struct{}] {
+	return f.ingestAccrualUC
+}
+
 func (f *testOrdersFactory) ProcessAccrualUseCase() port.BackgroundRunner {
 	return f.processAccrualUC
 }
 
+func (f *testOrdersFactory) CreateCampaignUseCase() port.UseCase[dto.CampaignInput, dto.CampaignOutput] {
+	return f.createCampaignUC
+}
+
+func (f *testOrdersFactory) UpdateCampaignUseCase() port.UseCase[dto.UpdateCampaignInput, dto.CampaignOutput] {
+	return f.updateCampaignUC
+}
+
+func (f *testOrdersFactory) DeleteCampaignUseCase() port.UseCase[int64, struct{}] {
+	return f.deleteCampaignUC
+}
+
+func (f *testOrdersFactory) GetCampaignUseCase() port.UseCase[int64, dto.CampaignOutput] {
+	return f.getCampaignUC
+}
+
+func (f *testOrdersFactory) ListCampaignsUseCase() port.UseCase[struct{}, []dto.CampaignOutput] {
+	return f.listCampaignsUC
+}
+
 func setupOrderRouter(t *testing.T) (*gomock.Controller, *testOrdersFactory, *gin.Engine) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
@@ -71,8 +129,17 @@ func setupOrderRouter(t *testing.T) (*gomock.Controller, *testOrdersFactory, *gi
 	protected := r.Group("", authSim(1))
 	protected.POST("/api/user/orders", h.Upload)
 	protected.GET("/api/user/orders", h.List)
+	protected.GET("/api/user/orders/:number", h.Get)
 
 	r.POST("/api/user/orders/noauth", h.Upload)
+	r.POST("/api/accrual/webhook", middleware.HMACSignature([]byte(testWebhookSecret), time.Now), h.AccrualWebhook)
+
+	admin := r.Group("/api/admin", middleware.AdminToken(testAdminToken))
+	admin.POST("/campaigns", h.CreateCampaign)
+	admin.GET("/campaigns", h.ListCampaigns)
+	admin.GET("/campaigns/:id", h.GetCampaign)
+	admin.PUT("/campaigns/:id", h.UpdateCampaign)
+	admin.DELETE("/campaigns/:id", h.DeleteCampaign)
 
 	return ctrl, factory, r
 }
@@ -139,12 +206,17 @@ func TestOrderHandler_Upload_Unauthorized(t *testing.T) {
 func TestOrderHandler_List_Success(t *testing.T) {
 	_, factory, router := setupOrderRouter(t)
 
-	accrual := 500.5
+	accrual := vo.Points(50050)
 	orders := []dto.OrderOutput{
-		{Number: "12345678903", Status: "PROCESSED", Accrual: &accrual, UploadedAt: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)},
+		{
+			Number: "12345678903", Status: "PROCESSED", Accrual: &accrual, UploadedAt: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC),
+			Bonuses: []dto.BonusOutput{{CampaignID: 3, CampaignName: "Double weekend", Amount: 25025}},
+		},
 		{Number: "99999999927", Status: "NEW", UploadedAt: time.Date(2026, 1, 21, 8, 0, 0, 0, time.UTC)},
 	}
-	factory.listOrdersUC = &stubUseCase[vo.UserID, []dto.OrderOutput]{out: orders}
+	factory.listOrdersUC = &stubUseCase[dto.ListOrdersInput, dto.OrderPageOutput]{
+		out: dto.OrderPageOutput{Orders: orders},
+	}
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/api/user/orders", nil)
@@ -158,11 +230,14 @@ func TestOrderHandler_List_Success(t *testing.T) {
 	assert.Len(t, resp, 2)
 	assert.Equal(t, "12345678903", resp[0]["number"])
 	assert.Equal(t, "PROCESSED", resp[0]["status"])
+	assert.Equal(t, 500.5, resp[0]["accrual"])
+	assert.Equal(t, []any{map[string]any{"campaign_id": 3.0, "campaign": "Double weekend", "amount": 250.25}}, resp[0]["bonuses"])
+	assert.NotContains(t, resp[1], "bonuses")
 }
 
 func TestOrderHandler_List_Empty(t *testing.T) {
 	_, factory, router := setupOrderRouter(t)
-	factory.listOrdersUC = &stubUseCase[vo.UserID, []dto.OrderOutput]{out: []dto.OrderOutput{}}
+	factory.listOrdersUC = &stubUseCase[dto.ListOrdersInput, dto.OrderPageOutput]{}
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/api/user/orders", nil)
@@ -170,3 +245,240 @@ func TestOrderHandler_List_Empty(t *testing.T) {
 
 	assert.Equal(t, http.StatusNoContent, w.Code)
 }
+
+func TestOrderHandler_List_Page(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	uc := &spyUseCase[dto.ListOrdersInput, dto.OrderPageOutput]{out: dto.OrderPageOutput{
+		Orders:     []dto.OrderOutput{{Number: "12345678903", Status: "NEW", UploadedAt: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)}},
+		NextCursor: "next-token",
+	}}
+	factory.listOrdersUC = uc
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/user/orders?status=NEW&status=PROCESSING&from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z&limit=1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, vo.UserID(1), uc.in.UserID)
+	assert.Equal(t, []string{"NEW", "PROCESSING"}, uc.in.Statuses)
+	assert.True(t, from.Equal(*uc.in.From))
+	assert.True(t, to.Equal(*uc.in.To))
+	assert.Equal(t, 1, uc.in.Limit)
+	assert.Equal(t, "next-token", w.Header().Get("X-Next-Cursor"))
+	assert.Equal(t,
+		`</api/user/orders?cursor=next-token&from=2026-01-01T00%3A00%3A00Z&limit=1&status=NEW&status=PROCESSING&to=2026-02-01T00%3A00%3A00Z>; rel="next"`,
+		w.Header().Get("Link"))
+}
+
+func TestOrderHandler_List_InvalidQuery(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.listOrdersUC = &stubUseCase[dto.ListOrdersInput, dto.OrderPageOutput]{}
+
+	for _, query := range []string{"status=DONE", "limit=-1", "limit=101", "from=yesterday"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/user/orders?"+query, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestOrderHandler_List_InvalidCursor(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.listOrdersUC = &stubUseCase[dto.ListOrdersInput, dto.OrderPageOutput]{err: application.ErrInvalidCursor}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/orders?cursor=garbage", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestOrderHandler_Get_Success(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+
+	uploadedAt := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+	processedAt := uploadedAt.Add(time.Hour)
+	accrual := vo.Points(50050)
+	factory.getOrderUC = &stubUseCase[dto.GetOrderInput, dto.OrderDetailsOutput]{out: dto.OrderDetailsOutput{
+		OrderOutput: dto.OrderOutput{Number: "12345678903", Status: "PROCESSED", Accrual: &accrual, UploadedAt: uploadedAt},
+		ProcessedAt: &processedAt,
+		History: []dto.StatusChangeOutput{
+			{To: "NEW", Source: "UPLOAD", At: uploadedAt},
+			{From: "NEW", To: "PROCESSED", Source: "ACCRUAL_POLL", RawStatus: "PROCESSED", At: processedAt},
+		},
+	}}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/orders/12345678903", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"number": "12345678903",
+		"status": "PROCESSED",
+		"accrual": 500.5,
+		"uploaded_at": "2026-01-20T12:00:00Z",
+		"processed_at": "2026-01-20T13:00:00Z",
+		"history": [
+			{"to": "NEW", "source": "UPLOAD", "at": "2026-01-20T12:00:00Z"},
+			{"from": "NEW", "to": "PROCESSED", "source": "ACCRUAL_POLL", "raw_status": "PROCESSED", "at": "2026-01-20T13:00:00Z"}
+		]
+	}`, w.Body.String())
+}
+
+func TestOrderHandler_Get_NotFound(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.getOrderUC = &stubUseCase[dto.GetOrderInput, dto.OrderDetailsOutput]{err: application.ErrNotFound}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/orders/12345678903", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestOrderHandler_Get_InvalidNumber(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.getOrderUC = &stubUseCase[dto.GetOrderInput, dto.OrderDetailsOutput]{err: application.ErrInvalidOrderNumber}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/user/orders/123", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func newWebhookRequest(body, secret string) *http.Request {
+	return newWebhookRequestAt(body, secret, time.Now())
+}
+
+func newWebhookRequestAt(body, secret string, signedAt time.Time) *http.Request {
+	ts := strconv.FormatInt(signedAt.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "." + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/accrual/webhook", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.SignatureTimestampHeader, ts)
+	req.Header.Set(middleware.SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestOrderHandler_AccrualWebhook_OK(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.ingestAccrualUC = &stubUseCase[dto.AccrualPushInput, struct{}]{}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(`{"order":"12345678903","status":"PROCESSED","accrual":500.5}`, testWebhookSecret))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestOrderHandler_AccrualWebhook_TruncatesAccrual(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	spy := &spyUseCase[dto.AccrualPushInput, struct{}]{}
+	factory.ingestAccrualUC = spy
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(`{"order":"12345678903","status":"PROCESSED","accrual":729.989}`, testWebhookSecret))
+
+	assert.Equal(t, http.StatusOK, w.Code, "pushed amounts are parsed like polled ones")
+	require.NotNil(t, spy.in.Accrual)
+	assert.Equal(t, vo.Points(72998), *spy.in.Accrual)
+}
+
+func TestOrderHandler_AccrualWebhook_NegativeAccrual(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.ingestAccrualUC = &stubUseCase[dto.AccrualPushInput, struct{}]{}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(`{"order":"12345678903","status":"PROCESSED","accrual":-1}`, testWebhookSecret))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestOrderHandler_AccrualWebhook_BadSignature(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.ingestAccrualUC = &stubUseCase[dto.AccrualPushInput, struct{}]{}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(`{"order":"12345678903","status":"PROCESSED","accrual":500.5}`, "other-secret"))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestOrderHandler_AccrualWebhook_StaleTimestamp(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.ingestAccrualUC = &stubUseCase[dto.AccrualPushInput, struct{}]{}
+
+	w := httptest.NewRecorder()
+	signedAt := time.Now().Add(-middleware.SignatureTolerance - time.Minute)
+	router.ServeHTTP(w, newWebhookRequestAt(`{"order":"12345678903","status":"PROCESSED","accrual":500.5}`, testWebhookSecret, signedAt))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "a captured push cannot be replayed later")
+}
+
+func TestOrderHandler_AccrualWebhook_BodyTooLarge(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.ingestAccrualUC = &stubUseCase[dto.AccrualPushInput, struct{}]{}
+
+	body := `{"order":"12345678903","status":"PROCESSED","pad":"` + strings.Repeat("x", 64<<10) + `"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(body, testWebhookSecret))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestOrderHandler_AccrualWebhook_MissingSignature(t *testing.T) {
+	_, _, router := setupOrderRouter(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/accrual/webhook",
+		bytes.NewBufferString(`{"order":"12345678903","status":"PROCESSED"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestOrderHandler_AccrualWebhook_UnknownStatus(t *testing.T) {
+	_, _, router := setupOrderRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(`{"order":"12345678903","status":"DONE"}`, testWebhookSecret))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestOrderHandler_AccrualWebhook_UnknownOrder(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.ingestAccrualUC = &stubUseCase[dto.AccrualPushInput, struct{}]{err: application.ErrNotFound}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(`{"order":"12345678903","status":"PROCESSING"}`, testWebhookSecret))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestOrderHandler_AccrualWebhook_InvalidNumber(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.ingestAccrualUC = &stubUseCase[dto.AccrualPushInput, struct{}]{err: application.ErrInvalidOrderNumber}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(`{"order":"123","status":"PROCESSING"}`, testWebhookSecret))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestOrderHandler_AccrualWebhook_Conflict(t *testing.T) {
+	_, factory, router := setupOrderRouter(t)
+	factory.ingestAccrualUC = &stubUseCase[dto.AccrualPushInput, struct{}]{err: application.ErrConflict}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest(`{"order":"12345678903","status":"PROCESSED","accrual":1}`, testWebhookSecret))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -17,4 +17,29 @@ func RegisterProtectedRoutes(
 	orderHandler := handler.NewOrderHandler(useCases, log)
 	protected.POST("/orders", orderHandler.Upload)
 	protected.GET("/orders", orderHandler.List)
+	protected.GET("/orders/:number", orderHandler.Get)
+}
+
+// RegisterWebhookRoutes registers endpoints the accrual system pushes order statuses to.
+func RegisterWebhookRoutes(
+	webhook *gin.RouterGroup,
+	useCases factory.UseCaseFactory,
+	log port.Logger,
+) {
+	orderHandler := handler.NewOrderHandler(useCases, log)
+	webhook.POST("/webhook", orderHandler.AccrualWebhook)
+}
+
+// RegisterAdminRoutes registers bonus campaign management endpoints.
+func RegisterAdminRoutes(
+	admin *gin.RouterGroup,
+	useCases factory.UseCaseFactory,
+	log port.Logger,
+) {
+	orderHandler := handler.NewOrderHandler(useCases, log)
+	admin.POST("/campaigns", orderHandler.CreateCampaign)
+	admin.GET("/campaigns", orderHandler.ListCampaigns)
+	admin.GET("/campaigns/:id", orderHandler.GetCampaign)
+	admin.PUT("/campaigns/:id", orderHandler.UpdateCampaign)
+	admin.DELETE("/campaigns/:id", orderHandler.DeleteCampaign)
 }
@@ -2,7 +2,6 @@ package worker
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"gophermart/internal/gophermart/application"
@@ -51,14 +50,14 @@ func (w *AccrualWorker) run(ctx context.Context) {
 func (w *AccrualWorker) poll(ctx context.Context) {
 	processed, err := w.processAccrual.Run(ctx)
 	if err != nil {
-		var rateLimit *application.ErrRateLimit
-		if errors.As(err, &rateLimit) {
-			w.log.Warn("accrual rate limited, backing off",
-				"retry_after", rateLimit.RetryAfter,
+		if retryAfter, ok := application.RetryAfter(err); ok {
+			w.log.Warn("accrual system asked to back off, pausing",
+				"retry_after", retryAfter,
+				"error", err,
 			)
 			select {
 			case <-ctx.Done():
-			case <-time.After(rateLimit.RetryAfter):
+			case <-time.After(retryAfter):
 			}
 			return
 		}
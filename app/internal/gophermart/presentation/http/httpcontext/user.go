@@ -5,9 +5,15 @@ import "github.com/gin-gonic/gin"
 // UserIDKey is the Gin context key for the authenticated user's ID.
 const UserIDKey = "user_id"
 
+// SessionIDKey is the Gin context key for the auth session of the request.
+const SessionIDKey = "session_id"
+
 // CookieName is the name of the auth cookie.
 const CookieName = "token"
 
+// RefreshCookieName is the name of the refresh token cookie.
+const RefreshCookieName = "refresh_token"
+
 // UserID returns the authenticated user's ID from Gin context.
 func UserID(c *gin.Context) (int64, bool) {
 	v, ok := c.Get(UserIDKey)
@@ -17,3 +23,13 @@ func UserID(c *gin.Context) (int64, bool) {
 	id, ok := v.(int64)
 	return id, ok
 }
+
+// SessionID returns the auth session ID of the request from Gin context.
+func SessionID(c *gin.Context) (int64, bool) {
+	v, ok := c.Get(SessionIDKey)
+	if !ok {
+		return 0, false
+	}
+	id, ok := v.(int64)
+	return id, ok
+}
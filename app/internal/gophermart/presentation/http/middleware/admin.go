@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminTokenHeader carries the shared secret of the admin API.
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminToken middleware rejects requests that do not present token in AdminTokenHeader.
+// The comparison takes constant time so the token cannot be guessed byte by byte.
+func AdminToken(token string) gin.HandlerFunc {
+	want := []byte(token)
+	return func(c *gin.Context) {
+		got := []byte(c.GetHeader(AdminTokenHeader))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
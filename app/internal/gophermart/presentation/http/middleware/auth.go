@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
+	"gophermart/internal/gophermart/application"
 	"gophermart/internal/gophermart/presentation/http/httpcontext"
 
 	"github.com/gin-gonic/gin"
@@ -14,9 +17,10 @@ type TokenExtractor interface {
 	Extract(c *gin.Context) (string, error)
 }
 
-// TokenValidator validates token and returns user ID.
+// TokenValidator validates token and returns the user and session IDs.
+// A rejected token is reported as application.ErrInvalidCredentials.
 type TokenValidator interface {
-	Validate(token string) (int64, error)
+	Validate(ctx context.Context, token string) (userID, sessionID int64, err error)
 }
 
 // BearerTokenExtractor extracts token from "token" Cookie or "Authorization: Bearer" header.
@@ -48,12 +52,17 @@ func Auth(extractor TokenExtractor, tokens TokenValidator) gin.HandlerFunc {
 			return
 		}
 
-		userID, err := tokens.Validate(token)
-		if err != nil {
+		userID, sessionID, err := tokens.Validate(c.Request.Context(), token)
+		if errors.Is(err, application.ErrInvalidCredentials) {
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
 		c.Set(httpcontext.UserIDKey, userID)
+		c.Set(httpcontext.SessionIDKey, sessionID)
 		c.Next()
 	}
 }
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"gophermart/internal/gophermart/application/port"
@@ -10,6 +12,10 @@ import (
 type GlobalRegistryParams struct {
 	Log    port.Logger
 	Tokens TokenValidator
+	// WebhookSecret signs accrual system pushes; empty disables the webhook.
+	WebhookSecret string
+	// AdminToken guards the admin API; empty disables it.
+	AdminToken string
 }
 
 // BuildAppMiddleware builds middleware for the whole HTTP app.
@@ -27,3 +33,17 @@ func BuildProtectedMiddleware(p GlobalRegistryParams) []gin.HandlerFunc {
 		Auth(nil, p.Tokens),
 	}
 }
+
+// BuildWebhookMiddleware builds middleware for inbound accrual system webhooks.
+func BuildWebhookMiddleware(p GlobalRegistryParams) []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		HMACSignature([]byte(p.WebhookSecret), time.Now),
+	}
+}
+
+// BuildAdminMiddleware builds middleware for admin API routes.
+func BuildAdminMiddleware(p GlobalRegistryParams) []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		AdminToken(p.AdminToken),
+	}
+}
@@ -0,0 +1,37 @@
+// Package pagination binds keyset pagination query parameters and advertises the next page.
+package pagination
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NextCursorHeader carries the cursor of the next page for clients that do not parse Link.
+const NextCursorHeader = "X-Next-Cursor"
+
+// Query holds the pagination and date range parameters shared by listing endpoints.
+// Without cursor and limit the whole listing is returned.
+type Query struct {
+	Cursor string     `form:"cursor"`
+	Limit  int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	From   *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// SetNext advertises the next page: a Link header with the request URL continued at cursor next
+// (other query parameters are kept) and the bare cursor in NextCursorHeader. No-op for the last page.
+func SetNext(c *gin.Context, next string) {
+	if next == "" {
+		return
+	}
+
+	u := *c.Request.URL
+	q := u.Query()
+	q.Set("cursor", next)
+	u.RawQuery = q.Encode()
+
+	c.Header("Link", fmt.Sprintf(`<%s>; rel="next"`, u.RequestURI()))
+	c.Header(NextCursorHeader, next)
+}
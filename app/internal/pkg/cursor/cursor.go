@@ -0,0 +1,37 @@
+// Package cursor implements opaque keyset pagination cursors.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalid is returned for a cursor that was not produced by Encode.
+var ErrInvalid = errors.New("invalid cursor")
+
+// Cursor is the keyset position of the last item of a page: its sort time and a unique tie-breaker key.
+type Cursor struct {
+	At  time.Time `json:"t"`
+	Key string    `json:"k"`
+}
+
+// Encode returns the cursor as a URL-safe token.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a token returned by Encode.
+func Decode(token string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalid
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.At.IsZero() || c.Key == "" {
+		return Cursor{}, ErrInvalid
+	}
+	return c, nil
+}
@@ -0,0 +1,41 @@
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	c := Cursor{At: time.Date(2026, 1, 1, 12, 0, 0, 123456000, time.UTC), Key: "12345678903"}
+
+	token := c.Encode()
+	assert.NotContains(t, token, "=")
+
+	got, err := Decode(token)
+	require.NoError(t, err)
+	assert.True(t, c.At.Equal(got.At))
+	assert.Equal(t, c.Key, got.Key)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!"},
+		{"not json", "bm90IGpzb24"},
+		{"missing key", Cursor{At: time.Now()}.Encode()},
+		{"missing time", Cursor{Key: "1"}.Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.token)
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
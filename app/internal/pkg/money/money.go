@@ -0,0 +1,76 @@
+// Package money implements exact two-decimal amounts stored as int64 minor units (hundredths).
+package money
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of minor units in one major unit.
+const Scale = 100
+
+// ErrInvalidAmount is returned for malformed numbers, more than two fractional digits or int64 overflow.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// numberRe matches the JSON number grammar.
+var numberRe = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+var bigScale = big.NewRat(Scale, 1)
+
+// Parse converts a decimal number (JSON number syntax) into minor units.
+// Amounts that cannot be represented exactly in hundredths are rejected rather than rounded.
+func Parse(s string) (int64, error) {
+	if !numberRe.MatchString(s) {
+		return 0, ErrInvalidAmount
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalidAmount
+	}
+	r.Mul(r, bigScale)
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return r.Num().Int64(), nil
+}
+
+// ParseTruncated converts a decimal number (JSON number syntax) into minor units,
+// dropping digits below a hundredth (truncation toward zero).
+// It is meant for amounts set by external systems, which may carry more precision than is stored.
+func ParseTruncated(s string) (int64, error) {
+	if !numberRe.MatchString(s) {
+		return 0, ErrInvalidAmount
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalidAmount
+	}
+	r.Mul(r, bigScale)
+	v := new(big.Int).Quo(r.Num(), r.Denom())
+	if !v.IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return v.Int64(), nil
+}
+
+// Format renders minor units as the shortest exact decimal, e.g. 72998 -> "729.98", 50050 -> "500.5", 4200 -> "42".
+func Format(v int64) string {
+	var sb strings.Builder
+	u := uint64(v)
+	if v < 0 {
+		sb.WriteByte('-')
+		u = -u
+	}
+	sb.WriteString(strconv.FormatUint(u/Scale, 10))
+	if frac := u % Scale; frac != 0 {
+		sb.WriteByte('.')
+		if frac < 10 {
+			sb.WriteByte('0')
+		}
+		sb.WriteString(strings.TrimRight(strconv.FormatUint(frac, 10), "0"))
+	}
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"integer", "42", 4200, false},
+		{"two decimals", "729.98", 72998, false},
+		{"one decimal", "500.5", 50050, false},
+		{"trailing zeros", "0.100", 10, false},
+		{"negative", "-3.2", -320, false},
+		{"exponent", "1.5e2", 15000, false},
+		{"sub-hundredth", "0.001", 0, true},
+		{"float drift", "0.30000000000000004", 0, true},
+		{"fraction syntax", "1/3", 0, true},
+		{"hex", "0x10", 0, true},
+		{"quoted", `"10"`, 0, true},
+		{"empty", "", 0, true},
+		{"overflow", "100000000000000000000", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAmount)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"two decimals", "729.98", 72998, false},
+		{"sub-hundredth dropped", "729.989", 72998, false},
+		{"below a hundredth", "0.001", 0, false},
+		{"negative toward zero", "-3.219", -321, false},
+		{"float drift", "0.30000000000000004", 30, false},
+		{"exponent", "1.2345e1", 1234, false},
+		{"quoted", `"10"`, 0, true},
+		{"overflow", "100000000000000000000", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTruncated(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAmount)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input int64
+		want  string
+	}{
+		{0, "0"},
+		{4200, "42"},
+		{72998, "729.98"},
+		{50050, "500.5"},
+		{1, "0.01"},
+		{-320, "-3.2"},
+		{math.MinInt64, "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(tt.input))
+		})
+	}
+}
+
+func TestParseFormatRoundTrip(t *testing.T) {
+	a, err := Parse("0.1")
+	assert.NoError(t, err)
+	b, err := Parse("0.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.3", Format(a+b))
+}
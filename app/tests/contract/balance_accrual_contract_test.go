@@ -36,19 +36,21 @@ func TestOrdersBalanceGatewayContract(t *testing.T) {
 	ctx := context.Background()
 	now := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC)
 	userID := ordersvo.UserID(101)
-	amount := ordersvo.Points(123.45)
+	orderNumber := ordersvo.OrderNumber("12345678903")
+	amount := ordersvo.Points(12345)
 
 	t.Run("maps input to provider API", func(t *testing.T) {
 		api := &accrualAPISpy{}
 		adapter := ordersintermodule.NewBalanceGatewayAdapter(api)
 
-		err := adapter.ApplyAccrual(ctx, userID, amount, now)
+		err := adapter.ApplyAccrual(ctx, userID, orderNumber, amount, now)
 		require.NoError(t, err)
 		require.True(t, api.called)
 		assert.Equal(t, ctx, api.gotCtx)
 		assert.Equal(t, balanceapi.ApplyAccrualInput{
 			UserID:      int64(userID),
-			Amount:      float64(amount),
+			OrderNumber: orderNumber.String(),
+			Amount:      12345,
 			ProcessedAt: now,
 		}, api.gotIn)
 	})
@@ -58,7 +60,7 @@ func TestOrdersBalanceGatewayContract(t *testing.T) {
 		api := &accrualAPISpy{err: expectedErr}
 		adapter := ordersintermodule.NewBalanceGatewayAdapter(api)
 
-		err := adapter.ApplyAccrual(ctx, userID, amount, now)
+		err := adapter.ApplyAccrual(ctx, userID, orderNumber, amount, now)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, expectedErr)
 	})
@@ -0,0 +1,67 @@
+package contract_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gophermart/internal/gophermart/application"
+	balanceintermodule "gophermart/internal/gophermart/modules/balance/adapters/intermodule"
+	balanceport "gophermart/internal/gophermart/modules/balance/application/port"
+	balancevo "gophermart/internal/gophermart/modules/balance/domain/vo"
+	identityapi "gophermart/internal/gophermart/modules/identity/application/api"
+)
+
+type userDirectoryAPISpy struct {
+	called   bool
+	gotCtx   context.Context
+	gotLogin string
+	ref      identityapi.UserRef
+	err      error
+}
+
+func (s *userDirectoryAPISpy) FindByLogin(ctx context.Context, login string) (identityapi.UserRef, error) {
+	s.called = true
+	s.gotCtx = ctx
+	s.gotLogin = login
+	return s.ref, s.err
+}
+
+func TestBalanceIdentityGatewayContract(t *testing.T) {
+	// Compile-time contract: balance adapter must satisfy consumer-owned port.
+	var _ balanceport.IdentityGateway = (*balanceintermodule.IdentityGatewayAdapter)(nil)
+
+	ctx := context.Background()
+
+	t.Run("maps provider API output", func(t *testing.T) {
+		api := &userDirectoryAPISpy{ref: identityapi.UserRef{UserID: 42, Login: "bob"}}
+		adapter := balanceintermodule.NewIdentityGatewayAdapter(api)
+
+		userID, err := adapter.FindUserIDByLogin(ctx, "bob")
+		require.NoError(t, err)
+		require.True(t, api.called)
+		assert.Equal(t, ctx, api.gotCtx)
+		assert.Equal(t, "bob", api.gotLogin)
+		assert.Equal(t, balancevo.UserID(42), userID)
+	})
+
+	t.Run("propagates not found", func(t *testing.T) {
+		api := &userDirectoryAPISpy{err: application.ErrNotFound}
+		adapter := balanceintermodule.NewIdentityGatewayAdapter(api)
+
+		_, err := adapter.FindUserIDByLogin(ctx, "bob")
+		assert.ErrorIs(t, err, application.ErrNotFound)
+	})
+
+	t.Run("propagates provider API error", func(t *testing.T) {
+		expectedErr := errors.New("provider failed")
+		api := &userDirectoryAPISpy{err: expectedErr}
+		adapter := balanceintermodule.NewIdentityGatewayAdapter(api)
+
+		_, err := adapter.FindUserIDByLogin(ctx, "bob")
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
@@ -0,0 +1,66 @@
+package contract_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	balanceintermodule "gophermart/internal/gophermart/modules/balance/adapters/intermodule"
+	balanceport "gophermart/internal/gophermart/modules/balance/application/port"
+	balancevo "gophermart/internal/gophermart/modules/balance/domain/vo"
+	ordersapi "gophermart/internal/gophermart/modules/orders/application/api"
+)
+
+type accrualHistoryAPISpy struct {
+	called  bool
+	gotCtx  context.Context
+	gotIn   ordersapi.ListAccrualsInput
+	records []ordersapi.AccrualRecord
+	err     error
+}
+
+func (s *accrualHistoryAPISpy) ListAccruals(ctx context.Context, in ordersapi.ListAccrualsInput) ([]ordersapi.AccrualRecord, error) {
+	s.called = true
+	s.gotCtx = ctx
+	s.gotIn = in
+	return s.records, s.err
+}
+
+func TestBalanceOrdersGatewayContract(t *testing.T) {
+	// Compile-time contract: balance adapter must satisfy consumer-owned port.
+	var _ balanceport.OrdersGateway = (*balanceintermodule.OrdersGatewayAdapter)(nil)
+
+	ctx := context.Background()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("maps provider API input and output", func(t *testing.T) {
+		processedAt := from.Add(time.Hour)
+		api := &accrualHistoryAPISpy{records: []ordersapi.AccrualRecord{
+			{OrderNumber: "12345678903", Amount: 50050, ProcessedAt: processedAt},
+		}}
+		adapter := balanceintermodule.NewOrdersGatewayAdapter(api)
+
+		accruals, err := adapter.ListAccruals(ctx, balancevo.UserID(42), from, to)
+		require.NoError(t, err)
+		require.True(t, api.called)
+		assert.Equal(t, ctx, api.gotCtx)
+		assert.Equal(t, ordersapi.ListAccrualsInput{UserID: 42, From: from, To: to}, api.gotIn)
+		assert.Equal(t, []balanceport.OrderAccrual{
+			{OrderNumber: "12345678903", Amount: balancevo.Points(50050), ProcessedAt: processedAt},
+		}, accruals)
+	})
+
+	t.Run("propagates provider API error", func(t *testing.T) {
+		expectedErr := errors.New("provider failed")
+		api := &accrualHistoryAPISpy{err: expectedErr}
+		adapter := balanceintermodule.NewOrdersGatewayAdapter(api)
+
+		_, err := adapter.ListAccruals(ctx, balancevo.UserID(42), from, to)
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
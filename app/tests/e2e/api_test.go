@@ -4,10 +4,16 @@ package e2e_test
 
 import (
 	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,13 +21,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gophermart/cmd/gophermart/bootstrap"
+	"gophermart/internal/accrualsim"
 	adapterclock "gophermart/internal/gophermart/adapters/clock"
 	"gophermart/internal/gophermart/adapters/repository/postgres"
+	"gophermart/internal/gophermart/application/port"
 	portmocks "gophermart/internal/gophermart/application/port/mocks"
 	balancerepopostgres "gophermart/internal/gophermart/modules/balance/adapters/repository/postgres"
 	balanceservice "gophermart/internal/gophermart/modules/balance/domain/service"
 	identityauth "gophermart/internal/gophermart/modules/identity/adapters/auth"
 	identityrepopostgres "gophermart/internal/gophermart/modules/identity/adapters/repository/postgres"
+	identityvo "gophermart/internal/gophermart/modules/identity/domain/vo"
 	ordersaccrual "gophermart/internal/gophermart/modules/orders/adapters/accrual"
 	ordersrepopostgres "gophermart/internal/gophermart/modules/orders/adapters/repository/postgres"
 	ordersvalidation "gophermart/internal/gophermart/modules/orders/adapters/validation"
@@ -30,10 +39,37 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// testAdminToken enables the admin API of the E2E server.
+const testAdminToken = "e2e-admin"
+
+// testSigningKeyID is the kid of the Ed25519 key signing E2E access tokens.
+const testSigningKeyID = "e2e-ed25519"
+
+// testLoginPolicy delays the fifth failed login of an account by a minute and locks it on the sixth.
+var testLoginPolicy = identityvo.LoginThrottlePolicy{
+	FreeAttempts:     4,
+	BaseDelay:        time.Minute,
+	MaxDelay:         time.Hour,
+	LockoutThreshold: 6,
+	LockoutDuration:  15 * time.Minute,
+	Window:           time.Hour,
+}
+
+// testIPPolicy lets all E2E clients share the loopback address.
+var testIPPolicy = identityvo.LoginThrottlePolicy{FreeAttempts: 1000}
+
 // setupE2EServer creates a full application stack with real DB and returns
 // an httptest.Server ready for HTTP requests.
 func setupE2EServer(t *testing.T) *httptest.Server {
 	t.Helper()
+	ts, _, _ := setupE2EEnv(t)
+	return ts
+}
+
+// setupE2EEnv creates the application stack talking to an in-process accrual simulator.
+// It returns the server, the simulator to script and the accrual poller to run on demand.
+func setupE2EEnv(t *testing.T) (*httptest.Server, *accrualsim.Simulator, port.BackgroundRunner) {
+	t.Helper()
 
 	pool := testutil.SetupPostgres(t)
 
@@ -43,18 +79,39 @@ func setupE2EServer(t *testing.T) *httptest.Server {
 	)
 
 	hasher := identityauth.NewBCryptHasher(4) // low cost for fast tests
-	tokens := identityauth.NewJWTProvider("test-secret", 1*time.Hour)
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edKey, err := identityauth.NewKey(testSigningKeyID, signingKey)
+	require.NoError(t, err)
+	jwtKeys, err := identityauth.NewKeySet(
+		testSigningKeyID,
+		edKey,
+		identityauth.NewHMACKey(identityauth.LegacyKeyID, []byte("test-secret")),
+	)
+	require.NoError(t, err)
+	tokens := identityauth.NewJWTProvider(jwtKeys, 1*time.Hour)
+	refreshTokens := identityauth.NewOpaqueTokenGenerator()
 	luhnValidator := ordersvalidation.NewLuhnValidator()
 	clk := adapterclock.Real{}
 
-	// Accrual client is unused in E2E (no background worker), pass a stub.
-	accrualHTTP := &http.Client{Timeout: 1 * time.Second}
-	accrualClient := ordersaccrual.NewClient("http://localhost:1", accrualHTTP)
+	// No background worker in E2E: tests script the simulator and run the poller explicitly.
+	sim := accrualsim.New(accrualsim.Config{})
+	accrualSrv := httptest.NewServer(sim)
+	t.Cleanup(accrualSrv.Close)
+	accrualClient := ordersaccrual.NewClient(accrualSrv.URL, accrualSrv.Client())
 
 	userRepo := identityrepopostgres.NewUserRepository(transactor)
+	sessionRepo := identityrepopostgres.NewSessionRepository(transactor)
+	loginFailureRepo := identityrepopostgres.NewLoginFailureRepository(transactor)
 	orderRepo := ordersrepopostgres.NewOrderRepository(transactor)
+	campaignRepo := ordersrepopostgres.NewCampaignRepository(transactor)
 	balanceRepo := balancerepopostgres.NewBalanceAccountRepository(transactor)
 	withdrawalRepo := balancerepopostgres.NewWithdrawalRepository(transactor)
+	creditRepo := balancerepopostgres.NewAccrualCreditRepository(transactor)
+	ledgerRepo := balancerepopostgres.NewLedgerRepository(transactor)
+	idempotencyRepo := balancerepopostgres.NewIdempotencyKeyRepository(transactor)
+	lotRepo := balancerepopostgres.NewAccrualLotRepository(transactor)
+	holdRepo := balancerepopostgres.NewHoldRepository(transactor)
 
 	balanceSvc := balanceservice.BalanceService{}
 
@@ -67,10 +124,20 @@ func setupE2EServer(t *testing.T) *httptest.Server {
 
 	ucFactory := bootstrap.NewUseCaseFactory(
 		bootstrap.WithUserRepo(userRepo),
+		bootstrap.WithSessionRepo(sessionRepo),
+		bootstrap.WithLoginFailureRepo(loginFailureRepo),
+		bootstrap.WithLoginThrottle(testLoginPolicy, testIPPolicy),
 		bootstrap.WithOrderRepo(orderRepo),
+		bootstrap.WithCampaignRepo(campaignRepo),
 		bootstrap.WithBalanceRepo(balanceRepo),
 		bootstrap.WithWithdrawalRepo(withdrawalRepo),
+		bootstrap.WithAccrualCreditRepo(creditRepo),
+		bootstrap.WithLedgerRepo(ledgerRepo),
+		bootstrap.WithIdempotencyKeyRepo(idempotencyRepo),
+		bootstrap.WithAccrualLotRepo(lotRepo),
+		bootstrap.WithHoldRepo(holdRepo),
 		bootstrap.WithHasher(hasher),
+		bootstrap.WithTokens(tokens, refreshTokens),
 		bootstrap.WithTransactor(transactor),
 		bootstrap.WithValidator(luhnValidator),
 		bootstrap.WithAccrualClient(accrualClient),
@@ -79,15 +146,17 @@ func setupE2EServer(t *testing.T) *httptest.Server {
 		bootstrap.WithLogger(log),
 		bootstrap.WithBatchSize(50),
 		bootstrap.WithMaxWorkers(5),
+		bootstrap.WithPollLease("e2e", time.Minute),
 		bootstrap.WithOptimisticRetries(3),
 	)
 
-	router := bootstrap.NewRouter(ucFactory, tokens, log)
+	router, err := bootstrap.NewRouter(ucFactory, "", testAdminToken, nil, log)
+	require.NoError(t, err)
 
 	ts := httptest.NewServer(router)
 	t.Cleanup(ts.Close)
 
-	return ts
+	return ts, sim, ucFactory.ProcessAccrualUseCase()
 }
 
 func doJSON(t *testing.T, client *http.Client, method, url string, body any) *http.Response {
@@ -144,6 +213,32 @@ func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return http.DefaultTransport.RoundTrip(req)
 }
 
+// adminClient calls the admin API with testAdminToken.
+func adminClient() *http.Client {
+	return &http.Client{Transport: &adminTransport{}}
+}
+
+type adminTransport struct{}
+
+func (t *adminTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-Admin-Token", testAdminToken)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// tokenUserID returns the user ID from the subject of an access token.
+func tokenUserID(t *testing.T, token string) int64 {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		Sub int64 `json:"sub"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &claims))
+	return claims.Sub
+}
+
 // TestE2E_FullUserFlow tests the complete user journey:
 // register -> login -> upload order -> list orders -> get balance -> withdraw -> list withdrawals.
 func TestE2E_FullUserFlow(t *testing.T) {
@@ -230,6 +325,197 @@ func TestE2E_FullUserFlow(t *testing.T) {
 	resp.Body.Close()
 }
 
+// TestE2E_RefreshAndLogout verifies refresh token rotation, reuse detection and logout.
+func TestE2E_RefreshAndLogout(t *testing.T) {
+	ts := setupE2EServer(t)
+	client := &http.Client{}
+
+	login := func() map[string]string {
+		t.Helper()
+		resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/login",
+			map[string]string{"login": "session-user", "password": "pass"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var tokens map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tokens))
+		resp.Body.Close()
+		return tokens
+	}
+	refresh := func(refreshToken string) *http.Response {
+		t.Helper()
+		return doJSON(t, client, http.MethodPost, ts.URL+"/api/user/token/refresh",
+			map[string]string{"refresh_token": refreshToken})
+	}
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "session-user", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Rotation: the refresh token is exchanged for a new pair, both access tokens stay valid.
+	first := login()
+	resp = refresh(first["refresh_token"])
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var second map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&second))
+	resp.Body.Close()
+	assert.NotEqual(t, first["refresh_token"], second["refresh_token"])
+
+	resp = doJSON(t, authedClient(second["access_token"]), http.MethodGet, ts.URL+"/api/user/balance", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Reuse of the rotated token revokes the whole session.
+	resp = refresh(first["refresh_token"])
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = refresh(second["refresh_token"])
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, authedClient(second["access_token"]), http.MethodGet, ts.URL+"/api/user/balance", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	// Logout revokes only its own session.
+	kept := login()
+	loggedOut := login()
+	resp = doJSON(t, authedClient(loggedOut["access_token"]), http.MethodPost, ts.URL+"/api/user/logout", nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, authedClient(loggedOut["access_token"]), http.MethodGet, ts.URL+"/api/user/balance", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = refresh(loggedOut["refresh_token"])
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, authedClient(kept["access_token"]), http.MethodGet, ts.URL+"/api/user/balance", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// TestE2E_ChangePassword verifies that a password change keeps the current session,
+// revokes the others and that only the new password logs in afterwards.
+func TestE2E_ChangePassword(t *testing.T) {
+	ts := setupE2EServer(t)
+	client := &http.Client{}
+
+	login := func(password string) (int, string) {
+		t.Helper()
+		resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/login",
+			map[string]string{"login": "password-user", "password": password})
+		defer resp.Body.Close()
+		var tokens map[string]string
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&tokens))
+		}
+		return resp.StatusCode, tokens["access_token"]
+	}
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "password-user", "password": "old-pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	_, current := login("old-pass")
+	_, other := login("old-pass")
+
+	resp = doJSON(t, authedClient(current), http.MethodPost, ts.URL+"/api/user/password",
+		map[string]string{"current_password": "wrong", "new_password": "new-pass"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, authedClient(current), http.MethodPost, ts.URL+"/api/user/password",
+		map[string]string{"current_password": "old-pass", "new_password": "new-pass"})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, authedClient(current), http.MethodGet, ts.URL+"/api/user/balance", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, authedClient(other), http.MethodGet, ts.URL+"/api/user/balance", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	status, _ := login("old-pass")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = login("new-pass")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// TestE2E_LoginThrottle verifies that repeated wrong passwords get 429 with Retry-After,
+// even for the right password, while other accounts can still log in.
+func TestE2E_LoginThrottle(t *testing.T) {
+	ts := setupE2EServer(t)
+	client := &http.Client{}
+
+	for _, login := range []string{"throttled-user", "bystander"} {
+		resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+			map[string]string{"login": login, "password": "right-pass"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	for range testLoginPolicy.FreeAttempts + 1 {
+		resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/login",
+			map[string]string{"login": "throttled-user", "password": "wrong"})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/login",
+		map[string]string{"login": "throttled-user", "password": "right-pass"})
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	require.NoError(t, err)
+	assert.InDelta(t, testLoginPolicy.BaseDelay.Seconds(), retryAfter, 5)
+	resp.Body.Close()
+
+	resp = doJSON(t, client, http.MethodPost, ts.URL+"/api/user/login",
+		map[string]string{"login": "bystander", "password": "right-pass"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// TestE2E_JWKS verifies that access tokens carry the kid of a key published in the JWKS
+// and that the shared HS256 secret is never published.
+func TestE2E_JWKS(t *testing.T) {
+	ts := setupE2EServer(t)
+	client := &http.Client{}
+
+	resp := doJSON(t, client, http.MethodGet, ts.URL+"/.well-known/jwks.json", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+	resp.Body.Close()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, testSigningKeyID, jwks.Keys[0]["kid"])
+	assert.Equal(t, "OKP", jwks.Keys[0]["kty"])
+	assert.Equal(t, "EdDSA", jwks.Keys[0]["alg"])
+
+	resp = doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "jwks-user", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var tokens map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&tokens))
+	resp.Body.Close()
+
+	header, _, ok := strings.Cut(tokens["access_token"], ".")
+	require.True(t, ok)
+	raw, err := base64.RawURLEncoding.DecodeString(header)
+	require.NoError(t, err)
+	var jose map[string]string
+	require.NoError(t, json.Unmarshal(raw, &jose))
+	assert.Equal(t, testSigningKeyID, jose["kid"])
+	assert.Equal(t, "EdDSA", jose["alg"])
+}
+
 // TestE2E_OrderConflictBetweenUsers verifies that an order uploaded by one user
 // cannot be uploaded by another user (409 Conflict).
 func TestE2E_OrderConflictBetweenUsers(t *testing.T) {
@@ -263,3 +549,379 @@ func TestE2E_OrderConflictBetweenUsers(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, resp.StatusCode)
 	resp.Body.Close()
 }
+
+// TestE2E_AccrualCreditsBalance verifies that an order processed by the accrual system
+// is credited to the user's balance once, however many times it is polled.
+func TestE2E_AccrualCreditsBalance(t *testing.T) {
+	ts, sim, processAccrual := setupE2EEnv(t)
+	client := &http.Client{}
+	ctx := context.Background()
+
+	sim.SetOrder("12345678903", accrualsim.Step{Status: "REGISTERED"}, accrualsim.Processed(50050))
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "accrual-user", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	ac := authedClient(extractToken(t, resp))
+	resp.Body.Close()
+
+	resp = doText(t, ac, http.MethodPost, ts.URL+"/api/user/orders", "12345678903")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+
+	for range 3 {
+		_, err := processAccrual.Run(ctx)
+		require.NoError(t, err)
+	}
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/orders", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var orders []map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&orders))
+	resp.Body.Close()
+	require.Len(t, orders, 1)
+	assert.Equal(t, "PROCESSED", orders[0]["status"])
+	assert.Equal(t, 500.5, orders[0]["accrual"])
+	assert.Equal(t, 2, sim.Requests("12345678903"), "final orders are not polled again")
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/balance", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var balance map[string]float64
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&balance))
+	resp.Body.Close()
+	assert.Equal(t, 500.5, balance["current"])
+}
+
+// TestE2E_AccrualTruncatesFraction verifies that an accrual with more than two fractional digits
+// is truncated to hundredths on its way to the balance.
+func TestE2E_AccrualTruncatesFraction(t *testing.T) {
+	ts, sim, processAccrual := setupE2EEnv(t)
+	client := &http.Client{}
+
+	accrual := accrualsim.Amount("729.989")
+	sim.SetOrder("12345678903", accrualsim.Step{Status: "PROCESSED", Accrual: &accrual})
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "fraction-user", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	ac := authedClient(extractToken(t, resp))
+	resp.Body.Close()
+
+	resp = doText(t, ac, http.MethodPost, ts.URL+"/api/user/orders", "12345678903")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+
+	_, err := processAccrual.Run(context.Background())
+	require.NoError(t, err)
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/balance", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var balance map[string]float64
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&balance))
+	resp.Body.Close()
+	assert.Equal(t, 729.98, balance["current"])
+}
+
+// TestE2E_CampaignBonus: admin starts a double points campaign, the processed order is credited
+// twice its accrual and the listing shows the campaign part.
+func TestE2E_CampaignBonus(t *testing.T) {
+	ts, sim, processAccrual := setupE2EEnv(t)
+	client := &http.Client{}
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	campaign := map[string]any{
+		"name":       "Double weekend",
+		"kind":       "MULTIPLIER",
+		"multiplier": 2,
+		"starts_at":  now.Add(-time.Hour).Format(time.RFC3339),
+		"ends_at":    now.Add(time.Hour).Format(time.RFC3339),
+	}
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/admin/campaigns", campaign)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	data, err := json.Marshal(campaign)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/admin/campaigns", bytes.NewReader(data))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Admin-Token", testAdminToken)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	resp.Body.Close()
+
+	sim.SetOrder("12345678903", accrualsim.Processed(10000))
+
+	resp = doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "campaign-user", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	ac := authedClient(extractToken(t, resp))
+	resp.Body.Close()
+
+	resp = doText(t, ac, http.MethodPost, ts.URL+"/api/user/orders", "12345678903")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+
+	_, err = processAccrual.Run(ctx)
+	require.NoError(t, err)
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/orders", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var orders []map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&orders))
+	resp.Body.Close()
+	require.Len(t, orders, 1)
+	assert.Equal(t, 200.0, orders[0]["accrual"])
+	assert.Equal(t, []any{map[string]any{
+		"campaign_id": created["id"],
+		"campaign":    "Double weekend",
+		"amount":      100.0,
+	}}, orders[0]["bonuses"])
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/balance", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var balance map[string]float64
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&balance))
+	resp.Body.Close()
+	assert.Equal(t, 200.0, balance["current"])
+}
+
+// TestE2E_WithdrawalRefund: accrual -> withdraw -> duplicate withdraw -> partial and full refund.
+func TestE2E_WithdrawalRefund(t *testing.T) {
+	ts, sim, processAccrual := setupE2EEnv(t)
+	client := &http.Client{}
+
+	sim.SetOrder("12345678903", accrualsim.Processed(10000))
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "refund-user", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	token := extractToken(t, resp)
+	ac := authedClient(token)
+	resp.Body.Close()
+
+	resp = doText(t, ac, http.MethodPost, ts.URL+"/api/user/orders", "12345678903")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+
+	_, err := processAccrual.Run(context.Background())
+	require.NoError(t, err)
+
+	withdraw := map[string]any{"order": "2377225624", "sum": 60}
+	resp = doJSON(t, ac, http.MethodPost, ts.URL+"/api/user/balance/withdraw", withdraw)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, ac, http.MethodPost, ts.URL+"/api/user/balance/withdraw", withdraw)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "one withdrawal per order")
+	resp.Body.Close()
+
+	refundURL := ts.URL + "/api/admin/users/" + strconv.FormatInt(tokenUserID(t, token), 10) +
+		"/withdrawals/2377225624/refund"
+	resp = doJSON(t, ac, http.MethodPost, refundURL, map[string]any{"sum": 20})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "refunds are not a customer operation")
+	resp.Body.Close()
+
+	admin := adminClient()
+	resp = doJSON(t, admin, http.MethodPost, refundURL, map[string]any{"sum": 20})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, admin, http.MethodPost, refundURL, map[string]any{"sum": 41})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "refund exceeds the rest")
+	resp.Body.Close()
+
+	resp = doJSON(t, admin, http.MethodPost, refundURL, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/balance", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var balance map[string]float64
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&balance))
+	resp.Body.Close()
+	assert.Equal(t, 100.0, balance["current"])
+	assert.Equal(t, 0.0, balance["withdrawn"])
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/withdrawals", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var withdrawals []map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&withdrawals))
+	resp.Body.Close()
+	require.Len(t, withdrawals, 1)
+	assert.Equal(t, 60.0, withdrawals[0]["refunded"])
+	assert.Equal(t, "FULL", withdrawals[0]["refund_status"])
+}
+
+// TestE2E_PeerTransfer: accrual -> transfer to another user -> both balances and histories show it.
+func TestE2E_PeerTransfer(t *testing.T) {
+	ts, sim, processAccrual := setupE2EEnv(t)
+	client := &http.Client{}
+
+	sim.SetOrder("12345678903", accrualsim.Processed(10000))
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "sender", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	sender := authedClient(extractToken(t, resp))
+	resp.Body.Close()
+
+	resp = doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "recipient", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	recipient := authedClient(extractToken(t, resp))
+	resp.Body.Close()
+
+	resp = doText(t, sender, http.MethodPost, ts.URL+"/api/user/orders", "12345678903")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+
+	_, err := processAccrual.Run(context.Background())
+	require.NoError(t, err)
+
+	transferURL := ts.URL + "/api/user/balance/transfer"
+	resp = doJSON(t, sender, http.MethodPost, transferURL, map[string]any{"login": "recipient", "sum": 30})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, sender, http.MethodPost, transferURL, map[string]any{"login": "recipient", "sum": 71})
+	assert.Equal(t, http.StatusPaymentRequired, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, sender, http.MethodPost, transferURL, map[string]any{"login": "nobody", "sum": 1})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doJSON(t, sender, http.MethodPost, transferURL, map[string]any{"login": "sender", "sum": 1})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	for _, tc := range []struct {
+		client  *http.Client
+		current float64
+		amount  float64
+	}{
+		{client: sender, current: 70, amount: -30},
+		{client: recipient, current: 30, amount: 30},
+	} {
+		resp = doJSON(t, tc.client, http.MethodGet, ts.URL+"/api/user/balance", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var balance map[string]float64
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&balance))
+		resp.Body.Close()
+		assert.Equal(t, tc.current, balance["current"])
+		assert.Equal(t, 0.0, balance["withdrawn"])
+
+		resp = doJSON(t, tc.client, http.MethodGet, ts.URL+"/api/user/balance/transactions", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var transactions []map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&transactions))
+		resp.Body.Close()
+		require.NotEmpty(t, transactions)
+		assert.Equal(t, "TRANSFER", transactions[0]["kind"])
+		assert.Equal(t, tc.amount, transactions[0]["amount"])
+	}
+}
+
+func TestE2E_OrderPagination(t *testing.T) {
+	ts := setupE2EServer(t)
+	client := &http.Client{}
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "pager", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	ac := authedClient(extractToken(t, resp))
+	resp.Body.Close()
+
+	for _, number := range []string{"12345678903", "79927398713", "2377225624"} {
+		resp = doText(t, ac, http.MethodPost, ts.URL+"/api/user/orders", number)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	// Follow Link headers two orders at a time until the last page.
+	var numbers []string
+	next := "/api/user/orders?status=NEW&limit=2"
+	for pages := 0; next != ""; pages++ {
+		require.Less(t, pages, 3)
+
+		resp = doJSON(t, ac, http.MethodGet, ts.URL+next, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var page []map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		resp.Body.Close()
+
+		for _, o := range page {
+			numbers = append(numbers, o["number"].(string))
+		}
+
+		next = ""
+		if link := resp.Header.Get("Link"); link != "" {
+			next = link[1:strings.Index(link, ">")]
+		}
+	}
+	assert.Equal(t, []string{"2377225624", "79927398713", "12345678903"}, numbers)
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/orders?cursor=garbage", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// TestE2E_Statement: accrual -> withdraw -> statement lists both between the balances.
+func TestE2E_Statement(t *testing.T) {
+	ts, sim, processAccrual := setupE2EEnv(t)
+	client := &http.Client{}
+
+	sim.SetOrder("12345678903", accrualsim.Processed(10000))
+
+	resp := doJSON(t, client, http.MethodPost, ts.URL+"/api/user/register",
+		map[string]string{"login": "statement-user", "password": "pass"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	ac := authedClient(extractToken(t, resp))
+	resp.Body.Close()
+
+	resp = doText(t, ac, http.MethodPost, ts.URL+"/api/user/orders", "12345678903")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+
+	_, err := processAccrual.Run(context.Background())
+	require.NoError(t, err)
+
+	resp = doJSON(t, ac, http.MethodPost, ts.URL+"/api/user/balance/withdraw",
+		map[string]any{"order": "2377225624", "sum": 30})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	from := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	to := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/balance/statement?from="+from+"&to="+to, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var statement struct {
+		OpeningBalance float64          `json:"opening_balance"`
+		ClosingBalance float64          `json:"closing_balance"`
+		Lines          []map[string]any `json:"lines"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&statement))
+	resp.Body.Close()
+
+	assert.Equal(t, 0.0, statement.OpeningBalance)
+	assert.Equal(t, 70.0, statement.ClosingBalance)
+	require.Len(t, statement.Lines, 2)
+	assert.Equal(t, "ACCRUAL", statement.Lines[0]["kind"])
+	assert.Equal(t, 100.0, statement.Lines[0]["amount"])
+	assert.Equal(t, "WITHDRAWAL", statement.Lines[1]["kind"])
+	assert.Equal(t, "2377225624", statement.Lines[1]["reference"])
+	assert.Equal(t, -30.0, statement.Lines[1]["amount"])
+
+	resp = doJSON(t, ac, http.MethodGet, ts.URL+"/api/user/balance/statement?format=csv&from="+from+"&to="+to, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Contains(t, string(body), ",ACCRUAL,12345678903,100\n")
+	assert.Contains(t, string(body), ",CLOSING_BALANCE,,70\n")
+}
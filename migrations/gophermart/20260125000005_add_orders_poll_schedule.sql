@@ -0,0 +1,20 @@
+-- +goose Up
+-- poll_attempts: accrual polls left unanswered (order not registered in accrual system)
+-- next_poll_at: earliest time the order is polled again (exponential backoff)
+-- invalid_reason: why the order ended up INVALID (REJECTED, POLL_EXPIRED), NULL otherwise
+ALTER TABLE orders
+    ADD COLUMN poll_attempts  INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN next_poll_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN invalid_reason TEXT;
+
+UPDATE orders SET invalid_reason = 'REJECTED' WHERE status = 2;
+
+CREATE INDEX idx_orders_status_next_poll ON orders (status, next_poll_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_orders_status_next_poll;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS invalid_reason,
+    DROP COLUMN IF EXISTS next_poll_at,
+    DROP COLUMN IF EXISTS poll_attempts;
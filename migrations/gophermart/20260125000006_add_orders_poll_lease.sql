@@ -0,0 +1,11 @@
+-- +goose Up
+-- lease_owner/lease_expires_at: accrual poll lease held by a gophermart instance.
+-- An expired lease is free to be claimed again (instance died mid-batch).
+ALTER TABLE orders
+    ADD COLUMN lease_owner      TEXT,
+    ADD COLUMN lease_expires_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS lease_expires_at,
+    DROP COLUMN IF EXISTS lease_owner;
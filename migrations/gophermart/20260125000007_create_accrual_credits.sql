@@ -0,0 +1,22 @@
+-- +goose Up
+-- One row per credited order: the unique order_number makes accrual crediting idempotent.
+CREATE TABLE IF NOT EXISTS accrual_credits (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    order_number TEXT NOT NULL UNIQUE,
+    amount       DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    credited_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_accrual_credits_user_id ON accrual_credits (user_id);
+
+-- Orders processed before this migration have already been credited.
+INSERT INTO accrual_credits (user_id, order_number, amount, credited_at)
+SELECT user_id, number, accrual, COALESCE(processed_at, uploaded_at)
+FROM orders
+WHERE status = 3 AND accrual > 0
+ON CONFLICT (order_number) DO NOTHING;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_accrual_credits_user_id;
+DROP TABLE IF EXISTS accrual_credits;
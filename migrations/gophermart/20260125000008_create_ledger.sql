@@ -0,0 +1,110 @@
+-- +goose Up
+-- Append-only double-entry points ledger. balance_accounts is a projection of it.
+-- kind: ACCRUAL, WITHDRAWAL, ADJUSTMENT, EXPIRY; reference: order number or reason.
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind       TEXT NOT NULL,
+    reference  TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- account: USER_POINTS (user_id set) or SYSTEM_* (user_id NULL).
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    id       BIGSERIAL PRIMARY KEY,
+    entry_id BIGINT NOT NULL REFERENCES ledger_entries (id) ON DELETE CASCADE,
+    account  TEXT NOT NULL,
+    user_id  BIGINT REFERENCES users (id) ON DELETE CASCADE,
+    side     TEXT NOT NULL CHECK (side IN ('DEBIT', 'CREDIT')),
+    amount   DOUBLE PRECISION NOT NULL CHECK (amount > 0)
+);
+
+CREATE INDEX idx_ledger_entries_user_created ON ledger_entries (user_id, created_at);
+CREATE INDEX idx_ledger_postings_entry_id ON ledger_postings (entry_id);
+CREATE INDEX idx_ledger_postings_user_id ON ledger_postings (user_id, entry_id);
+
+-- Rows are never updated or deleted, so a user with ledger history cannot be deleted either.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ledger_reject_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger is append-only: % on % is not allowed', TG_OP, TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_ledger_entries_append_only
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE PROCEDURE ledger_reject_change();
+
+CREATE TRIGGER trg_ledger_postings_append_only
+    BEFORE UPDATE OR DELETE ON ledger_postings
+    FOR EACH ROW EXECUTE PROCEDURE ledger_reject_change();
+
+-- Backfill: accruals from recorded credits, withdrawals from history,
+-- and an opening adjustment for whatever the running totals do not explain.
+-- CTEs referencing nextval are materialized once, so entry ids are stable across both inserts.
+WITH src AS (
+    SELECT nextval(pg_get_serial_sequence('ledger_entries', 'id')) AS entry_id, c.*
+    FROM accrual_credits c
+), entries AS (
+    INSERT INTO ledger_entries (id, user_id, kind, reference, created_at)
+    SELECT entry_id, user_id, 'ACCRUAL', order_number, credited_at FROM src
+)
+INSERT INTO ledger_postings (entry_id, account, user_id, side, amount)
+SELECT src.entry_id, p.account, p.user_id, p.side, src.amount
+FROM src
+CROSS JOIN LATERAL (VALUES
+    ('SYSTEM_ACCRUALS', NULL::BIGINT, 'DEBIT'),
+    ('USER_POINTS', src.user_id, 'CREDIT')
+) AS p (account, user_id, side);
+
+WITH src AS (
+    SELECT nextval(pg_get_serial_sequence('ledger_entries', 'id')) AS entry_id, w.*
+    FROM withdrawals w
+    WHERE w.amount > 0
+), entries AS (
+    INSERT INTO ledger_entries (id, user_id, kind, reference, created_at)
+    SELECT entry_id, user_id, 'WITHDRAWAL', order_number, processed_at FROM src
+)
+INSERT INTO ledger_postings (entry_id, account, user_id, side, amount)
+SELECT src.entry_id, p.account, p.user_id, p.side, src.amount
+FROM src
+CROSS JOIN LATERAL (VALUES
+    ('USER_POINTS', src.user_id, 'DEBIT'),
+    ('SYSTEM_REDEMPTIONS', NULL::BIGINT, 'CREDIT')
+) AS p (account, user_id, side);
+
+WITH drift AS (
+    SELECT a.user_id,
+           a.current - COALESCE((
+               SELECT SUM(CASE WHEN p.side = 'CREDIT' THEN p.amount ELSE -p.amount END)
+               FROM ledger_postings p
+               WHERE p.account = 'USER_POINTS' AND p.user_id = a.user_id
+           ), 0) AS delta
+    FROM balance_accounts a
+), src AS (
+    SELECT nextval(pg_get_serial_sequence('ledger_entries', 'id')) AS entry_id, d.*
+    FROM drift d
+    WHERE d.delta <> 0
+), entries AS (
+    INSERT INTO ledger_entries (id, user_id, kind, reference, created_at)
+    SELECT entry_id, user_id, 'ADJUSTMENT', 'opening-balance', NOW() FROM src
+)
+INSERT INTO ledger_postings (entry_id, account, user_id, side, amount)
+SELECT src.entry_id, p.account, p.user_id, p.side, ABS(src.delta)
+FROM src
+CROSS JOIN LATERAL (VALUES
+    ('SYSTEM_ADJUSTMENTS', NULL::BIGINT, CASE WHEN src.delta > 0 THEN 'DEBIT' ELSE 'CREDIT' END),
+    ('USER_POINTS', src.user_id, CASE WHEN src.delta > 0 THEN 'CREDIT' ELSE 'DEBIT' END)
+) AS p (account, user_id, side);
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_ledger_postings_append_only ON ledger_postings;
+DROP TRIGGER IF EXISTS trg_ledger_entries_append_only ON ledger_entries;
+DROP FUNCTION IF EXISTS ledger_reject_change();
+DROP INDEX IF EXISTS idx_ledger_postings_user_id;
+DROP INDEX IF EXISTS idx_ledger_postings_entry_id;
+DROP INDEX IF EXISTS idx_ledger_entries_user_created;
+DROP TABLE IF EXISTS ledger_postings;
+DROP TABLE IF EXISTS ledger_entries;
@@ -0,0 +1,60 @@
+-- +goose Up
+-- Points are exact hundredths: DOUBLE PRECISION sums drift (0.1 + 0.2), NUMERIC does not.
+-- Values are truncated to hundredths like money.ParseTruncated does for accrual responses.
+-- A positive amount below one hundredth would truncate to 0 and break its CHECK (amount > 0);
+-- such rows are financial history (the ledger is append-only), so the migration stops instead of dropping them.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    bad TEXT;
+BEGIN
+    SELECT string_agg(format('%s: %s', t.tbl, t.cnt), ', ')
+    INTO bad
+    FROM (
+        SELECT 'withdrawals' AS tbl, COUNT(*) AS cnt FROM withdrawals WHERE TRUNC(amount::NUMERIC, 2) = 0
+        UNION ALL
+        SELECT 'accrual_credits', COUNT(*) FROM accrual_credits WHERE TRUNC(amount::NUMERIC, 2) = 0
+        UNION ALL
+        SELECT 'ledger_postings', COUNT(*) FROM ledger_postings WHERE TRUNC(amount::NUMERIC, 2) = 0
+    ) t
+    WHERE t.cnt > 0;
+
+    IF bad IS NOT NULL THEN
+        RAISE EXCEPTION 'amounts below 0.01 would truncate to 0 (%); resolve these rows before converting points to NUMERIC', bad;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE balance_accounts
+    ALTER COLUMN current TYPE NUMERIC(18,2) USING TRUNC(current::NUMERIC, 2),
+    ALTER COLUMN withdrawn_total TYPE NUMERIC(18,2) USING TRUNC(withdrawn_total::NUMERIC, 2);
+
+ALTER TABLE orders
+    ALTER COLUMN accrual TYPE NUMERIC(18,2) USING TRUNC(accrual::NUMERIC, 2);
+
+ALTER TABLE withdrawals
+    ALTER COLUMN amount TYPE NUMERIC(18,2) USING TRUNC(amount::NUMERIC, 2);
+
+ALTER TABLE accrual_credits
+    ALTER COLUMN amount TYPE NUMERIC(18,2) USING TRUNC(amount::NUMERIC, 2);
+
+ALTER TABLE ledger_postings
+    ALTER COLUMN amount TYPE NUMERIC(18,2) USING TRUNC(amount::NUMERIC, 2);
+
+-- +goose Down
+ALTER TABLE ledger_postings
+    ALTER COLUMN amount TYPE DOUBLE PRECISION;
+
+ALTER TABLE accrual_credits
+    ALTER COLUMN amount TYPE DOUBLE PRECISION;
+
+ALTER TABLE withdrawals
+    ALTER COLUMN amount TYPE DOUBLE PRECISION;
+
+ALTER TABLE orders
+    ALTER COLUMN accrual TYPE DOUBLE PRECISION;
+
+ALTER TABLE balance_accounts
+    ALTER COLUMN current TYPE DOUBLE PRECISION,
+    ALTER COLUMN withdrawn_total TYPE DOUBLE PRECISION;
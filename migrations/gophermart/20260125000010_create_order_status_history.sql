@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE order_status_history (
+    id          BIGSERIAL PRIMARY KEY,
+    order_id    BIGINT      NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status SMALLINT,
+    to_status   SMALLINT    NOT NULL,
+    source      TEXT        NOT NULL,
+    raw_status  TEXT,
+    changed_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_order_status_history_order_id ON order_status_history(order_id, changed_at);
+
+-- Existing orders get a reconstructed timeline: upload, then the current status if it moved on.
+INSERT INTO order_status_history (order_id, from_status, to_status, source, changed_at)
+SELECT id, NULL, 0, 'UPLOAD', uploaded_at
+FROM orders;
+
+INSERT INTO order_status_history (order_id, from_status, to_status, source, changed_at)
+SELECT id, 0, status, 'BACKFILL', COALESCE(processed_at, updated_at)
+FROM orders
+WHERE status <> 0;
+
+-- +goose Down
+DROP TABLE IF EXISTS order_status_history;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Completed client requests by Idempotency-Key; a retried request is answered without executing again.
+-- Keys are purged once older than the configured TTL.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id     BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    key         TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys (created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_idempotency_keys_created_at;
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- One withdrawal per user and order number, so a refund addresses it by number.
+-- Existing withdrawals are financial history and are never rewritten here: if a user already has
+-- several withdrawals with one number, the migration stops and they have to be resolved by hand.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM withdrawals
+        GROUP BY user_id, order_number
+        HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'withdrawals has duplicate (user_id, order_number) rows; resolve them before adding refunds';
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+-- Scoped per user, so a conflict never reveals that another user used the number.
+CREATE UNIQUE INDEX idx_withdrawals_user_order_number ON withdrawals (user_id, order_number);
+
+ALTER TABLE withdrawals
+    ADD COLUMN refunded_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    ADD COLUMN refunded_at     TIMESTAMPTZ,
+    ADD CONSTRAINT chk_withdrawals_refunded_amount CHECK (refunded_amount >= 0 AND refunded_amount <= amount);
+
+-- +goose Down
+ALTER TABLE withdrawals
+    DROP CONSTRAINT IF EXISTS chk_withdrawals_refunded_amount,
+    DROP COLUMN IF EXISTS refunded_at,
+    DROP COLUMN IF EXISTS refunded_amount;
+
+DROP INDEX IF EXISTS idx_withdrawals_user_order_number;
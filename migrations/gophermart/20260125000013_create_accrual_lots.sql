@@ -0,0 +1,27 @@
+-- +goose Up
+-- Accrued points tracked as lots with an expiry date; withdrawals consume the oldest lots first.
+-- The remaining amounts of a user's lots add up to balance_accounts.current.
+CREATE TABLE IF NOT EXISTS accrual_lots (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reference  TEXT NOT NULL,
+    amount     NUMERIC(18,2) NOT NULL CHECK (amount > 0),
+    remaining  NUMERIC(18,2) NOT NULL CHECK (remaining >= 0 AND remaining <= amount),
+    accrued_at TIMESTAMPTZ NOT NULL,
+    -- NULL: the points never expire.
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_accrual_lots_user_active ON accrual_lots (user_id, accrued_at, id) WHERE remaining > 0;
+CREATE INDEX idx_accrual_lots_due ON accrual_lots (expires_at, id) WHERE remaining > 0;
+
+-- Backfill: points accrued before lots existed become one non-expiring lot per account.
+INSERT INTO accrual_lots (user_id, reference, amount, remaining, accrued_at, expires_at)
+SELECT user_id, 'BACKFILL', current, current, NOW(), NULL
+FROM balance_accounts
+WHERE current > 0;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_accrual_lots_due;
+DROP INDEX IF EXISTS idx_accrual_lots_user_active;
+DROP TABLE IF EXISTS accrual_lots;
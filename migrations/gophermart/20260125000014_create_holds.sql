@@ -0,0 +1,32 @@
+-- +goose Up
+-- Two-phase withdrawals: a hold reserves points at checkout and is captured into a withdrawal
+-- or voided. Held points stay in current but cannot be withdrawn or held again.
+ALTER TABLE balance_accounts
+    ADD COLUMN held NUMERIC(18,2) NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_balance_accounts_held CHECK (held >= 0 AND held <= current);
+
+CREATE TABLE IF NOT EXISTS holds (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    order_number TEXT NOT NULL,
+    amount       NUMERIC(18,2) NOT NULL CHECK (amount > 0),
+    status       TEXT NOT NULL CHECK (status IN ('ACTIVE', 'CAPTURED', 'VOIDED')),
+    created_at   TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    resolved_at  TIMESTAMPTZ
+);
+
+-- At most one active hold per user and order; scoped per user like withdrawals.
+CREATE UNIQUE INDEX idx_holds_active_order_number ON holds (user_id, order_number) WHERE status = 'ACTIVE';
+CREATE INDEX idx_holds_user_id ON holds (user_id, created_at DESC);
+CREATE INDEX idx_holds_active_expires_at ON holds (expires_at, id) WHERE status = 'ACTIVE';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_holds_active_expires_at;
+DROP INDEX IF EXISTS idx_holds_user_id;
+DROP INDEX IF EXISTS idx_holds_active_order_number;
+DROP TABLE IF EXISTS holds;
+
+ALTER TABLE balance_accounts
+    DROP CONSTRAINT IF EXISTS chk_balance_accounts_held,
+    DROP COLUMN IF EXISTS held;
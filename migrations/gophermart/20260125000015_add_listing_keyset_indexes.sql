@@ -0,0 +1,13 @@
+-- +goose Up
+-- Keyset pagination of user listings walks (time, order number) newest first;
+-- the composite indexes supersede the plain user_id ones.
+CREATE INDEX idx_orders_user_uploaded ON orders (user_id, uploaded_at DESC, number DESC);
+CREATE INDEX idx_withdrawals_user_processed ON withdrawals (user_id, processed_at DESC, order_number DESC);
+DROP INDEX IF EXISTS idx_orders_user_id;
+DROP INDEX IF EXISTS idx_withdrawals_user_id;
+
+-- +goose Down
+CREATE INDEX IF NOT EXISTS idx_withdrawals_user_id ON withdrawals (user_id);
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON orders (user_id);
+DROP INDEX IF EXISTS idx_withdrawals_user_processed;
+DROP INDEX IF EXISTS idx_orders_user_uploaded;
@@ -0,0 +1,48 @@
+-- +goose Up
+-- Bonus campaigns raise the points credited for processed orders within [starts_at, ends_at).
+CREATE TABLE IF NOT EXISTS campaigns (
+    id               BIGSERIAL PRIMARY KEY,
+    name             TEXT NOT NULL,
+    kind             TEXT NOT NULL CHECK (kind IN ('MULTIPLIER', 'BONUS')),
+    multiplier       NUMERIC(18,2),
+    bonus            NUMERIC(18,2),
+    first_order_only BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at        TIMESTAMPTZ NOT NULL,
+    ends_at          TIMESTAMPTZ NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_campaigns_period CHECK (ends_at > starts_at),
+    CONSTRAINT chk_campaigns_rule CHECK (
+        (kind = 'MULTIPLIER' AND multiplier > 1 AND multiplier <= 10 AND bonus IS NULL)
+        OR (kind = 'BONUS' AND bonus > 0 AND multiplier IS NULL)
+    )
+);
+
+CREATE INDEX idx_campaigns_period ON campaigns (starts_at, ends_at);
+
+CREATE TRIGGER set_campaigns_updated_at
+    BEFORE UPDATE ON campaigns
+    FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+-- The campaign name is copied so the breakdown survives campaign edits and deletion.
+CREATE TABLE IF NOT EXISTS order_bonuses (
+    order_id      BIGINT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    campaign_id   BIGINT NOT NULL,
+    campaign_name TEXT NOT NULL,
+    amount        NUMERIC(18,2) NOT NULL CHECK (amount > 0),
+    PRIMARY KEY (order_id, campaign_id)
+);
+
+-- The order that took a user's first-order campaign bonuses; the primary key lets only one
+-- of concurrently processed first orders claim them.
+CREATE TABLE IF NOT EXISTS first_order_claims (
+    user_id      BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    order_number TEXT NOT NULL REFERENCES orders (number) ON DELETE CASCADE,
+    claimed_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS first_order_claims;
+DROP TABLE IF EXISTS order_bonuses;
+DROP TRIGGER IF EXISTS set_campaigns_updated_at ON campaigns;
+DROP TABLE IF EXISTS campaigns;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- A session is one login; its refresh tokens rotate on every refresh. Access tokens carry the
+-- session id, so revoking the session (logout, refresh token reuse) invalidates them early.
+-- Refreshes move expires_at, but never past max_expires_at fixed at login.
+CREATE TABLE IF NOT EXISTS sessions (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at     TIMESTAMPTZ NOT NULL,
+    expires_at     TIMESTAMPTZ NOT NULL,
+    max_expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at     TIMESTAMPTZ,
+    CONSTRAINT chk_sessions_expires_at CHECK (expires_at <= max_expires_at)
+);
+
+-- Refresh tokens are single-use; used ones are kept to detect their reuse.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    token_hash TEXT PRIMARY KEY,
+    session_id BIGINT NOT NULL REFERENCES sessions (id) ON DELETE CASCADE,
+    issued_at  TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens (session_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_refresh_tokens_session_id;
+DROP INDEX IF EXISTS idx_sessions_user_id;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS sessions;
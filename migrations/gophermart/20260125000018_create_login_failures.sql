@@ -0,0 +1,15 @@
+-- +goose Up
+-- Consecutive failed logins per throttle key ("login:<login>" or "ip:<address>").
+-- A successful login deletes the login's row; stale rows are purged in the background.
+CREATE TABLE IF NOT EXISTS login_failures (
+    key            TEXT PRIMARY KEY,
+    count          INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_login_failures_last_failed_at ON login_failures (last_failed_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_login_failures_last_failed_at;
+DROP TABLE IF EXISTS login_failures;